@@ -19,26 +19,96 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ArticleService_Save_FullMethodName             = "/article.v1.ArticleService/Save"
-	ArticleService_Publish_FullMethodName          = "/article.v1.ArticleService/Publish"
-	ArticleService_Withdraw_FullMethodName         = "/article.v1.ArticleService/Withdraw"
-	ArticleService_List_FullMethodName             = "/article.v1.ArticleService/List"
-	ArticleService_GetById_FullMethodName          = "/article.v1.ArticleService/GetById"
-	ArticleService_GetPublishedById_FullMethodName = "/article.v1.ArticleService/GetPublishedById"
-	ArticleService_ListPub_FullMethodName          = "/article.v1.ArticleService/ListPub"
+	ArticleService_Save_FullMethodName                   = "/article.v1.ArticleService/Save"
+	ArticleService_Publish_FullMethodName                = "/article.v1.ArticleService/Publish"
+	ArticleService_Withdraw_FullMethodName               = "/article.v1.ArticleService/Withdraw"
+	ArticleService_List_FullMethodName                   = "/article.v1.ArticleService/List"
+	ArticleService_GetById_FullMethodName                = "/article.v1.ArticleService/GetById"
+	ArticleService_GetPublishedById_FullMethodName       = "/article.v1.ArticleService/GetPublishedById"
+	ArticleService_RecordRead_FullMethodName             = "/article.v1.ArticleService/RecordRead"
+	ArticleService_ExistsPublished_FullMethodName        = "/article.v1.ArticleService/ExistsPublished"
+	ArticleService_ListPub_FullMethodName                = "/article.v1.ArticleService/ListPub"
+	ArticleService_ListRevisions_FullMethodName          = "/article.v1.ArticleService/ListRevisions"
+	ArticleService_GetRevision_FullMethodName            = "/article.v1.ArticleService/GetRevision"
+	ArticleService_DiffRevisions_FullMethodName          = "/article.v1.ArticleService/DiffRevisions"
+	ArticleService_RestoreRevision_FullMethodName        = "/article.v1.ArticleService/RestoreRevision"
+	ArticleService_SchedulePublish_FullMethodName        = "/article.v1.ArticleService/SchedulePublish"
+	ArticleService_CancelScheduledPublish_FullMethodName = "/article.v1.ArticleService/CancelScheduledPublish"
+	ArticleService_ListPubByTag_FullMethodName           = "/article.v1.ArticleService/ListPubByTag"
+	ArticleService_CountTags_FullMethodName              = "/article.v1.ArticleService/CountTags"
+	ArticleService_AutosaveDraft_FullMethodName          = "/article.v1.ArticleService/AutosaveDraft"
+	ArticleService_GetDraft_FullMethodName               = "/article.v1.ArticleService/GetDraft"
+	ArticleService_InviteCollaborator_FullMethodName     = "/article.v1.ArticleService/InviteCollaborator"
+	ArticleService_AcceptInvitation_FullMethodName       = "/article.v1.ArticleService/AcceptInvitation"
+	ArticleService_RevokeCollaborator_FullMethodName     = "/article.v1.ArticleService/RevokeCollaborator"
+	ArticleService_ListCollaborators_FullMethodName      = "/article.v1.ArticleService/ListCollaborators"
+	ArticleService_Delete_FullMethodName                 = "/article.v1.ArticleService/Delete"
+	ArticleService_Restore_FullMethodName                = "/article.v1.ArticleService/Restore"
+	ArticleService_ListTrash_FullMethodName              = "/article.v1.ArticleService/ListTrash"
+	ArticleService_CreateSeries_FullMethodName           = "/article.v1.ArticleService/CreateSeries"
+	ArticleService_UpdateSeries_FullMethodName           = "/article.v1.ArticleService/UpdateSeries"
+	ArticleService_DeleteSeries_FullMethodName           = "/article.v1.ArticleService/DeleteSeries"
+	ArticleService_GetSeries_FullMethodName              = "/article.v1.ArticleService/GetSeries"
+	ArticleService_ListSeries_FullMethodName             = "/article.v1.ArticleService/ListSeries"
+	ArticleService_AddSeriesArticle_FullMethodName       = "/article.v1.ArticleService/AddSeriesArticle"
+	ArticleService_RemoveSeriesArticle_FullMethodName    = "/article.v1.ArticleService/RemoveSeriesArticle"
+	ArticleService_ReorderSeries_FullMethodName          = "/article.v1.ArticleService/ReorderSeries"
 )
 
 // ArticleServiceClient is the client API for ArticleService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ArticleServiceClient interface {
+	// Save fails with ABORTED if article.version is not the latest,
+	// the latest article is attached to the status details for the client to merge.
 	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveResponse, error)
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
 	Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	GetById(ctx context.Context, in *GetByIdRequest, opts ...grpc.CallOption) (*GetByIdResponse, error)
 	GetPublishedById(ctx context.Context, in *GetPublishedByIdRequest, opts ...grpc.CallOption) (*GetPublishedByIdResponse, error)
+	// RecordRead counts the read of the article served from a cache of the caller
+	RecordRead(ctx context.Context, in *RecordReadRequest, opts ...grpc.CallOption) (*RecordReadResponse, error)
+	// ExistsPublished checks the article is published without reading its authors or counting a read
+	ExistsPublished(ctx context.Context, in *ExistsPublishedRequest, opts ...grpc.CallOption) (*ExistsPublishedResponse, error)
 	ListPub(ctx context.Context, in *ListPubRequest, opts ...grpc.CallOption) (*ListPubResponse, error)
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*GetRevisionResponse, error)
+	DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error)
+	RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error)
+	SchedulePublish(ctx context.Context, in *SchedulePublishRequest, opts ...grpc.CallOption) (*SchedulePublishResponse, error)
+	CancelScheduledPublish(ctx context.Context, in *CancelScheduledPublishRequest, opts ...grpc.CallOption) (*CancelScheduledPublishResponse, error)
+	ListPubByTag(ctx context.Context, in *ListPubByTagRequest, opts ...grpc.CallOption) (*ListPubByTagResponse, error)
+	CountTags(ctx context.Context, in *CountTagsRequest, opts ...grpc.CallOption) (*CountTagsResponse, error)
+	// AutosaveDraft fails with ABORTED the same way as Save if the article has been saved since the draft's base version
+	AutosaveDraft(ctx context.Context, in *AutosaveDraftRequest, opts ...grpc.CallOption) (*AutosaveDraftResponse, error)
+	GetDraft(ctx context.Context, in *GetDraftRequest, opts ...grpc.CallOption) (*GetDraftResponse, error)
+	// uid in the requests below is the caller, PERMISSION_DENIED is returned if the role is not enough
+	// InviteCollaborator is only allowed for the owner, inviting a user again changes the role
+	InviteCollaborator(ctx context.Context, in *InviteCollaboratorRequest, opts ...grpc.CallOption) (*InviteCollaboratorResponse, error)
+	// AcceptInvitation fails with NOT_FOUND if the caller has no pending invitation
+	AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error)
+	// RevokeCollaborator is allowed for the owner, or for a collaborator to decline or leave
+	RevokeCollaborator(ctx context.Context, in *RevokeCollaboratorRequest, opts ...grpc.CallOption) (*RevokeCollaboratorResponse, error)
+	ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error)
+	// Delete moves the article to the trash, only allowed for the owner, it is taken offline
+	// and could be restored until the retention passes, then it is purged along with its interactions and comments
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Restore fails with NOT_FOUND if the article is not in the trash or the retention has passed,
+	// a published article goes back as private
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	// uid in the series requests below is the author, NOT_FOUND is returned if the series is of another author
+	CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error)
+	UpdateSeries(ctx context.Context, in *UpdateSeriesRequest, opts ...grpc.CallOption) (*UpdateSeriesResponse, error)
+	DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*DeleteSeriesResponse, error)
+	GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*GetSeriesResponse, error)
+	ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error)
+	// AddSeriesArticle fails with FAILED_PRECONDITION if the article is not published, already in a series or the series is full
+	AddSeriesArticle(ctx context.Context, in *AddSeriesArticleRequest, opts ...grpc.CallOption) (*AddSeriesArticleResponse, error)
+	RemoveSeriesArticle(ctx context.Context, in *RemoveSeriesArticleRequest, opts ...grpc.CallOption) (*RemoveSeriesArticleResponse, error)
+	// ReorderSeries takes all the articles of the series in the new order
+	ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*ReorderSeriesResponse, error)
 }
 
 type articleServiceClient struct {
@@ -103,6 +173,24 @@ func (c *articleServiceClient) GetPublishedById(ctx context.Context, in *GetPubl
 	return out, nil
 }
 
+func (c *articleServiceClient) RecordRead(ctx context.Context, in *RecordReadRequest, opts ...grpc.CallOption) (*RecordReadResponse, error) {
+	out := new(RecordReadResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RecordRead_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ExistsPublished(ctx context.Context, in *ExistsPublishedRequest, opts ...grpc.CallOption) (*ExistsPublishedResponse, error) {
+	out := new(ExistsPublishedResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ExistsPublished_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *articleServiceClient) ListPub(ctx context.Context, in *ListPubRequest, opts ...grpc.CallOption) (*ListPubResponse, error) {
 	out := new(ListPubResponse)
 	err := c.cc.Invoke(ctx, ArticleService_ListPub_FullMethodName, in, out, opts...)
@@ -112,17 +200,285 @@ func (c *articleServiceClient) ListPub(ctx context.Context, in *ListPubRequest,
 	return out, nil
 }
 
+func (c *articleServiceClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*GetRevisionResponse, error) {
+	out := new(GetRevisionResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) DiffRevisions(ctx context.Context, in *DiffRevisionsRequest, opts ...grpc.CallOption) (*DiffRevisionsResponse, error) {
+	out := new(DiffRevisionsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_DiffRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*RestoreRevisionResponse, error) {
+	out := new(RestoreRevisionResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RestoreRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) SchedulePublish(ctx context.Context, in *SchedulePublishRequest, opts ...grpc.CallOption) (*SchedulePublishResponse, error) {
+	out := new(SchedulePublishResponse)
+	err := c.cc.Invoke(ctx, ArticleService_SchedulePublish_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CancelScheduledPublish(ctx context.Context, in *CancelScheduledPublishRequest, opts ...grpc.CallOption) (*CancelScheduledPublishResponse, error) {
+	out := new(CancelScheduledPublishResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CancelScheduledPublish_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListPubByTag(ctx context.Context, in *ListPubByTagRequest, opts ...grpc.CallOption) (*ListPubByTagResponse, error) {
+	out := new(ListPubByTagResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListPubByTag_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CountTags(ctx context.Context, in *CountTagsRequest, opts ...grpc.CallOption) (*CountTagsResponse, error) {
+	out := new(CountTagsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CountTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) AutosaveDraft(ctx context.Context, in *AutosaveDraftRequest, opts ...grpc.CallOption) (*AutosaveDraftResponse, error) {
+	out := new(AutosaveDraftResponse)
+	err := c.cc.Invoke(ctx, ArticleService_AutosaveDraft_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetDraft(ctx context.Context, in *GetDraftRequest, opts ...grpc.CallOption) (*GetDraftResponse, error) {
+	out := new(GetDraftResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetDraft_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) InviteCollaborator(ctx context.Context, in *InviteCollaboratorRequest, opts ...grpc.CallOption) (*InviteCollaboratorResponse, error) {
+	out := new(InviteCollaboratorResponse)
+	err := c.cc.Invoke(ctx, ArticleService_InviteCollaborator_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) AcceptInvitation(ctx context.Context, in *AcceptInvitationRequest, opts ...grpc.CallOption) (*AcceptInvitationResponse, error) {
+	out := new(AcceptInvitationResponse)
+	err := c.cc.Invoke(ctx, ArticleService_AcceptInvitation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RevokeCollaborator(ctx context.Context, in *RevokeCollaboratorRequest, opts ...grpc.CallOption) (*RevokeCollaboratorResponse, error) {
+	out := new(RevokeCollaboratorResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RevokeCollaborator_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListCollaborators(ctx context.Context, in *ListCollaboratorsRequest, opts ...grpc.CallOption) (*ListCollaboratorsResponse, error) {
+	out := new(ListCollaboratorsResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListCollaborators_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, ArticleService_Restore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListTrash_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) CreateSeries(ctx context.Context, in *CreateSeriesRequest, opts ...grpc.CallOption) (*CreateSeriesResponse, error) {
+	out := new(CreateSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_CreateSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) UpdateSeries(ctx context.Context, in *UpdateSeriesRequest, opts ...grpc.CallOption) (*UpdateSeriesResponse, error) {
+	out := new(UpdateSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_UpdateSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) DeleteSeries(ctx context.Context, in *DeleteSeriesRequest, opts ...grpc.CallOption) (*DeleteSeriesResponse, error) {
+	out := new(DeleteSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_DeleteSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) GetSeries(ctx context.Context, in *GetSeriesRequest, opts ...grpc.CallOption) (*GetSeriesResponse, error) {
+	out := new(GetSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_GetSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error) {
+	out := new(ListSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ListSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) AddSeriesArticle(ctx context.Context, in *AddSeriesArticleRequest, opts ...grpc.CallOption) (*AddSeriesArticleResponse, error) {
+	out := new(AddSeriesArticleResponse)
+	err := c.cc.Invoke(ctx, ArticleService_AddSeriesArticle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) RemoveSeriesArticle(ctx context.Context, in *RemoveSeriesArticleRequest, opts ...grpc.CallOption) (*RemoveSeriesArticleResponse, error) {
+	out := new(RemoveSeriesArticleResponse)
+	err := c.cc.Invoke(ctx, ArticleService_RemoveSeriesArticle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *articleServiceClient) ReorderSeries(ctx context.Context, in *ReorderSeriesRequest, opts ...grpc.CallOption) (*ReorderSeriesResponse, error) {
+	out := new(ReorderSeriesResponse)
+	err := c.cc.Invoke(ctx, ArticleService_ReorderSeries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ArticleServiceServer is the server API for ArticleService service.
 // All implementations must embed UnimplementedArticleServiceServer
 // for forward compatibility
 type ArticleServiceServer interface {
+	// Save fails with ABORTED if article.version is not the latest,
+	// the latest article is attached to the status details for the client to merge.
 	Save(context.Context, *SaveRequest) (*SaveResponse, error)
 	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
 	Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error)
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	GetById(context.Context, *GetByIdRequest) (*GetByIdResponse, error)
 	GetPublishedById(context.Context, *GetPublishedByIdRequest) (*GetPublishedByIdResponse, error)
+	// RecordRead counts the read of the article served from a cache of the caller
+	RecordRead(context.Context, *RecordReadRequest) (*RecordReadResponse, error)
+	// ExistsPublished checks the article is published without reading its authors or counting a read
+	ExistsPublished(context.Context, *ExistsPublishedRequest) (*ExistsPublishedResponse, error)
 	ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error)
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	GetRevision(context.Context, *GetRevisionRequest) (*GetRevisionResponse, error)
+	DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error)
+	RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error)
+	SchedulePublish(context.Context, *SchedulePublishRequest) (*SchedulePublishResponse, error)
+	CancelScheduledPublish(context.Context, *CancelScheduledPublishRequest) (*CancelScheduledPublishResponse, error)
+	ListPubByTag(context.Context, *ListPubByTagRequest) (*ListPubByTagResponse, error)
+	CountTags(context.Context, *CountTagsRequest) (*CountTagsResponse, error)
+	// AutosaveDraft fails with ABORTED the same way as Save if the article has been saved since the draft's base version
+	AutosaveDraft(context.Context, *AutosaveDraftRequest) (*AutosaveDraftResponse, error)
+	GetDraft(context.Context, *GetDraftRequest) (*GetDraftResponse, error)
+	// uid in the requests below is the caller, PERMISSION_DENIED is returned if the role is not enough
+	// InviteCollaborator is only allowed for the owner, inviting a user again changes the role
+	InviteCollaborator(context.Context, *InviteCollaboratorRequest) (*InviteCollaboratorResponse, error)
+	// AcceptInvitation fails with NOT_FOUND if the caller has no pending invitation
+	AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error)
+	// RevokeCollaborator is allowed for the owner, or for a collaborator to decline or leave
+	RevokeCollaborator(context.Context, *RevokeCollaboratorRequest) (*RevokeCollaboratorResponse, error)
+	ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error)
+	// Delete moves the article to the trash, only allowed for the owner, it is taken offline
+	// and could be restored until the retention passes, then it is purged along with its interactions and comments
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Restore fails with NOT_FOUND if the article is not in the trash or the retention has passed,
+	// a published article goes back as private
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	// uid in the series requests below is the author, NOT_FOUND is returned if the series is of another author
+	CreateSeries(context.Context, *CreateSeriesRequest) (*CreateSeriesResponse, error)
+	UpdateSeries(context.Context, *UpdateSeriesRequest) (*UpdateSeriesResponse, error)
+	DeleteSeries(context.Context, *DeleteSeriesRequest) (*DeleteSeriesResponse, error)
+	GetSeries(context.Context, *GetSeriesRequest) (*GetSeriesResponse, error)
+	ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error)
+	// AddSeriesArticle fails with FAILED_PRECONDITION if the article is not published, already in a series or the series is full
+	AddSeriesArticle(context.Context, *AddSeriesArticleRequest) (*AddSeriesArticleResponse, error)
+	RemoveSeriesArticle(context.Context, *RemoveSeriesArticleRequest) (*RemoveSeriesArticleResponse, error)
+	// ReorderSeries takes all the articles of the series in the new order
+	ReorderSeries(context.Context, *ReorderSeriesRequest) (*ReorderSeriesResponse, error)
 	mustEmbedUnimplementedArticleServiceServer()
 }
 
@@ -148,9 +504,90 @@ func (UnimplementedArticleServiceServer) GetById(context.Context, *GetByIdReques
 func (UnimplementedArticleServiceServer) GetPublishedById(context.Context, *GetPublishedByIdRequest) (*GetPublishedByIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPublishedById not implemented")
 }
+func (UnimplementedArticleServiceServer) RecordRead(context.Context, *RecordReadRequest) (*RecordReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordRead not implemented")
+}
+func (UnimplementedArticleServiceServer) ExistsPublished(context.Context, *ExistsPublishedRequest) (*ExistsPublishedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExistsPublished not implemented")
+}
 func (UnimplementedArticleServiceServer) ListPub(context.Context, *ListPubRequest) (*ListPubResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPub not implemented")
 }
+func (UnimplementedArticleServiceServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedArticleServiceServer) GetRevision(context.Context, *GetRevisionRequest) (*GetRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedArticleServiceServer) DiffRevisions(context.Context, *DiffRevisionsRequest) (*DiffRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRevisions not implemented")
+}
+func (UnimplementedArticleServiceServer) RestoreRevision(context.Context, *RestoreRevisionRequest) (*RestoreRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedArticleServiceServer) SchedulePublish(context.Context, *SchedulePublishRequest) (*SchedulePublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePublish not implemented")
+}
+func (UnimplementedArticleServiceServer) CancelScheduledPublish(context.Context, *CancelScheduledPublishRequest) (*CancelScheduledPublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledPublish not implemented")
+}
+func (UnimplementedArticleServiceServer) ListPubByTag(context.Context, *ListPubByTagRequest) (*ListPubByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPubByTag not implemented")
+}
+func (UnimplementedArticleServiceServer) CountTags(context.Context, *CountTagsRequest) (*CountTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountTags not implemented")
+}
+func (UnimplementedArticleServiceServer) AutosaveDraft(context.Context, *AutosaveDraftRequest) (*AutosaveDraftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AutosaveDraft not implemented")
+}
+func (UnimplementedArticleServiceServer) GetDraft(context.Context, *GetDraftRequest) (*GetDraftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDraft not implemented")
+}
+func (UnimplementedArticleServiceServer) InviteCollaborator(context.Context, *InviteCollaboratorRequest) (*InviteCollaboratorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteCollaborator not implemented")
+}
+func (UnimplementedArticleServiceServer) AcceptInvitation(context.Context, *AcceptInvitationRequest) (*AcceptInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvitation not implemented")
+}
+func (UnimplementedArticleServiceServer) RevokeCollaborator(context.Context, *RevokeCollaboratorRequest) (*RevokeCollaboratorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCollaborator not implemented")
+}
+func (UnimplementedArticleServiceServer) ListCollaborators(context.Context, *ListCollaboratorsRequest) (*ListCollaboratorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollaborators not implemented")
+}
+func (UnimplementedArticleServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedArticleServiceServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedArticleServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedArticleServiceServer) CreateSeries(context.Context, *CreateSeriesRequest) (*CreateSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) UpdateSeries(context.Context, *UpdateSeriesRequest) (*UpdateSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) DeleteSeries(context.Context, *DeleteSeriesRequest) (*DeleteSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) GetSeries(context.Context, *GetSeriesRequest) (*GetSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSeries not implemented")
+}
+func (UnimplementedArticleServiceServer) AddSeriesArticle(context.Context, *AddSeriesArticleRequest) (*AddSeriesArticleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSeriesArticle not implemented")
+}
+func (UnimplementedArticleServiceServer) RemoveSeriesArticle(context.Context, *RemoveSeriesArticleRequest) (*RemoveSeriesArticleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSeriesArticle not implemented")
+}
+func (UnimplementedArticleServiceServer) ReorderSeries(context.Context, *ReorderSeriesRequest) (*ReorderSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderSeries not implemented")
+}
 func (UnimplementedArticleServiceServer) mustEmbedUnimplementedArticleServiceServer() {}
 
 // UnsafeArticleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -272,6 +709,42 @@ func _ArticleService_GetPublishedById_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArticleService_RecordRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RecordRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RecordRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RecordRead(ctx, req.(*RecordReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ExistsPublished_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExistsPublishedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ExistsPublished(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ExistsPublished_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ExistsPublished(ctx, req.(*ExistsPublishedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArticleService_ListPub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPubRequest)
 	if err := dec(in); err != nil {
@@ -290,40 +763,598 @@ func _ArticleService_ListPub_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
-// ArticleService_ServiceDesc is the grpc.ServiceDesc for ArticleService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var ArticleService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "article.v1.ArticleService",
-	HandlerType: (*ArticleServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Save",
-			Handler:    _ArticleService_Save_Handler,
-		},
-		{
-			MethodName: "Publish",
-			Handler:    _ArticleService_Publish_Handler,
-		},
-		{
-			MethodName: "Withdraw",
-			Handler:    _ArticleService_Withdraw_Handler,
-		},
-		{
-			MethodName: "List",
-			Handler:    _ArticleService_List_Handler,
-		},
-		{
-			MethodName: "GetById",
-			Handler:    _ArticleService_GetById_Handler,
-		},
-		{
-			MethodName: "GetPublishedById",
-			Handler:    _ArticleService_GetPublishedById_Handler,
-		},
-		{
-			MethodName: "ListPub",
-			Handler:    _ArticleService_ListPub_Handler,
+func _ArticleService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetRevision(ctx, req.(*GetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_DiffRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).DiffRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_DiffRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).DiffRevisions(ctx, req.(*DiffRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RestoreRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RestoreRevision(ctx, req.(*RestoreRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_SchedulePublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).SchedulePublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_SchedulePublish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).SchedulePublish(ctx, req.(*SchedulePublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CancelScheduledPublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledPublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CancelScheduledPublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CancelScheduledPublish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CancelScheduledPublish(ctx, req.(*CancelScheduledPublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListPubByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPubByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListPubByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListPubByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListPubByTag(ctx, req.(*ListPubByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CountTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CountTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CountTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CountTags(ctx, req.(*CountTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_AutosaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutosaveDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).AutosaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_AutosaveDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).AutosaveDraft(ctx, req.(*AutosaveDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetDraft(ctx, req.(*GetDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_InviteCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).InviteCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_InviteCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).InviteCollaborator(ctx, req.(*InviteCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_AcceptInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).AcceptInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_AcceptInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).AcceptInvitation(ctx, req.(*AcceptInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RevokeCollaborator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCollaboratorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RevokeCollaborator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RevokeCollaborator_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RevokeCollaborator(ctx, req.(*RevokeCollaboratorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListCollaborators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollaboratorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListCollaborators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListCollaborators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListCollaborators(ctx, req.(*ListCollaboratorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_CreateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).CreateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_CreateSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).CreateSeries(ctx, req.(*CreateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_UpdateSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).UpdateSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_UpdateSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).UpdateSeries(ctx, req.(*UpdateSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_DeleteSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).DeleteSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_DeleteSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).DeleteSeries(ctx, req.(*DeleteSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_GetSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).GetSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_GetSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).GetSeries(ctx, req.(*GetSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ListSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ListSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ListSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ListSeries(ctx, req.(*ListSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_AddSeriesArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSeriesArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).AddSeriesArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_AddSeriesArticle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).AddSeriesArticle(ctx, req.(*AddSeriesArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_RemoveSeriesArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSeriesArticleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).RemoveSeriesArticle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_RemoveSeriesArticle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).RemoveSeriesArticle(ctx, req.(*RemoveSeriesArticleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArticleService_ReorderSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArticleServiceServer).ReorderSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ArticleService_ReorderSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArticleServiceServer).ReorderSeries(ctx, req.(*ReorderSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ArticleService_ServiceDesc is the grpc.ServiceDesc for ArticleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ArticleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "article.v1.ArticleService",
+	HandlerType: (*ArticleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Save",
+			Handler:    _ArticleService_Save_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _ArticleService_Publish_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _ArticleService_Withdraw_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _ArticleService_List_Handler,
+		},
+		{
+			MethodName: "GetById",
+			Handler:    _ArticleService_GetById_Handler,
+		},
+		{
+			MethodName: "GetPublishedById",
+			Handler:    _ArticleService_GetPublishedById_Handler,
+		},
+		{
+			MethodName: "RecordRead",
+			Handler:    _ArticleService_RecordRead_Handler,
+		},
+		{
+			MethodName: "ExistsPublished",
+			Handler:    _ArticleService_ExistsPublished_Handler,
+		},
+		{
+			MethodName: "ListPub",
+			Handler:    _ArticleService_ListPub_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _ArticleService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _ArticleService_GetRevision_Handler,
+		},
+		{
+			MethodName: "DiffRevisions",
+			Handler:    _ArticleService_DiffRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _ArticleService_RestoreRevision_Handler,
+		},
+		{
+			MethodName: "SchedulePublish",
+			Handler:    _ArticleService_SchedulePublish_Handler,
+		},
+		{
+			MethodName: "CancelScheduledPublish",
+			Handler:    _ArticleService_CancelScheduledPublish_Handler,
+		},
+		{
+			MethodName: "ListPubByTag",
+			Handler:    _ArticleService_ListPubByTag_Handler,
+		},
+		{
+			MethodName: "CountTags",
+			Handler:    _ArticleService_CountTags_Handler,
+		},
+		{
+			MethodName: "AutosaveDraft",
+			Handler:    _ArticleService_AutosaveDraft_Handler,
+		},
+		{
+			MethodName: "GetDraft",
+			Handler:    _ArticleService_GetDraft_Handler,
+		},
+		{
+			MethodName: "InviteCollaborator",
+			Handler:    _ArticleService_InviteCollaborator_Handler,
+		},
+		{
+			MethodName: "AcceptInvitation",
+			Handler:    _ArticleService_AcceptInvitation_Handler,
+		},
+		{
+			MethodName: "RevokeCollaborator",
+			Handler:    _ArticleService_RevokeCollaborator_Handler,
+		},
+		{
+			MethodName: "ListCollaborators",
+			Handler:    _ArticleService_ListCollaborators_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ArticleService_Delete_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _ArticleService_Restore_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _ArticleService_ListTrash_Handler,
+		},
+		{
+			MethodName: "CreateSeries",
+			Handler:    _ArticleService_CreateSeries_Handler,
+		},
+		{
+			MethodName: "UpdateSeries",
+			Handler:    _ArticleService_UpdateSeries_Handler,
+		},
+		{
+			MethodName: "DeleteSeries",
+			Handler:    _ArticleService_DeleteSeries_Handler,
+		},
+		{
+			MethodName: "GetSeries",
+			Handler:    _ArticleService_GetSeries_Handler,
+		},
+		{
+			MethodName: "ListSeries",
+			Handler:    _ArticleService_ListSeries_Handler,
+		},
+		{
+			MethodName: "AddSeriesArticle",
+			Handler:    _ArticleService_AddSeriesArticle_Handler,
+		},
+		{
+			MethodName: "RemoveSeriesArticle",
+			Handler:    _ArticleService_RemoveSeriesArticle_Handler,
+		},
+		{
+			MethodName: "ReorderSeries",
+			Handler:    _ArticleService_ReorderSeries_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -19,10 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	CommentService_GetCommentList_FullMethodName = "/comment.v1.CommentService/GetCommentList"
-	CommentService_DeleteComment_FullMethodName  = "/comment.v1.CommentService/DeleteComment"
-	CommentService_CreateComment_FullMethodName  = "/comment.v1.CommentService/CreateComment"
-	CommentService_GetMoreReplies_FullMethodName = "/comment.v1.CommentService/GetMoreReplies"
+	CommentService_GetCommentList_FullMethodName      = "/comment.v1.CommentService/GetCommentList"
+	CommentService_DeleteComment_FullMethodName       = "/comment.v1.CommentService/DeleteComment"
+	CommentService_EditComment_FullMethodName         = "/comment.v1.CommentService/EditComment"
+	CommentService_GetEditHistory_FullMethodName      = "/comment.v1.CommentService/GetEditHistory"
+	CommentService_GetCommentCounts_FullMethodName    = "/comment.v1.CommentService/GetCommentCounts"
+	CommentService_CreateComment_FullMethodName       = "/comment.v1.CommentService/CreateComment"
+	CommentService_GetCommentByIds_FullMethodName     = "/comment.v1.CommentService/GetCommentByIds"
+	CommentService_GetMoreReplies_FullMethodName      = "/comment.v1.CommentService/GetMoreReplies"
+	CommentService_ListPendingComments_FullMethodName = "/comment.v1.CommentService/ListPendingComments"
+	CommentService_ReviewComment_FullMethodName       = "/comment.v1.CommentService/ReviewComment"
 )
 
 // CommentServiceClient is the client API for CommentService service.
@@ -31,11 +37,25 @@ const (
 type CommentServiceClient interface {
 	// GetCommentList if id = 0, means get top comments
 	GetCommentList(ctx context.Context, in *GetCommentListRequest, opts ...grpc.CallOption) (*GetCommentListResponse, error)
-	// DeleteComment Delete its own and all descendant comments
+	// DeleteComment tombstones the comment, its replies are kept.
+	// Only the commentator or the author of the article could delete it.
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentResponse, error)
+	// EditComment changes the content of a comment of the user, the content replaced is kept in the edit history
+	EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*EditCommentResponse, error)
+	// GetEditHistory returns the contents a comment had before its edits, latest first,
+	// NotFound for a comment the viewer could not see
+	GetEditHistory(ctx context.Context, in *GetEditHistoryRequest, opts ...grpc.CallOption) (*GetEditHistoryResponse, error)
+	// GetCommentCounts counts the approved comments not deleted of the resources, at most 200 of them at once
+	GetCommentCounts(ctx context.Context, in *GetCommentCountsRequest, opts ...grpc.CallOption) (*GetCommentCountsResponse, error)
 	// CreateComment create a comment
 	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
+	// GetCommentByIds returns the comments found, missing ids are skipped
+	GetCommentByIds(ctx context.Context, in *GetCommentByIdsRequest, opts ...grpc.CallOption) (*GetCommentByIdsResponse, error)
 	GetMoreReplies(ctx context.Context, in *GetMoreRepliesRequest, opts ...grpc.CallOption) (*GetMoreRepliesResponse, error)
+	// ListPendingComments pages the comments held for review, latest first
+	ListPendingComments(ctx context.Context, in *ListPendingCommentsRequest, opts ...grpc.CallOption) (*ListPendingCommentsResponse, error)
+	// ReviewComment approves or rejects a pending comment
+	ReviewComment(ctx context.Context, in *ReviewCommentRequest, opts ...grpc.CallOption) (*ReviewCommentResponse, error)
 }
 
 type commentServiceClient struct {
@@ -64,6 +84,33 @@ func (c *commentServiceClient) DeleteComment(ctx context.Context, in *DeleteComm
 	return out, nil
 }
 
+func (c *commentServiceClient) EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*EditCommentResponse, error) {
+	out := new(EditCommentResponse)
+	err := c.cc.Invoke(ctx, CommentService_EditComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) GetEditHistory(ctx context.Context, in *GetEditHistoryRequest, opts ...grpc.CallOption) (*GetEditHistoryResponse, error) {
+	out := new(GetEditHistoryResponse)
+	err := c.cc.Invoke(ctx, CommentService_GetEditHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) GetCommentCounts(ctx context.Context, in *GetCommentCountsRequest, opts ...grpc.CallOption) (*GetCommentCountsResponse, error) {
+	out := new(GetCommentCountsResponse)
+	err := c.cc.Invoke(ctx, CommentService_GetCommentCounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *commentServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error) {
 	out := new(CreateCommentResponse)
 	err := c.cc.Invoke(ctx, CommentService_CreateComment_FullMethodName, in, out, opts...)
@@ -73,6 +120,15 @@ func (c *commentServiceClient) CreateComment(ctx context.Context, in *CreateComm
 	return out, nil
 }
 
+func (c *commentServiceClient) GetCommentByIds(ctx context.Context, in *GetCommentByIdsRequest, opts ...grpc.CallOption) (*GetCommentByIdsResponse, error) {
+	out := new(GetCommentByIdsResponse)
+	err := c.cc.Invoke(ctx, CommentService_GetCommentByIds_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *commentServiceClient) GetMoreReplies(ctx context.Context, in *GetMoreRepliesRequest, opts ...grpc.CallOption) (*GetMoreRepliesResponse, error) {
 	out := new(GetMoreRepliesResponse)
 	err := c.cc.Invoke(ctx, CommentService_GetMoreReplies_FullMethodName, in, out, opts...)
@@ -82,17 +138,49 @@ func (c *commentServiceClient) GetMoreReplies(ctx context.Context, in *GetMoreRe
 	return out, nil
 }
 
+func (c *commentServiceClient) ListPendingComments(ctx context.Context, in *ListPendingCommentsRequest, opts ...grpc.CallOption) (*ListPendingCommentsResponse, error) {
+	out := new(ListPendingCommentsResponse)
+	err := c.cc.Invoke(ctx, CommentService_ListPendingComments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ReviewComment(ctx context.Context, in *ReviewCommentRequest, opts ...grpc.CallOption) (*ReviewCommentResponse, error) {
+	out := new(ReviewCommentResponse)
+	err := c.cc.Invoke(ctx, CommentService_ReviewComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentServiceServer is the server API for CommentService service.
 // All implementations must embed UnimplementedCommentServiceServer
 // for forward compatibility
 type CommentServiceServer interface {
 	// GetCommentList if id = 0, means get top comments
 	GetCommentList(context.Context, *GetCommentListRequest) (*GetCommentListResponse, error)
-	// DeleteComment Delete its own and all descendant comments
+	// DeleteComment tombstones the comment, its replies are kept.
+	// Only the commentator or the author of the article could delete it.
 	DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error)
+	// EditComment changes the content of a comment of the user, the content replaced is kept in the edit history
+	EditComment(context.Context, *EditCommentRequest) (*EditCommentResponse, error)
+	// GetEditHistory returns the contents a comment had before its edits, latest first,
+	// NotFound for a comment the viewer could not see
+	GetEditHistory(context.Context, *GetEditHistoryRequest) (*GetEditHistoryResponse, error)
+	// GetCommentCounts counts the approved comments not deleted of the resources, at most 200 of them at once
+	GetCommentCounts(context.Context, *GetCommentCountsRequest) (*GetCommentCountsResponse, error)
 	// CreateComment create a comment
 	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	// GetCommentByIds returns the comments found, missing ids are skipped
+	GetCommentByIds(context.Context, *GetCommentByIdsRequest) (*GetCommentByIdsResponse, error)
 	GetMoreReplies(context.Context, *GetMoreRepliesRequest) (*GetMoreRepliesResponse, error)
+	// ListPendingComments pages the comments held for review, latest first
+	ListPendingComments(context.Context, *ListPendingCommentsRequest) (*ListPendingCommentsResponse, error)
+	// ReviewComment approves or rejects a pending comment
+	ReviewComment(context.Context, *ReviewCommentRequest) (*ReviewCommentResponse, error)
 	mustEmbedUnimplementedCommentServiceServer()
 }
 
@@ -106,12 +194,30 @@ func (UnimplementedCommentServiceServer) GetCommentList(context.Context, *GetCom
 func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*DeleteCommentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
 }
+func (UnimplementedCommentServiceServer) EditComment(context.Context, *EditCommentRequest) (*EditCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditComment not implemented")
+}
+func (UnimplementedCommentServiceServer) GetEditHistory(context.Context, *GetEditHistoryRequest) (*GetEditHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEditHistory not implemented")
+}
+func (UnimplementedCommentServiceServer) GetCommentCounts(context.Context, *GetCommentCountsRequest) (*GetCommentCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommentCounts not implemented")
+}
 func (UnimplementedCommentServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
 }
+func (UnimplementedCommentServiceServer) GetCommentByIds(context.Context, *GetCommentByIdsRequest) (*GetCommentByIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommentByIds not implemented")
+}
 func (UnimplementedCommentServiceServer) GetMoreReplies(context.Context, *GetMoreRepliesRequest) (*GetMoreRepliesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMoreReplies not implemented")
 }
+func (UnimplementedCommentServiceServer) ListPendingComments(context.Context, *ListPendingCommentsRequest) (*ListPendingCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingComments not implemented")
+}
+func (UnimplementedCommentServiceServer) ReviewComment(context.Context, *ReviewCommentRequest) (*ReviewCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewComment not implemented")
+}
 func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
 
 // UnsafeCommentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -161,6 +267,60 @@ func _CommentService_DeleteComment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_EditComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).EditComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_EditComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).EditComment(ctx, req.(*EditCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_GetEditHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEditHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).GetEditHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_GetEditHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).GetEditHistory(ctx, req.(*GetEditHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_GetCommentCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).GetCommentCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_GetCommentCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).GetCommentCounts(ctx, req.(*GetCommentCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CommentService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateCommentRequest)
 	if err := dec(in); err != nil {
@@ -179,6 +339,24 @@ func _CommentService_CreateComment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_GetCommentByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentByIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).GetCommentByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_GetCommentByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).GetCommentByIds(ctx, req.(*GetCommentByIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CommentService_GetMoreReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMoreRepliesRequest)
 	if err := dec(in); err != nil {
@@ -197,6 +375,42 @@ func _CommentService_GetMoreReplies_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommentService_ListPendingComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ListPendingComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ListPendingComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ListPendingComments(ctx, req.(*ListPendingCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_ReviewComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ReviewComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ReviewComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ReviewComment(ctx, req.(*ReviewCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -212,14 +426,38 @@ var CommentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteComment",
 			Handler:    _CommentService_DeleteComment_Handler,
 		},
+		{
+			MethodName: "EditComment",
+			Handler:    _CommentService_EditComment_Handler,
+		},
+		{
+			MethodName: "GetEditHistory",
+			Handler:    _CommentService_GetEditHistory_Handler,
+		},
+		{
+			MethodName: "GetCommentCounts",
+			Handler:    _CommentService_GetCommentCounts_Handler,
+		},
 		{
 			MethodName: "CreateComment",
 			Handler:    _CommentService_CreateComment_Handler,
 		},
+		{
+			MethodName: "GetCommentByIds",
+			Handler:    _CommentService_GetCommentByIds_Handler,
+		},
 		{
 			MethodName: "GetMoreReplies",
 			Handler:    _CommentService_GetMoreReplies_Handler,
 		},
+		{
+			MethodName: "ListPendingComments",
+			Handler:    _CommentService_ListPendingComments_Handler,
+		},
+		{
+			MethodName: "ReviewComment",
+			Handler:    _CommentService_ReviewComment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "comment/v1/comment.proto",
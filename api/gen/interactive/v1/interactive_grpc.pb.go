@@ -19,13 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	InteractiveService_IncrReadCnt_FullMethodName    = "/interactive.v1.InteractiveService/IncrReadCnt"
-	InteractiveService_Like_FullMethodName           = "/interactive.v1.InteractiveService/Like"
-	InteractiveService_CancelLike_FullMethodName     = "/interactive.v1.InteractiveService/CancelLike"
-	InteractiveService_Favorite_FullMethodName       = "/interactive.v1.InteractiveService/Favorite"
-	InteractiveService_CancelFavorite_FullMethodName = "/interactive.v1.InteractiveService/CancelFavorite"
-	InteractiveService_Get_FullMethodName            = "/interactive.v1.InteractiveService/Get"
-	InteractiveService_GetByIds_FullMethodName       = "/interactive.v1.InteractiveService/GetByIds"
+	InteractiveService_IncrReadCnt_FullMethodName       = "/interactive.v1.InteractiveService/IncrReadCnt"
+	InteractiveService_Like_FullMethodName              = "/interactive.v1.InteractiveService/Like"
+	InteractiveService_CancelLike_FullMethodName        = "/interactive.v1.InteractiveService/CancelLike"
+	InteractiveService_Favorite_FullMethodName          = "/interactive.v1.InteractiveService/Favorite"
+	InteractiveService_CancelFavorite_FullMethodName    = "/interactive.v1.InteractiveService/CancelFavorite"
+	InteractiveService_Get_FullMethodName               = "/interactive.v1.InteractiveService/Get"
+	InteractiveService_GetByIds_FullMethodName          = "/interactive.v1.InteractiveService/GetByIds"
+	InteractiveService_CreateFavorites_FullMethodName   = "/interactive.v1.InteractiveService/CreateFavorites"
+	InteractiveService_UpdateFavorites_FullMethodName   = "/interactive.v1.InteractiveService/UpdateFavorites"
+	InteractiveService_DeleteFavorites_FullMethodName   = "/interactive.v1.InteractiveService/DeleteFavorites"
+	InteractiveService_ListFavorites_FullMethodName     = "/interactive.v1.InteractiveService/ListFavorites"
+	InteractiveService_ListFavoriteItems_FullMethodName = "/interactive.v1.InteractiveService/ListFavoriteItems"
+	InteractiveService_MoveFavoriteItems_FullMethodName = "/interactive.v1.InteractiveService/MoveFavoriteItems"
+	InteractiveService_ListLiked_FullMethodName         = "/interactive.v1.InteractiveService/ListLiked"
+	InteractiveService_ListReadHistory_FullMethodName   = "/interactive.v1.InteractiveService/ListReadHistory"
+	InteractiveService_ClearReadHistory_FullMethodName  = "/interactive.v1.InteractiveService/ClearReadHistory"
 )
 
 // InteractiveServiceClient is the client API for InteractiveService service.
@@ -39,6 +48,24 @@ type InteractiveServiceClient interface {
 	CancelFavorite(ctx context.Context, in *CancelFavoriteRequest, opts ...grpc.CallOption) (*CancelFavoriteResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	GetByIds(ctx context.Context, in *GetByIdsRequest, opts ...grpc.CallOption) (*GetByIdsResponse, error)
+	// favorites folders, an item is favorited in one folder of the user at most,
+	// Favorite with fid 0 keeps the item out of any folder
+	CreateFavorites(ctx context.Context, in *CreateFavoritesRequest, opts ...grpc.CallOption) (*CreateFavoritesResponse, error)
+	// UpdateFavorites renames the folder or changes its visibility, NOT_FOUND if it is not owned by uid
+	UpdateFavorites(ctx context.Context, in *UpdateFavoritesRequest, opts ...grpc.CallOption) (*UpdateFavoritesResponse, error)
+	// DeleteFavorites deletes the folder with the items in it
+	DeleteFavorites(ctx context.Context, in *DeleteFavoritesRequest, opts ...grpc.CallOption) (*DeleteFavoritesResponse, error)
+	// ListFavorites lists the folders of uid with their latest items as covers, private ones only to the owner
+	ListFavorites(ctx context.Context, in *ListFavoritesRequest, opts ...grpc.CallOption) (*ListFavoritesResponse, error)
+	// ListFavoriteItems pages the items in the folder latest first, a private folder is NOT_FOUND to others
+	ListFavoriteItems(ctx context.Context, in *ListFavoriteItemsRequest, opts ...grpc.CallOption) (*ListFavoriteItemsResponse, error)
+	MoveFavoriteItems(ctx context.Context, in *MoveFavoriteItemsRequest, opts ...grpc.CallOption) (*MoveFavoriteItemsResponse, error)
+	// ListLiked pages what the user liked in the biz, latest liked first
+	ListLiked(ctx context.Context, in *ListLikedRequest, opts ...grpc.CallOption) (*ListLikedResponse, error)
+	// ListReadHistory pages what the user read in the biz, latest read first,
+	// a resource read again is listed once, only the latest reads in the last 90 days are kept
+	ListReadHistory(ctx context.Context, in *ListReadHistoryRequest, opts ...grpc.CallOption) (*ListReadHistoryResponse, error)
+	ClearReadHistory(ctx context.Context, in *ClearReadHistoryRequest, opts ...grpc.CallOption) (*ClearReadHistoryResponse, error)
 }
 
 type interactiveServiceClient struct {
@@ -112,6 +139,87 @@ func (c *interactiveServiceClient) GetByIds(ctx context.Context, in *GetByIdsReq
 	return out, nil
 }
 
+func (c *interactiveServiceClient) CreateFavorites(ctx context.Context, in *CreateFavoritesRequest, opts ...grpc.CallOption) (*CreateFavoritesResponse, error) {
+	out := new(CreateFavoritesResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CreateFavorites_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) UpdateFavorites(ctx context.Context, in *UpdateFavoritesRequest, opts ...grpc.CallOption) (*UpdateFavoritesResponse, error) {
+	out := new(UpdateFavoritesResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_UpdateFavorites_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) DeleteFavorites(ctx context.Context, in *DeleteFavoritesRequest, opts ...grpc.CallOption) (*DeleteFavoritesResponse, error) {
+	out := new(DeleteFavoritesResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_DeleteFavorites_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListFavorites(ctx context.Context, in *ListFavoritesRequest, opts ...grpc.CallOption) (*ListFavoritesResponse, error) {
+	out := new(ListFavoritesResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListFavorites_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListFavoriteItems(ctx context.Context, in *ListFavoriteItemsRequest, opts ...grpc.CallOption) (*ListFavoriteItemsResponse, error) {
+	out := new(ListFavoriteItemsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListFavoriteItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) MoveFavoriteItems(ctx context.Context, in *MoveFavoriteItemsRequest, opts ...grpc.CallOption) (*MoveFavoriteItemsResponse, error) {
+	out := new(MoveFavoriteItemsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_MoveFavoriteItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListLiked(ctx context.Context, in *ListLikedRequest, opts ...grpc.CallOption) (*ListLikedResponse, error) {
+	out := new(ListLikedResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListLiked_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListReadHistory(ctx context.Context, in *ListReadHistoryRequest, opts ...grpc.CallOption) (*ListReadHistoryResponse, error) {
+	out := new(ListReadHistoryResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListReadHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ClearReadHistory(ctx context.Context, in *ClearReadHistoryRequest, opts ...grpc.CallOption) (*ClearReadHistoryResponse, error) {
+	out := new(ClearReadHistoryResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ClearReadHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InteractiveServiceServer is the server API for InteractiveService service.
 // All implementations must embed UnimplementedInteractiveServiceServer
 // for forward compatibility
@@ -123,6 +231,24 @@ type InteractiveServiceServer interface {
 	CancelFavorite(context.Context, *CancelFavoriteRequest) (*CancelFavoriteResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error)
+	// favorites folders, an item is favorited in one folder of the user at most,
+	// Favorite with fid 0 keeps the item out of any folder
+	CreateFavorites(context.Context, *CreateFavoritesRequest) (*CreateFavoritesResponse, error)
+	// UpdateFavorites renames the folder or changes its visibility, NOT_FOUND if it is not owned by uid
+	UpdateFavorites(context.Context, *UpdateFavoritesRequest) (*UpdateFavoritesResponse, error)
+	// DeleteFavorites deletes the folder with the items in it
+	DeleteFavorites(context.Context, *DeleteFavoritesRequest) (*DeleteFavoritesResponse, error)
+	// ListFavorites lists the folders of uid with their latest items as covers, private ones only to the owner
+	ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error)
+	// ListFavoriteItems pages the items in the folder latest first, a private folder is NOT_FOUND to others
+	ListFavoriteItems(context.Context, *ListFavoriteItemsRequest) (*ListFavoriteItemsResponse, error)
+	MoveFavoriteItems(context.Context, *MoveFavoriteItemsRequest) (*MoveFavoriteItemsResponse, error)
+	// ListLiked pages what the user liked in the biz, latest liked first
+	ListLiked(context.Context, *ListLikedRequest) (*ListLikedResponse, error)
+	// ListReadHistory pages what the user read in the biz, latest read first,
+	// a resource read again is listed once, only the latest reads in the last 90 days are kept
+	ListReadHistory(context.Context, *ListReadHistoryRequest) (*ListReadHistoryResponse, error)
+	ClearReadHistory(context.Context, *ClearReadHistoryRequest) (*ClearReadHistoryResponse, error)
 	mustEmbedUnimplementedInteractiveServiceServer()
 }
 
@@ -151,6 +277,33 @@ func (UnimplementedInteractiveServiceServer) Get(context.Context, *GetRequest) (
 func (UnimplementedInteractiveServiceServer) GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByIds not implemented")
 }
+func (UnimplementedInteractiveServiceServer) CreateFavorites(context.Context, *CreateFavoritesRequest) (*CreateFavoritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFavorites not implemented")
+}
+func (UnimplementedInteractiveServiceServer) UpdateFavorites(context.Context, *UpdateFavoritesRequest) (*UpdateFavoritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFavorites not implemented")
+}
+func (UnimplementedInteractiveServiceServer) DeleteFavorites(context.Context, *DeleteFavoritesRequest) (*DeleteFavoritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFavorites not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListFavorites(context.Context, *ListFavoritesRequest) (*ListFavoritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFavorites not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListFavoriteItems(context.Context, *ListFavoriteItemsRequest) (*ListFavoriteItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFavoriteItems not implemented")
+}
+func (UnimplementedInteractiveServiceServer) MoveFavoriteItems(context.Context, *MoveFavoriteItemsRequest) (*MoveFavoriteItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveFavoriteItems not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListLiked(context.Context, *ListLikedRequest) (*ListLikedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLiked not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListReadHistory(context.Context, *ListReadHistoryRequest) (*ListReadHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReadHistory not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ClearReadHistory(context.Context, *ClearReadHistoryRequest) (*ClearReadHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearReadHistory not implemented")
+}
 func (UnimplementedInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {}
 
 // UnsafeInteractiveServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -290,6 +443,168 @@ func _InteractiveService_GetByIds_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveService_CreateFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CreateFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CreateFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CreateFavorites(ctx, req.(*CreateFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_UpdateFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).UpdateFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_UpdateFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).UpdateFavorites(ctx, req.(*UpdateFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_DeleteFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).DeleteFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_DeleteFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).DeleteFavorites(ctx, req.(*DeleteFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListFavorites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListFavorites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListFavorites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListFavorites(ctx, req.(*ListFavoritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListFavoriteItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoriteItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListFavoriteItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListFavoriteItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListFavoriteItems(ctx, req.(*ListFavoriteItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_MoveFavoriteItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveFavoriteItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).MoveFavoriteItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_MoveFavoriteItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).MoveFavoriteItems(ctx, req.(*MoveFavoriteItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListLiked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListLiked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListLiked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListLiked(ctx, req.(*ListLikedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListReadHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReadHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListReadHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListReadHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListReadHistory(ctx, req.(*ListReadHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ClearReadHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearReadHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ClearReadHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ClearReadHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ClearReadHistory(ctx, req.(*ClearReadHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InteractiveService_ServiceDesc is the grpc.ServiceDesc for InteractiveService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -325,6 +640,42 @@ var InteractiveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByIds",
 			Handler:    _InteractiveService_GetByIds_Handler,
 		},
+		{
+			MethodName: "CreateFavorites",
+			Handler:    _InteractiveService_CreateFavorites_Handler,
+		},
+		{
+			MethodName: "UpdateFavorites",
+			Handler:    _InteractiveService_UpdateFavorites_Handler,
+		},
+		{
+			MethodName: "DeleteFavorites",
+			Handler:    _InteractiveService_DeleteFavorites_Handler,
+		},
+		{
+			MethodName: "ListFavorites",
+			Handler:    _InteractiveService_ListFavorites_Handler,
+		},
+		{
+			MethodName: "ListFavoriteItems",
+			Handler:    _InteractiveService_ListFavoriteItems_Handler,
+		},
+		{
+			MethodName: "MoveFavoriteItems",
+			Handler:    _InteractiveService_MoveFavoriteItems_Handler,
+		},
+		{
+			MethodName: "ListLiked",
+			Handler:    _InteractiveService_ListLiked_Handler,
+		},
+		{
+			MethodName: "ListReadHistory",
+			Handler:    _InteractiveService_ListReadHistory_Handler,
+		},
+		{
+			MethodName: "ClearReadHistory",
+			Handler:    _InteractiveService_ClearReadHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "interactive/v1/interactive.proto",
@@ -88,6 +88,7 @@ type Article struct {
 	Author  *Author                `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
 	Ctime   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=ctime,proto3" json:"ctime,omitempty"`
 	Utime   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=utime,proto3" json:"utime,omitempty"`
+	Tags    []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
 func (x *Article) Reset() {
@@ -171,6 +172,13 @@ func (x *Article) GetUtime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Article) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type RankTopNRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -332,6 +340,100 @@ func (x *TopNResponse) GetArticles() []*Article {
 	return nil
 }
 
+type TopNByTagRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *TopNByTagRequest) Reset() {
+	*x = TopNByTagRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ranking_v1_ranking_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopNByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopNByTagRequest) ProtoMessage() {}
+
+func (x *TopNByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ranking_v1_ranking_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopNByTagRequest.ProtoReflect.Descriptor instead.
+func (*TopNByTagRequest) Descriptor() ([]byte, []int) {
+	return file_ranking_v1_ranking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TopNByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type TopNByTagResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Articles []*Article `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+}
+
+func (x *TopNByTagResponse) Reset() {
+	*x = TopNByTagResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ranking_v1_ranking_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopNByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopNByTagResponse) ProtoMessage() {}
+
+func (x *TopNByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ranking_v1_ranking_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopNByTagResponse.ProtoReflect.Descriptor instead.
+func (*TopNByTagResponse) Descriptor() ([]byte, []int) {
+	return file_ranking_v1_ranking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TopNByTagResponse) GetArticles() []*Article {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
 var File_ranking_v1_ranking_proto protoreflect.FileDescriptor
 
 var file_ranking_v1_ranking_proto_rawDesc = []byte{
@@ -342,7 +444,7 @@ var file_ranking_v1_ranking_proto_rawDesc = []byte{
 	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2c, 0x0a, 0x06, 0x41, 0x75, 0x74, 0x68, 0x6f,
 	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
 	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xf1, 0x01, 0x0a, 0x07, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x85, 0x02, 0x0a, 0x07, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c,
 	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
 	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
@@ -357,15 +459,23 @@ var file_ranking_v1_ranking_proto_rawDesc = []byte{
 	0x52, 0x05, 0x63, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x75, 0x74, 0x69, 0x6d, 0x65,
 	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x05, 0x75, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x52, 0x61, 0x6e,
-	0x6b, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x12, 0x0a, 0x10,
-	0x52, 0x61, 0x6e, 0x6b, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x0d, 0x0a, 0x0b, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x3f, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x6d, 0x70, 0x52, 0x05, 0x75, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x11, 0x0a,
+	0x0f, 0x52, 0x61, 0x6e, 0x6b, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x12, 0x0a, 0x10, 0x52, 0x61, 0x6e, 0x6b, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0d, 0x0a, 0x0b, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x22, 0x24, 0x0a, 0x10, 0x54, 0x6f, 0x70, 0x4e, 0x42, 0x79, 0x54, 0x61,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x44, 0x0a, 0x11, 0x54, 0x6f,
+	0x70, 0x4e, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x2f, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
 	0x0b, 0x32, 0x13, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41,
 	0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
-	0x32, 0x96, 0x01, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76,
+	0x32, 0xe2, 0x01, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76,
 	0x69, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x08, 0x52, 0x61, 0x6e, 0x6b, 0x54, 0x6f, 0x70, 0x4e, 0x12,
 	0x1b, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e,
 	0x6b, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72,
@@ -374,18 +484,22 @@ var file_ranking_v1_ranking_proto_rawDesc = []byte{
 	0x54, 0x6f, 0x70, 0x4e, 0x12, 0x17, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x76,
 	0x31, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
 	0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0xa0, 0x01, 0x0a, 0x0e, 0x63, 0x6f,
-	0x6d, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x42, 0x0c, 0x52, 0x61,
-	0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x37, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x61, 0x7a, 0x79, 0x77, 0x6f, 0x6f,
-	0x2f, 0x6d, 0x65, 0x72, 0x63, 0x75, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e,
-	0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x72, 0x61, 0x6e, 0x6b,
-	0x69, 0x6e, 0x67, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x52, 0x58, 0x58, 0xaa, 0x02, 0x0a, 0x52, 0x61,
-	0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0a, 0x52, 0x61, 0x6e, 0x6b, 0x69,
-	0x6e, 0x67, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x16, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5c,
-	0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
-	0x0b, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x09, 0x54, 0x6f, 0x70,
+	0x4e, 0x42, 0x79, 0x54, 0x61, 0x67, 0x12, 0x1c, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x42, 0x79, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0xa0, 0x01, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x2e, 0x72, 0x61,
+	0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x42, 0x0c, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e,
+	0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x73, 0x75, 0x6b, 0x69, 0x79, 0x6f, 0x2f, 0x6d, 0x65, 0x72,
+	0x63, 0x75, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x72, 0x61, 0x6e,
+	0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x76,
+	0x31, 0xa2, 0x02, 0x03, 0x52, 0x58, 0x58, 0xaa, 0x02, 0x0a, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e,
+	0x67, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x0a, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5c, 0x56,
+	0x31, 0xe2, 0x02, 0x16, 0x52, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0x5c, 0x47,
+	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0b, 0x52, 0x61, 0x6e,
+	0x6b, 0x69, 0x6e, 0x67, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -400,7 +514,7 @@ func file_ranking_v1_ranking_proto_rawDescGZIP() []byte {
 	return file_ranking_v1_ranking_proto_rawDescData
 }
 
-var file_ranking_v1_ranking_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_ranking_v1_ranking_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_ranking_v1_ranking_proto_goTypes = []interface{}{
 	(*Author)(nil),                // 0: ranking.v1.Author
 	(*Article)(nil),               // 1: ranking.v1.Article
@@ -408,22 +522,27 @@ var file_ranking_v1_ranking_proto_goTypes = []interface{}{
 	(*RankTopNResponse)(nil),      // 3: ranking.v1.RankTopNResponse
 	(*TopNRequest)(nil),           // 4: ranking.v1.TopNRequest
 	(*TopNResponse)(nil),          // 5: ranking.v1.TopNResponse
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*TopNByTagRequest)(nil),      // 6: ranking.v1.TopNByTagRequest
+	(*TopNByTagResponse)(nil),     // 7: ranking.v1.TopNByTagResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
 }
 var file_ranking_v1_ranking_proto_depIdxs = []int32{
 	0, // 0: ranking.v1.Article.author:type_name -> ranking.v1.Author
-	6, // 1: ranking.v1.Article.ctime:type_name -> google.protobuf.Timestamp
-	6, // 2: ranking.v1.Article.utime:type_name -> google.protobuf.Timestamp
+	8, // 1: ranking.v1.Article.ctime:type_name -> google.protobuf.Timestamp
+	8, // 2: ranking.v1.Article.utime:type_name -> google.protobuf.Timestamp
 	1, // 3: ranking.v1.TopNResponse.articles:type_name -> ranking.v1.Article
-	2, // 4: ranking.v1.RankingService.RankTopN:input_type -> ranking.v1.RankTopNRequest
-	4, // 5: ranking.v1.RankingService.TopN:input_type -> ranking.v1.TopNRequest
-	3, // 6: ranking.v1.RankingService.RankTopN:output_type -> ranking.v1.RankTopNResponse
-	5, // 7: ranking.v1.RankingService.TopN:output_type -> ranking.v1.TopNResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	1, // 4: ranking.v1.TopNByTagResponse.articles:type_name -> ranking.v1.Article
+	2, // 5: ranking.v1.RankingService.RankTopN:input_type -> ranking.v1.RankTopNRequest
+	4, // 6: ranking.v1.RankingService.TopN:input_type -> ranking.v1.TopNRequest
+	6, // 7: ranking.v1.RankingService.TopNByTag:input_type -> ranking.v1.TopNByTagRequest
+	3, // 8: ranking.v1.RankingService.RankTopN:output_type -> ranking.v1.RankTopNResponse
+	5, // 9: ranking.v1.RankingService.TopN:output_type -> ranking.v1.TopNResponse
+	7, // 10: ranking.v1.RankingService.TopNByTag:output_type -> ranking.v1.TopNByTagResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_ranking_v1_ranking_proto_init() }
@@ -504,6 +623,30 @@ func file_ranking_v1_ranking_proto_init() {
 				return nil
 			}
 		}
+		file_ranking_v1_ranking_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopNByTagRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ranking_v1_ranking_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopNByTagResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -511,7 +654,7 @@ func file_ranking_v1_ranking_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ranking_v1_ranking_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
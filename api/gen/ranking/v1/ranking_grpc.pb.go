@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	RankingService_RankTopN_FullMethodName = "/ranking.v1.RankingService/RankTopN"
-	RankingService_TopN_FullMethodName     = "/ranking.v1.RankingService/TopN"
+	RankingService_RankTopN_FullMethodName  = "/ranking.v1.RankingService/RankTopN"
+	RankingService_TopN_FullMethodName      = "/ranking.v1.RankingService/TopN"
+	RankingService_TopNByTag_FullMethodName = "/ranking.v1.RankingService/TopNByTag"
 )
 
 // RankingServiceClient is the client API for RankingService service.
@@ -29,6 +30,7 @@ const (
 type RankingServiceClient interface {
 	RankTopN(ctx context.Context, in *RankTopNRequest, opts ...grpc.CallOption) (*RankTopNResponse, error)
 	TopN(ctx context.Context, in *TopNRequest, opts ...grpc.CallOption) (*TopNResponse, error)
+	TopNByTag(ctx context.Context, in *TopNByTagRequest, opts ...grpc.CallOption) (*TopNByTagResponse, error)
 }
 
 type rankingServiceClient struct {
@@ -57,12 +59,22 @@ func (c *rankingServiceClient) TopN(ctx context.Context, in *TopNRequest, opts .
 	return out, nil
 }
 
+func (c *rankingServiceClient) TopNByTag(ctx context.Context, in *TopNByTagRequest, opts ...grpc.CallOption) (*TopNByTagResponse, error) {
+	out := new(TopNByTagResponse)
+	err := c.cc.Invoke(ctx, RankingService_TopNByTag_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RankingServiceServer is the server API for RankingService service.
 // All implementations must embed UnimplementedRankingServiceServer
 // for forward compatibility
 type RankingServiceServer interface {
 	RankTopN(context.Context, *RankTopNRequest) (*RankTopNResponse, error)
 	TopN(context.Context, *TopNRequest) (*TopNResponse, error)
+	TopNByTag(context.Context, *TopNByTagRequest) (*TopNByTagResponse, error)
 	mustEmbedUnimplementedRankingServiceServer()
 }
 
@@ -76,6 +88,9 @@ func (UnimplementedRankingServiceServer) RankTopN(context.Context, *RankTopNRequ
 func (UnimplementedRankingServiceServer) TopN(context.Context, *TopNRequest) (*TopNResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TopN not implemented")
 }
+func (UnimplementedRankingServiceServer) TopNByTag(context.Context, *TopNByTagRequest) (*TopNByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopNByTag not implemented")
+}
 func (UnimplementedRankingServiceServer) mustEmbedUnimplementedRankingServiceServer() {}
 
 // UnsafeRankingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +140,24 @@ func _RankingService_TopN_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RankingService_TopNByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopNByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RankingServiceServer).TopNByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RankingService_TopNByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RankingServiceServer).TopNByTag(ctx, req.(*TopNByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RankingService_ServiceDesc is the grpc.ServiceDesc for RankingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,6 +173,10 @@ var RankingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TopN",
 			Handler:    _RankingService_TopN_Handler,
 		},
+		{
+			MethodName: "TopNByTag",
+			Handler:    _RankingService_TopNByTag_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ranking/v1/ranking.proto",
@@ -25,6 +25,7 @@ const (
 	UserService_Profile_FullMethodName                = "/user.v1.UserService/Profile"
 	UserService_FindOrCreate_FullMethodName           = "/user.v1.UserService/FindOrCreate"
 	UserService_FindOrCreateByWechat_FullMethodName   = "/user.v1.UserService/FindOrCreateByWechat"
+	UserService_FindByNickNames_FullMethodName        = "/user.v1.UserService/FindByNickNames"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -37,6 +38,8 @@ type UserServiceClient interface {
 	Profile(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
 	FindOrCreate(ctx context.Context, in *FindOrCreateRequest, opts ...grpc.CallOption) (*FindOrCreateResponse, error)
 	FindOrCreateByWechat(ctx context.Context, in *FindOrCreateByWechatRequest, opts ...grpc.CallOption) (*FindOrCreateByWechatResponse, error)
+	// FindByNickNames returns the users having the nick names, a nick name shared by several users returns all of them.
+	FindByNickNames(ctx context.Context, in *FindByNickNamesRequest, opts ...grpc.CallOption) (*FindByNickNamesResponse, error)
 }
 
 type userServiceClient struct {
@@ -101,6 +104,15 @@ func (c *userServiceClient) FindOrCreateByWechat(ctx context.Context, in *FindOr
 	return out, nil
 }
 
+func (c *userServiceClient) FindByNickNames(ctx context.Context, in *FindByNickNamesRequest, opts ...grpc.CallOption) (*FindByNickNamesResponse, error) {
+	out := new(FindByNickNamesResponse)
+	err := c.cc.Invoke(ctx, UserService_FindByNickNames_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -111,6 +123,8 @@ type UserServiceServer interface {
 	Profile(context.Context, *ProfileRequest) (*ProfileResponse, error)
 	FindOrCreate(context.Context, *FindOrCreateRequest) (*FindOrCreateResponse, error)
 	FindOrCreateByWechat(context.Context, *FindOrCreateByWechatRequest) (*FindOrCreateByWechatResponse, error)
+	// FindByNickNames returns the users having the nick names, a nick name shared by several users returns all of them.
+	FindByNickNames(context.Context, *FindByNickNamesRequest) (*FindByNickNamesResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -136,6 +150,9 @@ func (UnimplementedUserServiceServer) FindOrCreate(context.Context, *FindOrCreat
 func (UnimplementedUserServiceServer) FindOrCreateByWechat(context.Context, *FindOrCreateByWechatRequest) (*FindOrCreateByWechatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindOrCreateByWechat not implemented")
 }
+func (UnimplementedUserServiceServer) FindByNickNames(context.Context, *FindByNickNamesRequest) (*FindByNickNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindByNickNames not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -257,6 +274,24 @@ func _UserService_FindOrCreateByWechat_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_FindByNickNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindByNickNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FindByNickNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_FindByNickNames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FindByNickNames(ctx, req.(*FindByNickNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -288,6 +323,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindOrCreateByWechat",
 			Handler:    _UserService_FindOrCreateByWechat_Handler,
 		},
+		{
+			MethodName: "FindByNickNames",
+			Handler:    _UserService_FindByNickNames_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/v1/user.proto",
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/crontask/domain"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var _ cronx.Executor = (*BatchExecutor)(nil)
+
+// BatchFunc processes at most limit articles due at now, returning how many it processed.
+type BatchFunc func(ctx context.Context, now time.Time, limit int) (int, error)
+
+// BatchExecutor runs a batch of the article service, such as publishing the scheduled articles,
+// flushing the autosaved drafts or purging the trashed articles. Each batch claims the articles
+// by their status, or unmarks them only once written, so executing it again after a preemption failover,
+// or on several instances at the same time, processes each article once.
+type BatchExecutor struct {
+	name      string
+	fn        BatchFunc
+	timeout   time.Duration
+	batchSize int
+	l         logger.Logger
+}
+
+func NewBatchExecutor(name string, fn BatchFunc, timeout time.Duration, l logger.Logger) *BatchExecutor {
+	return &BatchExecutor{
+		name:      name,
+		fn:        fn,
+		timeout:   timeout,
+		batchSize: 100,
+		l:         l,
+	}
+}
+
+func (executor *BatchExecutor) Name() string {
+	return executor.name
+}
+
+func (executor *BatchExecutor) Exec(ctx context.Context, tsk domain.Task) error {
+	ctx, cancel := context.WithTimeout(ctx, executor.timeout)
+	defer cancel()
+	cnt, err := executor.fn(ctx, time.Now(), executor.batchSize)
+	if cnt > 0 {
+		executor.l.Info("article batch executed",
+			logger.String("task", tsk.Name),
+			logger.String("executor", executor.name),
+			logger.Int("count", cnt))
+	}
+	return err
+}
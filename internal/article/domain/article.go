@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"strings"
 	"time"
+
+	"github.com/tsukiyo/mercury/pkg/markdown"
 )
 
 type ArticleStatus uint8
@@ -11,24 +14,66 @@ const (
 	ArticleStatusUnpublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	ArticleStatusScheduled
+	// ArticleStatusTrashed the article is deleted by the owner, it could be restored until it is purged
+	ArticleStatusTrashed
 )
 
 type Article struct {
-	Id      int64
-	Title   string
-	Content string
-	Author  Author
-	Status  ArticleStatus
-	Ctime   time.Time
-	Utime   time.Time
+	Id          int64
+	Title       string
+	Content     string
+	Author      Author
+	Status      ArticleStatus
+	ScheduledAt time.Time
+	Tags        []string
+	// Authors are Author, who owns the article, followed by the editors, only set for the published article
+	Authors []Author
+	// Version increases every time the content is saved, a new article starts at 1.
+	// A save based on an older version is rejected, zero saves unconditionally.
+	Version int64
+	// Abstract is the plain text of Content cut short, it is cut when the content is saved
+	Abstract string
+	// Rendered is produced from Content on publish, it is only set for published articles
+	Rendered RenderedContent
+	// TrashedAt is only set for trashed articles
+	TrashedAt time.Time
+	Ctime     time.Time
+	Utime     time.Time
 }
 
-func (atcl Article) Abstract() string {
-	cs := []rune(atcl.Content)
-	if len(cs) < 100 {
-		return atcl.Content
-	}
-	return string(cs[:100]) + "..."
+// AbstractOf cuts the plain text of the markdown content short.
+// It parses the whole content, so it is called when the content is saved instead of when it is read.
+func AbstractOf(content string) string {
+	return markdown.Abstract(markdown.Text(markdown.Parse(content)), markdown.AbstractLength)
+}
+
+// Draft is the autosaved content of an article, it is kept apart from the article until the author saves it.
+type Draft struct {
+	ArticleId int64
+	AuthorId  int64
+	Title     string
+	Content   string
+	Tags      []string
+	// BaseVersion is the version of the article the draft is edited from
+	BaseVersion int64
+	Utime       time.Time
+}
+
+// RenderedContent is what readers are served instead of the markdown source.
+type RenderedContent struct {
+	// HTML is sanitized
+	HTML        string
+	Abstract    string
+	ReadingTime time.Duration
+	TOC         []TOCEntry
+}
+
+// TOCEntry is a heading of the article, Anchor is the id of the heading in HTML.
+type TOCEntry struct {
+	Level  int
+	Title  string
+	Anchor string
 }
 
 func (status ArticleStatus) ToUint8() uint8 {
@@ -51,12 +96,155 @@ func (status ArticleStatus) String() string {
 		return "published"
 	case ArticleStatusPrivate:
 		return "private"
+	case ArticleStatusScheduled:
+		return "scheduled"
+	case ArticleStatusTrashed:
+		return "trashed"
 	default:
 		return "unknown"
 	}
 }
 
+const (
+	MaxTagsPerArticle = 10
+	MaxTagLength      = 32
+)
+
+// NormalizeTags trims, lower-cases and de-duplicates tags in their original order, empty tags are dropped.
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	return res
+}
+
+// TagCount is the number of published articles carrying the tag.
+type TagCount struct {
+	Tag   string
+	Count int64
+}
+
 type Author struct {
 	Id   int64
 	Name string
 }
+
+// CollaboratorRole is ordered, a role is allowed to do whatever the lower ones do.
+// Viewers read the article and its revisions, editors save, publish and schedule it,
+// and the owner, who is the author, manages the collaborators.
+type CollaboratorRole uint8
+
+const (
+	CollaboratorRoleUnknown CollaboratorRole = iota
+	CollaboratorRoleViewer
+	CollaboratorRoleEditor
+	CollaboratorRoleOwner
+)
+
+func (role CollaboratorRole) ToUint8() uint8 {
+	return uint8(role)
+}
+
+// Covers reports whether the role is allowed to do what the other role does.
+func (role CollaboratorRole) Covers(other CollaboratorRole) bool {
+	return other.Valid() && role >= other
+}
+
+func (role CollaboratorRole) Valid() bool {
+	return role > CollaboratorRoleUnknown && role <= CollaboratorRoleOwner
+}
+
+// Invitable reports whether a user could be invited with the role, an article has only one owner.
+func (role CollaboratorRole) Invitable() bool {
+	return role == CollaboratorRoleViewer || role == CollaboratorRoleEditor
+}
+
+func (role CollaboratorRole) String() string {
+	switch role {
+	case CollaboratorRoleViewer:
+		return "viewer"
+	case CollaboratorRoleEditor:
+		return "editor"
+	case CollaboratorRoleOwner:
+		return "owner"
+	default:
+		return "unknown"
+	}
+}
+
+type CollaboratorStatus uint8
+
+const (
+	CollaboratorStatusUnknown CollaboratorStatus = iota
+	// CollaboratorStatusPending is invited and has no access until the invitation is accepted
+	CollaboratorStatusPending
+	CollaboratorStatusAccepted
+)
+
+func (status CollaboratorStatus) ToUint8() uint8 {
+	return uint8(status)
+}
+
+// Collaborator is a user invited to work on the article, the owner is listed as an accepted one.
+type Collaborator struct {
+	ArticleId int64
+	User      Author
+	Role      CollaboratorRole
+	Status    CollaboratorStatus
+	InviterId int64
+	Ctime     time.Time
+	Utime     time.Time
+}
+
+// ArticleRevision is a snapshot of an article taken on every save and publish.
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	Title     string
+	Content   string
+	Author    Author
+	Status    ArticleStatus
+	Ctime     time.Time
+}
+
+type DiffOp uint8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpInsert
+	DiffOpDelete
+)
+
+func (op DiffOp) String() string {
+	switch op {
+	case DiffOpInsert:
+		return "insert"
+	case DiffOpDelete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+type DiffLine struct {
+	Op      DiffOp
+	Content string
+}
+
+// RevisionDiff is the line-level difference between two revisions of an article.
+type RevisionDiff struct {
+	FromId  int64
+	ToId    int64
+	Title   []DiffLine
+	Content []DiffLine
+}
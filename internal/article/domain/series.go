@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// MaxArticlesPerSeries bounds a series, which is loaded in full for reordering and navigation.
+const MaxArticlesPerSeries = 200
+
+// Series groups published articles of the author in order, an article belongs to one series at most.
+type Series struct {
+	Id          int64
+	Author      Author
+	Title       string
+	Description string
+	// Articles are in order, the ones withdrawn since they were added are left out
+	Articles []SeriesArticle
+	Ctime    time.Time
+	Utime    time.Time
+}
+
+// SeriesArticle is a published article in a series with its interactive counts.
+type SeriesArticle struct {
+	Id          int64
+	Title       string
+	Abstract    string
+	ReadCnt     int64
+	LikeCnt     int64
+	FavoriteCnt int64
+	Utime       time.Time
+}
+
+// SeriesNav locates a published article in its series, Prev and Next have zero ids at either end.
+type SeriesNav struct {
+	SeriesId    int64
+	SeriesTitle string
+	Prev        SeriesArticle
+	Next        SeriesArticle
+}
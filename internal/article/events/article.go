@@ -2,17 +2,73 @@ package events
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/IBM/sarama"
 )
 
-const topicReadEvent = "article_read_event"
+const (
+	topicReadEvent      = "article_read_event"
+	TopicPublishEvent   = "article_publish_event"
+	TopicWithdrawEvent  = "article_withdraw_event"
+	TopicArticleChanged = "article_changed_event"
+	TopicDeleteEvent    = "article_delete_event"
+)
 
 type ReadEvent struct {
 	Aid int64
 	Uid int64
 }
 
+// PublishEvent carries the published version of the article
+type PublishEvent struct {
+	Aid      int64
+	AuthorId int64
+	Title    string
+	Content  string
+	// Utime unix timestamp in milliseconds
+	Utime int64
+}
+
+type WithdrawEvent struct {
+	Aid int64
+	// Utime unix timestamp in milliseconds
+	Utime int64
+}
+
+// DeleteEvent is sent once the article is purged from the trash,
+// the data of other services tied to the article should be deleted along with it.
+type DeleteEvent struct {
+	Aid      int64
+	AuthorId int64
+	// Utime unix timestamp in milliseconds
+	Utime int64
+}
+
+type ArticleChangeKind string
+
+const (
+	// ArticleChangePublished the article goes online for the first time or again after withdrawal
+	ArticleChangePublished ArticleChangeKind = "published"
+	// ArticleChangeUpdated the online article is republished with new content
+	ArticleChangeUpdated ArticleChangeKind = "updated"
+	// ArticleChangeWithdrawn the article is taken offline
+	ArticleChangeWithdrawn ArticleChangeKind = "withdrawn"
+)
+
+// ArticleChangedEvent tells downstream services that the online library has changed.
+type ArticleChangedEvent struct {
+	Kind     ArticleChangeKind
+	Aid      int64
+	AuthorId int64
+	Title    string
+	Status   uint8
+	// Utime unix timestamp in milliseconds
+	Utime int64
+}
+
+// SaramaSyncProducer sends the read events to kafka directly, they need no transaction to go along with.
+// Events of the online library are written to the outbox by the DAO inside its transactions instead.
 type SaramaSyncProducer struct {
 	producer sarama.SyncProducer
 }
@@ -28,10 +84,10 @@ func (pdr *SaramaSyncProducer) ProduceReadEvent(evt ReadEvent) error {
 	if err != nil {
 		return err
 	}
-	_, _, err = pdr.producer.
-		SendMessage(&sarama.ProducerMessage{
-			Topic: topicReadEvent,
-			Value: sarama.ByteEncoder(val),
-		})
+	_, _, err = pdr.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicReadEvent,
+		Key:   sarama.StringEncoder(strconv.FormatInt(evt.Aid, 10)),
+		Value: sarama.ByteEncoder(val),
+	})
 	return err
 }
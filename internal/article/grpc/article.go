@@ -2,7 +2,11 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/tsukiyo/mercury/internal/article/domain"
@@ -16,11 +20,13 @@ import (
 type ArticleServiceServer struct {
 	articlev1.UnimplementedArticleServiceServer
 	service service.ArticleService
+	series  service.SeriesService
 }
 
-func NewArticleServiceServer(svc service.ArticleService) *ArticleServiceServer {
+func NewArticleServiceServer(svc service.ArticleService, series service.SeriesService) *ArticleServiceServer {
 	return &ArticleServiceServer{
 		service: svc,
+		series:  series,
 	}
 }
 
@@ -29,13 +35,57 @@ func (a *ArticleServiceServer) Register(server grpc.ServiceRegistrar) {
 }
 
 func (a *ArticleServiceServer) Save(ctx context.Context, req *articlev1.SaveRequest) (*articlev1.SaveResponse, error) {
-	id, err := a.service.Save(ctx, convertToDomain(req.Article))
-	return &articlev1.SaveResponse{Id: id}, err
+	id, version, err := a.service.Save(ctx, convertToDomain(req.Article))
+	if err != nil {
+		return nil, toConflictStatus(err)
+	}
+	return &articlev1.SaveResponse{Id: id, Version: version}, nil
 }
 
 func (a *ArticleServiceServer) Publish(ctx context.Context, req *articlev1.PublishRequest) (*articlev1.PublishResponse, error) {
 	id, err := a.service.Publish(ctx, convertToDomain(req.Article))
-	return &articlev1.PublishResponse{Id: id}, err
+	if errors.Is(err, service.ErrContentRejected) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, toConflictStatus(err)
+	}
+	return &articlev1.PublishResponse{Id: id}, nil
+}
+
+func (a *ArticleServiceServer) AutosaveDraft(ctx context.Context, req *articlev1.AutosaveDraftRequest) (*articlev1.AutosaveDraftResponse, error) {
+	draft := req.GetDraft()
+	err := a.service.AutosaveDraft(ctx, domain.Draft{
+		ArticleId:   draft.GetArticleId(),
+		AuthorId:    draft.GetAuthorId(),
+		Title:       draft.GetTitle(),
+		Content:     draft.GetContent(),
+		Tags:        draft.GetTags(),
+		BaseVersion: draft.GetBaseVersion(),
+	})
+	if err != nil {
+		return nil, toConflictStatus(err)
+	}
+	return &articlev1.AutosaveDraftResponse{}, nil
+}
+
+func (a *ArticleServiceServer) GetDraft(ctx context.Context, req *articlev1.GetDraftRequest) (*articlev1.GetDraftResponse, error) {
+	draft, err := a.service.GetDraft(ctx, req.GetId(), req.GetAuthorId())
+	if errors.Is(err, service.ErrDraftNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &articlev1.GetDraftResponse{Draft: &articlev1.Draft{
+		ArticleId:   draft.ArticleId,
+		AuthorId:    draft.AuthorId,
+		Title:       draft.Title,
+		Content:     draft.Content,
+		Tags:        draft.Tags,
+		BaseVersion: draft.BaseVersion,
+		Utime:       timestamppb.New(draft.Utime),
+	}}, nil
 }
 
 func (a *ArticleServiceServer) Withdraw(ctx context.Context, req *articlev1.WithdrawRequest) (*articlev1.WithdrawResponse, error) {
@@ -44,7 +94,17 @@ func (a *ArticleServiceServer) Withdraw(ctx context.Context, req *articlev1.With
 }
 
 func (a *ArticleServiceServer) List(ctx context.Context, req *articlev1.ListRequest) (*articlev1.ListResponse, error) {
-	articles, err := a.service.List(ctx, req.GetAuthor(), int(req.GetOffset()), int(req.GetLimit()))
+	var (
+		articles []domain.Article
+		next     string
+		err      error
+	)
+	// offset is kept for the callers not moved to cursor yet
+	if offset := req.GetOffset(); offset > 0 && req.GetCursor() == "" {
+		articles, err = a.service.List(ctx, req.GetAuthor(), int(offset), int(req.GetLimit()))
+	} else {
+		articles, next, err = a.service.ListByCursor(ctx, req.GetAuthor(), req.GetCursor(), int(req.GetLimit()))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -52,27 +112,192 @@ func (a *ArticleServiceServer) List(ctx context.Context, req *articlev1.ListRequ
 	for _, article := range articles {
 		res = append(res, convertToV(article))
 	}
-	return &articlev1.ListResponse{Articles: res}, nil
+	return &articlev1.ListResponse{Articles: res, NextCursor: next}, nil
 }
 
 func (a *ArticleServiceServer) GetById(ctx context.Context, req *articlev1.GetByIdRequest) (*articlev1.GetByIdResponse, error) {
-	article, err := a.service.GetById(ctx, req.GetId())
+	article, err := a.service.GetById(ctx, req.GetId(), req.GetUid())
 	if err != nil {
-		return nil, err
+		return nil, toCollaboratorStatus(err)
 	}
 	return &articlev1.GetByIdResponse{Article: convertToV(article)}, nil
 }
 
+func (a *ArticleServiceServer) InviteCollaborator(ctx context.Context, req *articlev1.InviteCollaboratorRequest) (*articlev1.InviteCollaboratorResponse, error) {
+	err := a.service.InviteCollaborator(ctx, domain.Collaborator{
+		ArticleId: req.GetId(),
+		User: domain.Author{
+			Id: req.GetInviteeId(),
+		},
+		Role:      domain.CollaboratorRole(req.GetRole()),
+		InviterId: req.GetUid(),
+	})
+	if err != nil {
+		return nil, toCollaboratorStatus(err)
+	}
+	return &articlev1.InviteCollaboratorResponse{}, nil
+}
+
+func (a *ArticleServiceServer) AcceptInvitation(ctx context.Context, req *articlev1.AcceptInvitationRequest) (*articlev1.AcceptInvitationResponse, error) {
+	err := a.service.AcceptInvitation(ctx, req.GetId(), req.GetUid())
+	if err != nil {
+		return nil, toCollaboratorStatus(err)
+	}
+	return &articlev1.AcceptInvitationResponse{}, nil
+}
+
+func (a *ArticleServiceServer) RevokeCollaborator(ctx context.Context, req *articlev1.RevokeCollaboratorRequest) (*articlev1.RevokeCollaboratorResponse, error) {
+	err := a.service.RevokeCollaborator(ctx, req.GetId(), req.GetUid(), req.GetCollaboratorId())
+	if err != nil {
+		return nil, toCollaboratorStatus(err)
+	}
+	return &articlev1.RevokeCollaboratorResponse{}, nil
+}
+
+func (a *ArticleServiceServer) ListCollaborators(ctx context.Context, req *articlev1.ListCollaboratorsRequest) (*articlev1.ListCollaboratorsResponse, error) {
+	collabs, err := a.service.ListCollaborators(ctx, req.GetId(), req.GetUid())
+	if err != nil {
+		return nil, toCollaboratorStatus(err)
+	}
+	list := make([]*articlev1.Collaborator, 0, len(collabs))
+	for _, c := range collabs {
+		list = append(list, &articlev1.Collaborator{
+			ArticleId: c.ArticleId,
+			Uid:       c.User.Id,
+			Role:      articlev1.CollaboratorRole(c.Role),
+			Status:    articlev1.CollaboratorStatus(c.Status),
+			InviterId: c.InviterId,
+			Ctime:     timestamppb.New(c.Ctime),
+		})
+	}
+	return &articlev1.ListCollaboratorsResponse{Collaborators: list}, nil
+}
+
+// toCollaboratorStatus reports the errors of checking roles and managing collaborators with their codes.
+func toCollaboratorStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrPossibleIncorrectAuthor):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrCollaboratorNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidInvitation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
+
 func (a *ArticleServiceServer) GetPublishedById(ctx context.Context, req *articlev1.GetPublishedByIdRequest) (*articlev1.GetPublishedByIdResponse, error) {
 	article, err := a.service.GetPublishedById(ctx, req.GetId(), req.GetUid())
+	if errors.Is(err, service.ErrArticleTrashed) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	nav, err := a.series.GetNav(ctx, req.GetId())
 	if err != nil {
 		return nil, err
 	}
-	return &articlev1.GetPublishedByIdResponse{Article: convertToV(article)}, nil
+	return &articlev1.GetPublishedByIdResponse{
+		Article: convertToV(article),
+		Series:  convertSeriesNavToV(nav),
+	}, nil
+}
+
+func (a *ArticleServiceServer) RecordRead(ctx context.Context, req *articlev1.RecordReadRequest) (*articlev1.RecordReadResponse, error) {
+	err := a.service.RecordRead(ctx, req.GetId(), req.GetUid())
+	return &articlev1.RecordReadResponse{}, err
+}
+
+func (a *ArticleServiceServer) ExistsPublished(ctx context.Context, req *articlev1.ExistsPublishedRequest) (*articlev1.ExistsPublishedResponse, error) {
+	authorId, exists, err := a.service.ExistsPublished(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &articlev1.ExistsPublishedResponse{Exists: exists, AuthorId: authorId}, nil
 }
 
 func (a *ArticleServiceServer) ListPub(ctx context.Context, req *articlev1.ListPubRequest) (*articlev1.ListPubResponse, error) {
-	atcls, err := a.service.ListPub(ctx, req.GetStartTime().AsTime(), int(req.GetOffset()), int(req.GetLimit()))
+	var (
+		atcls []domain.Article
+		next  string
+		err   error
+	)
+	// offset is kept for the callers not moved to cursor yet
+	if offset := req.GetOffset(); offset > 0 && req.GetCursor() == "" {
+		atcls, err = a.service.ListPub(ctx, req.GetStartTime().AsTime(), int(offset), int(req.GetLimit()))
+	} else {
+		// without start_time the first page starts from the latest article
+		var start time.Time
+		if req.GetStartTime() != nil {
+			start = req.GetStartTime().AsTime()
+		}
+		atcls, next, err = a.service.ListPubByCursor(ctx, start, req.GetCursor(), int(req.GetLimit()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.Article, 0, len(atcls))
+	for _, atcl := range atcls {
+		list = append(list, convertToV(atcl))
+	}
+	return &articlev1.ListPubResponse{Articles: list, NextCursor: next}, nil
+}
+
+func (a *ArticleServiceServer) ListRevisions(ctx context.Context, req *articlev1.ListRevisionsRequest) (*articlev1.ListRevisionsResponse, error) {
+	revs, err := a.service.ListRevisions(ctx, req.GetId(), req.GetUid(), int(req.GetOffset()), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*articlev1.ArticleRevision, 0, len(revs))
+	for _, rev := range revs {
+		list = append(list, convertRevisionToV(rev))
+	}
+	return &articlev1.ListRevisionsResponse{Revisions: list}, nil
+}
+
+func (a *ArticleServiceServer) GetRevision(ctx context.Context, req *articlev1.GetRevisionRequest) (*articlev1.GetRevisionResponse, error) {
+	rev, err := a.service.GetRevision(ctx, req.GetId(), req.GetRevisionId(), req.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &articlev1.GetRevisionResponse{Revision: convertRevisionToV(rev)}, nil
+}
+
+func (a *ArticleServiceServer) DiffRevisions(ctx context.Context, req *articlev1.DiffRevisionsRequest) (*articlev1.DiffRevisionsResponse, error) {
+	diff, err := a.service.DiffRevisions(ctx, req.GetId(), req.GetFromRevisionId(), req.GetToRevisionId(), req.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &articlev1.DiffRevisionsResponse{
+		FromRevisionId: diff.FromId,
+		ToRevisionId:   diff.ToId,
+		Title:          convertDiffLinesToV(diff.Title),
+		Content:        convertDiffLinesToV(diff.Content),
+	}, nil
+}
+
+func (a *ArticleServiceServer) RestoreRevision(ctx context.Context, req *articlev1.RestoreRevisionRequest) (*articlev1.RestoreRevisionResponse, error) {
+	err := a.service.RestoreRevision(ctx, req.GetId(), req.GetRevisionId(), req.GetUid())
+	return &articlev1.RestoreRevisionResponse{}, err
+}
+
+func (a *ArticleServiceServer) SchedulePublish(ctx context.Context, req *articlev1.SchedulePublishRequest) (*articlev1.SchedulePublishResponse, error) {
+	err := a.service.SchedulePublish(ctx, req.GetId(), req.GetUid(), req.GetPublishTime().AsTime())
+	if errors.Is(err, service.ErrContentRejected) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &articlev1.SchedulePublishResponse{}, err
+}
+
+func (a *ArticleServiceServer) CancelScheduledPublish(ctx context.Context, req *articlev1.CancelScheduledPublishRequest) (*articlev1.CancelScheduledPublishResponse, error) {
+	err := a.service.CancelScheduledPublish(ctx, req.GetId(), req.GetUid())
+	return &articlev1.CancelScheduledPublishResponse{}, err
+}
+
+func (a *ArticleServiceServer) ListPubByTag(ctx context.Context, req *articlev1.ListPubByTagRequest) (*articlev1.ListPubByTagResponse, error) {
+	atcls, next, err := a.service.ListPubByTag(ctx, req.GetTag(), req.GetCursor(), int(req.GetLimit()))
 	if err != nil {
 		return nil, err
 	}
@@ -80,11 +305,23 @@ func (a *ArticleServiceServer) ListPub(ctx context.Context, req *articlev1.ListP
 	for _, atcl := range atcls {
 		list = append(list, convertToV(atcl))
 	}
-	return &articlev1.ListPubResponse{Articles: list}, nil
+	return &articlev1.ListPubByTagResponse{Articles: list, NextCursor: next}, nil
+}
+
+func (a *ArticleServiceServer) CountTags(ctx context.Context, req *articlev1.CountTagsRequest) (*articlev1.CountTagsResponse, error) {
+	cnts, err := a.service.CountTags(ctx, req.GetTags(), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]*articlev1.TagCount, 0, len(cnts))
+	for _, cnt := range cnts {
+		tags = append(tags, &articlev1.TagCount{Tag: cnt.Tag, Count: cnt.Count})
+	}
+	return &articlev1.CountTagsResponse{Tags: tags}, nil
 }
 
 func convertToV(domainArticle domain.Article) *articlev1.Article {
-	return &articlev1.Article{
+	res := &articlev1.Article{
 		Id:      domainArticle.Id,
 		Title:   domainArticle.Title,
 		Status:  int32(domainArticle.Status),
@@ -93,10 +330,60 @@ func convertToV(domainArticle domain.Article) *articlev1.Article {
 			Id:   domainArticle.Author.Id,
 			Name: domainArticle.Author.Name,
 		},
-		Ctime:    timestamppb.New(domainArticle.Ctime),
-		Utime:    timestamppb.New(domainArticle.Utime),
-		Abstract: domainArticle.Abstract(),
+		Ctime:       timestamppb.New(domainArticle.Ctime),
+		Utime:       timestamppb.New(domainArticle.Utime),
+		Abstract:    domainArticle.Abstract,
+		Tags:        domainArticle.Tags,
+		Html:        domainArticle.Rendered.HTML,
+		ReadingTime: int64(domainArticle.Rendered.ReadingTime / time.Second),
+		Toc:         convertTOCToV(domainArticle.Rendered.TOC),
+		Version:     domainArticle.Version,
+		Authors:     convertAuthorsToV(domainArticle.Authors),
+	}
+	if !domainArticle.ScheduledAt.IsZero() {
+		res.ScheduledAt = timestamppb.New(domainArticle.ScheduledAt)
+	}
+	if !domainArticle.TrashedAt.IsZero() {
+		res.TrashedAt = timestamppb.New(domainArticle.TrashedAt)
+	}
+	return res
+}
+
+// toConflictStatus reports a version conflict as ABORTED with the latest article in the details,
+// editing a trashed article as FAILED_PRECONDITION.
+func toConflictStatus(err error) error {
+	var conflict *service.VersionConflictError
+	if !errors.As(err, &conflict) {
+		return toTrashStatus(err)
 	}
+	st, er := status.New(codes.Aborted, conflict.Error()).WithDetails(convertToV(conflict.Current))
+	if er != nil {
+		return status.Error(codes.Aborted, conflict.Error())
+	}
+	return st.Err()
+}
+
+func convertAuthorsToV(authors []domain.Author) []*articlev1.Author {
+	res := make([]*articlev1.Author, 0, len(authors))
+	for _, author := range authors {
+		res = append(res, &articlev1.Author{
+			Id:   author.Id,
+			Name: author.Name,
+		})
+	}
+	return res
+}
+
+func convertTOCToV(toc []domain.TOCEntry) []*articlev1.TocEntry {
+	res := make([]*articlev1.TocEntry, 0, len(toc))
+	for _, entry := range toc {
+		res = append(res, &articlev1.TocEntry{
+			Level:  int32(entry.Level),
+			Title:  entry.Title,
+			Anchor: entry.Anchor,
+		})
+	}
+	return res
 }
 
 func convertToDomain(vArticle *articlev1.Article) domain.Article {
@@ -108,8 +395,32 @@ func convertToDomain(vArticle *articlev1.Article) domain.Article {
 			Id:   vArticle.GetAuthor().GetId(),
 			Name: vArticle.GetAuthor().GetName(),
 		},
-		Status: domain.ArticleStatus(vArticle.GetStatus()),
-		Ctime:  vArticle.GetCtime().AsTime(),
-		Utime:  vArticle.GetUtime().AsTime(),
+		Status:  domain.ArticleStatus(vArticle.GetStatus()),
+		Tags:    vArticle.GetTags(),
+		Version: vArticle.GetVersion(),
+		Ctime:   vArticle.GetCtime().AsTime(),
+		Utime:   vArticle.GetUtime().AsTime(),
+	}
+}
+
+func convertRevisionToV(rev domain.ArticleRevision) *articlev1.ArticleRevision {
+	return &articlev1.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Status:    int32(rev.Status),
+		Ctime:     timestamppb.New(rev.Ctime),
+	}
+}
+
+func convertDiffLinesToV(lines []domain.DiffLine) []*articlev1.DiffLine {
+	res := make([]*articlev1.DiffLine, 0, len(lines))
+	for _, line := range lines {
+		res = append(res, &articlev1.DiffLine{
+			Op:      articlev1.DiffOp(line.Op),
+			Content: line.Content,
+		})
 	}
+	return res
 }
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	"github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/internal/article/service"
+)
+
+func (a *ArticleServiceServer) CreateSeries(ctx context.Context, req *articlev1.CreateSeriesRequest) (*articlev1.CreateSeriesResponse, error) {
+	id, err := a.series.Create(ctx, convertSeriesToDomain(req.GetSeries()))
+	if err != nil {
+		return nil, toSeriesStatus(err)
+	}
+	return &articlev1.CreateSeriesResponse{Id: id}, nil
+}
+
+func (a *ArticleServiceServer) UpdateSeries(ctx context.Context, req *articlev1.UpdateSeriesRequest) (*articlev1.UpdateSeriesResponse, error) {
+	err := a.series.Update(ctx, convertSeriesToDomain(req.GetSeries()))
+	if err != nil {
+		return nil, toSeriesStatus(err)
+	}
+	return &articlev1.UpdateSeriesResponse{}, nil
+}
+
+func (a *ArticleServiceServer) DeleteSeries(ctx context.Context, req *articlev1.DeleteSeriesRequest) (*articlev1.DeleteSeriesResponse, error) {
+	err := a.series.Delete(ctx, req.GetId(), req.GetUid())
+	if err != nil {
+		return nil, toSeriesStatus(err)
+	}
+	return &articlev1.DeleteSeriesResponse{}, nil
+}
+
+func (a *ArticleServiceServer) GetSeries(ctx context.Context, req *articlev1.GetSeriesRequest) (*articlev1.GetSeriesResponse, error) {
+	s, err := a.series.GetSeries(ctx, req.GetId())
+	if err != nil {
+		return nil, toSeriesStatus(err)
+	}
+	return &articlev1.GetSeriesResponse{Series: convertSeriesToV(s)}, nil
+}
+
+func (a *ArticleServiceServer) ListSeries(ctx context.Context, req *articlev1.ListSeriesRequest) (*articlev1.ListSeriesResponse, error) {
+	list, err := a.series.ListSeries(ctx, req.GetAuthorId())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*articlev1.Series, 0, len(list))
+	for _, s := range list {
+		res = append(res, convertSeriesToV(s))
+	}
+	return &articlev1.ListSeriesResponse{Series: res}, nil
+}
+
+func (a *ArticleServiceServer) AddSeriesArticle(ctx context.Context, req *articlev1.AddSeriesArticleRequest) (*articlev1.AddSeriesArticleResponse, error) {
+	err := a.series.AddArticle(ctx, req.GetId(), req.GetUid(), req.GetArticleId())
+	if err != nil {
+		return nil, toSeriesStatus(err)
+	}
+	return &articlev1.AddSeriesArticleResponse{}, nil
+}
+
+func (a *ArticleServiceServer) RemoveSeriesArticle(ctx context.Context, req *articlev1.RemoveSeriesArticleRequest) (*articlev1.RemoveSeriesArticleResponse, error) {
+	err := a.series.RemoveArticle(ctx, req.GetId(), req.GetUid(), req.GetArticleId())
+	if err != nil {
+		return nil, toSeriesStatus(err)
+	}
+	return &articlev1.RemoveSeriesArticleResponse{}, nil
+}
+
+func (a *ArticleServiceServer) ReorderSeries(ctx context.Context, req *articlev1.ReorderSeriesRequest) (*articlev1.ReorderSeriesResponse, error) {
+	err := a.series.Reorder(ctx, req.GetId(), req.GetUid(), req.GetArticleIds())
+	if err != nil {
+		return nil, toSeriesStatus(err)
+	}
+	return &articlev1.ReorderSeriesResponse{}, nil
+}
+
+// toSeriesStatus reports the errors of managing series with their codes.
+func toSeriesStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrSeriesNotFound), errors.Is(err, service.ErrArticleNotInSeries):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrArticleNotPublished), errors.Is(err, service.ErrArticleInSeries),
+		errors.Is(err, service.ErrSeriesFull):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrSeriesMismatch), errors.Is(err, service.ErrInvalidSeries):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
+
+func convertSeriesToDomain(s *articlev1.Series) domain.Series {
+	return domain.Series{
+		Id: s.GetId(),
+		Author: domain.Author{
+			Id: s.GetAuthor().GetId(),
+		},
+		Title:       s.GetTitle(),
+		Description: s.GetDescription(),
+	}
+}
+
+func convertSeriesToV(s domain.Series) *articlev1.Series {
+	atcls := make([]*articlev1.SeriesArticle, 0, len(s.Articles))
+	for _, atcl := range s.Articles {
+		atcls = append(atcls, convertSeriesArticleToV(atcl))
+	}
+	return &articlev1.Series{
+		Id: s.Id,
+		Author: &articlev1.Author{
+			Id: s.Author.Id,
+		},
+		Title:       s.Title,
+		Description: s.Description,
+		Articles:    atcls,
+		Ctime:       timestamppb.New(s.Ctime),
+		Utime:       timestamppb.New(s.Utime),
+	}
+}
+
+func convertSeriesArticleToV(atcl domain.SeriesArticle) *articlev1.SeriesArticle {
+	return &articlev1.SeriesArticle{
+		Id:          atcl.Id,
+		Title:       atcl.Title,
+		Abstract:    atcl.Abstract,
+		ReadCnt:     atcl.ReadCnt,
+		LikeCnt:     atcl.LikeCnt,
+		FavoriteCnt: atcl.FavoriteCnt,
+		Utime:       timestamppb.New(atcl.Utime),
+	}
+}
+
+// convertSeriesNavToV returns nil for the article not in a series, and leaves prev or next unset at either end.
+func convertSeriesNavToV(nav domain.SeriesNav) *articlev1.SeriesNav {
+	if nav.SeriesId == 0 {
+		return nil
+	}
+	res := &articlev1.SeriesNav{
+		SeriesId:    nav.SeriesId,
+		SeriesTitle: nav.SeriesTitle,
+	}
+	if nav.Prev.Id > 0 {
+		res.Prev = convertSeriesArticleToV(nav.Prev)
+	}
+	if nav.Next.Id > 0 {
+		res.Next = convertSeriesArticleToV(nav.Next)
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	"github.com/tsukiyo/mercury/internal/article/service"
+)
+
+func (a *ArticleServiceServer) Delete(ctx context.Context, req *articlev1.DeleteRequest) (*articlev1.DeleteResponse, error) {
+	err := a.service.Delete(ctx, req.GetId(), req.GetUid())
+	if err != nil {
+		return nil, toTrashStatus(err)
+	}
+	return &articlev1.DeleteResponse{}, nil
+}
+
+func (a *ArticleServiceServer) Restore(ctx context.Context, req *articlev1.RestoreRequest) (*articlev1.RestoreResponse, error) {
+	err := a.service.Restore(ctx, req.GetId(), req.GetUid())
+	if err != nil {
+		return nil, toTrashStatus(err)
+	}
+	return &articlev1.RestoreResponse{}, nil
+}
+
+func (a *ArticleServiceServer) ListTrash(ctx context.Context, req *articlev1.ListTrashRequest) (*articlev1.ListTrashResponse, error) {
+	atcls, err := a.service.ListTrash(ctx, req.GetUid(), int(req.GetOffset()), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*articlev1.Article, 0, len(atcls))
+	for _, atcl := range atcls {
+		res = append(res, convertToV(atcl))
+	}
+	return &articlev1.ListTrashResponse{Articles: res}, nil
+}
+
+// toTrashStatus reports the errors of moving articles in and out of the trash with their codes.
+func toTrashStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrPossibleIncorrectAuthor):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrArticleTrashed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrArticleNotTrashed):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,58 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/article/cron"
+	service2 "github.com/tsukiyo/mercury/internal/article/service"
+	"github.com/tsukiyo/mercury/internal/crontask/domain"
+	"github.com/tsukiyo/mercury/internal/crontask/service"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func InitScheduler(svc service.TaskService, articleSvc service2.ArticleService, l logger.Logger) *cronx.Scheduler {
+	publisher := cron.NewBatchExecutor("article_scheduled_publish", articleSvc.PublishScheduled, time.Second*30, l)
+	flusher := cron.NewBatchExecutor("article_draft_flush", articleSvc.FlushDrafts, time.Second*30, l)
+	purger := cron.NewBatchExecutor("article_trash_purge", articleSvc.PurgeTrashed, time.Minute, l)
+
+	scheduler := cronx.NewScheduler(svc, l)
+	scheduler.RegisterExecutor(publisher)
+	scheduler.RegisterExecutor(flusher)
+	scheduler.RegisterExecutor(purger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	err := scheduler.RegisterTask(ctx, domain.Task{
+		Name:     publisher.Name(),
+		Executor: publisher.Name(),
+		// @every 10s
+		Expression: "*/10 * * * * ?",
+		NextTime:   time.Now(),
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = scheduler.RegisterTask(ctx, domain.Task{
+		Name:     flusher.Name(),
+		Executor: flusher.Name(),
+		// @every 30s
+		Expression: "*/30 * * * * ?",
+		NextTime:   time.Now(),
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = scheduler.RegisterTask(ctx, domain.Task{
+		Name:     purger.Name(),
+		Executor: purger.Name(),
+		// @hourly
+		Expression: "0 0 * * * ?",
+		NextTime:   time.Now(),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return scheduler
+}
@@ -13,9 +13,11 @@ import (
 	"gorm.io/plugin/opentelemetry/tracing"
 	gormPrometheus "gorm.io/plugin/prometheus"
 
+	crontaskDao "github.com/tsukiyo/mercury/internal/crontask/repository/dao"
 	interactiveDao "github.com/tsukiyo/mercury/internal/interactive/repository/dao"
 	"github.com/tsukiyo/mercury/pkg/gormx/callbacks/metrics"
 	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/outbox"
 )
 
 func InitDB(l logger.Logger) *gorm.DB {
@@ -92,6 +94,16 @@ func InitDB(l logger.Logger) *gorm.DB {
 		panic(err)
 	}
 
+	err = crontaskDao.InitTable(db)
+	if err != nil {
+		panic(err)
+	}
+
+	err = outbox.InitTable(db)
+	if err != nil {
+		panic(err)
+	}
+
 	return db
 }
 
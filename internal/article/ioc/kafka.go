@@ -2,7 +2,12 @@ package ioc
 
 import (
 	"github.com/IBM/sarama"
+	rlock "github.com/gotomicro/redis-lock"
 	"github.com/spf13/viper"
+	"gorm.io/gorm"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/outbox"
 )
 
 func InitKafka() sarama.Client {
@@ -31,3 +36,7 @@ func NewSyncProducer(client sarama.Client) sarama.SyncProducer {
 	}
 	return syncProducer
 }
+
+func InitOutboxRelay(db *gorm.DB, producer sarama.SyncProducer, client *rlock.Client, l logger.Logger) *outbox.Relay {
+	return outbox.NewRelay(db, producer, client, "article", l)
+}
@@ -1,8 +1,11 @@
 package ioc
 
 import (
+	rlock "github.com/gotomicro/redis-lock"
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/pkg/redisx/hook/metrics"
 )
 
 func InitRedis() redis.Cmdable {
@@ -23,5 +26,11 @@ func InitRedis() redis.Cmdable {
 		Password: cfg.Password,
 		DB:       cfg.DB,
 	})
+	// hit and miss of the read-through caches are labelled by the hook
+	cmd.AddHook(metrics.NewPrometheusHook("lazywoo", "mercury", "instance-0", "redis_cmd"))
 	return cmd
 }
+
+func InitRLockClient(cmd redis.Cmdable) *rlock.Client {
+	return rlock.NewClient(cmd)
+}
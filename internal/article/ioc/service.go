@@ -0,0 +1,37 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+
+	userv1 "github.com/tsukiyo/mercury/api/gen/user/v1"
+	"github.com/tsukiyo/mercury/internal/article/events"
+	"github.com/tsukiyo/mercury/internal/article/repository"
+	"github.com/tsukiyo/mercury/internal/article/service"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/moderation"
+)
+
+func InitArticleService(
+	repo repository.ArticleRepository,
+	userSvc userv1.UserServiceClient,
+	producer events.Producer,
+	codec *cursorx.Codec,
+	filter moderation.ContentFilter,
+	l logger.Logger,
+) service.ArticleService {
+	type Config struct {
+		Retention time.Duration `yaml:"retention"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("article.trash", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.Retention <= 0 {
+		panic("article.trash.retention is required to purge trashed articles")
+	}
+	return service.NewArticleService(repo, userSvc, producer, codec, filter, cfg.Retention, l)
+}
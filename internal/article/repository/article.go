@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
 	"time"
 
 	"github.com/tsukiyo/mercury/internal/article/domain"
@@ -13,16 +15,75 @@ import (
 	"github.com/tsukiyo/mercury/pkg/logger"
 )
 
+var (
+	ErrArticleNotFound         = dao.ErrArticleNotFound
+	ErrPossibleIncorrectAuthor = dao.ErrPossibleIncorrectAuthor
+	ErrArticleNotScheduled     = dao.ErrArticleNotScheduled
+	ErrStaleContent            = dao.ErrStaleContent
+	ErrVersionConflict         = dao.ErrVersionConflict
+	ErrDraftNotFound           = dao.ErrDraftNotFound
+	ErrCollaboratorNotFound    = dao.ErrCollaboratorNotFound
+	ErrArticleTrashed          = dao.ErrArticleTrashed
+	ErrArticleNotTrashed       = dao.ErrArticleNotTrashed
+)
+
 //go:generate mockgen -source=./article.go -package=repomocks -destination=mocks/article.mock.go ArticleRepository
 type ArticleRepository interface {
 	Create(ctx context.Context, atcl domain.Article) (int64, error)
-	Update(ctx context.Context, atcl domain.Article) error
-	List(ctx context.Context, authorId int64, offset int, limit int) ([]domain.Article, error)
+	// Update returns the new version, or ErrVersionConflict if atcl.Version is set and not the latest.
+	Update(ctx context.Context, atcl domain.Article) (int64, error)
+	// List returns articles the user owns or collaborates on.
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// ListBefore continues after the article identified by utime and id, zero utime means the first page.
+	ListBefore(ctx context.Context, uid int64, utime time.Time, id int64, limit int) ([]domain.Article, error)
 	ListPub(ctx context.Context, utime time.Time, offset int, limit int) ([]domain.Article, error)
+	// ListPubBefore continues after the article identified by utime and id.
+	ListPubBefore(ctx context.Context, utime time.Time, id int64, limit int) ([]domain.Article, error)
+	// ListPubByTag continues after the article identified by utime and id, zero utime means the first page.
+	ListPubByTag(ctx context.Context, tag string, utime time.Time, id int64, limit int) ([]domain.Article, error)
+	CountTags(ctx context.Context, tags []string, limit int) ([]domain.TagCount, error)
 	Sync(ctx context.Context, atcl domain.Article) (int64, error)
-	SyncStatus(ctx context.Context, id, authorId int64, status domain.ArticleStatus) error
+	SyncStatus(ctx context.Context, id, uid int64, status domain.ArticleStatus) error
 	GetById(ctx context.Context, id int64) (domain.Article, error)
+	// GetPublishedById leaves Rendered empty if the article has not been rendered since it was last published,
+	// Authors are the author and the accepted editors.
 	GetPublishedById(ctx context.Context, id int64) (domain.Article, error)
+	// SaveRendered keeps atcl.Rendered for the published article, returns ErrStaleContent if atcl.Utime is not the latest.
+	SaveRendered(ctx context.Context, atcl domain.Article) error
+	ListRevisions(ctx context.Context, id, uid int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, revisionId, uid int64) (domain.ArticleRevision, error)
+	Schedule(ctx context.Context, id, uid int64, at time.Time) error
+	CancelSchedule(ctx context.Context, id, uid int64) error
+	ListScheduled(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
+	SyncScheduled(ctx context.Context, id int64) (domain.Article, error)
+
+	// SaveDraft keeps the draft in the cache, it is flushed to the database by FlushDrafts.
+	SaveDraft(ctx context.Context, draft domain.Draft) error
+	// GetDraft returns ErrDraftNotFound if the article has no draft.
+	GetDraft(ctx context.Context, articleId int64) (domain.Draft, error)
+	// DeleteDraft deletes the draft if it is edited from maxBaseVersion or before.
+	DeleteDraft(ctx context.Context, articleId, maxBaseVersion int64) error
+	// FlushDrafts writes at most limit drafts autosaved before the time to the database, returns how many are written.
+	FlushDrafts(ctx context.Context, before time.Time, limit int) (int, error)
+
+	// GetRole returns CollaboratorRoleUnknown if the user has no role on the article.
+	GetRole(ctx context.Context, id, uid int64) (domain.CollaboratorRole, error)
+	// InviteCollaborator returns ErrPossibleIncorrectAuthor unless c.InviterId owns the article.
+	InviteCollaborator(ctx context.Context, c domain.Collaborator) error
+	AcceptInvitation(ctx context.Context, id, uid int64) error
+	// RevokeCollaborator removes the user by the owner, or by the user themselves.
+	RevokeCollaborator(ctx context.Context, id, operatorId, uid int64) error
+	// ListCollaborators lists the owner first.
+	ListCollaborators(ctx context.Context, id int64) ([]domain.Collaborator, error)
+
+	// Trash returns ErrPossibleIncorrectAuthor unless uid owns the article.
+	Trash(ctx context.Context, id, uid int64) error
+	// Restore returns ErrArticleNotTrashed unless the article is trashed after the time.
+	Restore(ctx context.Context, id, uid int64, after time.Time) error
+	ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error)
+	ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
+	// Purge deletes the article trashed before the time along with what is cached for it.
+	Purge(ctx context.Context, id int64, before time.Time) error
 }
 
 var _ ArticleRepository = (*CachedArticleRepository)(nil)
@@ -42,29 +103,46 @@ func NewCachedArticleRepository(articleDao dao.ArticleDAO, articleCache cache.Ar
 }
 
 func (repo *CachedArticleRepository) domainToEntity(atcl domain.Article) dao.Article {
-	return dao.Article{
+	res := dao.Article{
 		Id:       atcl.Id,
 		Title:    atcl.Title,
 		Content:  atcl.Content,
+		Abstract: domain.AbstractOf(atcl.Content),
 		AuthorId: atcl.Author.Id,
 		Status:   atcl.Status.ToUint8(),
+		Tags:     atcl.Tags,
+		Version:  atcl.Version,
 		Ctime:    atcl.Ctime.UnixMilli(),
 		Utime:    atcl.Utime.UnixMilli(),
 	}
+	if !atcl.ScheduledAt.IsZero() {
+		res.ScheduledAt = atcl.ScheduledAt.UnixMilli()
+	}
+	return res
 }
 
 func (repo *CachedArticleRepository) entityToDomain(atcl dao.Article) domain.Article {
-	return domain.Article{
-		Id:      atcl.Id,
-		Title:   atcl.Title,
-		Content: atcl.Content,
+	res := domain.Article{
+		Id:       atcl.Id,
+		Title:    atcl.Title,
+		Content:  atcl.Content,
+		Abstract: atcl.Abstract,
 		Author: domain.Author{
 			Id: atcl.AuthorId,
 		},
-		Status: domain.ArticleStatus(atcl.Status),
-		Ctime:  time.UnixMilli(atcl.Ctime),
-		Utime:  time.UnixMilli(atcl.Utime),
+		Status:  domain.ArticleStatus(atcl.Status),
+		Tags:    atcl.Tags,
+		Version: atcl.Version,
+		Ctime:   time.UnixMilli(atcl.Ctime),
+		Utime:   time.UnixMilli(atcl.Utime),
+	}
+	if atcl.ScheduledAt > 0 {
+		res.ScheduledAt = time.UnixMilli(atcl.ScheduledAt)
+	}
+	if atcl.TrashedAt > 0 {
+		res.TrashedAt = time.UnixMilli(atcl.TrashedAt)
 	}
+	return res
 }
 
 func (repo *CachedArticleRepository) Create(ctx context.Context, atcl domain.Article) (int64, error) {
@@ -79,49 +157,87 @@ func (repo *CachedArticleRepository) Create(ctx context.Context, atcl domain.Art
 	return id, nil
 }
 
-func (repo *CachedArticleRepository) Update(ctx context.Context, atcl domain.Article) error {
-	err := repo.articleDAO.UpdateById(ctx, repo.domainToEntity(atcl))
+func (repo *CachedArticleRepository) Update(ctx context.Context, atcl domain.Article) (int64, error) {
+	version, err := repo.articleDAO.UpdateById(ctx, repo.domainToEntity(atcl))
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	err = repo.articleCache.DelFirstPage(ctx, atcl.Author.Id)
+	// the cached article carries the version checked by autosaves
+	if err = repo.articleCache.Del(ctx, atcl.Id); err != nil {
+		repo.logger.Error("delete article redis failed", logger.Int64("id", atcl.Id), logger.Error(err))
+	}
+	repo.delFirstPages(ctx, atcl.Id, atcl.Author.Id)
+	return version, nil
+}
+
+// delFirstPages deletes the first pages of the user and everyone else listing the article.
+func (repo *CachedArticleRepository) delFirstPages(ctx context.Context, id, uid int64) {
+	uids := []int64{uid}
+	collabs, err := repo.articleDAO.ListCollaborators(ctx, id)
 	if err != nil {
-		repo.logger.Error("delete first page redis failed", logger.Int64("author_id", atcl.Author.Id), logger.Error(err))
+		repo.logger.Error("list collaborators failed", logger.Int64("id", id), logger.Error(err))
+	}
+	for _, c := range collabs {
+		if c.Status == domain.CollaboratorStatusAccepted.ToUint8() && c.UserId != uid {
+			uids = append(uids, c.UserId)
+		}
+	}
+	for _, u := range uids {
+		if err = repo.articleCache.DelFirstPage(ctx, u); err != nil {
+			repo.logger.Error("delete first page redis failed", logger.Int64("author_id", u), logger.Error(err))
+		}
 	}
-	return err
 }
 
 func (repo *CachedArticleRepository) List(ctx context.Context, authorId int64, offset int, limit int) ([]domain.Article, error) {
-	if offset == 0 && limit <= 100 {
-		data, err := repo.articleCache.GetFirstPage(ctx, authorId)
-		if err == nil {
-			go func() {
-				err := repo.preCache(ctx, data)
-				if err != nil {
-					repo.logger.Error("pre redis articles failed", logger.Int64("author_id", authorId), logger.Error(err))
-				}
-			}()
-			return data[:min(len(data), limit)], err
-		}
+	if offset == 0 {
+		return repo.ListBefore(ctx, authorId, time.Time{}, 0, limit)
 	}
 	atcls, err := repo.articleDAO.GetByAuthor(ctx, authorId, offset, limit)
 	if err != nil {
 		return nil, err
 	}
-	data, err := slice.Map[dao.Article, domain.Article](atcls, func(idx int, src dao.Article) domain.Article {
+	return slice.Map[dao.Article, domain.Article](atcls, func(idx int, src dao.Article) domain.Article {
 		return repo.entityToDomain(src)
 	}), nil
+}
 
-	go func() {
-		err = repo.preCache(ctx, data)
-	}()
+func (repo *CachedArticleRepository) ListBefore(ctx context.Context, authorId int64, utime time.Time, id int64, limit int) ([]domain.Article, error) {
+	// the first page is always loaded and cached in full, a shorter one means the author has no more articles
+	const firstPageSize = 100
+	if !utime.IsZero() || limit > firstPageSize {
+		atcls, err := repo.articleDAO.GetByAuthorBefore(ctx, authorId, utime, id, limit)
+		if err != nil {
+			return nil, err
+		}
+		return slice.Map[dao.Article, domain.Article](atcls, func(idx int, src dao.Article) domain.Article {
+			return repo.entityToDomain(src)
+		}), nil
+	}
 
-	err = repo.articleCache.SetFirstPage(ctx, authorId, data)
+	data, err := repo.articleCache.GetFirstPage(ctx, authorId)
 	if err != nil {
-		repo.logger.Error("write back redis failure", logger.Int64("author_id", authorId), logger.Error(err))
+		var atcls []dao.Article
+		atcls, err = repo.articleDAO.GetByAuthorBefore(ctx, authorId, utime, id, firstPageSize)
+		if err != nil {
+			return nil, err
+		}
+		data = slice.Map[dao.Article, domain.Article](atcls, func(idx int, src dao.Article) domain.Article {
+			return repo.entityToDomain(src)
+		})
+		err = repo.articleCache.SetFirstPage(ctx, authorId, slices.Clone(data))
+		if err != nil {
+			repo.logger.Error("write back redis failure", logger.Int64("author_id", authorId), logger.Error(err))
+		}
 	}
-	return data, nil
+	go func() {
+		err := repo.preCache(ctx, data)
+		if err != nil {
+			repo.logger.Error("pre redis articles failed", logger.Int64("author_id", authorId), logger.Error(err))
+		}
+	}()
+	return data[:min(len(data), limit)], nil
 }
 
 func (repo *CachedArticleRepository) ListPub(ctx context.Context, utime time.Time, offset int, limit int) ([]domain.Article, error) {
@@ -135,42 +251,120 @@ func (repo *CachedArticleRepository) ListPub(ctx context.Context, utime time.Tim
 	}), nil
 }
 
+func (repo *CachedArticleRepository) ListPubBefore(ctx context.Context, utime time.Time, id int64, limit int) ([]domain.Article, error) {
+	pubAtcls, err := repo.articleDAO.ListPubBefore(ctx, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return slice.Map[dao.PublishedArticle, domain.Article](pubAtcls, func(idx int, src dao.PublishedArticle) domain.Article {
+		return repo.entityToDomain(dao.Article(src))
+	}), nil
+}
+
+func (repo *CachedArticleRepository) ListPubByTag(ctx context.Context, tag string, utime time.Time, id int64, limit int) ([]domain.Article, error) {
+	pubAtcls, err := repo.articleDAO.ListPubByTag(ctx, tag, utime, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return slice.Map[dao.PublishedArticle, domain.Article](pubAtcls, func(idx int, src dao.PublishedArticle) domain.Article {
+		return repo.entityToDomain(dao.Article(src))
+	}), nil
+}
+
+func (repo *CachedArticleRepository) CountTags(ctx context.Context, tags []string, limit int) ([]domain.TagCount, error) {
+	cnts, err := repo.articleDAO.CountTags(ctx, tags, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.TagCount, domain.TagCount](cnts, func(idx int, src dao.TagCount) domain.TagCount {
+		return domain.TagCount{
+			Tag:   src.Name,
+			Count: src.Cnt,
+		}
+	}), nil
+}
+
+func (repo *CachedArticleRepository) renderedToEntity(rendered domain.RenderedContent) *dao.PublishedArticleContent {
+	toc, _ := json.Marshal(rendered.TOC)
+	return &dao.PublishedArticleContent{
+		HTML:        rendered.HTML,
+		Abstract:    rendered.Abstract,
+		ReadingTime: int64(rendered.ReadingTime / time.Second),
+		TOC:         string(toc),
+	}
+}
+
+func (repo *CachedArticleRepository) renderedToDomain(content *dao.PublishedArticleContent) domain.RenderedContent {
+	if content == nil {
+		return domain.RenderedContent{}
+	}
+	var toc []domain.TOCEntry
+	if err := json.Unmarshal([]byte(content.TOC), &toc); err != nil {
+		repo.logger.Error("unmarshal table of contents failed", logger.Int64("id", content.Id), logger.Error(err))
+	}
+	return domain.RenderedContent{
+		HTML:        content.HTML,
+		Abstract:    content.Abstract,
+		ReadingTime: time.Duration(content.ReadingTime) * time.Second,
+		TOC:         toc,
+	}
+}
+
 func (repo *CachedArticleRepository) Sync(ctx context.Context, atcl domain.Article) (int64, error) {
-	id, err := repo.articleDAO.Sync(ctx, repo.domainToEntity(atcl))
+	entity := repo.domainToEntity(atcl)
+	entity.Rendered = repo.renderedToEntity(atcl.Rendered)
+	id, err := repo.articleDAO.Sync(ctx, entity)
 	if err != nil {
 		return 0, err
 	}
+	// the online article is loaded again with its owner and editors, atcl has the editor publishing it only
+	repo.delPub(ctx, id)
 	go func() {
-		if err := repo.articleCache.DelFirstPage(ctx, atcl.Author.Id); err != nil {
-			repo.logger.Error("write page back to redis failure", logger.Int64("author_id", atcl.Author.Id), logger.Error(err))
-		}
-		if err := repo.articleCache.SetPub(ctx, atcl); err != nil {
-			repo.logger.Error("write article back to redis failure", logger.Int64("id", atcl.Id), logger.Error(err))
+		if err := repo.articleCache.Del(ctx, id); err != nil {
+			repo.logger.Error("delete article redis failed", logger.Int64("id", id), logger.Error(err))
 		}
+		repo.delFirstPages(ctx, id, atcl.Author.Id)
 	}()
 	return id, err
 }
 
-func (repo *CachedArticleRepository) SyncStatus(ctx context.Context, id, authorId int64, status domain.ArticleStatus) error {
-	return repo.articleDAO.SyncStatus(ctx, id, authorId, status.ToUint8())
+func (repo *CachedArticleRepository) SyncStatus(ctx context.Context, id, uid int64, status domain.ArticleStatus) error {
+	err := repo.articleDAO.SyncStatus(ctx, id, uid, status.ToUint8())
+	if err != nil {
+		return err
+	}
+	// readers see the status once the cached online article is gone
+	repo.delPub(ctx, id)
+	return nil
 }
 
-func (repo *CachedArticleRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
-	cachedAtcl, err := repo.articleCache.Get(ctx, id)
-	if err == nil {
-		return cachedAtcl, err
-	}
-	res, err := repo.articleDAO.GetById(ctx, id)
-	if err != nil {
-		return domain.Article{}, err
+// delPub drops the cached online article everywhere, it is loaded again from the database on the next read.
+func (repo *CachedArticleRepository) delPub(ctx context.Context, id int64) {
+	if err := repo.articleCache.DelPub(ctx, id); err != nil {
+		repo.logger.Error("delete published article redis failed", logger.Int64("id", id), logger.Error(err))
 	}
-	return repo.entityToDomain(res), nil
+}
+
+func (repo *CachedArticleRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
+	return repo.articleCache.Get(ctx, id, func(ctx context.Context) (domain.Article, error) {
+		res, err := repo.articleDAO.GetById(ctx, id)
+		if err != nil {
+			return domain.Article{}, err
+		}
+		return repo.entityToDomain(res), nil
+	})
 }
 
 func (repo *CachedArticleRepository) GetPublishedById(ctx context.Context, id int64) (domain.Article, error) {
-	if cachedAtcl, err := repo.articleCache.GetPub(ctx, id); err == nil {
-		return cachedAtcl, nil
-	}
+	return repo.articleCache.GetPub(ctx, id, func(ctx context.Context) (domain.Article, error) {
+		return repo.loadPublished(ctx, id)
+	})
+}
+
+// loadPublished reads the online article on a miss, hot articles missing together read it once.
+func (repo *CachedArticleRepository) loadPublished(ctx context.Context, id int64) (domain.Article, error) {
 	atcl, err := repo.articleDAO.GetPubById(ctx, id)
 	if err != nil {
 		return domain.Article{}, err
@@ -184,21 +378,261 @@ func (repo *CachedArticleRepository) GetPublishedById(ctx context.Context, id in
 		Title:   atcl.Title,
 		Content: atcl.Content,
 		Status:  domain.ArticleStatus(atcl.Status),
+		Tags:    atcl.Tags,
 		Author: domain.Author{
 			Id: atcl.AuthorId,
 			// Name: dbUser.NickName,
 		},
-		Ctime: time.UnixMilli(atcl.Ctime),
-		Utime: time.UnixMilli(atcl.Utime),
+		Version:  atcl.Version,
+		Rendered: repo.renderedToDomain(atcl.Rendered),
+		Ctime:    time.UnixMilli(atcl.Ctime),
+		Utime:    time.UnixMilli(atcl.Utime),
 	}
-	go func() {
-		if err = repo.articleCache.SetPub(ctx, res); err != nil {
-			repo.logger.Error("redis published article failed", logger.Int64("article_id", res.Id), logger.Error(err))
-		}
-	}()
+	res.Authors, err = repo.authors(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	// cached even if it is not rendered yet, SaveRendered overwrites it once rendered
 	return res, nil
 }
 
+func (repo *CachedArticleRepository) SaveRendered(ctx context.Context, atcl domain.Article) error {
+	content := repo.renderedToEntity(atcl.Rendered)
+	content.Id, content.Version = atcl.Id, atcl.Utime.UnixMilli()
+	if err := repo.articleDAO.SaveRendered(ctx, *content); err != nil {
+		return err
+	}
+	repo.delPub(ctx, atcl.Id)
+	return nil
+}
+
+// authors are the owner and the accepted editors.
+func (repo *CachedArticleRepository) authors(ctx context.Context, id int64) ([]domain.Author, error) {
+	collabs, err := repo.articleDAO.ListCollaborators(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	authors := make([]domain.Author, 0, len(collabs))
+	for _, c := range collabs {
+		if c.Status == domain.CollaboratorStatusAccepted.ToUint8() && c.Role >= domain.CollaboratorRoleEditor.ToUint8() {
+			authors = append(authors, domain.Author{Id: c.UserId})
+		}
+	}
+	return authors, nil
+}
+
+func (repo *CachedArticleRepository) ListRevisions(ctx context.Context, id, uid int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	revs, err := repo.articleDAO.ListRevisions(ctx, id, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleRevision, domain.ArticleRevision](revs, func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+		return repo.revisionToDomain(src)
+	}), nil
+}
+
+func (repo *CachedArticleRepository) GetRevision(ctx context.Context, revisionId, uid int64) (domain.ArticleRevision, error) {
+	rev, err := repo.articleDAO.GetRevision(ctx, revisionId, uid)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return repo.revisionToDomain(rev), nil
+}
+
+func (repo *CachedArticleRepository) Schedule(ctx context.Context, id, uid int64, at time.Time) error {
+	err := repo.articleDAO.Schedule(ctx, id, uid, at)
+	if err != nil {
+		return err
+	}
+	repo.delFirstPages(ctx, id, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) CancelSchedule(ctx context.Context, id, uid int64) error {
+	err := repo.articleDAO.CancelSchedule(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	repo.delFirstPages(ctx, id, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListScheduled(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	atcls, err := repo.articleDAO.ListScheduled(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](atcls, func(idx int, src dao.Article) domain.Article {
+		return repo.entityToDomain(src)
+	}), nil
+}
+
+func (repo *CachedArticleRepository) SyncScheduled(ctx context.Context, id int64) (domain.Article, error) {
+	res, err := repo.articleDAO.SyncScheduled(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	atcl := repo.entityToDomain(res)
+	repo.delFirstPages(ctx, id, atcl.Author.Id)
+	repo.delPub(ctx, id)
+	return atcl, nil
+}
+
+func (repo *CachedArticleRepository) SaveDraft(ctx context.Context, draft domain.Draft) error {
+	return repo.articleCache.SetDraft(ctx, draft)
+}
+
+func (repo *CachedArticleRepository) GetDraft(ctx context.Context, articleId int64) (domain.Draft, error) {
+	if draft, err := repo.articleCache.GetDraft(ctx, articleId); err == nil {
+		return draft, nil
+	}
+	draft, err := repo.articleDAO.GetDraft(ctx, articleId)
+	if err != nil {
+		return domain.Draft{}, err
+	}
+	return repo.draftToDomain(draft), nil
+}
+
+func (repo *CachedArticleRepository) DeleteDraft(ctx context.Context, articleId, maxBaseVersion int64) error {
+	draft, err := repo.articleCache.GetDraft(ctx, articleId)
+	switch {
+	case err == nil && draft.BaseVersion <= maxBaseVersion:
+		if err = repo.articleCache.DelDraft(ctx, articleId); err != nil {
+			return err
+		}
+	case err != nil && err != cache.ErrKeyNotExist:
+		return err
+	}
+	return repo.articleDAO.DeleteDraft(ctx, articleId, maxBaseVersion)
+}
+
+func (repo *CachedArticleRepository) FlushDrafts(ctx context.Context, before time.Time, limit int) (int, error) {
+	ids, err := repo.articleCache.DirtyDrafts(ctx, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	cnt := 0
+	for _, id := range ids {
+		draft, err := repo.articleCache.GetDraft(ctx, id)
+		if err == cache.ErrKeyNotExist {
+			// expired before it could be flushed
+			if err = repo.articleCache.DelDraft(ctx, id); err != nil {
+				return cnt, err
+			}
+			continue
+		}
+		if err != nil {
+			return cnt, err
+		}
+		if err = repo.articleDAO.UpsertDraft(ctx, repo.draftToEntity(draft)); err != nil {
+			return cnt, err
+		}
+		if err = repo.articleCache.CleanDraft(ctx, id, draft.Utime); err != nil {
+			return cnt, err
+		}
+		cnt++
+	}
+	return cnt, nil
+}
+
+func (repo *CachedArticleRepository) GetRole(ctx context.Context, id, uid int64) (domain.CollaboratorRole, error) {
+	role, err := repo.articleDAO.GetRole(ctx, id, uid)
+	return domain.CollaboratorRole(role), err
+}
+
+func (repo *CachedArticleRepository) InviteCollaborator(ctx context.Context, c domain.Collaborator) error {
+	return repo.articleDAO.InviteCollaborator(ctx, dao.ArticleCollaborator{
+		ArticleId: c.ArticleId,
+		UserId:    c.User.Id,
+		Role:      c.Role.ToUint8(),
+		InviterId: c.InviterId,
+	})
+}
+
+func (repo *CachedArticleRepository) AcceptInvitation(ctx context.Context, id, uid int64) error {
+	err := repo.articleDAO.AcceptInvitation(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	// the article joins the list of the user
+	if err = repo.articleCache.DelFirstPage(ctx, uid); err != nil {
+		repo.logger.Error("delete first page redis failed", logger.Int64("author_id", uid), logger.Error(err))
+	}
+	// and the user joins the authors of the online article
+	repo.delPub(ctx, id)
+	return nil
+}
+
+func (repo *CachedArticleRepository) RevokeCollaborator(ctx context.Context, id, operatorId, uid int64) error {
+	err := repo.articleDAO.DeleteCollaborator(ctx, id, operatorId, uid)
+	if err != nil {
+		return err
+	}
+	if err = repo.articleCache.DelFirstPage(ctx, uid); err != nil {
+		repo.logger.Error("delete first page redis failed", logger.Int64("author_id", uid), logger.Error(err))
+	}
+	repo.delPub(ctx, id)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListCollaborators(ctx context.Context, id int64) ([]domain.Collaborator, error) {
+	collabs, err := repo.articleDAO.ListCollaborators(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleCollaborator, domain.Collaborator](collabs, func(idx int, src dao.ArticleCollaborator) domain.Collaborator {
+		return domain.Collaborator{
+			ArticleId: src.ArticleId,
+			User: domain.Author{
+				Id: src.UserId,
+			},
+			Role:      domain.CollaboratorRole(src.Role),
+			Status:    domain.CollaboratorStatus(src.Status),
+			InviterId: src.InviterId,
+			Ctime:     time.UnixMilli(src.Ctime),
+			Utime:     time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (repo *CachedArticleRepository) draftToEntity(draft domain.Draft) dao.ArticleDraft {
+	return dao.ArticleDraft{
+		ArticleId:   draft.ArticleId,
+		AuthorId:    draft.AuthorId,
+		Title:       draft.Title,
+		Content:     draft.Content,
+		Tags:        draft.Tags,
+		BaseVersion: draft.BaseVersion,
+		Utime:       draft.Utime.UnixMilli(),
+	}
+}
+
+func (repo *CachedArticleRepository) draftToDomain(draft dao.ArticleDraft) domain.Draft {
+	return domain.Draft{
+		ArticleId:   draft.ArticleId,
+		AuthorId:    draft.AuthorId,
+		Title:       draft.Title,
+		Content:     draft.Content,
+		Tags:        draft.Tags,
+		BaseVersion: draft.BaseVersion,
+		Utime:       time.UnixMilli(draft.Utime),
+	}
+}
+
+func (repo *CachedArticleRepository) revisionToDomain(rev dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Author: domain.Author{
+			Id: rev.AuthorId,
+		},
+		Status: domain.ArticleStatus(rev.Status),
+		Ctime:  time.UnixMilli(rev.Ctime),
+	}
+}
+
 // preCache will redis first article in page and returns error.
 func (repo *CachedArticleRepository) preCache(ctx context.Context, atcls []domain.Article) error {
 	const contentSizeThreshold = 1024 * 1024
@@ -2,31 +2,81 @@ package cache
 
 import (
 	"context"
+	_ "embed"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/internal/article/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/cachex"
+	"github.com/tsukiyo/mercury/pkg/logger"
 
 	"github.com/redis/go-redis/v9"
 )
 
+var ErrKeyNotExist = redis.Nil
+
+//go:embed lua/clean_draft.lua
+var luaCleanDraft string
+
+// draftsDirtyKey is the sorted set of drafts not flushed yet, scored by their utime
+const draftsDirtyKey = "article:draft:dirty"
+
 //go:generate mockgen -source=./article.go -package=cachemocks -destination=mocks/article.mock.go ArticleCache
 type ArticleCache interface {
 	SetFirstPage(ctx context.Context, authorId int64, atcls []domain.Article) error
 	DelFirstPage(ctx context.Context, authorId int64) error
 	GetFirstPage(ctx context.Context, authorId int64) ([]domain.Article, error)
 
+	// Get and GetPub read through the cache, load is called once for concurrent misses of the article,
+	// a missing article is cached for a short time and returned with dao.ErrArticleNotFound.
+
 	Set(ctx context.Context, atcl domain.Article) error
-	Get(ctx context.Context, id int64) (domain.Article, error)
+	Get(ctx context.Context, id int64, load cachex.Loader[domain.Article]) (domain.Article, error)
+	Del(ctx context.Context, id int64) error
 
 	SetPub(ctx context.Context, atcl domain.Article) error
-	GetPub(ctx context.Context, id int64) (domain.Article, error)
+	GetPub(ctx context.Context, id int64, load cachex.Loader[domain.Article]) (domain.Article, error)
+	DelPub(ctx context.Context, id int64) error
+	// InvalidatePub drops the local copies of the online article on the other instances and the BFF,
+	// which caches it along with the series navigation, the copy in redis is kept.
+	InvalidatePub(ctx context.Context, id int64) error
+
+	// SetDraft keeps the autosaved draft and marks it dirty until it is flushed.
+	SetDraft(ctx context.Context, draft domain.Draft) error
+	GetDraft(ctx context.Context, articleId int64) (domain.Draft, error)
+	DelDraft(ctx context.Context, articleId int64) error
+	// DirtyDrafts returns the articles whose drafts were autosaved before the time and have not been flushed.
+	DirtyDrafts(ctx context.Context, before time.Time, limit int) ([]int64, error)
+	// CleanDraft unmarks the draft flushed with the utime, unless it has been autosaved again.
+	CleanDraft(ctx context.Context, articleId int64, utime time.Time) error
 }
 
-func NewRedisArticleCache(client redis.Cmdable) ArticleCache {
+// NewRedisArticleCache keeps the articles in the process as well, the changes are broadcast by inv
+// for the other instances and the BFF to drop their local copies.
+func NewRedisArticleCache(client redis.Cmdable, inv *cachex.Invalidator, l logger.Logger) ArticleCache {
+	store := cachex.NewRedisStore[domain.Article](client)
+	atcls := cachex.NewReadThrough[domain.Article](store, "article_author", cachex.Options{
+		TTL:         time.Second * 10,
+		Jitter:      time.Second * 2,
+		NotFound:    dao.ErrArticleNotFound,
+		NotFoundTTL: time.Second * 3,
+		Beta:        1,
+	}, l)
+	pubs := cachex.NewReadThrough[domain.Article](store, "article_reader", cachex.Options{
+		TTL:         time.Minute * 30,
+		Jitter:      time.Minute * 5,
+		NotFound:    dao.ErrArticleNotFound,
+		NotFoundTTL: time.Second * 30,
+		Beta:        1,
+	}, l)
 	return &RedisArticleCache{
 		client: client,
+		inv:    inv,
+		atcls:  cachex.NewTwoLevel[domain.Article](cachex.NewLocalCache[domain.Article](16<<20, time.Second*5), atcls, inv, l),
+		pubs:   cachex.NewTwoLevel[domain.Article](cachex.NewLocalCache[domain.Article](64<<20, time.Minute), pubs, inv, l),
 	}
 }
 
@@ -34,11 +84,14 @@ var _ ArticleCache = (*RedisArticleCache)(nil)
 
 type RedisArticleCache struct {
 	client redis.Cmdable
+	inv    *cachex.Invalidator
+	atcls  *cachex.TwoLevel[domain.Article]
+	pubs   *cachex.TwoLevel[domain.Article]
 }
 
 func (cache *RedisArticleCache) SetFirstPage(ctx context.Context, authorId int64, atcls []domain.Article) error {
 	for i := range atcls {
-		atcls[i].Content = atcls[i].Abstract()
+		atcls[i].Content = atcls[i].Abstract
 	}
 	bs, err := json.Marshal(atcls)
 	if err != nil {
@@ -66,45 +119,101 @@ func (cache *RedisArticleCache) GetFirstPage(ctx context.Context, authorId int64
 }
 
 func (cache *RedisArticleCache) Set(ctx context.Context, atcl domain.Article) error {
-	data, err := json.Marshal(atcl)
+	return cache.atcls.Set(ctx, cache.authorArticleKey(atcl.Id), atcl)
+}
+
+func (cache *RedisArticleCache) Get(ctx context.Context, id int64, load cachex.Loader[domain.Article]) (domain.Article, error) {
+	return cache.atcls.Get(ctx, cache.authorArticleKey(id), load)
+}
+
+func (cache *RedisArticleCache) Del(ctx context.Context, id int64) error {
+	return cache.atcls.Del(ctx, cache.authorArticleKey(id))
+}
+
+func (cache *RedisArticleCache) SetPub(ctx context.Context, atcl domain.Article) error {
+	return cache.pubs.Set(ctx, cache.readerArticleKey(atcl.Id), atcl)
+}
+
+func (cache *RedisArticleCache) GetPub(ctx context.Context, id int64, load cachex.Loader[domain.Article]) (domain.Article, error) {
+	return cache.pubs.Get(ctx, cache.readerArticleKey(id), load)
+}
+
+func (cache *RedisArticleCache) DelPub(ctx context.Context, id int64) error {
+	return cache.pubs.Del(ctx, cache.readerArticleKey(id))
+}
+
+func (cache *RedisArticleCache) InvalidatePub(ctx context.Context, id int64) error {
+	return cache.inv.Publish(ctx, cache.readerArticleKey(id))
+}
+
+func (cache *RedisArticleCache) readerArticleKey(id int64) string {
+	return fmt.Sprintf("article:reader:%d", id)
+}
+
+func (cache *RedisArticleCache) authorArticleKey(id int64) string {
+	return fmt.Sprintf("article:author:%d", id)
+}
+
+func (cache *RedisArticleCache) SetDraft(ctx context.Context, draft domain.Draft) error {
+	bs, err := json.Marshal(draft)
 	if err != nil {
 		return err
 	}
-	return cache.client.Set(ctx, cache.authorArticleKey(atcl.Id), data, time.Second*10).Err()
+	// kept longer than the flush interval, the flushed ones are read from the database after expiring
+	_, err = cache.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, cache.draftKey(draft.ArticleId), bs, time.Hour*24)
+		pipe.ZAdd(ctx, draftsDirtyKey, redis.Z{
+			Score:  float64(draft.Utime.UnixMilli()),
+			Member: draft.ArticleId,
+		})
+		return nil
+	})
+	return err
 }
 
-func (cache *RedisArticleCache) Get(ctx context.Context, id int64) (domain.Article, error) {
-	var atcl domain.Article
-	bs, err := cache.client.Get(ctx, cache.authorArticleKey(id)).Bytes()
+func (cache *RedisArticleCache) GetDraft(ctx context.Context, articleId int64) (domain.Draft, error) {
+	var draft domain.Draft
+	bs, err := cache.client.Get(ctx, cache.draftKey(articleId)).Bytes()
 	if err != nil {
-		return domain.Article{}, err
+		return domain.Draft{}, err
 	}
-	err = json.Unmarshal(bs, &atcl)
-	return atcl, err
+	err = json.Unmarshal(bs, &draft)
+	return draft, err
 }
 
-func (cache *RedisArticleCache) SetPub(ctx context.Context, atcl domain.Article) error {
-	bs, err := json.Marshal(atcl)
-	if err != nil {
-		return err
-	}
-	return cache.client.Set(ctx, cache.readerArticleKey(atcl.Id), bs, time.Minute*30).Err()
+func (cache *RedisArticleCache) DelDraft(ctx context.Context, articleId int64) error {
+	_, err := cache.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, cache.draftKey(articleId))
+		pipe.ZRem(ctx, draftsDirtyKey, articleId)
+		return nil
+	})
+	return err
 }
 
-func (cache *RedisArticleCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
-	var atcl domain.Article
-	bs, err := cache.client.Get(ctx, cache.authorArticleKey(id)).Bytes()
+func (cache *RedisArticleCache) DirtyDrafts(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	members, err := cache.client.ZRangeByScore(ctx, draftsDirtyKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
 	if err != nil {
-		return domain.Article{}, err
+		return nil, err
+	}
+	ids := make([]int64, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
 	}
-	err = json.Unmarshal(bs, &atcl)
-	return atcl, err
+	return ids, nil
 }
 
-func (cache *RedisArticleCache) readerArticleKey(id int64) string {
-	return fmt.Sprintf("article:reader:%d", id)
+func (cache *RedisArticleCache) CleanDraft(ctx context.Context, articleId int64, utime time.Time) error {
+	return cache.client.Eval(ctx, luaCleanDraft, []string{draftsDirtyKey}, articleId, utime.UnixMilli()).Err()
 }
 
-func (cache *RedisArticleCache) authorArticleKey(id int64) string {
-	return fmt.Sprintf("article:author:%d", id)
+func (cache *RedisArticleCache) draftKey(articleId int64) string {
+	return fmt.Sprintf("article:draft:%d", articleId)
 }
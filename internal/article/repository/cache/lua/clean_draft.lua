@@ -0,0 +1,10 @@
+-- removes the draft from the dirty set unless it has been autosaved again since it was flushed
+local dirtyKey = KEYS[1]
+local id = ARGV[1]
+local flushed = tonumber(ARGV[2])
+
+local score = redis.call("ZSCORE", dirtyKey, id)
+if score and tonumber(score) == flushed then
+    return redis.call("ZREM", dirtyKey, id)
+end
+return 0
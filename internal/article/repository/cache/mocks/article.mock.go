@@ -12,8 +12,10 @@ package cachemocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/tsukiyo/mercury/internal/article/domain"
+	cachex "github.com/tsukiyo/mercury/pkg/cachex"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,48 @@ func (m *MockArticleCache) EXPECT() *MockArticleCacheMockRecorder {
 	return m.recorder
 }
 
+// CleanDraft mocks base method.
+func (m *MockArticleCache) CleanDraft(ctx context.Context, articleId int64, utime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanDraft", ctx, articleId, utime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanDraft indicates an expected call of CleanDraft.
+func (mr *MockArticleCacheMockRecorder) CleanDraft(ctx, articleId, utime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanDraft", reflect.TypeOf((*MockArticleCache)(nil).CleanDraft), ctx, articleId, utime)
+}
+
+// Del mocks base method.
+func (m *MockArticleCache) Del(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockArticleCacheMockRecorder) Del(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockArticleCache)(nil).Del), ctx, id)
+}
+
+// DelDraft mocks base method.
+func (m *MockArticleCache) DelDraft(ctx context.Context, articleId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelDraft", ctx, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelDraft indicates an expected call of DelDraft.
+func (mr *MockArticleCacheMockRecorder) DelDraft(ctx, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelDraft", reflect.TypeOf((*MockArticleCache)(nil).DelDraft), ctx, articleId)
+}
+
 // DelFirstPage mocks base method.
 func (m *MockArticleCache) DelFirstPage(ctx context.Context, authorId int64) error {
 	m.ctrl.T.Helper()
@@ -54,19 +98,63 @@ func (mr *MockArticleCacheMockRecorder) DelFirstPage(ctx, authorId any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelFirstPage", reflect.TypeOf((*MockArticleCache)(nil).DelFirstPage), ctx, authorId)
 }
 
+// DelPub mocks base method.
+func (m *MockArticleCache) DelPub(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelPub", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelPub indicates an expected call of DelPub.
+func (mr *MockArticleCacheMockRecorder) DelPub(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelPub", reflect.TypeOf((*MockArticleCache)(nil).DelPub), ctx, id)
+}
+
+// DirtyDrafts mocks base method.
+func (m *MockArticleCache) DirtyDrafts(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DirtyDrafts", ctx, before, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DirtyDrafts indicates an expected call of DirtyDrafts.
+func (mr *MockArticleCacheMockRecorder) DirtyDrafts(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DirtyDrafts", reflect.TypeOf((*MockArticleCache)(nil).DirtyDrafts), ctx, before, limit)
+}
+
 // Get mocks base method.
-func (m *MockArticleCache) Get(ctx context.Context, id int64) (domain.Article, error) {
+func (m *MockArticleCache) Get(ctx context.Context, id int64, load cachex.Loader[domain.Article]) (domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret := m.ctrl.Call(m, "Get", ctx, id, load)
 	ret0, _ := ret[0].(domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockArticleCacheMockRecorder) Get(ctx, id any) *gomock.Call {
+func (mr *MockArticleCacheMockRecorder) Get(ctx, id, load any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticleCache)(nil).Get), ctx, id, load)
+}
+
+// GetDraft mocks base method.
+func (m *MockArticleCache) GetDraft(ctx context.Context, articleId int64) (domain.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", ctx, articleId)
+	ret0, _ := ret[0].(domain.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockArticleCacheMockRecorder) GetDraft(ctx, articleId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticleCache)(nil).Get), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockArticleCache)(nil).GetDraft), ctx, articleId)
 }
 
 // GetFirstPage mocks base method.
@@ -85,18 +173,32 @@ func (mr *MockArticleCacheMockRecorder) GetFirstPage(ctx, authorId any) *gomock.
 }
 
 // GetPub mocks base method.
-func (m *MockArticleCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
+func (m *MockArticleCache) GetPub(ctx context.Context, id int64, load cachex.Loader[domain.Article]) (domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPub", ctx, id)
+	ret := m.ctrl.Call(m, "GetPub", ctx, id, load)
 	ret0, _ := ret[0].(domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPub indicates an expected call of GetPub.
-func (mr *MockArticleCacheMockRecorder) GetPub(ctx, id any) *gomock.Call {
+func (mr *MockArticleCacheMockRecorder) GetPub(ctx, id, load any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPub", reflect.TypeOf((*MockArticleCache)(nil).GetPub), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPub", reflect.TypeOf((*MockArticleCache)(nil).GetPub), ctx, id, load)
+}
+
+// InvalidatePub mocks base method.
+func (m *MockArticleCache) InvalidatePub(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePub", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePub indicates an expected call of InvalidatePub.
+func (mr *MockArticleCacheMockRecorder) InvalidatePub(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePub", reflect.TypeOf((*MockArticleCache)(nil).InvalidatePub), ctx, id)
 }
 
 // Set mocks base method.
@@ -113,6 +215,20 @@ func (mr *MockArticleCacheMockRecorder) Set(ctx, atcl any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockArticleCache)(nil).Set), ctx, atcl)
 }
 
+// SetDraft mocks base method.
+func (m *MockArticleCache) SetDraft(ctx context.Context, draft domain.Draft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDraft", ctx, draft)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDraft indicates an expected call of SetDraft.
+func (mr *MockArticleCacheMockRecorder) SetDraft(ctx, draft any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDraft", reflect.TypeOf((*MockArticleCache)(nil).SetDraft), ctx, draft)
+}
+
 // SetFirstPage mocks base method.
 func (m *MockArticleCache) SetFirstPage(ctx context.Context, authorId int64, atcls []domain.Article) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,166 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tsukiyo/mercury/internal/article/domain"
+)
+
+var (
+	roleViewer = domain.CollaboratorRoleViewer.ToUint8()
+	roleEditor = domain.CollaboratorRoleEditor.ToUint8()
+	roleOwner  = domain.CollaboratorRoleOwner.ToUint8()
+
+	collaboratorPending  = domain.CollaboratorStatusPending.ToUint8()
+	collaboratorAccepted = domain.CollaboratorStatusAccepted.ToUint8()
+)
+
+func (dao *GORMArticleDAO) GetRole(ctx context.Context, articleId, uid int64) (uint8, error) {
+	return roleOf(dao.db.WithContext(ctx), articleId, uid)
+}
+
+func (dao *GORMArticleDAO) InviteCollaborator(ctx context.Context, c ArticleCollaborator) error {
+	now := time.Now().UnixMilli()
+	c.Status = collaboratorPending
+	c.Ctime, c.Utime = now, now
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := authorize(tx, c.ArticleId, c.InviterId, roleOwner); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "article_id"}, {Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"role":       c.Role,
+				"inviter_id": c.InviterId,
+				"utime":      now,
+			}),
+		}).Create(&c).Error
+	})
+}
+
+func (dao *GORMArticleDAO) AcceptInvitation(ctx context.Context, articleId, uid int64) error {
+	res := dao.db.WithContext(ctx).Model(&ArticleCollaborator{}).
+		Where("article_id = ? AND user_id = ? AND status = ?", articleId, uid, collaboratorPending).
+		Updates(map[string]any{
+			"status": collaboratorAccepted,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return ErrCollaboratorNotFound
+	}
+	return nil
+}
+
+func (dao *GORMArticleDAO) DeleteCollaborator(ctx context.Context, articleId, operatorId, uid int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if operatorId != uid {
+			if err := authorize(tx, articleId, operatorId, roleOwner); err != nil {
+				return err
+			}
+		}
+		res := tx.Where("article_id = ? AND user_id = ?", articleId, uid).
+			Delete(&ArticleCollaborator{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrCollaboratorNotFound
+		}
+		return nil
+	})
+}
+
+func (dao *GORMArticleDAO) ListCollaborators(ctx context.Context, articleId int64) ([]ArticleCollaborator, error) {
+	var atcls []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Select("id", "author_id", "ctime").
+		Where("id = ?", articleId).
+		Limit(1).
+		Find(&atcls).Error
+	if err != nil || len(atcls) == 0 {
+		return nil, err
+	}
+	var collabs []ArticleCollaborator
+	err = dao.db.WithContext(ctx).Model(&ArticleCollaborator{}).
+		Where("article_id = ?", articleId).
+		Order("id ASC").
+		Find(&collabs).Error
+	if err != nil {
+		return nil, err
+	}
+	return append([]ArticleCollaborator{ownerOf(atcls[0])}, collabs...), nil
+}
+
+// ownerOf is the author as a collaborator.
+func ownerOf(atcl Article) ArticleCollaborator {
+	return ArticleCollaborator{
+		ArticleId: atcl.Id,
+		UserId:    atcl.AuthorId,
+		Role:      roleOwner,
+		Status:    collaboratorAccepted,
+		Ctime:     atcl.Ctime,
+		Utime:     atcl.Ctime,
+	}
+}
+
+// roleOf returns the role of the user on the article, zero if the article does not exist or the user has no role.
+func roleOf(tx *gorm.DB, articleId, uid int64) (uint8, error) {
+	authorId, err := authorOf(tx, articleId)
+	if err != nil || authorId == 0 {
+		return 0, err
+	}
+	if authorId == uid {
+		return roleOwner, nil
+	}
+	var collabs []ArticleCollaborator
+	err = tx.Model(&ArticleCollaborator{}).
+		Select("role").
+		Where("article_id = ? AND user_id = ? AND status = ?", articleId, uid, collaboratorAccepted).
+		Limit(1).
+		Find(&collabs).Error
+	if err != nil || len(collabs) == 0 {
+		return 0, err
+	}
+	return collabs[0].Role, nil
+}
+
+// authorize returns ErrPossibleIncorrectAuthor unless the user has the role or a higher one on the article.
+func authorize(tx *gorm.DB, articleId, uid int64, role uint8) error {
+	cur, err := roleOf(tx, articleId, uid)
+	if err != nil {
+		return err
+	}
+	if cur < role {
+		return ErrPossibleIncorrectAuthor
+	}
+	return nil
+}
+
+// authorOf returns the author of the article, zero if it does not exist.
+func authorOf(tx *gorm.DB, articleId int64) (int64, error) {
+	var atcls []Article
+	err := tx.Model(&Article{}).
+		Select("author_id").
+		Where("id = ?", articleId).
+		Limit(1).
+		Find(&atcls).Error
+	if err != nil || len(atcls) == 0 {
+		return 0, err
+	}
+	return atcls[0].AuthorId, nil
+}
+
+// collaborating is the condition of articles the user owns or collaborates on.
+func collaborating(tx *gorm.DB, table string, uid int64) *gorm.DB {
+	return tx.Where(table+".author_id = ? OR "+table+".id IN (?)", uid,
+		tx.Session(&gorm.Session{NewDB: true}).Model(&ArticleCollaborator{}).
+			Select("article_id").
+			Where("user_id = ? AND status = ?", uid, collaboratorAccepted))
+}
@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveRendered stores the rendered content unless the online article has changed since it was rendered.
+func (dao *GORMArticleDAO) SaveRendered(ctx context.Context, content PublishedArticleContent) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pubAtcl PublishedArticle
+		// a concurrent publish waits until the content is saved, and overwrites it then
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&PublishedArticle{}).
+			Select("utime").
+			Where("id = ?", content.Id).
+			First(&pubAtcl).Error
+		if err != nil {
+			return err
+		}
+		if pubAtcl.Utime != content.Version {
+			return ErrStaleContent
+		}
+		return upsertContent(tx, content)
+	})
+}
+
+func upsertContent(tx *gorm.DB, content PublishedArticleContent) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"html", "abstract", "reading_time", "toc", "version"}),
+	}).Create(&content).Error
+}
+
+// findContent returns nil if the online article has not been rendered or the content is stale.
+func findContent(tx *gorm.DB, pubAtcl PublishedArticle) (*PublishedArticleContent, error) {
+	var contents []PublishedArticleContent
+	err := tx.Model(&PublishedArticleContent{}).
+		Where("id = ? AND version = ?", pubAtcl.Id, pubAtcl.Utime).
+		Limit(1).
+		Find(&contents).Error
+	if err != nil || len(contents) == 0 {
+		return nil, err
+	}
+	return &contents[0], nil
+}
@@ -0,0 +1,56 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (dao *GORMArticleDAO) UpsertDraft(ctx context.Context, draft ArticleDraft) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var drafts []ArticleDraft
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&ArticleDraft{}).
+			Select("article_id", "utime").
+			Where("article_id = ?", draft.ArticleId).
+			Limit(1).
+			Find(&drafts).Error
+		if err != nil {
+			return err
+		}
+		if len(drafts) == 0 {
+			return tx.Create(&draft).Error
+		}
+		// flushed out of order
+		if drafts[0].Utime >= draft.Utime {
+			return nil
+		}
+		// updated by the struct for the serializer of tags
+		return tx.Model(&ArticleDraft{}).
+			Where("article_id = ?", draft.ArticleId).
+			Select("title", "content", "tags", "base_version", "utime").
+			Updates(&draft).Error
+	})
+}
+
+func (dao *GORMArticleDAO) GetDraft(ctx context.Context, articleId int64) (ArticleDraft, error) {
+	var drafts []ArticleDraft
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", articleId).
+		Limit(1).
+		Find(&drafts).Error
+	if err != nil {
+		return ArticleDraft{}, err
+	}
+	if len(drafts) == 0 {
+		return ArticleDraft{}, ErrDraftNotFound
+	}
+	return drafts[0], nil
+}
+
+func (dao *GORMArticleDAO) DeleteDraft(ctx context.Context, articleId, maxBaseVersion int64) error {
+	return dao.db.WithContext(ctx).
+		Where("article_id = ? AND base_version <= ?", articleId, maxBaseVersion).
+		Delete(&ArticleDraft{}).Error
+}
@@ -2,14 +2,87 @@ package dao
 
 // Article Production Library
 type Article struct {
-	Id       int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
-	Title    string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
-	Content  string `gorm:"type=BLOB" bson:"content,omitempty"`
-	AuthorId int64  `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8  `bson:"status,omitempty"`
-	Ctime    int64  `bson:"ctime,omitempty"`
-	Utime    int64  `bson:"utime,omitempty" gorm:"index"`
+	Id          int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	Title       string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	Content     string `gorm:"type=BLOB" bson:"content,omitempty"`
+	AuthorId    int64  `gorm:"index" bson:"author_id,omitempty"`
+	Status      uint8  `bson:"status,omitempty"`
+	ScheduledAt int64  `bson:"scheduled_at,omitempty" gorm:"index"`
+	// Version increases on every update of the content,
+	// the online library keeps the version it was published from
+	Version int64 `bson:"version,omitempty"`
+	// Abstract is cut from Content whenever it is saved
+	Abstract string `gorm:"type:varchar(1024)" bson:"abstract,omitempty"`
+	// Tags are kept in the link tables by GORM and embedded by MongoDB
+	Tags []string `gorm:"-" bson:"tags,omitempty"`
+	// Rendered is only used by the online library,
+	// GORM keeps it in PublishedArticleContent and MongoDB embeds it
+	Rendered *PublishedArticleContent `gorm:"-" bson:"rendered,omitempty"`
+	// TrashedAt is when the owner deleted the article, it is purged once the retention passes
+	TrashedAt int64 `bson:"trashed_at,omitempty" gorm:"index"`
+	Ctime     int64 `bson:"ctime,omitempty"`
+	Utime     int64 `bson:"utime,omitempty" gorm:"index"`
 }
 
 // PublishedArticle OnLive Library
 type PublishedArticle Article
+
+// PublishedArticleContent is rendered from the content of the online library,
+// Version is the utime of the online article it was rendered from, it is stale once they differ.
+type PublishedArticleContent struct {
+	Id       int64  `gorm:"primaryKey;autoIncrement:false" bson:"-"`
+	HTML     string `gorm:"type:MEDIUMTEXT" bson:"html,omitempty"`
+	Abstract string `gorm:"type:varchar(1024)" bson:"abstract,omitempty"`
+	// ReadingTime in seconds
+	ReadingTime int64 `bson:"reading_time,omitempty"`
+	// TOC is the json of the headings
+	TOC     string `gorm:"type:TEXT" bson:"toc,omitempty"`
+	Version int64  `bson:"version,omitempty"`
+}
+
+// ArticleDraft is the autosaved content of an article, flushed from the cache,
+// BaseVersion is the version of the article it is edited from.
+type ArticleDraft struct {
+	ArticleId   int64    `gorm:"primaryKey;autoIncrement:false" bson:"article_id,omitempty"`
+	AuthorId    int64    `gorm:"index" bson:"author_id,omitempty"`
+	Title       string   `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	Content     string   `gorm:"type=BLOB" bson:"content,omitempty"`
+	Tags        []string `gorm:"serializer:json;type:TEXT" bson:"tags,omitempty"`
+	BaseVersion int64    `bson:"base_version"`
+	Utime       int64    `bson:"utime,omitempty"`
+}
+
+// ArticleCollaborator is a user invited to an article, the author owns the article without being recorded here.
+// Role and Status are domain.CollaboratorRole and domain.CollaboratorStatus.
+type ArticleCollaborator struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleId int64 `gorm:"uniqueIndex:idx_article_user" bson:"article_id,omitempty"`
+	UserId    int64 `gorm:"uniqueIndex:idx_article_user;index" bson:"user_id,omitempty"`
+	Role      uint8 `bson:"role,omitempty"`
+	Status    uint8 `bson:"status,omitempty"`
+	InviterId int64 `bson:"inviter_id,omitempty"`
+	Ctime     int64 `bson:"ctime,omitempty"`
+	Utime     int64 `bson:"utime,omitempty"`
+}
+
+// ArticleRevision snapshot of an article, recorded on every save and publish
+type ArticleRevision struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleId int64  `gorm:"index" bson:"article_id,omitempty"`
+	Title     string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	Content   string `gorm:"type=BLOB" bson:"content,omitempty"`
+	AuthorId  int64  `bson:"author_id,omitempty"`
+	Status    uint8  `bson:"status,omitempty"`
+	Ctime     int64  `bson:"ctime,omitempty"`
+}
+
+func newRevision(atcl Article, now int64) ArticleRevision {
+	return ArticleRevision{
+		ArticleId: atcl.Id,
+		Title:     atcl.Title,
+		Content:   atcl.Content,
+		AuthorId:  atcl.AuthorId,
+		Status:    atcl.Status,
+		Ctime:     now,
+	}
+}
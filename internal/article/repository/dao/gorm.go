@@ -2,11 +2,21 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+
+	"github.com/tsukiyo/mercury/internal/article/domain"
+)
+
+var (
+	statusPublished   = domain.ArticleStatusPublished.ToUint8()
+	statusScheduled   = domain.ArticleStatusScheduled.ToUint8()
+	statusUnpublished = domain.ArticleStatusUnpublished.ToUint8()
+	statusTrashed     = domain.ArticleStatusTrashed.ToUint8()
 )
 
 type GORMArticleDAO struct {
@@ -23,37 +33,115 @@ func (dao *GORMArticleDAO) Insert(ctx context.Context, atcl Article) (int64, err
 	now := time.Now().UnixMilli()
 	atcl.Ctime = now
 	atcl.Utime = now
-	err := dao.db.WithContext(ctx).Create(&atcl).Error
+	atcl.Version = 1
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&atcl).Error; err != nil {
+			return err
+		}
+		rev := newRevision(atcl, now)
+		if err := tx.Create(&rev).Error; err != nil {
+			return err
+		}
+		return replaceTags[ArticleTag](tx, atcl.Id, atcl.Tags)
+	})
 	return atcl.Id, err
 }
 
-func (dao *GORMArticleDAO) UpdateById(ctx context.Context, atcl Article) error {
+func (dao *GORMArticleDAO) UpdateById(ctx context.Context, atcl Article) (int64, error) {
 	now := time.Now().UnixMilli()
-	res := dao.db.WithContext(ctx).Model(&atcl).
-		Where("id = ? AND author_id = ?", atcl.Id, atcl.AuthorId).
-		Updates(map[string]any{
-			"title":   atcl.Title,
-			"content": atcl.Content,
-			"status":  atcl.Status,
-			"utime":   now,
-		})
-	if res.Error != nil {
-		return res.Error
+	var version int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cur Article
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&Article{}).
+			Select("id", "status", "version").
+			Where("id = ?", atcl.Id).
+			First(&cur).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("update article failed, perhaps invalid id: id %d author_id %d", atcl.Id, atcl.AuthorId)
+		}
+		if err != nil {
+			return err
+		}
+		if err = authorize(tx, atcl.Id, atcl.AuthorId, roleEditor); err != nil {
+			return err
+		}
+		if cur.Status == statusTrashed {
+			return ErrArticleTrashed
+		}
+		if atcl.Version > 0 && atcl.Version != cur.Version {
+			return ErrVersionConflict
+		}
+		version = cur.Version + 1
+		err = tx.Model(&Article{}).
+			Where("id = ?", atcl.Id).
+			Updates(map[string]any{
+				"title":    atcl.Title,
+				"content":  atcl.Content,
+				"abstract": atcl.Abstract,
+				"status":   atcl.Status,
+				"version":  version,
+				"utime":    now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		rev := newRevision(atcl, now)
+		if err := tx.Create(&rev).Error; err != nil {
+			return err
+		}
+		return replaceTags[ArticleTag](tx, atcl.Id, atcl.Tags)
+	})
+	return version, err
+}
+
+func (dao *GORMArticleDAO) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]Article, error) {
+	var atcls []Article
+	err := collaborating(dao.db.WithContext(ctx).Model(&Article{}), "articles", uid).
+		Where("articles.status <> ?", statusTrashed).
+		Offset(offset).Limit(limit).
+		Order("utime DESC").
+		Find(&atcls).Error
+	if err != nil {
+		return nil, err
 	}
-	if res.RowsAffected == 0 {
-		return fmt.Errorf("update article failed, perhaps invalid id: id %d author_id %d", atcl.Id, atcl.AuthorId)
+	ids := make([]int64, 0, len(atcls))
+	for _, atcl := range atcls {
+		ids = append(ids, atcl.Id)
 	}
-	return nil
+	tags, err := findTags[ArticleTag](dao.db.WithContext(ctx), ids...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range atcls {
+		atcls[i].Tags = tags[atcls[i].Id]
+	}
+	return atcls, nil
 }
 
-func (dao *GORMArticleDAO) GetByAuthor(ctx context.Context, authorId int64, offset, limit int) ([]Article, error) {
+func (dao *GORMArticleDAO) GetByAuthorBefore(ctx context.Context, uid int64, utime time.Time, id int64, limit int) ([]Article, error) {
 	var atcls []Article
-	err := dao.db.WithContext(ctx).Model(&Article{}).
-		Where("author_id = ?", authorId).
-		Offset(offset).Limit(limit).
+	err := before(collaborating(dao.db.WithContext(ctx).Model(&Article{}), "articles", uid), "articles", utime, id).
+		Where("articles.status <> ?", statusTrashed).
 		Order("utime DESC").
+		Order("id DESC").
+		Limit(limit).
 		Find(&atcls).Error
-	return atcls, err
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(atcls))
+	for _, atcl := range atcls {
+		ids = append(ids, atcl.Id)
+	}
+	tags, err := findTags[ArticleTag](dao.db.WithContext(ctx), ids...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range atcls {
+		atcls[i].Tags = tags[atcls[i].Id]
+	}
+	return atcls, nil
 }
 
 func (dao *GORMArticleDAO) GetById(ctx context.Context, id int64) (Article, error) {
@@ -61,6 +149,11 @@ func (dao *GORMArticleDAO) GetById(ctx context.Context, id int64) (Article, erro
 	err := dao.db.WithContext(ctx).Model(&Article{}).
 		Where("id = ?", id).
 		First(&atcl).Error
+	if err != nil {
+		return Article{}, err
+	}
+	tags, err := findTags[ArticleTag](dao.db.WithContext(ctx), id)
+	atcl.Tags = tags[id]
 	return atcl, err
 }
 
@@ -69,6 +162,15 @@ func (dao *GORMArticleDAO) GetPubById(ctx context.Context, id int64) (PublishedA
 	err := dao.db.WithContext(ctx).Model(&PublishedArticle{}).
 		Where("id = ?", id).
 		First(&pubAtcl).Error
+	if err != nil {
+		return PublishedArticle{}, err
+	}
+	tags, err := findTags[PublishedArticleTag](dao.db.WithContext(ctx), id)
+	if err != nil {
+		return PublishedArticle{}, err
+	}
+	pubAtcl.Tags = tags[id]
+	pubAtcl.Rendered, err = findContent(dao.db.WithContext(ctx), pubAtcl)
 	return pubAtcl, err
 }
 
@@ -84,24 +186,19 @@ func (dao *GORMArticleDAO) Sync(ctx context.Context, atcl Article) (int64, error
 	)
 	if id == 0 {
 		id, err = txDao.Insert(ctx, atcl)
+		atcl.Version = 1
 	} else {
-		err = txDao.UpdateById(ctx, atcl)
+		atcl.Version, err = txDao.UpdateById(ctx, atcl)
+		if err == nil {
+			// published by an editor, the online library belongs to the author
+			atcl.AuthorId, err = authorOf(tx, id)
+		}
 	}
 	if err != nil {
 		return 0, err
 	}
 	atcl.Id = id
-	pubAtcl := PublishedArticle(atcl)
-	pubAtcl.Utime, pubAtcl.Ctime = now, now
-	err = tx.Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "id"}},
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"title":   atcl.Title,
-			"content": atcl.Content,
-			"status":  atcl.Status,
-			"utime":   now,
-		}),
-	}).Create(&pubAtcl).Error
+	err = upsertPublished(tx, atcl, now)
 	if err != nil {
 		return 0, err
 	}
@@ -109,10 +206,13 @@ func (dao *GORMArticleDAO) Sync(ctx context.Context, atcl Article) (int64, error
 	return id, tx.Error
 }
 
-func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id, authorId int64, status uint8) error {
+func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id, uid int64, status uint8) error {
 	now := time.Now().UnixMilli()
 	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		res := tx.Model(&Article{}).Where("id = ? AND author_id = ?", id, authorId).Updates(map[string]any{
+		if err := authorize(tx, id, uid, roleEditor); err != nil {
+			return err
+		}
+		res := tx.Model(&Article{}).Where("id = ? AND status <> ?", id, statusTrashed).Updates(map[string]any{
 			"status": status,
 			"utime":  now,
 		})
@@ -120,7 +220,7 @@ func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id, authorId int64, s
 			return res.Error
 		}
 		if res.RowsAffected != 1 {
-			return ErrPossibleIncorrectAuthor
+			return ErrArticleTrashed
 		}
 
 		res = tx.Model(&PublishedArticle{}).Where("id = ?", id).Updates(map[string]any{
@@ -134,7 +234,7 @@ func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id, authorId int64, s
 			return ErrPossibleIncorrectAuthor
 		}
 
-		return nil
+		return insertStatusEvent(tx, id, status, now)
 	})
 }
 
@@ -142,9 +242,251 @@ func (dao *GORMArticleDAO) SyncStatus(ctx context.Context, id, authorId int64, s
 func (dao *GORMArticleDAO) ListPubByUtime(ctx context.Context, utime time.Time, offset int, limit int) ([]PublishedArticle, error) {
 	var pubAtcls []PublishedArticle
 	err := dao.db.WithContext(ctx).Model(&PublishedArticle{}).
-		Order("utime DESC").Where("utime < ?", utime.UnixMilli()).
+		Order("utime DESC").Where("utime < ? AND status <> ?", utime.UnixMilli(), statusTrashed).
 		Limit(limit).
 		Offset(offset).
 		Find(&pubAtcls).Error
-	return pubAtcls, err
+	if err != nil {
+		return nil, err
+	}
+	return pubAtcls, dao.fillPubTags(ctx, pubAtcls)
+}
+
+func (dao *GORMArticleDAO) ListPubBefore(ctx context.Context, utime time.Time, id int64, limit int) ([]PublishedArticle, error) {
+	var pubAtcls []PublishedArticle
+	err := before(dao.db.WithContext(ctx).Model(&PublishedArticle{}), "published_articles", utime, id).
+		Where("status <> ?", statusTrashed).
+		Order("utime DESC").
+		Order("id DESC").
+		Limit(limit).
+		Find(&pubAtcls).Error
+	if err != nil {
+		return nil, err
+	}
+	return pubAtcls, dao.fillPubTags(ctx, pubAtcls)
+}
+
+// ListPubByTag returns published articles with the tag, ordered by utime and id descending.
+// Pass the utime and id of the last article of previous page to continue, zero utime means the first page.
+func (dao *GORMArticleDAO) ListPubByTag(ctx context.Context, tag string, utime time.Time, id int64, limit int) ([]PublishedArticle, error) {
+	var pubAtcls []PublishedArticle
+	query := dao.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Joins("JOIN published_article_tags AS pat ON pat.article_id = published_articles.id").
+		Joins("JOIN tags AS t ON t.id = pat.tag_id").
+		Where("t.name = ? AND published_articles.status = ?", tag, statusPublished)
+	err := before(query, "published_articles", utime, id).
+		Order("published_articles.utime DESC").
+		Order("published_articles.id DESC").
+		Limit(limit).
+		Find(&pubAtcls).Error
+	if err != nil {
+		return nil, err
+	}
+	return pubAtcls, dao.fillPubTags(ctx, pubAtcls)
+}
+
+// CountTags counts published articles of each tag, most used first.
+// Only the given tags are counted unless names is empty.
+func (dao *GORMArticleDAO) CountTags(ctx context.Context, names []string, limit int) ([]TagCount, error) {
+	var cnts []TagCount
+	query := dao.db.WithContext(ctx).Table("published_article_tags AS pat").
+		Select("t.name AS name, COUNT(*) AS cnt").
+		Joins("JOIN tags AS t ON t.id = pat.tag_id").
+		Joins("JOIN published_articles AS pa ON pa.id = pat.article_id").
+		Where("pa.status = ?", statusPublished)
+	if len(names) > 0 {
+		query = query.Where("t.name IN ?", names)
+	}
+	err := query.
+		Group("t.name").
+		Order("cnt DESC").
+		Limit(limit).
+		Scan(&cnts).Error
+	return cnts, err
+}
+
+// before keeps the rows after (utime, id) in descending order, zero utime keeps all.
+func before(query *gorm.DB, table string, utime time.Time, id int64) *gorm.DB {
+	if utime.IsZero() {
+		return query
+	}
+	ut := utime.UnixMilli()
+	return query.Where(fmt.Sprintf("%[1]s.utime < ? OR (%[1]s.utime = ? AND %[1]s.id < ?)", table), ut, ut, id)
+}
+
+func (dao *GORMArticleDAO) fillPubTags(ctx context.Context, pubAtcls []PublishedArticle) error {
+	ids := make([]int64, 0, len(pubAtcls))
+	for _, pubAtcl := range pubAtcls {
+		ids = append(ids, pubAtcl.Id)
+	}
+	tags, err := findTags[PublishedArticleTag](dao.db.WithContext(ctx), ids...)
+	if err != nil {
+		return err
+	}
+	for i := range pubAtcls {
+		pubAtcls[i].Tags = tags[pubAtcls[i].Id]
+	}
+	return nil
+}
+
+// ListRevisions returns revisions of the article from newest to oldest.
+func (dao *GORMArticleDAO) ListRevisions(ctx context.Context, articleId, uid int64, offset, limit int) ([]ArticleRevision, error) {
+	err := authorize(dao.db.WithContext(ctx), articleId, uid, roleViewer)
+	if err != nil {
+		return nil, err
+	}
+	var revs []ArticleRevision
+	err = dao.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("article_id = ?", articleId).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&revs).Error
+	return revs, err
+}
+
+func (dao *GORMArticleDAO) GetRevision(ctx context.Context, id, uid int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := dao.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("id = ?", id).
+		First(&rev).Error
+	if err != nil {
+		return ArticleRevision{}, err
+	}
+	if err = authorize(dao.db.WithContext(ctx), rev.ArticleId, uid, roleViewer); err != nil {
+		return ArticleRevision{}, err
+	}
+	return rev, nil
+}
+
+// Schedule marks the article as scheduled, saving it again before the time comes cancels the schedule.
+func (dao *GORMArticleDAO) Schedule(ctx context.Context, id, uid int64, at time.Time) error {
+	err := authorize(dao.db.WithContext(ctx), id, uid, roleEditor)
+	if err != nil {
+		return err
+	}
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status <> ?", id, statusTrashed).
+		Updates(map[string]any{
+			"status":       statusScheduled,
+			"scheduled_at": at.UnixMilli(),
+			"utime":        time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return ErrArticleTrashed
+	}
+	return nil
+}
+
+func (dao *GORMArticleDAO) CancelSchedule(ctx context.Context, id, uid int64) error {
+	err := authorize(dao.db.WithContext(ctx), id, uid, roleEditor)
+	if err != nil {
+		return err
+	}
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status = ?", id, statusScheduled).
+		Updates(map[string]any{
+			"status":       statusUnpublished,
+			"scheduled_at": 0,
+			"utime":        time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return ErrArticleNotScheduled
+	}
+	return nil
+}
+
+func (dao *GORMArticleDAO) ListScheduled(ctx context.Context, before time.Time, limit int) ([]Article, error) {
+	var atcls []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Select("id", "author_id", "scheduled_at").
+		Where("status = ? AND scheduled_at <= ?", statusScheduled, before.UnixMilli()).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Find(&atcls).Error
+	return atcls, err
+}
+
+func (dao *GORMArticleDAO) SyncScheduled(ctx context.Context, id int64) (Article, error) {
+	var atcl Article
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		var err error
+		atcl, err = claimScheduled(tx, id, now)
+		if err != nil {
+			return err
+		}
+		return upsertPublished(tx, atcl, now)
+	})
+	return atcl, err
+}
+
+// claimScheduled switches the due article from scheduled to published and records a revision.
+// The status condition makes it safe for several executors to publish the same article concurrently.
+func claimScheduled(tx *gorm.DB, id int64, now int64) (Article, error) {
+	res := tx.Model(&Article{}).
+		Where("id = ? AND status = ? AND scheduled_at <= ?", id, statusScheduled, now).
+		Updates(map[string]any{
+			"status":       statusPublished,
+			"scheduled_at": 0,
+			"utime":        now,
+		})
+	if res.Error != nil {
+		return Article{}, res.Error
+	}
+	if res.RowsAffected != 1 {
+		return Article{}, ErrArticleNotScheduled
+	}
+	var atcl Article
+	err := tx.Model(&Article{}).Where("id = ?", id).First(&atcl).Error
+	if err != nil {
+		return Article{}, err
+	}
+	tags, err := findTags[ArticleTag](tx, id)
+	if err != nil {
+		return Article{}, err
+	}
+	atcl.Tags = tags[id]
+	rev := newRevision(atcl, now)
+	return atcl, tx.Create(&rev).Error
+}
+
+func upsertPublished(tx *gorm.DB, atcl Article, now int64) error {
+	pubAtcl := PublishedArticle(atcl)
+	pubAtcl.Utime, pubAtcl.Ctime = now, now
+	err := insertPublishedEvent(tx, atcl, now)
+	if err != nil {
+		return err
+	}
+	err = replaceTags[PublishedArticleTag](tx, atcl.Id, atcl.Tags)
+	if err != nil {
+		return err
+	}
+	err = tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"title":    atcl.Title,
+			"content":  atcl.Content,
+			"abstract": atcl.Abstract,
+			"status":   atcl.Status,
+			"version":  atcl.Version,
+			"utime":    now,
+		}),
+	}).Create(&pubAtcl).Error
+	if err != nil || atcl.Rendered == nil {
+		return err
+	}
+	return upsertContent(tx, renderedVersion(atcl, now))
+}
+
+// renderedVersion is the rendered content of atcl published at now.
+func renderedVersion(atcl Article, now int64) PublishedArticleContent {
+	content := *atcl.Rendered
+	content.Id, content.Version = atcl.Id, now
+	return content
 }
@@ -2,11 +2,63 @@ package dao
 
 import (
 	"gorm.io/gorm"
+
+	"github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/pkg/gormx"
 )
 
 func InitTable(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&Article{},
 		&PublishedArticle{},
+		&PublishedArticleContent{},
+		&ArticleRevision{},
+		&ArticleDraft{},
+		&ArticleCollaborator{},
+		&Series{},
+		&SeriesArticle{},
+		&Tag{},
+		&ArticleTag{},
+		&PublishedArticleTag{},
 	)
+	if err != nil {
+		return err
+	}
+	return gormx.RunOnce(db, "article_abstracts", func(tx *gorm.DB) error {
+		err := fillAbstracts(tx, "articles")
+		if err != nil {
+			return err
+		}
+		return fillAbstracts(tx, "published_articles")
+	})
+}
+
+// fillAbstracts cuts the abstracts of the articles saved before the abstract was stored.
+func fillAbstracts(tx *gorm.DB, table string) error {
+	const batchSize = 200
+	var lastId int64
+	for {
+		var batch []Article
+		err := tx.Table(table).
+			Select("id", "content").
+			Where("id > ? AND abstract = ?", lastId, "").
+			Order("id ASC").
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+		for _, atcl := range batch {
+			err = tx.Table(table).
+				Where("id = ?", atcl.Id).
+				Update("abstract", domain.AbstractOf(atcl.Content)).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastId = batch[len(batch)-1].Id
+	}
 }
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,15 +22,24 @@ type MongoDBDAO struct {
 	col *mongo.Collection
 	// OnLive Library
 	liveCol *mongo.Collection
-	node    *snowflake.Node
+	// Revision Library
+	revCol *mongo.Collection
+	// Draft Library
+	draftCol *mongo.Collection
+	// collaborators except authors
+	collabCol *mongo.Collection
+	node      *snowflake.Node
 }
 
 func NewMongoDBDAO(mdb *mongo.Database, node *snowflake.Node) ArticleDAO {
 	return &MongoDBDAO{
 		// mdb:     mdb,
-		col:     mdb.Collection("articles"),
-		liveCol: mdb.Collection("published_articles"),
-		node:    node,
+		col:       mdb.Collection("articles"),
+		liveCol:   mdb.Collection("published_articles"),
+		revCol:    mdb.Collection("article_revisions"),
+		draftCol:  mdb.Collection("article_drafts"),
+		collabCol: mdb.Collection("article_collaborators"),
+		node:      node,
 	}
 }
 
@@ -55,7 +65,56 @@ func InitCollections(db *mongo.Database) error {
 		return err
 	}
 	_, err = db.Collection("published_articles").Indexes().
-		CreateMany(ctx, index)
+		CreateMany(ctx, append(index, mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: "tags", Value: 1},
+				bson.E{Key: "utime", Value: -1},
+				bson.E{Key: "id", Value: -1},
+			},
+			Options: options.Index(),
+		}))
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_revisions").Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{
+					bson.E{Key: "article_id", Value: 1},
+					bson.E{Key: "id", Value: -1},
+				},
+				Options: options.Index(),
+			},
+		})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_drafts").Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{bson.E{Key: "article_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_collaborators").Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					bson.E{Key: "article_id", Value: 1},
+					bson.E{Key: "user_id", Value: 1},
+				},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{bson.E{Key: "user_id", Value: 1}},
+				Options: options.Index(),
+			},
+		})
 	return err
 }
 
@@ -64,39 +123,87 @@ func (dao *MongoDBDAO) Insert(ctx context.Context, atcl Article) (int64, error)
 	now := time.Now().UnixMilli()
 	atcl.Id = id
 	atcl.Ctime, atcl.Utime = now, now
+	atcl.Version = 1
 	_, err := dao.col.InsertOne(ctx, atcl)
 	if err != nil {
 		return 0, err
 	}
+	err = dao.insertRevision(ctx, atcl, now)
+	if err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
-func (dao *MongoDBDAO) UpdateById(ctx context.Context, atcl Article) error {
-	filter := bson.M{"id": atcl.Id, "author_id": atcl.AuthorId}
+func (dao *MongoDBDAO) UpdateById(ctx context.Context, atcl Article) (int64, error) {
+	now := time.Now().UnixMilli()
+	if err := dao.authorize(ctx, atcl.Id, atcl.AuthorId, roleEditor); err != nil {
+		return 0, err
+	}
+	filter := bson.M{"id": atcl.Id, "status": bson.M{"$ne": statusTrashed}}
+	if atcl.Version > 0 {
+		filter["version"] = atcl.Version
+	}
 	update := bson.M{
 		"$set": bson.M{
-			"title":   atcl.Title,
-			"content": atcl.Content,
-			"status":  atcl.Status,
-			"utime":   time.Now().UnixMilli(),
+			"title":    atcl.Title,
+			"content":  atcl.Content,
+			"abstract": atcl.Abstract,
+			"status":   atcl.Status,
+			"tags":     atcl.Tags,
+			"utime":    now,
 		},
+		"$inc": bson.M{"version": 1},
 	}
-	res, err := dao.col.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return err
+	var updated Article
+	err := dao.col.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().
+			SetReturnDocument(options.After).
+			SetProjection(bson.M{"version": 1})).
+		Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		var cur Article
+		er := dao.col.FindOne(ctx, bson.M{"id": atcl.Id},
+			options.FindOne().SetProjection(bson.M{"status": 1})).Decode(&cur)
+		switch {
+		case errors.Is(er, mongo.ErrNoDocuments):
+			return 0, fmt.Errorf("update article failed, perhaps invalid id: id %d author_id %d", atcl.Id, atcl.AuthorId)
+		case er != nil:
+			return 0, er
+		case cur.Status == statusTrashed:
+			return 0, ErrArticleTrashed
+		}
+		return 0, ErrVersionConflict
 	}
-	if res.ModifiedCount == 0 {
-		return fmt.Errorf("update article failed, perhaps invalid id: id %d author_id %d", atcl.Id, atcl.AuthorId)
+	if err != nil {
+		return 0, err
 	}
-
-	return nil
+	return updated.Version, dao.insertRevision(ctx, atcl, now)
 }
 
-func (dao *MongoDBDAO) GetByAuthor(ctx context.Context, author int64, offset, limit int) ([]Article, error) {
+func (dao *MongoDBDAO) GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]Article, error) {
 	// TODO implement me
 	panic("implement me")
 }
 
+func (dao *MongoDBDAO) GetByAuthorBefore(ctx context.Context, uid int64, utime time.Time, id int64, limit int) ([]Article, error) {
+	collaborating, err := dao.collaborating(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{"$and": bson.A{mongoBefore(utime, id), collaborating, bson.M{"status": bson.M{"$ne": statusTrashed}}}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var atcls []Article
+	err = cursor.All(ctx, &atcls)
+	return atcls, err
+}
+
 func (dao *MongoDBDAO) GetById(ctx context.Context, id int64) (Article, error) {
 	// TODO implement me
 	panic("implement me")
@@ -107,16 +214,25 @@ func (dao *MongoDBDAO) GetPubById(ctx context.Context, id int64) (PublishedArtic
 	panic("implement me")
 }
 
+// Sync does not write events to the outbox, which relies on the transactions of GORM based DAOs.
 func (dao *MongoDBDAO) Sync(ctx context.Context, atcl Article) (int64, error) {
 	var (
 		id  = atcl.Id
 		err error
+		// the production library is not rendered
+		rendered = atcl.Rendered
 	)
+	atcl.Rendered = nil
 
 	if id > 0 {
-		err = dao.UpdateById(ctx, atcl)
+		atcl.Version, err = dao.UpdateById(ctx, atcl)
+		if err == nil {
+			// published by an editor, the online library belongs to the author
+			atcl.AuthorId, err = dao.authorOf(ctx, id)
+		}
 	} else {
 		id, err = dao.Insert(ctx, atcl)
+		atcl.Version = 1
 	}
 	if err != nil {
 		return 0, err
@@ -125,24 +241,61 @@ func (dao *MongoDBDAO) Sync(ctx context.Context, atcl Article) (int64, error) {
 	atcl.Id = id
 	now := time.Now().UnixMilli()
 	atcl.Utime = now
-	filter := bson.M{
-		"id":        atcl.Id,
-		"author_id": atcl.AuthorId,
-	}
-	updates := bson.M{
-		"$set": PublishedArticle(atcl),
-		"$setOnInsert": bson.M{
-			"ctime": now,
-		},
+	if rendered != nil {
+		atcl.Rendered = rendered
+		content := renderedVersion(atcl, now)
+		atcl.Rendered = &content
 	}
-	_, err = dao.liveCol.UpdateOne(ctx, filter, updates, options.Update().SetUpsert(true))
+	filter := bson.M{"id": atcl.Id}
+	_, err = dao.liveCol.UpdateOne(ctx, filter, liveUpdates(PublishedArticle(atcl), now), options.Update().SetUpsert(true))
 	if err != nil {
 		return 0, err
 	}
 	return id, nil
 }
 
-func (dao *MongoDBDAO) SyncStatus(ctx context.Context, id, author int64, status uint8) error {
+func (dao *MongoDBDAO) SaveRendered(ctx context.Context, content PublishedArticleContent) error {
+	res, err := dao.liveCol.UpdateOne(ctx,
+		bson.M{"id": content.Id, "utime": content.Version},
+		bson.M{"$set": bson.M{"rendered": content}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrStaleContent
+	}
+	return nil
+}
+
+func (dao *MongoDBDAO) UpsertDraft(ctx context.Context, draft ArticleDraft) error {
+	// a later draft fails the filter, and the upsert then conflicts with it
+	_, err := dao.draftCol.ReplaceOne(ctx,
+		bson.M{"article_id": draft.ArticleId, "utime": bson.M{"$lt": draft.Utime}},
+		draft, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (dao *MongoDBDAO) GetDraft(ctx context.Context, articleId int64) (ArticleDraft, error) {
+	var draft ArticleDraft
+	err := dao.draftCol.FindOne(ctx, bson.M{"article_id": articleId}).Decode(&draft)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ArticleDraft{}, ErrDraftNotFound
+	}
+	return draft, err
+}
+
+func (dao *MongoDBDAO) DeleteDraft(ctx context.Context, articleId, maxBaseVersion int64) error {
+	_, err := dao.draftCol.DeleteOne(ctx, bson.M{
+		"article_id":   articleId,
+		"base_version": bson.M{"$lte": maxBaseVersion},
+	})
+	return err
+}
+
+func (dao *MongoDBDAO) SyncStatus(ctx context.Context, id, uid int64, status uint8) error {
 	// TODO implement me
 	panic("implement me")
 }
@@ -151,3 +304,498 @@ func (dao *MongoDBDAO) ListPubByUtime(ctx context.Context, utime time.Time, offs
 	// TODO implement me
 	panic("implement me")
 }
+
+func (dao *MongoDBDAO) ListPubBefore(ctx context.Context, utime time.Time, id int64, limit int) ([]PublishedArticle, error) {
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	filter := mongoBefore(utime, id)
+	filter["status"] = bson.M{"$ne": statusTrashed}
+	cursor, err := dao.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var pubAtcls []PublishedArticle
+	err = cursor.All(ctx, &pubAtcls)
+	return pubAtcls, err
+}
+
+func (dao *MongoDBDAO) ListRevisions(ctx context.Context, articleId, uid int64, offset, limit int) ([]ArticleRevision, error) {
+	if err := dao.authorize(ctx, articleId, uid, roleViewer); err != nil {
+		return nil, err
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := dao.revCol.Find(ctx, bson.M{"article_id": articleId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var revs []ArticleRevision
+	err = cursor.All(ctx, &revs)
+	return revs, err
+}
+
+func (dao *MongoDBDAO) GetRevision(ctx context.Context, id, uid int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := dao.revCol.FindOne(ctx, bson.M{"id": id}).Decode(&rev)
+	if err != nil {
+		return ArticleRevision{}, err
+	}
+	if err = dao.authorize(ctx, rev.ArticleId, uid, roleViewer); err != nil {
+		return ArticleRevision{}, err
+	}
+	return rev, nil
+}
+
+func (dao *MongoDBDAO) Schedule(ctx context.Context, id, uid int64, at time.Time) error {
+	if err := dao.authorize(ctx, id, uid, roleEditor); err != nil {
+		return err
+	}
+	filter := bson.M{"id": id, "status": bson.M{"$ne": statusTrashed}}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       statusScheduled,
+			"scheduled_at": at.UnixMilli(),
+			"utime":        time.Now().UnixMilli(),
+		},
+	}
+	res, err := dao.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleTrashed
+	}
+	return nil
+}
+
+func (dao *MongoDBDAO) CancelSchedule(ctx context.Context, id, uid int64) error {
+	if err := dao.authorize(ctx, id, uid, roleEditor); err != nil {
+		return err
+	}
+	filter := bson.M{"id": id, "status": statusScheduled}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       statusUnpublished,
+			"scheduled_at": 0,
+			"utime":        time.Now().UnixMilli(),
+		},
+	}
+	res, err := dao.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotScheduled
+	}
+	return nil
+}
+
+func (dao *MongoDBDAO) ListScheduled(ctx context.Context, before time.Time, limit int) ([]Article, error) {
+	filter := bson.M{
+		"status":       statusScheduled,
+		"scheduled_at": bson.M{"$lte": before.UnixMilli()},
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "scheduled_at", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var atcls []Article
+	err = cursor.All(ctx, &atcls)
+	return atcls, err
+}
+
+func (dao *MongoDBDAO) SyncScheduled(ctx context.Context, id int64) (Article, error) {
+	now := time.Now().UnixMilli()
+	filter := bson.M{
+		"id":           id,
+		"status":       statusScheduled,
+		"scheduled_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       statusPublished,
+			"scheduled_at": 0,
+			"utime":        now,
+		},
+	}
+	var atcl Article
+	err := dao.col.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&atcl)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return Article{}, ErrArticleNotScheduled
+	}
+	if err != nil {
+		return Article{}, err
+	}
+	if err = dao.insertRevision(ctx, atcl, now); err != nil {
+		return Article{}, err
+	}
+	live := PublishedArticle(atcl)
+	// ctime of the online library is only set on insert
+	live.Ctime = 0
+	_, err = dao.liveCol.UpdateOne(ctx,
+		bson.M{"id": atcl.Id},
+		liveUpdates(live, now),
+		options.Update().SetUpsert(true))
+	return atcl, err
+}
+
+func (dao *MongoDBDAO) ListPubByTag(ctx context.Context, tag string, utime time.Time, id int64, limit int) ([]PublishedArticle, error) {
+	filter := mongoBefore(utime, id)
+	filter["tags"] = tag
+	filter["status"] = statusPublished
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "utime", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := dao.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var pubAtcls []PublishedArticle
+	err = cursor.All(ctx, &pubAtcls)
+	return pubAtcls, err
+}
+
+func (dao *MongoDBDAO) CountTags(ctx context.Context, names []string, limit int) ([]TagCount, error) {
+	match := bson.M{"status": statusPublished}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$tags"}},
+	}
+	if len(names) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"tags": bson.M{"$in": names}}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$tags", "cnt": bson.M{"$sum": 1}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "cnt", Value: -1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+	cursor, err := dao.liveCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Name string `bson:"_id"`
+		Cnt  int64  `bson:"cnt"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	cnts := make([]TagCount, 0, len(rows))
+	for _, row := range rows {
+		cnts = append(cnts, TagCount{Name: row.Name, Cnt: row.Cnt})
+	}
+	return cnts, nil
+}
+
+// liveUpdates upserts the online library, empty tags and rendered content are omitted by $set
+// so they have to be unset explicitly.
+func liveUpdates(live PublishedArticle, now int64) bson.M {
+	updates := bson.M{
+		"$set": live,
+		"$setOnInsert": bson.M{
+			"ctime": now,
+		},
+	}
+	unset := bson.M{}
+	if len(live.Tags) == 0 {
+		unset["tags"] = ""
+	}
+	if live.Rendered == nil {
+		unset["rendered"] = ""
+	}
+	if len(unset) > 0 {
+		updates["$unset"] = unset
+	}
+	return updates
+}
+
+func (dao *MongoDBDAO) GetRole(ctx context.Context, articleId, uid int64) (uint8, error) {
+	authorId, err := dao.authorOf(ctx, articleId)
+	if err != nil || authorId == 0 {
+		return 0, err
+	}
+	if authorId == uid {
+		return roleOwner, nil
+	}
+	var c ArticleCollaborator
+	err = dao.collabCol.FindOne(ctx, bson.M{
+		"article_id": articleId,
+		"user_id":    uid,
+		"status":     collaboratorAccepted,
+	}).Decode(&c)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	return c.Role, err
+}
+
+func (dao *MongoDBDAO) InviteCollaborator(ctx context.Context, c ArticleCollaborator) error {
+	if err := dao.authorize(ctx, c.ArticleId, c.InviterId, roleOwner); err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	_, err := dao.collabCol.UpdateOne(ctx,
+		bson.M{"article_id": c.ArticleId, "user_id": c.UserId},
+		bson.M{
+			"$set": bson.M{
+				"role":       c.Role,
+				"inviter_id": c.InviterId,
+				"utime":      now,
+			},
+			"$setOnInsert": bson.M{
+				"id":     dao.node.Generate().Int64(),
+				"status": collaboratorPending,
+				"ctime":  now,
+			},
+		},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+func (dao *MongoDBDAO) AcceptInvitation(ctx context.Context, articleId, uid int64) error {
+	res, err := dao.collabCol.UpdateOne(ctx,
+		bson.M{"article_id": articleId, "user_id": uid, "status": collaboratorPending},
+		bson.M{"$set": bson.M{"status": collaboratorAccepted, "utime": time.Now().UnixMilli()}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrCollaboratorNotFound
+	}
+	return nil
+}
+
+func (dao *MongoDBDAO) DeleteCollaborator(ctx context.Context, articleId, operatorId, uid int64) error {
+	if operatorId != uid {
+		if err := dao.authorize(ctx, articleId, operatorId, roleOwner); err != nil {
+			return err
+		}
+	}
+	res, err := dao.collabCol.DeleteOne(ctx, bson.M{"article_id": articleId, "user_id": uid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrCollaboratorNotFound
+	}
+	return nil
+}
+
+func (dao *MongoDBDAO) ListCollaborators(ctx context.Context, articleId int64) ([]ArticleCollaborator, error) {
+	var atcl Article
+	err := dao.col.FindOne(ctx, bson.M{"id": articleId},
+		options.FindOne().SetProjection(bson.M{"id": 1, "author_id": 1, "ctime": 1})).
+		Decode(&atcl)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := dao.collabCol.Find(ctx, bson.M{"article_id": articleId},
+		options.Find().SetSort(bson.D{bson.E{Key: "ctime", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var collabs []ArticleCollaborator
+	if err = cursor.All(ctx, &collabs); err != nil {
+		return nil, err
+	}
+	return append([]ArticleCollaborator{ownerOf(atcl)}, collabs...), nil
+}
+
+// authorize returns ErrPossibleIncorrectAuthor unless the user has the role or a higher one on the article.
+func (dao *MongoDBDAO) authorize(ctx context.Context, articleId, uid int64, role uint8) error {
+	cur, err := dao.GetRole(ctx, articleId, uid)
+	if err != nil {
+		return err
+	}
+	if cur < role {
+		return ErrPossibleIncorrectAuthor
+	}
+	return nil
+}
+
+// authorOf returns the author of the article, zero if it does not exist.
+func (dao *MongoDBDAO) authorOf(ctx context.Context, articleId int64) (int64, error) {
+	var atcl Article
+	err := dao.col.FindOne(ctx, bson.M{"id": articleId},
+		options.FindOne().SetProjection(bson.M{"author_id": 1})).
+		Decode(&atcl)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	return atcl.AuthorId, err
+}
+
+// collaborating filters the articles the user owns or collaborates on.
+func (dao *MongoDBDAO) collaborating(ctx context.Context, uid int64) (bson.M, error) {
+	cursor, err := dao.collabCol.Find(ctx,
+		bson.M{"user_id": uid, "status": collaboratorAccepted},
+		options.Find().SetProjection(bson.M{"article_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var collabs []ArticleCollaborator
+	if err = cursor.All(ctx, &collabs); err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(collabs))
+	for _, c := range collabs {
+		ids = append(ids, c.ArticleId)
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"author_id": uid},
+		bson.M{"id": bson.M{"$in": ids}},
+	}}, nil
+}
+
+// Trash and Restore do not write events to the outbox either, the same as Sync.
+func (dao *MongoDBDAO) Trash(ctx context.Context, id, uid int64) error {
+	if err := dao.authorize(ctx, id, uid, roleOwner); err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	res, err := dao.col.UpdateOne(ctx,
+		bson.M{"id": id, "status": bson.M{"$ne": statusTrashed}},
+		bson.M{"$set": bson.M{
+			"status":       statusTrashed,
+			"scheduled_at": 0,
+			"trashed_at":   now,
+			"utime":        now,
+		}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleTrashed
+	}
+	_, err = dao.liveCol.UpdateOne(ctx, bson.M{"id": id},
+		bson.M{"$set": bson.M{"status": statusTrashed, "utime": now}})
+	return err
+}
+
+func (dao *MongoDBDAO) Restore(ctx context.Context, id, uid int64, after time.Time) error {
+	if err := dao.authorize(ctx, id, uid, roleOwner); err != nil {
+		return err
+	}
+	published, err := dao.liveCol.CountDocuments(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	status := statusUnpublished
+	if published > 0 {
+		status = statusPrivate
+	}
+	now := time.Now().UnixMilli()
+	res, err := dao.col.UpdateOne(ctx,
+		bson.M{"id": id, "status": statusTrashed, "trashed_at": bson.M{"$gt": after.UnixMilli()}},
+		bson.M{
+			"$set":   bson.M{"status": status, "utime": now},
+			"$unset": bson.M{"trashed_at": ""},
+		})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotTrashed
+	}
+	if published == 0 {
+		return nil
+	}
+	_, err = dao.liveCol.UpdateOne(ctx, bson.M{"id": id},
+		bson.M{"$set": bson.M{"status": status, "utime": now}})
+	return err
+}
+
+func (dao *MongoDBDAO) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]Article, error) {
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "trashed_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := dao.col.Find(ctx, bson.M{"author_id": uid, "status": statusTrashed}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var atcls []Article
+	err = cursor.All(ctx, &atcls)
+	return atcls, err
+}
+
+func (dao *MongoDBDAO) ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]Article, error) {
+	filter := bson.M{
+		"status":     statusTrashed,
+		"trashed_at": bson.M{"$lte": before.UnixMilli()},
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "trashed_at", Value: 1}}).
+		SetProjection(bson.M{"id": 1, "author_id": 1, "trashed_at": 1}).
+		SetLimit(int64(limit))
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var atcls []Article
+	err = cursor.All(ctx, &atcls)
+	return atcls, err
+}
+
+// Purge deletes the production library last, so that a purge failing halfway is retried by the next execution.
+// Without transactions, restoring the article while it is being purged loses its revisions and draft.
+func (dao *MongoDBDAO) Purge(ctx context.Context, id int64, before time.Time) error {
+	filter := bson.M{
+		"id":         id,
+		"status":     statusTrashed,
+		"trashed_at": bson.M{"$lte": before.UnixMilli()},
+	}
+	cnt, err := dao.col.CountDocuments(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return ErrArticleNotTrashed
+	}
+	if _, err = dao.liveCol.DeleteOne(ctx, bson.M{"id": id}); err != nil {
+		return err
+	}
+	for _, col := range []*mongo.Collection{dao.revCol, dao.draftCol, dao.collabCol} {
+		if _, err = col.DeleteMany(ctx, bson.M{"article_id": id}); err != nil {
+			return err
+		}
+	}
+	res, err := dao.col.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrArticleNotTrashed
+	}
+	return nil
+}
+
+func (dao *MongoDBDAO) insertRevision(ctx context.Context, atcl Article, now int64) error {
+	rev := newRevision(atcl, now)
+	rev.Id = dao.node.Generate().Int64()
+	_, err := dao.revCol.InsertOne(ctx, rev)
+	return err
+}
+
+// mongoBefore filters the documents after (utime, id) in descending order, zero utime keeps all.
+func mongoBefore(utime time.Time, id int64) bson.M {
+	filter := bson.M{}
+	if !utime.IsZero() {
+		ut := utime.UnixMilli()
+		filter["$or"] = bson.A{
+			bson.M{"utime": bson.M{"$lt": ut}},
+			bson.M{"utime": ut, "id": bson.M{"$lt": id}},
+		}
+	}
+	return filter
+}
@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/tsukiyo/mercury/internal/article/events"
+	"github.com/tsukiyo/mercury/pkg/outbox"
+)
+
+// events of the online library are written to the outbox in the same transaction as the change,
+// keyed by the article id so that events of an article are relayed in order.
+
+func writeEvent(tx *gorm.DB, topic string, aid int64, evt any) error {
+	return outbox.Write(tx, topic, strconv.FormatInt(aid, 10), evt)
+}
+
+// insertPublishedEvent must be called before the online library is overwritten,
+// republishing an online article is an update.
+func insertPublishedEvent(tx *gorm.DB, atcl Article, now int64) error {
+	var prev PublishedArticle
+	err := tx.Model(&PublishedArticle{}).
+		Select("status").
+		Where("id = ?", atcl.Id).
+		Limit(1).
+		Find(&prev).Error
+	if err != nil {
+		return err
+	}
+	kind := events.ArticleChangePublished
+	if prev.Status == statusPublished {
+		kind = events.ArticleChangeUpdated
+	}
+	err = writeEvent(tx, events.TopicArticleChanged, atcl.Id, events.ArticleChangedEvent{
+		Kind:     kind,
+		Aid:      atcl.Id,
+		AuthorId: atcl.AuthorId,
+		Title:    atcl.Title,
+		Status:   atcl.Status,
+		Utime:    now,
+	})
+	if err != nil {
+		return err
+	}
+	return writeEvent(tx, events.TopicPublishEvent, atcl.Id, events.PublishEvent{
+		Aid:      atcl.Id,
+		AuthorId: atcl.AuthorId,
+		Title:    atcl.Title,
+		Content:  atcl.Content,
+		Utime:    now,
+	})
+}
+
+func insertStatusEvent(tx *gorm.DB, id int64, status uint8, now int64) error {
+	var atcl Article
+	err := tx.Model(&PublishedArticle{}).
+		Select("id", "author_id", "title").
+		Where("id = ?", id).
+		First(&atcl).Error
+	if err != nil {
+		return err
+	}
+	kind := events.ArticleChangeWithdrawn
+	if status == statusPublished {
+		kind = events.ArticleChangePublished
+	}
+	err = writeEvent(tx, events.TopicArticleChanged, id, events.ArticleChangedEvent{
+		Kind:     kind,
+		Aid:      id,
+		AuthorId: atcl.AuthorId,
+		Title:    atcl.Title,
+		Status:   status,
+		Utime:    now,
+	})
+	if err != nil || status == statusPublished {
+		return err
+	}
+	return writeEvent(tx, events.TopicWithdrawEvent, id, events.WithdrawEvent{
+		Aid:   id,
+		Utime: now,
+	})
+}
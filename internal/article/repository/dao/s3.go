@@ -28,30 +28,50 @@ func (dao *S3DAO) Sync(ctx context.Context, atcl Article) (int64, error) {
 	err := dao.db.Transaction(func(tx *gorm.DB) error {
 		var err error
 		now := time.Now().UnixMilli()
+		// revisions are recorded along with the production library by txDAO
 		txDAO := NewGORMArticleDAO(tx)
 		if id == 0 {
 			id, err = txDAO.Insert(ctx, atcl)
+			atcl.Version = 1
 		} else {
-			err = txDAO.UpdateById(ctx, atcl)
+			atcl.Version, err = txDAO.UpdateById(ctx, atcl)
+			if err == nil {
+				// published by an editor, the online library belongs to the author
+				atcl.AuthorId, err = authorOf(tx, id)
+			}
 		}
 		if err != nil {
 			return err
 		}
 		atcl.Id = id
+		err = insertPublishedEvent(tx, atcl, now)
+		if err != nil {
+			return err
+		}
+		err = replaceTags[PublishedArticleTag](tx, atcl.Id, atcl.Tags)
+		if err != nil {
+			return err
+		}
 		publishArt := PublishedArticle(atcl)
 		publishArt.Utime = now
 		publishArt.Ctime = now
 		publishArt.Content = ""
-		return tx.Clauses(clause.OnConflict{
+		err = tx.Clauses(clause.OnConflict{
 			// ID 冲突的时候。实际上，在 MYSQL 里面你写不写都可以
 			Columns: []clause.Column{{Name: "id"}},
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"title": atcl.Title,
 				//"content": atcl.Content,
-				"utime":  now,
-				"status": atcl.Status,
+				"utime":   now,
+				"status":  atcl.Status,
+				"version": atcl.Version,
 			}),
 		}).Create(&publishArt).Error
+		if err != nil || atcl.Rendered == nil {
+			return err
+		}
+		// the content lives in OSS, readers are served the html kept in the database
+		return upsertContent(tx, renderedVersion(atcl, now))
 	})
 	if err != nil {
 		return 0, err
@@ -70,10 +90,13 @@ func (dao *S3DAO) Sync(ctx context.Context, atcl Article) (int64, error) {
 	return id, err
 }
 
-func (dao *S3DAO) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
+func (dao *S3DAO) SyncStatus(ctx context.Context, id int64, uid int64, status uint8) error {
 	now := time.Now().UnixMilli()
 	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		res := tx.Model(&Article{}).Where("id = ? AND author_id = ?", id, authorId).Updates(map[string]any{
+		if err := authorize(tx, id, uid, roleEditor); err != nil {
+			return err
+		}
+		res := tx.Model(&Article{}).Where("id = ? AND status <> ?", id, statusTrashed).Updates(map[string]any{
 			"status": status,
 			"utime":  now,
 		})
@@ -81,7 +104,7 @@ func (dao *S3DAO) SyncStatus(ctx context.Context, id int64, authorId int64, stat
 			return res.Error
 		}
 		if res.RowsAffected != 1 {
-			return ErrPossibleIncorrectAuthor
+			return ErrArticleTrashed
 		}
 
 		res = tx.Model(&PublishedArticle{}).Where("id = ?", id).Updates(map[string]any{
@@ -95,7 +118,7 @@ func (dao *S3DAO) SyncStatus(ctx context.Context, id int64, authorId int64, stat
 			return ErrPossibleIncorrectAuthor
 		}
 
-		return nil
+		return insertStatusEvent(tx, id, status, now)
 	})
 	if err != nil {
 		return err
@@ -109,3 +132,62 @@ func (dao *S3DAO) SyncStatus(ctx context.Context, id int64, authorId int64, stat
 	}
 	return err
 }
+
+func (dao *S3DAO) SyncScheduled(ctx context.Context, id int64) (Article, error) {
+	var atcl Article
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		var err error
+		atcl, err = claimScheduled(tx, id, now)
+		if err != nil {
+			return err
+		}
+		err = insertPublishedEvent(tx, atcl, now)
+		if err != nil {
+			return err
+		}
+		err = replaceTags[PublishedArticleTag](tx, atcl.Id, atcl.Tags)
+		if err != nil {
+			return err
+		}
+		publishArt := PublishedArticle(atcl)
+		publishArt.Utime = now
+		publishArt.Ctime = now
+		publishArt.Content = ""
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"title":   atcl.Title,
+				"utime":   now,
+				"status":  atcl.Status,
+				"version": atcl.Version,
+			}),
+		}).Create(&publishArt).Error
+	})
+	if err != nil {
+		return Article{}, err
+	}
+	key := fmt.Sprintf("mercury/%v", atcl.Id)
+	contentType := "text/plain;charset=utf-8"
+	_, err = dao.oss.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      dao.bucket,
+		Key:         &key,
+		Body:        bytes.NewReader([]byte(atcl.Content)),
+		ContentType: &contentType,
+	})
+	return atcl, err
+}
+
+// Purge deletes the content kept in OSS after the database, an object left by a failed deletion is only garbage.
+func (dao *S3DAO) Purge(ctx context.Context, id int64, before time.Time) error {
+	err := dao.GORMArticleDAO.Purge(ctx, id, before)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("mercury/%v", id)
+	_, err = dao.oss.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: dao.bucket,
+		Key:    &key,
+	})
+	return err
+}
@@ -0,0 +1,296 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSeriesNotFound      = errors.New("the series does not exist or belongs to another author")
+	ErrArticleNotPublished = errors.New("only the published articles of the author could be added to the series")
+	ErrArticleInSeries     = errors.New("the article has been added to a series")
+	ErrArticleNotInSeries  = errors.New("the article is not in the series")
+	ErrSeriesFull          = errors.New("the series has too many articles")
+	ErrSeriesMismatch      = errors.New("the articles to reorder differ from the ones in the series")
+)
+
+// Series is owned by its author, the articles are linked by SeriesArticle.
+type Series struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	AuthorId    int64  `gorm:"index"`
+	Title       string `gorm:"type:varchar(256)"`
+	Description string `gorm:"type:varchar(1024)"`
+	Ctime       int64
+	Utime       int64
+}
+
+// SeriesArticle places an article in a series, ordered by Position ascending.
+// An article is in one series at most so that readers could navigate to the previous and next ones.
+type SeriesArticle struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	SeriesId  int64 `gorm:"index:idx_series_position,priority:1"`
+	ArticleId int64 `gorm:"unique"`
+	Position  int   `gorm:"index:idx_series_position,priority:2"`
+	Ctime     int64
+}
+
+// SeriesEntry is a published article of a series.
+type SeriesEntry struct {
+	Id       int64
+	Title    string
+	Abstract string
+	Utime    int64
+}
+
+type SeriesDAO interface {
+	Insert(ctx context.Context, s Series) (int64, error)
+	// Update updates the title and description, returns ErrSeriesNotFound if the series is not of s.AuthorId.
+	Update(ctx context.Context, s Series) error
+	// Delete deletes the series along with the links to its articles.
+	Delete(ctx context.Context, id, authorId int64) error
+	GetById(ctx context.Context, id int64) (Series, error)
+	// GetByArticle returns ErrSeriesNotFound if the article is not in a series.
+	GetByArticle(ctx context.Context, articleId int64) (Series, error)
+	ListByAuthor(ctx context.Context, authorId int64) ([]Series, error)
+	// AddArticle appends the published article of the author to the series, which holds maxArticles at most.
+	AddArticle(ctx context.Context, id, authorId, articleId int64, maxArticles int) error
+	RemoveArticle(ctx context.Context, id, authorId, articleId int64) error
+	// Reorder places the articles in the given order, which has to be all the articles of the series.
+	Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error
+	// ListArticles returns the articles of the series in order, the ones not published at the moment are left out.
+	ListArticles(ctx context.Context, id int64) ([]SeriesEntry, error)
+	// ArticleIds returns the ids of all the articles of the series, published or not.
+	ArticleIds(ctx context.Context, id int64) ([]int64, error)
+}
+
+type GORMSeriesDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMSeriesDAO(db *gorm.DB) SeriesDAO {
+	return &GORMSeriesDAO{
+		db: db,
+	}
+}
+
+func (dao *GORMSeriesDAO) Insert(ctx context.Context, s Series) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime, s.Utime = now, now
+	err := dao.db.WithContext(ctx).Create(&s).Error
+	return s.Id, err
+}
+
+func (dao *GORMSeriesDAO) Update(ctx context.Context, s Series) error {
+	res := dao.db.WithContext(ctx).Model(&Series{}).
+		Where("id = ? AND author_id = ?", s.Id, s.AuthorId).
+		Updates(map[string]any{
+			"title":       s.Title,
+			"description": s.Description,
+			"utime":       time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (dao *GORMSeriesDAO) Delete(ctx context.Context, id, authorId int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND author_id = ?", id, authorId).Delete(&Series{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrSeriesNotFound
+		}
+		return tx.Where("series_id = ?", id).Delete(&SeriesArticle{}).Error
+	})
+}
+
+func (dao *GORMSeriesDAO) GetById(ctx context.Context, id int64) (Series, error) {
+	var s Series
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&s).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Series{}, ErrSeriesNotFound
+	}
+	return s, err
+}
+
+func (dao *GORMSeriesDAO) GetByArticle(ctx context.Context, articleId int64) (Series, error) {
+	var s Series
+	err := dao.db.WithContext(ctx).Model(&Series{}).
+		Joins("JOIN series_articles AS sa ON sa.series_id = series.id").
+		Where("sa.article_id = ?", articleId).
+		First(&s).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Series{}, ErrSeriesNotFound
+	}
+	return s, err
+}
+
+func (dao *GORMSeriesDAO) ListByAuthor(ctx context.Context, authorId int64) ([]Series, error) {
+	var res []Series
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ?", authorId).
+		Order("utime DESC").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMSeriesDAO) AddArticle(ctx context.Context, id, authorId, articleId int64, maxArticles int) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// appending to the same series is serialized by the lock for the positions
+		if err := lockSeries(tx, id, authorId); err != nil {
+			return err
+		}
+		var cnt int64
+		err := tx.Model(&PublishedArticle{}).
+			Where("id = ? AND author_id = ? AND status = ?", articleId, authorId, statusPublished).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return ErrArticleNotPublished
+		}
+		err = tx.Model(&SeriesArticle{}).Where("article_id = ?", articleId).Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrArticleInSeries
+		}
+		var last struct {
+			Cnt      int64
+			Position int
+		}
+		err = tx.Model(&SeriesArticle{}).
+			Select("COUNT(*) AS cnt, COALESCE(MAX(position), 0) AS position").
+			Where("series_id = ?", id).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		if last.Cnt >= int64(maxArticles) {
+			return ErrSeriesFull
+		}
+		err = tx.Create(&SeriesArticle{
+			SeriesId:  id,
+			ArticleId: articleId,
+			Position:  last.Position + 1,
+			Ctime:     time.Now().UnixMilli(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return touchSeries(tx, id)
+	})
+}
+
+func (dao *GORMSeriesDAO) RemoveArticle(ctx context.Context, id, authorId, articleId int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockSeries(tx, id, authorId); err != nil {
+			return err
+		}
+		res := tx.Where("series_id = ? AND article_id = ?", id, articleId).Delete(&SeriesArticle{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrArticleNotInSeries
+		}
+		return touchSeries(tx, id)
+	})
+}
+
+func (dao *GORMSeriesDAO) Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockSeries(tx, id, authorId); err != nil {
+			return err
+		}
+		var cur []int64
+		err := tx.Model(&SeriesArticle{}).
+			Where("series_id = ?", id).
+			Pluck("article_id", &cur).Error
+		if err != nil {
+			return err
+		}
+		if !sameArticles(cur, articleIds) {
+			return ErrSeriesMismatch
+		}
+		for i, articleId := range articleIds {
+			err = tx.Model(&SeriesArticle{}).
+				Where("series_id = ? AND article_id = ?", id, articleId).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return touchSeries(tx, id)
+	})
+}
+
+func (dao *GORMSeriesDAO) ListArticles(ctx context.Context, id int64) ([]SeriesEntry, error) {
+	var entries []SeriesEntry
+	err := dao.db.WithContext(ctx).Table("series_articles AS sa").
+		Select("pa.id AS id, pa.title AS title, pa.abstract AS abstract, pa.utime AS utime").
+		Joins("JOIN published_articles AS pa ON pa.id = sa.article_id").
+		Where("sa.series_id = ? AND pa.status = ?", id, statusPublished).
+		Order("sa.position ASC").
+		Scan(&entries).Error
+	return entries, err
+}
+
+func (dao *GORMSeriesDAO) ArticleIds(ctx context.Context, id int64) ([]int64, error) {
+	var ids []int64
+	err := dao.db.WithContext(ctx).Model(&SeriesArticle{}).
+		Where("series_id = ?", id).
+		Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+// lockSeries returns ErrSeriesNotFound unless the series is of the author.
+func lockSeries(tx *gorm.DB, id, authorId int64) error {
+	var res []Series
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Model(&Series{}).
+		Select("id").
+		Where("id = ? AND author_id = ?", id, authorId).
+		Find(&res).Error
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+func touchSeries(tx *gorm.DB, id int64) error {
+	return tx.Model(&Series{}).Where("id = ?", id).Update("utime", time.Now().UnixMilli()).Error
+}
+
+// sameArticles reports whether the ids are the current articles without duplicates.
+func sameArticles(cur, ids []int64) bool {
+	if len(cur) != len(ids) {
+		return false
+	}
+	set := make(map[int64]struct{}, len(cur))
+	for _, id := range cur {
+		set[id] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+		delete(set, id)
+	}
+	return true
+}
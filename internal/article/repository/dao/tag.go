@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Tag struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Name  string `gorm:"type:varchar(64);unique"`
+	Ctime int64
+}
+
+// ArticleTag links the production library with tags
+type ArticleTag struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:idx_article_tag"`
+	TagId     int64 `gorm:"uniqueIndex:idx_article_tag;index"`
+	Ctime     int64
+}
+
+// PublishedArticleTag links the online library with tags
+type PublishedArticleTag ArticleTag
+
+type TagCount struct {
+	Name string
+	Cnt  int64
+}
+
+// replaceTags makes the tags of the article exactly the given names.
+func replaceTags[T ArticleTag | PublishedArticleTag](tx *gorm.DB, articleId int64, names []string) error {
+	tagIds, err := findOrCreateTags(tx, names)
+	if err != nil {
+		return err
+	}
+
+	del := tx.Where("article_id = ?", articleId)
+	if len(tagIds) > 0 {
+		del = del.Where("tag_id NOT IN ?", tagIds)
+	}
+	if err = del.Delete(new(T)).Error; err != nil {
+		return err
+	}
+	if len(tagIds) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	links := make([]T, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		links = append(links, T(ArticleTag{
+			ArticleId: articleId,
+			TagId:     tagId,
+			Ctime:     now,
+		}))
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
+
+func findOrCreateTags(tx *gorm.DB, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	now := time.Now().UnixMilli()
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name, Ctime: now})
+	}
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	err = tx.Model(&Tag{}).Where("name IN ?", names).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// findTags returns tag names of each article
+func findTags[T ArticleTag | PublishedArticleTag](tx *gorm.DB, articleIds ...int64) (map[int64][]string, error) {
+	if len(articleIds) == 0 {
+		return map[int64][]string{}, nil
+	}
+	type row struct {
+		ArticleId int64
+		Name      string
+	}
+	var rows []row
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
+	}
+	err := tx.Table(stmt.Schema.Table+" AS at").
+		Select("at.article_id, t.name").
+		Joins("JOIN tags AS t ON t.id = at.tag_id").
+		Where("at.article_id IN ?", articleIds).
+		Order("at.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64][]string, len(articleIds))
+	for _, r := range rows {
+		res[r.ArticleId] = append(res[r.ArticleId], r.Name)
+	}
+	return res, nil
+}
@@ -0,0 +1,144 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tsukiyo/mercury/internal/article/events"
+)
+
+func (dao *GORMArticleDAO) Trash(ctx context.Context, id, uid int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := authorize(tx, id, uid, roleOwner); err != nil {
+			return err
+		}
+		res := tx.Model(&Article{}).
+			Where("id = ? AND status <> ?", id, statusTrashed).
+			Updates(map[string]any{
+				"status":       statusTrashed,
+				"scheduled_at": 0,
+				"trashed_at":   now,
+				"utime":        now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrArticleTrashed
+		}
+		res = tx.Model(&PublishedArticle{}).Where("id = ?", id).Updates(map[string]any{
+			"status": statusTrashed,
+			"utime":  now,
+		})
+		// never published, nothing to take offline
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return insertStatusEvent(tx, id, statusTrashed, now)
+	})
+}
+
+func (dao *GORMArticleDAO) Restore(ctx context.Context, id, uid int64, after time.Time) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := authorize(tx, id, uid, roleOwner); err != nil {
+			return err
+		}
+		var pubAtcls []PublishedArticle
+		err := tx.Model(&PublishedArticle{}).Select("id").Where("id = ?", id).Find(&pubAtcls).Error
+		if err != nil {
+			return err
+		}
+		status := statusUnpublished
+		if len(pubAtcls) > 0 {
+			status = statusPrivate
+		}
+		res := tx.Model(&Article{}).
+			Where("id = ? AND status = ? AND trashed_at > ?", id, statusTrashed, after.UnixMilli()).
+			Updates(map[string]any{
+				"status":     status,
+				"trashed_at": 0,
+				"utime":      now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrArticleNotTrashed
+		}
+		if len(pubAtcls) == 0 {
+			return nil
+		}
+		return tx.Model(&PublishedArticle{}).Where("id = ?", id).Updates(map[string]any{
+			"status": status,
+			"utime":  now,
+		}).Error
+	})
+}
+
+func (dao *GORMArticleDAO) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]Article, error) {
+	var atcls []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? AND status = ?", uid, statusTrashed).
+		Order("trashed_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&atcls).Error
+	return atcls, err
+}
+
+func (dao *GORMArticleDAO) ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]Article, error) {
+	var atcls []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Select("id", "author_id", "trashed_at").
+		Where("status = ? AND trashed_at <= ?", statusTrashed, before.UnixMilli()).
+		Order("trashed_at ASC").
+		Limit(limit).
+		Find(&atcls).Error
+	return atcls, err
+}
+
+func (dao *GORMArticleDAO) Purge(ctx context.Context, id int64, before time.Time) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return purge(tx, id, before)
+	})
+}
+
+// purge claims the article by locking it while it is still trashed before the time,
+// so that it is not restored halfway and executors purging it concurrently delete it once.
+func purge(tx *gorm.DB, id int64, before time.Time) error {
+	var atcls []Article
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Model(&Article{}).
+		Select("id", "author_id").
+		Where("id = ? AND status = ? AND trashed_at <= ?", id, statusTrashed, before.UnixMilli()).
+		Find(&atcls).Error
+	if err != nil {
+		return err
+	}
+	if len(atcls) == 0 {
+		return ErrArticleNotTrashed
+	}
+	for _, model := range []any{&Article{}, &PublishedArticle{}, &PublishedArticleContent{}} {
+		if err = tx.Where("id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	for _, model := range []any{
+		&ArticleTag{}, &PublishedArticleTag{}, &ArticleRevision{},
+		&ArticleDraft{}, &ArticleCollaborator{}, &SeriesArticle{},
+	} {
+		if err = tx.Where("article_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return writeEvent(tx, events.TopicDeleteEvent, id, events.DeleteEvent{
+		Aid:      id,
+		AuthorId: atcls[0].AuthorId,
+		Utime:    time.Now().UnixMilli(),
+	})
+}
@@ -4,17 +4,90 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrArticleNotFound         = gorm.ErrRecordNotFound
+	ErrPossibleIncorrectAuthor = errors.New("the user is attempting to manipulate non personal data")
+	ErrArticleNotScheduled     = errors.New("the article is not scheduled or has been published")
+	ErrStaleContent            = errors.New("the article has been published again since the content was rendered")
+	ErrVersionConflict         = errors.New("the article has been saved since the version being edited")
+	ErrDraftNotFound           = errors.New("the article has no draft")
+	ErrCollaboratorNotFound    = errors.New("the user is not invited to the article")
+	ErrArticleTrashed          = errors.New("the article is in the trash")
+	ErrArticleNotTrashed       = errors.New("the article is not in the trash or its retention has passed")
 )
 
-var ErrPossibleIncorrectAuthor = errors.New("the user is attempting to manipulate non personal data")
+// Methods taking the id of the user check the role of the user on the article instead of the author,
+// they return ErrPossibleIncorrectAuthor if the user is neither the author nor an accepted collaborator with the role.
 
 type ArticleDAO interface {
 	Insert(ctx context.Context, atcl Article) (int64, error)
-	UpdateById(ctx context.Context, atcl Article) error
-	GetByAuthor(ctx context.Context, authorId int64, offset, limit int) ([]Article, error)
+	// UpdateById updates the content by atcl.AuthorId, who is an editor at least, and returns the new version,
+	// returns ErrVersionConflict if atcl.Version is set and the article has been saved since.
+	// The author of the article is kept, the revision is recorded for the editor.
+	UpdateById(ctx context.Context, atcl Article) (int64, error)
+	// GetByAuthor returns articles the user owns or collaborates on.
+	GetByAuthor(ctx context.Context, uid int64, offset, limit int) ([]Article, error)
+	// GetByAuthorBefore returns articles the user owns or collaborates on ordered by utime and id descending,
+	// continuing after the article identified by utime and id, zero utime means the first page.
+	GetByAuthorBefore(ctx context.Context, uid int64, utime time.Time, id int64, limit int) ([]Article, error)
 	GetById(ctx context.Context, id int64) (Article, error)
+	// GetPubById returns the online article with its rendered content, Rendered is nil if it is missing or stale.
 	GetPubById(ctx context.Context, id int64) (PublishedArticle, error)
+	// SaveRendered stores the content rendered from the online article whose utime is content.Version,
+	// returns ErrStaleContent if the article has been published again since.
+	SaveRendered(ctx context.Context, content PublishedArticleContent) error
+	// Sync publishes the article, along with atcl.Rendered if set, atcl.AuthorId is checked the same way as UpdateById.
 	Sync(ctx context.Context, atcl Article) (int64, error)
-	SyncStatus(ctx context.Context, id, authorId int64, status uint8) error
+	// SyncStatus requires an editor.
+	SyncStatus(ctx context.Context, id, uid int64, status uint8) error
 	ListPubByUtime(ctx context.Context, utime time.Time, offset int, limit int) ([]PublishedArticle, error)
+	// ListPubBefore is the keyset version of ListPubByUtime, ordered by utime and id descending.
+	ListPubBefore(ctx context.Context, utime time.Time, id int64, limit int) ([]PublishedArticle, error)
+	ListPubByTag(ctx context.Context, tag string, utime time.Time, id int64, limit int) ([]PublishedArticle, error)
+	CountTags(ctx context.Context, names []string, limit int) ([]TagCount, error)
+	// ListRevisions and GetRevision require a viewer.
+	ListRevisions(ctx context.Context, articleId, uid int64, offset, limit int) ([]ArticleRevision, error)
+	GetRevision(ctx context.Context, id, uid int64) (ArticleRevision, error)
+	// Schedule and CancelSchedule require an editor.
+	Schedule(ctx context.Context, id, uid int64, at time.Time) error
+	CancelSchedule(ctx context.Context, id, uid int64) error
+	// UpsertDraft keeps the draft unless a later one has been kept.
+	UpsertDraft(ctx context.Context, draft ArticleDraft) error
+	// GetDraft returns ErrDraftNotFound if the article has no draft.
+	GetDraft(ctx context.Context, articleId int64) (ArticleDraft, error)
+	// DeleteDraft deletes the draft if it is edited from maxBaseVersion or before, which the saved article supersedes.
+	DeleteDraft(ctx context.Context, articleId, maxBaseVersion int64) error
+	// GetRole returns the role of the user on the article, zero if the user has none.
+	GetRole(ctx context.Context, articleId, uid int64) (uint8, error)
+	// InviteCollaborator invites the user by c.InviterId, who owns the article,
+	// inviting the user again changes the role and keeps the status.
+	InviteCollaborator(ctx context.Context, c ArticleCollaborator) error
+	// AcceptInvitation returns ErrCollaboratorNotFound unless the user has a pending invitation.
+	AcceptInvitation(ctx context.Context, articleId, uid int64) error
+	// DeleteCollaborator removes the user by the owner, or by the user declining or leaving,
+	// returns ErrCollaboratorNotFound if the user is not invited.
+	DeleteCollaborator(ctx context.Context, articleId, operatorId, uid int64) error
+	// ListCollaborators returns the author as the accepted owner followed by the invited users in order of invitation.
+	ListCollaborators(ctx context.Context, articleId int64) ([]ArticleCollaborator, error)
+	ListScheduled(ctx context.Context, before time.Time, limit int) ([]Article, error)
+	// SyncScheduled publishes the article if it is still scheduled and due,
+	// returns ErrArticleNotScheduled if it has been published or cancelled.
+	SyncScheduled(ctx context.Context, id int64) (Article, error)
+	// Trash moves the article to the trash by the owner, the online article is taken offline and the schedule is cancelled.
+	// Returns ErrArticleTrashed if it is in the trash already.
+	Trash(ctx context.Context, id, uid int64) error
+	// Restore takes the article trashed after the time out of the trash by the owner, it goes back to private
+	// if it has been published, or unpublished otherwise. Returns ErrArticleNotTrashed if there is no such article.
+	Restore(ctx context.Context, id, uid int64, after time.Time) error
+	// ListTrash returns the trashed articles of the owner, the latest trashed first.
+	ListTrash(ctx context.Context, uid int64, offset, limit int) ([]Article, error)
+	// ListTrashedBefore returns the articles trashed before the time, the earliest trashed first.
+	ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]Article, error)
+	// Purge deletes the article trashed before the time for good, along with its online copy, revisions,
+	// draft, tags, collaborators and series membership. Returns ErrArticleNotTrashed if it has been restored or purged.
+	Purge(ctx context.Context, id int64, before time.Time) error
 }
@@ -41,6 +41,49 @@ func (m *MockArticleRepository) EXPECT() *MockArticleRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockArticleRepository) AcceptInvitation(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockArticleRepositoryMockRecorder) AcceptInvitation(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockArticleRepository)(nil).AcceptInvitation), ctx, id, uid)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleRepository) CancelSchedule(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleRepositoryMockRecorder) CancelSchedule(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleRepository)(nil).CancelSchedule), ctx, id, uid)
+}
+
+// CountTags mocks base method.
+func (m *MockArticleRepository) CountTags(ctx context.Context, tags []string, limit int) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTags", ctx, tags, limit)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTags indicates an expected call of CountTags.
+func (mr *MockArticleRepositoryMockRecorder) CountTags(ctx, tags, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTags", reflect.TypeOf((*MockArticleRepository)(nil).CountTags), ctx, tags, limit)
+}
+
 // Create mocks base method.
 func (m *MockArticleRepository) Create(ctx context.Context, atcl domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +99,35 @@ func (mr *MockArticleRepositoryMockRecorder) Create(ctx, atcl any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRepository)(nil).Create), ctx, atcl)
 }
 
+// DeleteDraft mocks base method.
+func (m *MockArticleRepository) DeleteDraft(ctx context.Context, articleId, maxBaseVersion int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraft", ctx, articleId, maxBaseVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockArticleRepositoryMockRecorder) DeleteDraft(ctx, articleId, maxBaseVersion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockArticleRepository)(nil).DeleteDraft), ctx, articleId, maxBaseVersion)
+}
+
+// FlushDrafts mocks base method.
+func (m *MockArticleRepository) FlushDrafts(ctx context.Context, before time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushDrafts", ctx, before, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushDrafts indicates an expected call of FlushDrafts.
+func (mr *MockArticleRepositoryMockRecorder) FlushDrafts(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushDrafts", reflect.TypeOf((*MockArticleRepository)(nil).FlushDrafts), ctx, before, limit)
+}
+
 // GetById mocks base method.
 func (m *MockArticleRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +143,21 @@ func (mr *MockArticleRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleRepository)(nil).GetById), ctx, id)
 }
 
+// GetDraft mocks base method.
+func (m *MockArticleRepository) GetDraft(ctx context.Context, articleId int64) (domain.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", ctx, articleId)
+	ret0, _ := ret[0].(domain.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockArticleRepositoryMockRecorder) GetDraft(ctx, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockArticleRepository)(nil).GetDraft), ctx, articleId)
+}
+
 // GetPublishedById mocks base method.
 func (m *MockArticleRepository) GetPublishedById(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -86,19 +173,93 @@ func (mr *MockArticleRepositoryMockRecorder) GetPublishedById(ctx, id any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockArticleRepository)(nil).GetPublishedById), ctx, id)
 }
 
+// GetRevision mocks base method.
+func (m *MockArticleRepository) GetRevision(ctx context.Context, revisionId, uid int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, revisionId, uid)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleRepositoryMockRecorder) GetRevision(ctx, revisionId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleRepository)(nil).GetRevision), ctx, revisionId, uid)
+}
+
+// GetRole mocks base method.
+func (m *MockArticleRepository) GetRole(ctx context.Context, id, uid int64) (domain.CollaboratorRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, id, uid)
+	ret0, _ := ret[0].(domain.CollaboratorRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockArticleRepositoryMockRecorder) GetRole(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockArticleRepository)(nil).GetRole), ctx, id, uid)
+}
+
+// InviteCollaborator mocks base method.
+func (m *MockArticleRepository) InviteCollaborator(ctx context.Context, c domain.Collaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteCollaborator", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteCollaborator indicates an expected call of InviteCollaborator.
+func (mr *MockArticleRepositoryMockRecorder) InviteCollaborator(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteCollaborator", reflect.TypeOf((*MockArticleRepository)(nil).InviteCollaborator), ctx, c)
+}
+
 // List mocks base method.
-func (m *MockArticleRepository) List(ctx context.Context, authorId int64, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleRepository) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, authorId, offset, limit)
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockArticleRepositoryMockRecorder) List(ctx, authorId, offset, limit any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleRepository)(nil).List), ctx, authorId, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleRepository)(nil).List), ctx, uid, offset, limit)
+}
+
+// ListBefore mocks base method.
+func (m *MockArticleRepository) ListBefore(ctx context.Context, uid int64, utime time.Time, id int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBefore", ctx, uid, utime, id, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBefore indicates an expected call of ListBefore.
+func (mr *MockArticleRepositoryMockRecorder) ListBefore(ctx, uid, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBefore", reflect.TypeOf((*MockArticleRepository)(nil).ListBefore), ctx, uid, utime, id, limit)
+}
+
+// ListCollaborators mocks base method.
+func (m *MockArticleRepository) ListCollaborators(ctx context.Context, id int64) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollaborators", ctx, id)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollaborators indicates an expected call of ListCollaborators.
+func (mr *MockArticleRepositoryMockRecorder) ListCollaborators(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollaborators", reflect.TypeOf((*MockArticleRepository)(nil).ListCollaborators), ctx, id)
 }
 
 // ListPub mocks base method.
@@ -116,6 +277,180 @@ func (mr *MockArticleRepositoryMockRecorder) ListPub(ctx, utime, offset, limit a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleRepository)(nil).ListPub), ctx, utime, offset, limit)
 }
 
+// ListPubBefore mocks base method.
+func (m *MockArticleRepository) ListPubBefore(ctx context.Context, utime time.Time, id int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubBefore", ctx, utime, id, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubBefore indicates an expected call of ListPubBefore.
+func (mr *MockArticleRepositoryMockRecorder) ListPubBefore(ctx, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubBefore", reflect.TypeOf((*MockArticleRepository)(nil).ListPubBefore), ctx, utime, id, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleRepository) ListPubByTag(ctx context.Context, tag string, utime time.Time, id int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, utime, id, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleRepositoryMockRecorder) ListPubByTag(ctx, tag, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleRepository)(nil).ListPubByTag), ctx, tag, utime, id, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleRepository) ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, id, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleRepositoryMockRecorder) ListRevisions(ctx, id, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleRepository)(nil).ListRevisions), ctx, id, uid, offset, limit)
+}
+
+// ListScheduled mocks base method.
+func (m *MockArticleRepository) ListScheduled(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduled", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduled indicates an expected call of ListScheduled.
+func (mr *MockArticleRepositoryMockRecorder) ListScheduled(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduled", reflect.TypeOf((*MockArticleRepository)(nil).ListScheduled), ctx, before, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleRepository) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleRepositoryMockRecorder) ListTrash(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleRepository)(nil).ListTrash), ctx, uid, offset, limit)
+}
+
+// ListTrashedBefore mocks base method.
+func (m *MockArticleRepository) ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashedBefore", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrashedBefore indicates an expected call of ListTrashedBefore.
+func (mr *MockArticleRepositoryMockRecorder) ListTrashedBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashedBefore", reflect.TypeOf((*MockArticleRepository)(nil).ListTrashedBefore), ctx, before, limit)
+}
+
+// Purge mocks base method.
+func (m *MockArticleRepository) Purge(ctx context.Context, id int64, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleRepositoryMockRecorder) Purge(ctx, id, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleRepository)(nil).Purge), ctx, id, before)
+}
+
+// Restore mocks base method.
+func (m *MockArticleRepository) Restore(ctx context.Context, id, uid int64, after time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, uid, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleRepositoryMockRecorder) Restore(ctx, id, uid, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleRepository)(nil).Restore), ctx, id, uid, after)
+}
+
+// RevokeCollaborator mocks base method.
+func (m *MockArticleRepository) RevokeCollaborator(ctx context.Context, id, operatorId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCollaborator", ctx, id, operatorId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCollaborator indicates an expected call of RevokeCollaborator.
+func (mr *MockArticleRepositoryMockRecorder) RevokeCollaborator(ctx, id, operatorId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCollaborator", reflect.TypeOf((*MockArticleRepository)(nil).RevokeCollaborator), ctx, id, operatorId, uid)
+}
+
+// SaveDraft mocks base method.
+func (m *MockArticleRepository) SaveDraft(ctx context.Context, draft domain.Draft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraft", ctx, draft)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDraft indicates an expected call of SaveDraft.
+func (mr *MockArticleRepositoryMockRecorder) SaveDraft(ctx, draft any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraft", reflect.TypeOf((*MockArticleRepository)(nil).SaveDraft), ctx, draft)
+}
+
+// SaveRendered mocks base method.
+func (m *MockArticleRepository) SaveRendered(ctx context.Context, atcl domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRendered", ctx, atcl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRendered indicates an expected call of SaveRendered.
+func (mr *MockArticleRepositoryMockRecorder) SaveRendered(ctx, atcl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRendered", reflect.TypeOf((*MockArticleRepository)(nil).SaveRendered), ctx, atcl)
+}
+
+// Schedule mocks base method.
+func (m *MockArticleRepository) Schedule(ctx context.Context, id, uid int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, id, uid, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockArticleRepositoryMockRecorder) Schedule(ctx, id, uid, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockArticleRepository)(nil).Schedule), ctx, id, uid, at)
+}
+
 // Sync mocks base method.
 func (m *MockArticleRepository) Sync(ctx context.Context, atcl domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -131,28 +466,58 @@ func (mr *MockArticleRepositoryMockRecorder) Sync(ctx, atcl any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockArticleRepository)(nil).Sync), ctx, atcl)
 }
 
+// SyncScheduled mocks base method.
+func (m *MockArticleRepository) SyncScheduled(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncScheduled", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncScheduled indicates an expected call of SyncScheduled.
+func (mr *MockArticleRepositoryMockRecorder) SyncScheduled(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncScheduled", reflect.TypeOf((*MockArticleRepository)(nil).SyncScheduled), ctx, id)
+}
+
 // SyncStatus mocks base method.
-func (m *MockArticleRepository) SyncStatus(ctx context.Context, id, authorId int64, status domain.ArticleStatus) error {
+func (m *MockArticleRepository) SyncStatus(ctx context.Context, id, uid int64, status domain.ArticleStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, authorId, status)
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, uid, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SyncStatus indicates an expected call of SyncStatus.
-func (mr *MockArticleRepositoryMockRecorder) SyncStatus(ctx, id, authorId, status any) *gomock.Call {
+func (mr *MockArticleRepositoryMockRecorder) SyncStatus(ctx, id, uid, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleRepository)(nil).SyncStatus), ctx, id, authorId, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleRepository)(nil).SyncStatus), ctx, id, uid, status)
 }
 
-// Update mocks base method.
-func (m *MockArticleRepository) Update(ctx context.Context, atcl domain.Article) error {
+// Trash mocks base method.
+func (m *MockArticleRepository) Trash(ctx context.Context, id, uid int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, atcl)
+	ret := m.ctrl.Call(m, "Trash", ctx, id, uid)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Trash indicates an expected call of Trash.
+func (mr *MockArticleRepositoryMockRecorder) Trash(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockArticleRepository)(nil).Trash), ctx, id, uid)
+}
+
+// Update mocks base method.
+func (m *MockArticleRepository) Update(ctx context.Context, atcl domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, atcl)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Update indicates an expected call of Update.
 func (mr *MockArticleRepositoryMockRecorder) Update(ctx, atcl any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./series.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./series.go -package=repomocks -destination=mocks/series.mock.go SeriesRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/tsukiyo/mercury/internal/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSeriesRepository is a mock of SeriesRepository interface.
+type MockSeriesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesRepositoryMockRecorder
+}
+
+// MockSeriesRepositoryMockRecorder is the mock recorder for MockSeriesRepository.
+type MockSeriesRepositoryMockRecorder struct {
+	mock *MockSeriesRepository
+}
+
+// NewMockSeriesRepository creates a new mock instance.
+func NewMockSeriesRepository(ctrl *gomock.Controller) *MockSeriesRepository {
+	mock := &MockSeriesRepository{ctrl: ctrl}
+	mock.recorder = &MockSeriesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesRepository) EXPECT() *MockSeriesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddArticle mocks base method.
+func (m *MockSeriesRepository) AddArticle(ctx context.Context, id, authorId, articleId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddArticle", ctx, id, authorId, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddArticle indicates an expected call of AddArticle.
+func (mr *MockSeriesRepositoryMockRecorder) AddArticle(ctx, id, authorId, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddArticle", reflect.TypeOf((*MockSeriesRepository)(nil).AddArticle), ctx, id, authorId, articleId)
+}
+
+// Create mocks base method.
+func (m *MockSeriesRepository) Create(ctx context.Context, s domain.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSeriesRepositoryMockRecorder) Create(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSeriesRepository)(nil).Create), ctx, s)
+}
+
+// Delete mocks base method.
+func (m *MockSeriesRepository) Delete(ctx context.Context, id, authorId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, authorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeriesRepositoryMockRecorder) Delete(ctx, id, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeriesRepository)(nil).Delete), ctx, id, authorId)
+}
+
+// GetByArticle mocks base method.
+func (m *MockSeriesRepository) GetByArticle(ctx context.Context, articleId int64) (domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticle", ctx, articleId)
+	ret0, _ := ret[0].(domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticle indicates an expected call of GetByArticle.
+func (mr *MockSeriesRepositoryMockRecorder) GetByArticle(ctx, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticle", reflect.TypeOf((*MockSeriesRepository)(nil).GetByArticle), ctx, articleId)
+}
+
+// GetById mocks base method.
+func (m *MockSeriesRepository) GetById(ctx context.Context, id int64) (domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockSeriesRepositoryMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockSeriesRepository)(nil).GetById), ctx, id)
+}
+
+// ListArticles mocks base method.
+func (m *MockSeriesRepository) ListArticles(ctx context.Context, id int64) ([]domain.SeriesArticle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArticles", ctx, id)
+	ret0, _ := ret[0].([]domain.SeriesArticle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArticles indicates an expected call of ListArticles.
+func (mr *MockSeriesRepositoryMockRecorder) ListArticles(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArticles", reflect.TypeOf((*MockSeriesRepository)(nil).ListArticles), ctx, id)
+}
+
+// ListByAuthor mocks base method.
+func (m *MockSeriesRepository) ListByAuthor(ctx context.Context, authorId int64) ([]domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAuthor", ctx, authorId)
+	ret0, _ := ret[0].([]domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAuthor indicates an expected call of ListByAuthor.
+func (mr *MockSeriesRepositoryMockRecorder) ListByAuthor(ctx, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAuthor", reflect.TypeOf((*MockSeriesRepository)(nil).ListByAuthor), ctx, authorId)
+}
+
+// RemoveArticle mocks base method.
+func (m *MockSeriesRepository) RemoveArticle(ctx context.Context, id, authorId, articleId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveArticle", ctx, id, authorId, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveArticle indicates an expected call of RemoveArticle.
+func (mr *MockSeriesRepositoryMockRecorder) RemoveArticle(ctx, id, authorId, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveArticle", reflect.TypeOf((*MockSeriesRepository)(nil).RemoveArticle), ctx, id, authorId, articleId)
+}
+
+// Reorder mocks base method.
+func (m *MockSeriesRepository) Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, id, authorId, articleIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockSeriesRepositoryMockRecorder) Reorder(ctx, id, authorId, articleIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockSeriesRepository)(nil).Reorder), ctx, id, authorId, articleIds)
+}
+
+// Update mocks base method.
+func (m *MockSeriesRepository) Update(ctx context.Context, s domain.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesRepositoryMockRecorder) Update(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesRepository)(nil).Update), ctx, s)
+}
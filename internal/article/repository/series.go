@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/internal/article/repository/cache"
+	"github.com/tsukiyo/mercury/internal/article/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var (
+	ErrSeriesNotFound      = dao.ErrSeriesNotFound
+	ErrArticleNotPublished = dao.ErrArticleNotPublished
+	ErrArticleInSeries     = dao.ErrArticleInSeries
+	ErrArticleNotInSeries  = dao.ErrArticleNotInSeries
+	ErrSeriesFull          = dao.ErrSeriesFull
+	ErrSeriesMismatch      = dao.ErrSeriesMismatch
+)
+
+//go:generate mockgen -source=./series.go -package=repomocks -destination=mocks/series.mock.go SeriesRepository
+type SeriesRepository interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	// Update returns ErrSeriesNotFound if the series is not of s.Author.
+	Update(ctx context.Context, s domain.Series) error
+	Delete(ctx context.Context, id, authorId int64) error
+	// GetById returns the series without its articles.
+	GetById(ctx context.Context, id int64) (domain.Series, error)
+	// GetByArticle returns ErrSeriesNotFound if the article is not in a series.
+	GetByArticle(ctx context.Context, articleId int64) (domain.Series, error)
+	ListByAuthor(ctx context.Context, authorId int64) ([]domain.Series, error)
+	AddArticle(ctx context.Context, id, authorId, articleId int64) error
+	RemoveArticle(ctx context.Context, id, authorId, articleId int64) error
+	Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error
+	// ListArticles returns the published articles of the series in order, without interactive counts.
+	ListArticles(ctx context.Context, id int64) ([]domain.SeriesArticle, error)
+}
+
+var _ SeriesRepository = (*seriesRepository)(nil)
+
+// seriesRepository invalidates the online articles of a series once it changes,
+// the BFF caches them along with the navigation to the previous and the next ones.
+type seriesRepository struct {
+	dao   dao.SeriesDAO
+	cache cache.ArticleCache
+	l     logger.Logger
+}
+
+func NewSeriesRepository(dao dao.SeriesDAO, cache cache.ArticleCache, l logger.Logger) SeriesRepository {
+	return &seriesRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (repo *seriesRepository) Create(ctx context.Context, s domain.Series) (int64, error) {
+	return repo.dao.Insert(ctx, repo.toEntity(s))
+}
+
+func (repo *seriesRepository) Update(ctx context.Context, s domain.Series) error {
+	err := repo.dao.Update(ctx, repo.toEntity(s))
+	if err != nil {
+		return err
+	}
+	// the title is in the navigation
+	repo.invalidateNav(ctx, repo.articleIds(ctx, s.Id))
+	return nil
+}
+
+func (repo *seriesRepository) Delete(ctx context.Context, id, authorId int64) error {
+	ids := repo.articleIds(ctx, id)
+	err := repo.dao.Delete(ctx, id, authorId)
+	if err != nil {
+		return err
+	}
+	repo.invalidateNav(ctx, ids)
+	return nil
+}
+
+func (repo *seriesRepository) GetById(ctx context.Context, id int64) (domain.Series, error) {
+	s, err := repo.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return repo.toDomain(s), nil
+}
+
+func (repo *seriesRepository) GetByArticle(ctx context.Context, articleId int64) (domain.Series, error) {
+	s, err := repo.dao.GetByArticle(ctx, articleId)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return repo.toDomain(s), nil
+}
+
+func (repo *seriesRepository) ListByAuthor(ctx context.Context, authorId int64) ([]domain.Series, error) {
+	res, err := repo.dao.ListByAuthor(ctx, authorId)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Series, domain.Series](res, func(idx int, src dao.Series) domain.Series {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *seriesRepository) AddArticle(ctx context.Context, id, authorId, articleId int64) error {
+	err := repo.dao.AddArticle(ctx, id, authorId, articleId, domain.MaxArticlesPerSeries)
+	if err != nil {
+		return err
+	}
+	repo.invalidateNav(ctx, repo.articleIds(ctx, id))
+	return nil
+}
+
+func (repo *seriesRepository) RemoveArticle(ctx context.Context, id, authorId, articleId int64) error {
+	err := repo.dao.RemoveArticle(ctx, id, authorId, articleId)
+	if err != nil {
+		return err
+	}
+	repo.invalidateNav(ctx, append(repo.articleIds(ctx, id), articleId))
+	return nil
+}
+
+func (repo *seriesRepository) Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error {
+	err := repo.dao.Reorder(ctx, id, authorId, articleIds)
+	if err != nil {
+		return err
+	}
+	repo.invalidateNav(ctx, articleIds)
+	return nil
+}
+
+// articleIds returns the articles of the series to invalidate, none if they could not be found.
+func (repo *seriesRepository) articleIds(ctx context.Context, id int64) []int64 {
+	ids, err := repo.dao.ArticleIds(ctx, id)
+	if err != nil {
+		repo.l.Error("find articles of series failed", logger.Int64("series_id", id), logger.Error(err))
+	}
+	return ids
+}
+
+// invalidateNav drops the cached copies of the articles whose navigation has changed,
+// the ones missed expire with the local caches.
+func (repo *seriesRepository) invalidateNav(ctx context.Context, ids []int64) {
+	for _, id := range ids {
+		if err := repo.cache.InvalidatePub(ctx, id); err != nil {
+			repo.l.Error("invalidate series article failed", logger.Int64("aid", id), logger.Error(err))
+		}
+	}
+}
+
+func (repo *seriesRepository) ListArticles(ctx context.Context, id int64) ([]domain.SeriesArticle, error) {
+	entries, err := repo.dao.ListArticles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.SeriesEntry, domain.SeriesArticle](entries, func(idx int, src dao.SeriesEntry) domain.SeriesArticle {
+		return domain.SeriesArticle{
+			Id:       src.Id,
+			Title:    src.Title,
+			Abstract: src.Abstract,
+			Utime:    time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (repo *seriesRepository) toEntity(s domain.Series) dao.Series {
+	return dao.Series{
+		Id:          s.Id,
+		AuthorId:    s.Author.Id,
+		Title:       s.Title,
+		Description: s.Description,
+	}
+}
+
+func (repo *seriesRepository) toDomain(s dao.Series) domain.Series {
+	return domain.Series{
+		Id: s.Id,
+		Author: domain.Author{
+			Id: s.AuthorId,
+		},
+		Title:       s.Title,
+		Description: s.Description,
+		Ctime:       time.UnixMilli(s.Ctime),
+		Utime:       time.UnixMilli(s.Utime),
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/internal/article/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func (repo *CachedArticleRepository) Trash(ctx context.Context, id, uid int64) error {
+	err := repo.articleDAO.Trash(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	repo.delArticle(ctx, id)
+	repo.delFirstPages(ctx, id, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) Restore(ctx context.Context, id, uid int64, after time.Time) error {
+	err := repo.articleDAO.Restore(ctx, id, uid, after)
+	if err != nil {
+		return err
+	}
+	repo.delArticle(ctx, id)
+	repo.delFirstPages(ctx, id, uid)
+	return nil
+}
+
+func (repo *CachedArticleRepository) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	atcls, err := repo.articleDAO.ListTrash(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](atcls, func(idx int, src dao.Article) domain.Article {
+		return repo.entityToDomain(src)
+	}), nil
+}
+
+func (repo *CachedArticleRepository) ListTrashedBefore(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	atcls, err := repo.articleDAO.ListTrashedBefore(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](atcls, func(idx int, src dao.Article) domain.Article {
+		return repo.entityToDomain(src)
+	}), nil
+}
+
+func (repo *CachedArticleRepository) Purge(ctx context.Context, id int64, before time.Time) error {
+	// the collaborators are purged along with the article, their first pages are found beforehand
+	collabs, err := repo.articleDAO.ListCollaborators(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = repo.articleDAO.Purge(ctx, id, before); err != nil {
+		return err
+	}
+	repo.delArticle(ctx, id)
+	if err = repo.articleCache.DelDraft(ctx, id); err != nil {
+		repo.logger.Error("delete draft redis failed", logger.Int64("id", id), logger.Error(err))
+	}
+	for _, c := range collabs {
+		if c.Status != domain.CollaboratorStatusAccepted.ToUint8() {
+			continue
+		}
+		if err = repo.articleCache.DelFirstPage(ctx, c.UserId); err != nil {
+			repo.logger.Error("delete first page redis failed", logger.Int64("author_id", c.UserId), logger.Error(err))
+		}
+	}
+	return nil
+}
+
+// delArticle deletes the article cached for its members and readers.
+func (repo *CachedArticleRepository) delArticle(ctx context.Context, id int64) {
+	if err := repo.articleCache.Del(ctx, id); err != nil {
+		repo.logger.Error("delete article redis failed", logger.Int64("id", id), logger.Error(err))
+	}
+	repo.delPub(ctx, id)
+}
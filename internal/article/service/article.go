@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	userv1 "github.com/tsukiyo/mercury/api/gen/user/v1"
 
@@ -11,81 +14,507 @@ import (
 	"github.com/tsukiyo/mercury/internal/article/domain"
 	"github.com/tsukiyo/mercury/internal/article/events"
 	"github.com/tsukiyo/mercury/internal/article/repository"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+	"github.com/tsukiyo/mercury/pkg/diffx"
 	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/markdown"
+	"github.com/tsukiyo/mercury/pkg/moderation"
+)
+
+var (
+	ErrPossibleIncorrectAuthor = repository.ErrPossibleIncorrectAuthor
+	ErrRevisionMismatch        = errors.New("the revision does not belong to the article")
+	ErrArticleNotScheduled     = repository.ErrArticleNotScheduled
+	ErrInvalidScheduleTime     = errors.New("the publish time must be in the future")
+	ErrInvalidTags             = errors.New("too many tags or the tag is too long")
+	ErrInvalidCursor           = cursorx.ErrInvalidCursor
+	ErrStaleContent            = repository.ErrStaleContent
+	ErrVersionConflict         = repository.ErrVersionConflict
+	ErrDraftNotFound           = repository.ErrDraftNotFound
+	ErrDraftWithoutArticle     = errors.New("the article has to be saved before its draft is autosaved")
+	ErrCollaboratorNotFound    = repository.ErrCollaboratorNotFound
+	ErrInvalidInvitation       = errors.New("only another user could be invited as an editor or a viewer")
+	ErrArticleTrashed          = repository.ErrArticleTrashed
+	ErrArticleNotFound         = repository.ErrArticleNotFound
+	ErrArticleNotTrashed       = repository.ErrArticleNotTrashed
+	ErrContentRejected         = errors.New("the content is rejected by the moderation")
+)
+
+// VersionConflictError is returned when the article has been saved since the version being edited,
+// Current is the latest article for the author to merge with. It matches ErrVersionConflict.
+type VersionConflictError struct {
+	Current domain.Article
+}
+
+func (e *VersionConflictError) Error() string {
+	return ErrVersionConflict.Error()
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// scopes of cursors, a cursor is only accepted by the list issuing it
+const (
+	scopeAuthor = "article:author"
+	scopePub    = "article:pub"
+	scopeTag    = "article:tag:"
 )
 
 var _ ArticleService = (*articleService)(nil)
 
 //go:generate mockgen -source=./article.go -package=svcmocks -destination=mocks/article.mock.go ArticleService
 type ArticleService interface {
-	// author
+	// author, the methods taking the id of the user check the role of the user instead,
+	// ErrPossibleIncorrectAuthor is returned if the role is not enough.
 
-	Save(ctx context.Context, atcl domain.Article) (int64, error)
+	// Save returns the id and the new version of the article,
+	// or a *VersionConflictError if atcl.Version is set and the article has been saved since.
+	Save(ctx context.Context, atcl domain.Article) (int64, int64, error)
+	// Publish checks atcl.Version the same way as Save, atcl.Author is the editor publishing it
+	// while the published article keeps the owner as its author.
 	Publish(ctx context.Context, atcl domain.Article) (int64, error)
-	Withdraw(ctx context.Context, id, authorId int64) error
-	List(ctx context.Context, authorId int64,
+	Withdraw(ctx context.Context, id, uid int64) error
+	// List pages articles the user owns or collaborates on by offset.
+	// Deprecated: use ListByCursor.
+	List(ctx context.Context, uid int64,
 		offset, limit int) ([]domain.Article, error)
-	GetById(ctx context.Context, id int64) (domain.Article, error)
+	// ListByCursor pages articles the user owns or collaborates on by the cursor, an empty cursor means the first page.
+	// The returned cursor is empty when there are no more articles.
+	ListByCursor(ctx context.Context, uid int64, cursor string, limit int) ([]domain.Article, string, error)
+	// GetById requires a viewer.
+	GetById(ctx context.Context, id, uid int64) (domain.Article, error)
+
+	// collaborator
+
+	// InviteCollaborator invites c.User by c.InviterId, who owns the article,
+	// the invitation takes effect once accepted, inviting again changes the role.
+	InviteCollaborator(ctx context.Context, c domain.Collaborator) error
+	// AcceptInvitation returns ErrCollaboratorNotFound if the user has no pending invitation.
+	AcceptInvitation(ctx context.Context, id, uid int64) error
+	// RevokeCollaborator removes the collaborator by the owner, a collaborator could revoke themselves to leave.
+	RevokeCollaborator(ctx context.Context, id, operatorId, collaboratorId int64) error
+	// ListCollaborators requires a viewer, the owner is listed first.
+	ListCollaborators(ctx context.Context, id, uid int64) ([]domain.Collaborator, error)
+
+	// revision
+
+	ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, id, revisionId, uid int64) (domain.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, id, fromId, toId, uid int64) (domain.RevisionDiff, error)
+	RestoreRevision(ctx context.Context, id, revisionId, uid int64) error
+
+	// draft
+
+	// AutosaveDraft keeps the draft of draft.AuthorId, who is an editor at least, apart from the article until it is saved,
+	// returns a *VersionConflictError if the article has been saved since draft.BaseVersion.
+	// Editors share the draft of the article.
+	AutosaveDraft(ctx context.Context, draft domain.Draft) error
+	// GetDraft requires an editor, returns ErrDraftNotFound if the article has no draft, a saved draft is deleted.
+	GetDraft(ctx context.Context, id, uid int64) (domain.Draft, error)
+	// FlushDrafts writes drafts autosaved before the time to the database and returns how many were written.
+	FlushDrafts(ctx context.Context, before time.Time, batchSize int) (int, error)
+
+	// trash
+
+	// Delete moves the article to the trash by the owner, it is taken offline and could be restored
+	// until the retention passes, then it is purged for good by PurgeTrashed.
+	Delete(ctx context.Context, id, uid int64) error
+	// Restore takes the article out of the trash by the owner, a published one goes back as private.
+	// Returns ErrArticleNotTrashed if it is not in the trash or the retention has passed.
+	Restore(ctx context.Context, id, uid int64) error
+	// ListTrash pages the trashed articles of the owner, the latest trashed first.
+	ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error)
+	// PurgeTrashed purges articles whose retention has passed by now and returns how many were purged.
+	PurgeTrashed(ctx context.Context, now time.Time, batchSize int) (int, error)
+
+	// schedule
+
+	SchedulePublish(ctx context.Context, id, uid int64, at time.Time) error
+	CancelScheduledPublish(ctx context.Context, id, uid int64) error
+	// PublishScheduled publishes articles scheduled before now and returns how many were published.
+	PublishScheduled(ctx context.Context, now time.Time, batchSize int) (int, error)
 
 	// reader
 
+	// GetPublishedById returns the article with its authors, the owner and the editors.
 	GetPublishedById(ctx context.Context, id, uid int64) (domain.Article, error)
+	// RecordRead counts the read of the article by the user, GetPublishedById records it already.
+	RecordRead(ctx context.Context, id, uid int64) error
+	// ExistsPublished reports whether the article is published and not trashed, with the id of its author if it is.
+	ExistsPublished(ctx context.Context, id int64) (authorId int64, exists bool, err error)
+	// ListPub pages published articles updated before start by offset.
+	// Deprecated: use ListPubByCursor.
 	ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error)
+	// ListPubByCursor pages published articles by the cursor, the first page starts from articles updated before start.
+	ListPubByCursor(ctx context.Context, start time.Time, cursor string, limit int) ([]domain.Article, string, error)
+	// ListPubByTag pages published articles with the tag by the cursor, an empty cursor means the first page.
+	// The returned cursor is empty when there are no more articles.
+	ListPubByTag(ctx context.Context, tag, cursor string, limit int) ([]domain.Article, string, error)
+	// CountTags counts published articles of the given tags, or of the most used tags if tags is empty.
+	CountTags(ctx context.Context, tags []string, limit int) ([]domain.TagCount, error)
 }
 
 type articleService struct {
 	articleRepo repository.ArticleRepository
 	userSvc     userv1.UserServiceClient
 	producer    events.Producer
-	logger      logger.Logger
+	codec       *cursorx.Codec
+	// filter moderates the articles on publish, the same chain as the comments
+	filter moderation.ContentFilter
+	// trashRetention is how long a trashed article could be restored
+	trashRetention time.Duration
+	logger         logger.Logger
 }
 
 func NewArticleService(
 	articleRepo repository.ArticleRepository,
 	userSvc userv1.UserServiceClient,
 	producer events.Producer,
+	codec *cursorx.Codec,
+	filter moderation.ContentFilter,
+	trashRetention time.Duration,
 	logger logger.Logger,
 ) ArticleService {
 	return &articleService{
-		articleRepo: articleRepo,
-		userSvc:     userSvc,
-		producer:    producer,
-		logger:      logger,
+		articleRepo:    articleRepo,
+		userSvc:        userSvc,
+		producer:       producer,
+		codec:          codec,
+		filter:         filter,
+		trashRetention: trashRetention,
+		logger:         logger,
 	}
 }
 
-func (svc *articleService) Save(ctx context.Context, atcl domain.Article) (int64, error) {
+func (svc *articleService) Save(ctx context.Context, atcl domain.Article) (int64, int64, error) {
 	atcl.Status = domain.ArticleStatusUnpublished
+	tags, err := normalizeTags(atcl.Tags)
+	if err != nil {
+		return 0, 0, err
+	}
+	atcl.Tags = tags
 	if atcl.Id > 0 {
-		err := svc.articleRepo.Update(ctx, atcl)
-		return atcl.Id, err
+		version, err := svc.articleRepo.Update(ctx, atcl)
+		if err != nil {
+			return 0, 0, svc.conflict(ctx, atcl.Id, err)
+		}
+		// the drafts edited from the versions before are saved now
+		svc.deleteDraft(ctx, atcl.Id, version-1)
+		return atcl.Id, version, nil
 	}
 
-	return svc.articleRepo.Create(ctx, atcl)
+	id, err := svc.articleRepo.Create(ctx, atcl)
+	return id, 1, err
 }
 
 func (svc *articleService) Publish(ctx context.Context, atcl domain.Article) (int64, error) {
 	atcl.Status = domain.ArticleStatusPublished
-	author, err := svc.findAuthor(ctx, atcl.Id)
+	tags, err := normalizeTags(atcl.Tags)
+	if err != nil {
+		return 0, err
+	}
+	atcl.Tags = tags
+	err = svc.moderate(ctx, atcl)
 	if err != nil {
 		return 0, err
 	}
-	atcl.Author = author
-	return svc.articleRepo.Sync(ctx, atcl)
+	// rendered once on publish instead of on every read
+	atcl.Rendered = render(atcl.Content)
+	id, err := svc.articleRepo.Sync(ctx, atcl)
+	if err != nil {
+		return 0, svc.conflict(ctx, atcl.Id, err)
+	}
+	if atcl.Version > 0 {
+		svc.deleteDraft(ctx, id, atcl.Version)
+	}
+	return id, nil
+}
+
+// moderate returns ErrContentRejected if the article is rejected by the filter,
+// articles have no review queue, the ones to review are published and logged for the admins.
+func (svc *articleService) moderate(ctx context.Context, atcl domain.Article) error {
+	res, err := svc.filter.Filter(ctx, atcl.Title+"\n"+atcl.Content)
+	if err != nil {
+		return err
+	}
+	switch res.Verdict {
+	case moderation.VerdictReject:
+		return ErrContentRejected
+	case moderation.VerdictReview:
+		svc.logger.Warn("published article to review",
+			logger.Int64("aid", atcl.Id),
+			logger.Int64("uid", atcl.Author.Id),
+			logger.String("hits", strings.Join(res.Hits, ", ")))
+	}
+	return nil
 }
 
-func (svc *articleService) Withdraw(ctx context.Context, id, authorId int64) error {
-	return svc.articleRepo.SyncStatus(ctx, id, authorId, domain.ArticleStatusPrivate)
+func (svc *articleService) Withdraw(ctx context.Context, id, uid int64) error {
+	return svc.articleRepo.SyncStatus(ctx, id, uid, domain.ArticleStatusPrivate)
 }
 
-func (svc *articleService) List(ctx context.Context, authorId int64, offset, limit int) ([]domain.Article, error) {
-	return svc.articleRepo.List(ctx, authorId, offset, limit)
+func (svc *articleService) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	return svc.articleRepo.List(ctx, uid, offset, limit)
 }
 
-func (svc *articleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
+func (svc *articleService) ListByCursor(ctx context.Context, uid int64, cursor string, limit int) ([]domain.Article, string, error) {
+	cur, err := svc.codec.Decode(scopeAuthor, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	atcls, err := svc.articleRepo.ListBefore(ctx, uid, cur.Utime, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return atcls, svc.nextCursor(scopeAuthor, atcls, limit), nil
+}
+
+func (svc *articleService) GetById(ctx context.Context, id, uid int64) (domain.Article, error) {
+	if err := svc.authorize(ctx, id, uid, domain.CollaboratorRoleViewer); err != nil {
+		return domain.Article{}, err
+	}
 	return svc.articleRepo.GetById(ctx, id)
 }
 
+func (svc *articleService) InviteCollaborator(ctx context.Context, c domain.Collaborator) error {
+	if !c.Role.Invitable() || c.User.Id <= 0 || c.User.Id == c.InviterId {
+		return ErrInvalidInvitation
+	}
+	return svc.articleRepo.InviteCollaborator(ctx, c)
+}
+
+func (svc *articleService) AcceptInvitation(ctx context.Context, id, uid int64) error {
+	return svc.articleRepo.AcceptInvitation(ctx, id, uid)
+}
+
+func (svc *articleService) RevokeCollaborator(ctx context.Context, id, operatorId, collaboratorId int64) error {
+	return svc.articleRepo.RevokeCollaborator(ctx, id, operatorId, collaboratorId)
+}
+
+func (svc *articleService) ListCollaborators(ctx context.Context, id, uid int64) ([]domain.Collaborator, error) {
+	if err := svc.authorize(ctx, id, uid, domain.CollaboratorRoleViewer); err != nil {
+		return nil, err
+	}
+	return svc.articleRepo.ListCollaborators(ctx, id)
+}
+
+// authorize returns ErrPossibleIncorrectAuthor unless the user has the role or a higher one on the article.
+func (svc *articleService) authorize(ctx context.Context, id, uid int64, role domain.CollaboratorRole) error {
+	cur, err := svc.articleRepo.GetRole(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	if !cur.Covers(role) {
+		return ErrPossibleIncorrectAuthor
+	}
+	return nil
+}
+
+func (svc *articleService) AutosaveDraft(ctx context.Context, draft domain.Draft) error {
+	if draft.ArticleId <= 0 {
+		return ErrDraftWithoutArticle
+	}
+	tags, err := normalizeTags(draft.Tags)
+	if err != nil {
+		return err
+	}
+	draft.Tags = tags
+	if err = svc.authorize(ctx, draft.ArticleId, draft.AuthorId, domain.CollaboratorRoleEditor); err != nil {
+		return err
+	}
+	cur, err := svc.articleRepo.GetById(ctx, draft.ArticleId)
+	if err != nil {
+		return err
+	}
+	if cur.Status == domain.ArticleStatusTrashed {
+		return ErrArticleTrashed
+	}
+	// reported right away instead of on the next save
+	if cur.Version != draft.BaseVersion {
+		return &VersionConflictError{Current: cur}
+	}
+	draft.Utime = time.Now()
+	return svc.articleRepo.SaveDraft(ctx, draft)
+}
+
+func (svc *articleService) GetDraft(ctx context.Context, id, uid int64) (domain.Draft, error) {
+	if err := svc.authorize(ctx, id, uid, domain.CollaboratorRoleEditor); err != nil {
+		return domain.Draft{}, err
+	}
+	return svc.articleRepo.GetDraft(ctx, id)
+}
+
+func (svc *articleService) FlushDrafts(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	total := 0
+	for {
+		cnt, err := svc.articleRepo.FlushDrafts(ctx, before, batchSize)
+		total += cnt
+		if err != nil || cnt < batchSize {
+			return total, err
+		}
+	}
+}
+
+// conflict attaches the latest article to ErrVersionConflict
+func (svc *articleService) conflict(ctx context.Context, id int64, err error) error {
+	if !errors.Is(err, ErrVersionConflict) {
+		return err
+	}
+	cur, er := svc.articleRepo.GetById(ctx, id)
+	if er != nil {
+		svc.logger.Error("failed to get the article in conflict", logger.Int64("id", id), logger.Error(er))
+		return err
+	}
+	return &VersionConflictError{Current: cur}
+}
+
+// deleteDraft is best effort, a draft left behind is older than the saved article
+func (svc *articleService) deleteDraft(ctx context.Context, id, maxBaseVersion int64) {
+	if err := svc.articleRepo.DeleteDraft(ctx, id, maxBaseVersion); err != nil {
+		svc.logger.Error("failed to delete the saved draft", logger.Int64("id", id), logger.Error(err))
+	}
+}
+
+func (svc *articleService) ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	return svc.articleRepo.ListRevisions(ctx, id, uid, offset, limit)
+}
+
+func (svc *articleService) GetRevision(ctx context.Context, id, revisionId, uid int64) (domain.ArticleRevision, error) {
+	rev, err := svc.articleRepo.GetRevision(ctx, revisionId, uid)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	if rev.ArticleId != id {
+		return domain.ArticleRevision{}, ErrRevisionMismatch
+	}
+	return rev, nil
+}
+
+func (svc *articleService) DiffRevisions(ctx context.Context, id, fromId, toId, uid int64) (domain.RevisionDiff, error) {
+	from, err := svc.GetRevision(ctx, id, fromId, uid)
+	if err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	to, err := svc.GetRevision(ctx, id, toId, uid)
+	if err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	return domain.RevisionDiff{
+		FromId:  from.Id,
+		ToId:    to.Id,
+		Title:   toDiffLines(diffx.Lines(from.Title, to.Title)),
+		Content: toDiffLines(diffx.Lines(from.Content, to.Content)),
+	}, nil
+}
+
+// RestoreRevision saves the revision as the latest draft, which is recorded as a new revision as well.
+// Tags are not part of revisions, the current ones are kept.
+func (svc *articleService) RestoreRevision(ctx context.Context, id, revisionId, uid int64) error {
+	rev, err := svc.GetRevision(ctx, id, revisionId, uid)
+	if err != nil {
+		return err
+	}
+	cur, err := svc.articleRepo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	_, _, err = svc.Save(ctx, domain.Article{
+		Id:      id,
+		Title:   rev.Title,
+		Content: rev.Content,
+		Tags:    cur.Tags,
+		Author: domain.Author{
+			Id: uid,
+		},
+	})
+	return err
+}
+
+// SchedulePublish publishes the current draft at the given time,
+// saving the draft again before that cancels the schedule.
+func (svc *articleService) SchedulePublish(ctx context.Context, id, uid int64, at time.Time) error {
+	if !at.After(time.Now()) {
+		return ErrInvalidScheduleTime
+	}
+	atcl, err := svc.GetById(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	// checked again at the publish time, as the rules could change meanwhile
+	err = svc.moderate(ctx, atcl)
+	if err != nil {
+		return err
+	}
+	return svc.articleRepo.Schedule(ctx, id, uid, at)
+}
+
+func (svc *articleService) CancelScheduledPublish(ctx context.Context, id, uid int64) error {
+	return svc.articleRepo.CancelSchedule(ctx, id, uid)
+}
+
+func (svc *articleService) PublishScheduled(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	cnt := 0
+	for {
+		atcls, err := svc.articleRepo.ListScheduled(ctx, now, batchSize)
+		if err != nil {
+			return cnt, err
+		}
+		var failed error
+		for _, atcl := range atcls {
+			err := svc.moderateScheduled(ctx, atcl)
+			if err != nil {
+				failed = err
+				continue
+			}
+			published, err := svc.articleRepo.SyncScheduled(ctx, atcl.Id)
+			switch {
+			case err == nil:
+				cnt++
+				svc.renderPublished(ctx, published)
+			case errors.Is(err, ErrArticleNotScheduled):
+				// published by another executor or cancelled by the author
+			default:
+				svc.logger.Error("publish scheduled article failed",
+					logger.Int64("aid", atcl.Id),
+					logger.Error(err))
+				failed = err
+			}
+		}
+		// leave the failed ones to the next execution
+		if failed != nil {
+			return cnt, failed
+		}
+		if len(atcls) < batchSize {
+			return cnt, nil
+		}
+	}
+}
+
+// moderateScheduled checks the content of the scheduled article before publishing it,
+// a rejected one is unscheduled and an error leaves it scheduled for the next execution.
+func (svc *articleService) moderateScheduled(ctx context.Context, scheduled domain.Article) error {
+	atcl, err := svc.articleRepo.GetById(ctx, scheduled.Id)
+	if err == nil {
+		err = svc.moderate(ctx, atcl)
+	}
+	if errors.Is(err, ErrContentRejected) {
+		svc.logger.Warn("scheduled article rejected by the moderation",
+			logger.Int64("aid", scheduled.Id),
+			logger.Int64("uid", scheduled.Author.Id))
+		err = svc.articleRepo.CancelSchedule(ctx, scheduled.Id, scheduled.Author.Id)
+		if err == nil || errors.Is(err, ErrArticleNotScheduled) {
+			return nil
+		}
+	}
+	if err != nil {
+		svc.logger.Error("moderate scheduled article failed",
+			logger.Int64("aid", scheduled.Id),
+			logger.Error(err))
+	}
+	return err
+}
+
 func (svc *articleService) GetPublishedById(ctx context.Context, id, uid int64) (domain.Article, error) {
 	var eg errgroup.Group
 	var err error
@@ -105,28 +534,86 @@ func (svc *articleService) GetPublishedById(ctx context.Context, id, uid int64)
 	if err != nil {
 		return domain.Article{}, err
 	}
+	if atcl.Status == domain.ArticleStatusTrashed {
+		return domain.Article{}, ErrArticleTrashed
+	}
+	if atcl.Rendered.HTML == "" && atcl.Content != "" {
+		// published before being rendered, or saving the rendered content failed on publish
+		*atcl = svc.renderPublished(ctx, *atcl)
+	}
 	atcl.Author = *author
-	if err == nil {
-		go func() {
-			er := svc.producer.ProduceReadEvent(events.ReadEvent{
-				Aid: id,
-				Uid: uid,
-			})
-			if er != nil {
-				svc.logger.Error("send reader read event failed",
-					logger.Int64("uid", uid),
-					logger.Int64("aid", id),
-					logger.Error(err))
-			}
-		}()
+	if er := svc.RecordRead(ctx, id, uid); er != nil {
+		svc.logger.Error("write reader read event failed",
+			logger.Int64("uid", uid),
+			logger.Int64("aid", id),
+			logger.Error(er))
 	}
 	return *atcl, err
 }
 
+func (svc *articleService) RecordRead(ctx context.Context, id, uid int64) error {
+	// sent to kafka directly, reads need no transaction to go along with
+	return svc.producer.ProduceReadEvent(events.ReadEvent{
+		Aid: id,
+		Uid: uid,
+	})
+}
+
+func (svc *articleService) ExistsPublished(ctx context.Context, id int64) (int64, bool, error) {
+	atcl, err := svc.articleRepo.GetPublishedById(ctx, id)
+	if errors.Is(err, ErrArticleNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if atcl.Status != domain.ArticleStatusPublished {
+		return 0, false, nil
+	}
+	return atcl.Author.Id, true, nil
+}
+
 func (svc *articleService) ListPub(ctx context.Context, start time.Time, offset, limit int) ([]domain.Article, error) {
 	return svc.articleRepo.ListPub(ctx, start, offset, limit)
 }
 
+func (svc *articleService) ListPubByCursor(ctx context.Context, start time.Time, cursor string, limit int) ([]domain.Article, string, error) {
+	cur, err := svc.codec.Decode(scopePub, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if cursor == "" {
+		// id 0 leaves out every article updated exactly at start
+		cur.Utime = start
+	}
+	atcls, err := svc.articleRepo.ListPubBefore(ctx, cur.Utime, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return atcls, svc.nextCursor(scopePub, atcls, limit), nil
+}
+
+func (svc *articleService) ListPubByTag(ctx context.Context, tag, cursor string, limit int) ([]domain.Article, string, error) {
+	tags := domain.NormalizeTags([]string{tag})
+	if len(tags) == 0 {
+		return nil, "", nil
+	}
+	scope := scopeTag + tags[0]
+	cur, err := svc.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	atcls, err := svc.articleRepo.ListPubByTag(ctx, tags[0], cur.Utime, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return atcls, svc.nextCursor(scope, atcls, limit), nil
+}
+
+func (svc *articleService) CountTags(ctx context.Context, tags []string, limit int) ([]domain.TagCount, error) {
+	return svc.articleRepo.CountTags(ctx, domain.NormalizeTags(tags), limit)
+}
+
 func (svc *articleService) findAuthor(ctx context.Context, id int64) (domain.Author, error) {
 	atcl, err := svc.articleRepo.GetPublishedById(ctx, id)
 	if err != nil {
@@ -143,3 +630,71 @@ func (svc *articleService) findAuthor(ctx context.Context, id int64) (domain.Aut
 		Name: resp.GetUser().GetNickName(),
 	}, nil
 }
+
+// nextCursor points at the last article of a full page, a shorter page is the last one.
+func (svc *articleService) nextCursor(scope string, atcls []domain.Article, limit int) string {
+	if len(atcls) == 0 || len(atcls) < limit {
+		return ""
+	}
+	last := atcls[len(atcls)-1]
+	return svc.codec.Encode(scope, cursorx.Cursor{Utime: last.Utime, Id: last.Id})
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	tags = domain.NormalizeTags(tags)
+	if len(tags) > domain.MaxTagsPerArticle {
+		return nil, ErrInvalidTags
+	}
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > domain.MaxTagLength {
+			return nil, ErrInvalidTags
+		}
+	}
+	return tags, nil
+}
+
+// renderPublished renders the published article and keeps the result for later readers,
+// failing to keep it only costs rendering again.
+func (svc *articleService) renderPublished(ctx context.Context, atcl domain.Article) domain.Article {
+	atcl.Rendered = render(atcl.Content)
+	err := svc.articleRepo.SaveRendered(ctx, atcl)
+	if err != nil && !errors.Is(err, ErrStaleContent) {
+		svc.logger.Error("save rendered article failed",
+			logger.Int64("aid", atcl.Id),
+			logger.Error(err))
+	}
+	return atcl
+}
+
+func render(content string) domain.RenderedContent {
+	res := markdown.Process(content)
+	toc := make([]domain.TOCEntry, 0, len(res.TOC))
+	for _, heading := range res.TOC {
+		toc = append(toc, domain.TOCEntry{
+			Level:  heading.Level,
+			Title:  heading.Text,
+			Anchor: heading.ID,
+		})
+	}
+	return domain.RenderedContent{
+		HTML:        res.HTML,
+		Abstract:    res.Abstract,
+		ReadingTime: res.ReadingTime,
+		TOC:         toc,
+	}
+}
+
+func toDiffLines(edits []diffx.Edit) []domain.DiffLine {
+	lines := make([]domain.DiffLine, 0, len(edits))
+	for _, edit := range edits {
+		op := domain.DiffOpEqual
+		switch edit.Op {
+		case diffx.OpInsert:
+			op = domain.DiffOpInsert
+		case diffx.OpDelete:
+			op = domain.DiffOpDelete
+		}
+		lines = append(lines, domain.DiffLine{Op: op, Content: edit.Line})
+	}
+	return lines
+}
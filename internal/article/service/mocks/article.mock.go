@@ -41,19 +41,151 @@ func (m *MockArticleService) EXPECT() *MockArticleServiceMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockArticleService) AcceptInvitation(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockArticleServiceMockRecorder) AcceptInvitation(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockArticleService)(nil).AcceptInvitation), ctx, id, uid)
+}
+
+// AutosaveDraft mocks base method.
+func (m *MockArticleService) AutosaveDraft(ctx context.Context, draft domain.Draft) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutosaveDraft", ctx, draft)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AutosaveDraft indicates an expected call of AutosaveDraft.
+func (mr *MockArticleServiceMockRecorder) AutosaveDraft(ctx, draft any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutosaveDraft", reflect.TypeOf((*MockArticleService)(nil).AutosaveDraft), ctx, draft)
+}
+
+// CancelScheduledPublish mocks base method.
+func (m *MockArticleService) CancelScheduledPublish(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledPublish", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduledPublish indicates an expected call of CancelScheduledPublish.
+func (mr *MockArticleServiceMockRecorder) CancelScheduledPublish(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledPublish", reflect.TypeOf((*MockArticleService)(nil).CancelScheduledPublish), ctx, id, uid)
+}
+
+// CountTags mocks base method.
+func (m *MockArticleService) CountTags(ctx context.Context, tags []string, limit int) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTags", ctx, tags, limit)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTags indicates an expected call of CountTags.
+func (mr *MockArticleServiceMockRecorder) CountTags(ctx, tags, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTags", reflect.TypeOf((*MockArticleService)(nil).CountTags), ctx, tags, limit)
+}
+
+// Delete mocks base method.
+func (m *MockArticleService) Delete(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleServiceMockRecorder) Delete(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleService)(nil).Delete), ctx, id, uid)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleService) DiffRevisions(ctx context.Context, id, fromId, toId, uid int64) (domain.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, id, fromId, toId, uid)
+	ret0, _ := ret[0].(domain.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceMockRecorder) DiffRevisions(ctx, id, fromId, toId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleService)(nil).DiffRevisions), ctx, id, fromId, toId, uid)
+}
+
+// ExistsPublished mocks base method.
+func (m *MockArticleService) ExistsPublished(ctx context.Context, id int64) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsPublished", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExistsPublished indicates an expected call of ExistsPublished.
+func (mr *MockArticleServiceMockRecorder) ExistsPublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsPublished", reflect.TypeOf((*MockArticleService)(nil).ExistsPublished), ctx, id)
+}
+
+// FlushDrafts mocks base method.
+func (m *MockArticleService) FlushDrafts(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushDrafts", ctx, before, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushDrafts indicates an expected call of FlushDrafts.
+func (mr *MockArticleServiceMockRecorder) FlushDrafts(ctx, before, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushDrafts", reflect.TypeOf((*MockArticleService)(nil).FlushDrafts), ctx, before, batchSize)
+}
+
 // GetById mocks base method.
-func (m *MockArticleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
+func (m *MockArticleService) GetById(ctx context.Context, id, uid int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret := m.ctrl.Call(m, "GetById", ctx, id, uid)
 	ret0, _ := ret[0].(domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockArticleServiceMockRecorder) GetById(ctx, id any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) GetById(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleService)(nil).GetById), ctx, id, uid)
+}
+
+// GetDraft mocks base method.
+func (m *MockArticleService) GetDraft(ctx context.Context, id, uid int64) (domain.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", ctx, id, uid)
+	ret0, _ := ret[0].(domain.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockArticleServiceMockRecorder) GetDraft(ctx, id, uid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockArticleService)(nil).GetById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockArticleService)(nil).GetDraft), ctx, id, uid)
 }
 
 // GetPublishedById mocks base method.
@@ -71,19 +203,79 @@ func (mr *MockArticleServiceMockRecorder) GetPublishedById(ctx, id, uid any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedById", reflect.TypeOf((*MockArticleService)(nil).GetPublishedById), ctx, id, uid)
 }
 
+// GetRevision mocks base method.
+func (m *MockArticleService) GetRevision(ctx context.Context, id, revisionId, uid int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, id, revisionId, uid)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleServiceMockRecorder) GetRevision(ctx, id, revisionId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleService)(nil).GetRevision), ctx, id, revisionId, uid)
+}
+
+// InviteCollaborator mocks base method.
+func (m *MockArticleService) InviteCollaborator(ctx context.Context, c domain.Collaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteCollaborator", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteCollaborator indicates an expected call of InviteCollaborator.
+func (mr *MockArticleServiceMockRecorder) InviteCollaborator(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteCollaborator", reflect.TypeOf((*MockArticleService)(nil).InviteCollaborator), ctx, c)
+}
+
 // List mocks base method.
-func (m *MockArticleService) List(ctx context.Context, authorId int64, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleService) List(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, authorId, offset, limit)
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockArticleServiceMockRecorder) List(ctx, authorId, offset, limit any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleService)(nil).List), ctx, uid, offset, limit)
+}
+
+// ListByCursor mocks base method.
+func (m *MockArticleService) ListByCursor(ctx context.Context, uid int64, cursor string, limit int) ([]domain.Article, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockArticleServiceMockRecorder) ListByCursor(ctx, uid, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockArticleService)(nil).List), ctx, authorId, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockArticleService)(nil).ListByCursor), ctx, uid, cursor, limit)
+}
+
+// ListCollaborators mocks base method.
+func (m *MockArticleService) ListCollaborators(ctx context.Context, id, uid int64) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollaborators", ctx, id, uid)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollaborators indicates an expected call of ListCollaborators.
+func (mr *MockArticleServiceMockRecorder) ListCollaborators(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollaborators", reflect.TypeOf((*MockArticleService)(nil).ListCollaborators), ctx, id, uid)
 }
 
 // ListPub mocks base method.
@@ -101,6 +293,68 @@ func (mr *MockArticleServiceMockRecorder) ListPub(ctx, start, offset, limit any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), ctx, start, offset, limit)
 }
 
+// ListPubByCursor mocks base method.
+func (m *MockArticleService) ListPubByCursor(ctx context.Context, start time.Time, cursor string, limit int) ([]domain.Article, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByCursor", ctx, start, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPubByCursor indicates an expected call of ListPubByCursor.
+func (mr *MockArticleServiceMockRecorder) ListPubByCursor(ctx, start, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByCursor", reflect.TypeOf((*MockArticleService)(nil).ListPubByCursor), ctx, start, cursor, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleService) ListPubByTag(ctx context.Context, tag, cursor string, limit int) ([]domain.Article, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, tag, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceMockRecorder) ListPubByTag(ctx, tag, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleService)(nil).ListPubByTag), ctx, tag, cursor, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleService) ListRevisions(ctx context.Context, id, uid int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, id, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceMockRecorder) ListRevisions(ctx, id, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleService)(nil).ListRevisions), ctx, id, uid, offset, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleService) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleServiceMockRecorder) ListTrash(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleService)(nil).ListTrash), ctx, uid, offset, limit)
+}
+
 // Publish mocks base method.
 func (m *MockArticleService) Publish(ctx context.Context, atcl domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -116,13 +370,100 @@ func (mr *MockArticleServiceMockRecorder) Publish(ctx, atcl any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleService)(nil).Publish), ctx, atcl)
 }
 
+// PublishScheduled mocks base method.
+func (m *MockArticleService) PublishScheduled(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, now, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockArticleServiceMockRecorder) PublishScheduled(ctx, now, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockArticleService)(nil).PublishScheduled), ctx, now, batchSize)
+}
+
+// PurgeTrashed mocks base method.
+func (m *MockArticleService) PurgeTrashed(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashed", ctx, now, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashed indicates an expected call of PurgeTrashed.
+func (mr *MockArticleServiceMockRecorder) PurgeTrashed(ctx, now, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashed", reflect.TypeOf((*MockArticleService)(nil).PurgeTrashed), ctx, now, batchSize)
+}
+
+// RecordRead mocks base method.
+func (m *MockArticleService) RecordRead(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRead", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRead indicates an expected call of RecordRead.
+func (mr *MockArticleServiceMockRecorder) RecordRead(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRead", reflect.TypeOf((*MockArticleService)(nil).RecordRead), ctx, id, uid)
+}
+
+// Restore mocks base method.
+func (m *MockArticleService) Restore(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleServiceMockRecorder) Restore(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleService)(nil).Restore), ctx, id, uid)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticleService) RestoreRevision(ctx context.Context, id, revisionId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, id, revisionId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServiceMockRecorder) RestoreRevision(ctx, id, revisionId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleService)(nil).RestoreRevision), ctx, id, revisionId, uid)
+}
+
+// RevokeCollaborator mocks base method.
+func (m *MockArticleService) RevokeCollaborator(ctx context.Context, id, operatorId, collaboratorId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCollaborator", ctx, id, operatorId, collaboratorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeCollaborator indicates an expected call of RevokeCollaborator.
+func (mr *MockArticleServiceMockRecorder) RevokeCollaborator(ctx, id, operatorId, collaboratorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCollaborator", reflect.TypeOf((*MockArticleService)(nil).RevokeCollaborator), ctx, id, operatorId, collaboratorId)
+}
+
 // Save mocks base method.
-func (m *MockArticleService) Save(ctx context.Context, atcl domain.Article) (int64, error) {
+func (m *MockArticleService) Save(ctx context.Context, atcl domain.Article) (int64, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Save", ctx, atcl)
 	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Save indicates an expected call of Save.
@@ -131,16 +472,30 @@ func (mr *MockArticleServiceMockRecorder) Save(ctx, atcl any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleService)(nil).Save), ctx, atcl)
 }
 
+// SchedulePublish mocks base method.
+func (m *MockArticleService) SchedulePublish(ctx context.Context, id, uid int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePublish", ctx, id, uid, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SchedulePublish indicates an expected call of SchedulePublish.
+func (mr *MockArticleServiceMockRecorder) SchedulePublish(ctx, id, uid, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePublish", reflect.TypeOf((*MockArticleService)(nil).SchedulePublish), ctx, id, uid, at)
+}
+
 // Withdraw mocks base method.
-func (m *MockArticleService) Withdraw(ctx context.Context, id, authorId int64) error {
+func (m *MockArticleService) Withdraw(ctx context.Context, id, uid int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Withdraw", ctx, id, authorId)
+	ret := m.ctrl.Call(m, "Withdraw", ctx, id, uid)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Withdraw indicates an expected call of Withdraw.
-func (mr *MockArticleServiceMockRecorder) Withdraw(ctx, id, authorId any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) Withdraw(ctx, id, uid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockArticleService)(nil).Withdraw), ctx, id, authorId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockArticleService)(nil).Withdraw), ctx, id, uid)
 }
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./series.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./series.go -package=svcmocks -destination=mocks/series.mock.go SeriesService
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/tsukiyo/mercury/internal/article/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSeriesService is a mock of SeriesService interface.
+type MockSeriesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesServiceMockRecorder
+}
+
+// MockSeriesServiceMockRecorder is the mock recorder for MockSeriesService.
+type MockSeriesServiceMockRecorder struct {
+	mock *MockSeriesService
+}
+
+// NewMockSeriesService creates a new mock instance.
+func NewMockSeriesService(ctrl *gomock.Controller) *MockSeriesService {
+	mock := &MockSeriesService{ctrl: ctrl}
+	mock.recorder = &MockSeriesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesService) EXPECT() *MockSeriesServiceMockRecorder {
+	return m.recorder
+}
+
+// AddArticle mocks base method.
+func (m *MockSeriesService) AddArticle(ctx context.Context, id, authorId, articleId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddArticle", ctx, id, authorId, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddArticle indicates an expected call of AddArticle.
+func (mr *MockSeriesServiceMockRecorder) AddArticle(ctx, id, authorId, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddArticle", reflect.TypeOf((*MockSeriesService)(nil).AddArticle), ctx, id, authorId, articleId)
+}
+
+// Create mocks base method.
+func (m *MockSeriesService) Create(ctx context.Context, s domain.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSeriesServiceMockRecorder) Create(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSeriesService)(nil).Create), ctx, s)
+}
+
+// Delete mocks base method.
+func (m *MockSeriesService) Delete(ctx context.Context, id, authorId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, authorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeriesServiceMockRecorder) Delete(ctx, id, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeriesService)(nil).Delete), ctx, id, authorId)
+}
+
+// GetNav mocks base method.
+func (m *MockSeriesService) GetNav(ctx context.Context, articleId int64) (domain.SeriesNav, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNav", ctx, articleId)
+	ret0, _ := ret[0].(domain.SeriesNav)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNav indicates an expected call of GetNav.
+func (mr *MockSeriesServiceMockRecorder) GetNav(ctx, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNav", reflect.TypeOf((*MockSeriesService)(nil).GetNav), ctx, articleId)
+}
+
+// GetSeries mocks base method.
+func (m *MockSeriesService) GetSeries(ctx context.Context, id int64) (domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeries", ctx, id)
+	ret0, _ := ret[0].(domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeries indicates an expected call of GetSeries.
+func (mr *MockSeriesServiceMockRecorder) GetSeries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeries", reflect.TypeOf((*MockSeriesService)(nil).GetSeries), ctx, id)
+}
+
+// ListSeries mocks base method.
+func (m *MockSeriesService) ListSeries(ctx context.Context, authorId int64) ([]domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", ctx, authorId)
+	ret0, _ := ret[0].([]domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockSeriesServiceMockRecorder) ListSeries(ctx, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockSeriesService)(nil).ListSeries), ctx, authorId)
+}
+
+// RemoveArticle mocks base method.
+func (m *MockSeriesService) RemoveArticle(ctx context.Context, id, authorId, articleId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveArticle", ctx, id, authorId, articleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveArticle indicates an expected call of RemoveArticle.
+func (mr *MockSeriesServiceMockRecorder) RemoveArticle(ctx, id, authorId, articleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveArticle", reflect.TypeOf((*MockSeriesService)(nil).RemoveArticle), ctx, id, authorId, articleId)
+}
+
+// Reorder mocks base method.
+func (m *MockSeriesService) Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, id, authorId, articleIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockSeriesServiceMockRecorder) Reorder(ctx, id, authorId, articleIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockSeriesService)(nil).Reorder), ctx, id, authorId, articleIds)
+}
+
+// Update mocks base method.
+func (m *MockSeriesService) Update(ctx context.Context, s domain.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesServiceMockRecorder) Update(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesService)(nil).Update), ctx, s)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	interactivev1 "github.com/tsukiyo/mercury/api/gen/interactive/v1"
+
+	"github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/internal/article/repository"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var (
+	ErrSeriesNotFound      = repository.ErrSeriesNotFound
+	ErrArticleNotPublished = repository.ErrArticleNotPublished
+	ErrArticleInSeries     = repository.ErrArticleInSeries
+	ErrArticleNotInSeries  = repository.ErrArticleNotInSeries
+	ErrSeriesFull          = repository.ErrSeriesFull
+	ErrSeriesMismatch      = repository.ErrSeriesMismatch
+	ErrInvalidSeries       = errors.New("the title of the series is empty or too long, or the description is too long")
+)
+
+const (
+	maxSeriesTitleLen       = 128
+	maxSeriesDescriptionLen = 512
+)
+
+var _ SeriesService = (*seriesService)(nil)
+
+//go:generate mockgen -source=./series.go -package=svcmocks -destination=mocks/series.mock.go SeriesService
+type SeriesService interface {
+	// the methods modifying a series return ErrSeriesNotFound unless the series is of the author.
+
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	Update(ctx context.Context, s domain.Series) error
+	Delete(ctx context.Context, id, authorId int64) error
+	// GetSeries returns the series with its published articles in order along with their interactive counts.
+	GetSeries(ctx context.Context, id int64) (domain.Series, error)
+	// ListSeries returns the series of the author without their articles.
+	ListSeries(ctx context.Context, authorId int64) ([]domain.Series, error)
+	// AddArticle appends a published article of the author to the series.
+	AddArticle(ctx context.Context, id, authorId, articleId int64) error
+	RemoveArticle(ctx context.Context, id, authorId, articleId int64) error
+	// Reorder takes all the articles of the series in the new order.
+	Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error
+	// GetNav returns the zero SeriesNav if the article is not in a series.
+	GetNav(ctx context.Context, articleId int64) (domain.SeriesNav, error)
+}
+
+type seriesService struct {
+	repo    repository.SeriesRepository
+	intrSvc interactivev1.InteractiveServiceClient
+	logger  logger.Logger
+}
+
+func NewSeriesService(
+	repo repository.SeriesRepository,
+	intrSvc interactivev1.InteractiveServiceClient,
+	logger logger.Logger,
+) SeriesService {
+	return &seriesService{
+		repo:    repo,
+		intrSvc: intrSvc,
+		logger:  logger,
+	}
+}
+
+func (svc *seriesService) Create(ctx context.Context, s domain.Series) (int64, error) {
+	if err := validateSeries(s); err != nil {
+		return 0, err
+	}
+	return svc.repo.Create(ctx, s)
+}
+
+func (svc *seriesService) Update(ctx context.Context, s domain.Series) error {
+	if err := validateSeries(s); err != nil {
+		return err
+	}
+	return svc.repo.Update(ctx, s)
+}
+
+func (svc *seriesService) Delete(ctx context.Context, id, authorId int64) error {
+	return svc.repo.Delete(ctx, id, authorId)
+}
+
+func (svc *seriesService) GetSeries(ctx context.Context, id int64) (domain.Series, error) {
+	s, err := svc.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	atcls, err := svc.repo.ListArticles(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	s.Articles = svc.withCounts(ctx, atcls)
+	return s, nil
+}
+
+func (svc *seriesService) ListSeries(ctx context.Context, authorId int64) ([]domain.Series, error) {
+	return svc.repo.ListByAuthor(ctx, authorId)
+}
+
+func (svc *seriesService) AddArticle(ctx context.Context, id, authorId, articleId int64) error {
+	return svc.repo.AddArticle(ctx, id, authorId, articleId)
+}
+
+func (svc *seriesService) RemoveArticle(ctx context.Context, id, authorId, articleId int64) error {
+	return svc.repo.RemoveArticle(ctx, id, authorId, articleId)
+}
+
+func (svc *seriesService) Reorder(ctx context.Context, id, authorId int64, articleIds []int64) error {
+	return svc.repo.Reorder(ctx, id, authorId, articleIds)
+}
+
+func (svc *seriesService) GetNav(ctx context.Context, articleId int64) (domain.SeriesNav, error) {
+	s, err := svc.repo.GetByArticle(ctx, articleId)
+	if errors.Is(err, ErrSeriesNotFound) {
+		return domain.SeriesNav{}, nil
+	}
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	atcls, err := svc.repo.ListArticles(ctx, s.Id)
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	nav := domain.SeriesNav{
+		SeriesId:    s.Id,
+		SeriesTitle: s.Title,
+	}
+	for i, atcl := range atcls {
+		if atcl.Id != articleId {
+			continue
+		}
+		if i > 0 {
+			nav.Prev = atcls[i-1]
+		}
+		if i+1 < len(atcls) {
+			nav.Next = atcls[i+1]
+		}
+		break
+	}
+	return nav, nil
+}
+
+// withCounts fills the interactive counts of the articles,
+// the articles are returned without counts if the interactive service fails.
+func (svc *seriesService) withCounts(ctx context.Context, atcls []domain.SeriesArticle) []domain.SeriesArticle {
+	if len(atcls) == 0 {
+		return atcls
+	}
+	resp, err := svc.intrSvc.GetByIds(ctx, &interactivev1.GetByIdsRequest{
+		Biz: "article",
+		BizIds: slice.Map[domain.SeriesArticle, int64](atcls, func(idx int, src domain.SeriesArticle) int64 {
+			return src.Id
+		}),
+	})
+	if err != nil {
+		svc.logger.Error("get interactives of series articles failed", logger.Error(err))
+		return atcls
+	}
+	for i := range atcls {
+		intr, ok := resp.GetInteractives()[atcls[i].Id]
+		if !ok {
+			continue
+		}
+		atcls[i].ReadCnt = intr.GetReadCnt()
+		atcls[i].LikeCnt = intr.GetLikeCnt()
+		atcls[i].FavoriteCnt = intr.GetFavoriteCnt()
+	}
+	return atcls
+}
+
+func validateSeries(s domain.Series) error {
+	n := utf8.RuneCountInString(s.Title)
+	if n == 0 || n > maxSeriesTitleLen || utf8.RuneCountInString(s.Description) > maxSeriesDescriptionLen {
+		return ErrInvalidSeries
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func (svc *articleService) Delete(ctx context.Context, id, uid int64) error {
+	return svc.articleRepo.Trash(ctx, id, uid)
+}
+
+func (svc *articleService) Restore(ctx context.Context, id, uid int64) error {
+	return svc.articleRepo.Restore(ctx, id, uid, time.Now().Add(-svc.trashRetention))
+}
+
+func (svc *articleService) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	return svc.articleRepo.ListTrash(ctx, uid, offset, limit)
+}
+
+func (svc *articleService) PurgeTrashed(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	before := now.Add(-svc.trashRetention)
+	cnt := 0
+	for {
+		atcls, err := svc.articleRepo.ListTrashedBefore(ctx, before, batchSize)
+		if err != nil {
+			return cnt, err
+		}
+		var failed error
+		for _, atcl := range atcls {
+			err = svc.articleRepo.Purge(ctx, atcl.Id, before)
+			switch {
+			case err == nil:
+				cnt++
+			case errors.Is(err, ErrArticleNotTrashed):
+				// purged by another executor or restored by the owner
+			default:
+				svc.logger.Error("purge trashed article failed",
+					logger.Int64("aid", atcl.Id),
+					logger.Error(err))
+				failed = err
+			}
+		}
+		// leave the failed ones to the next execution
+		if failed != nil {
+			return cnt, failed
+		}
+		if len(atcls) < batchSize {
+			return cnt, nil
+		}
+	}
+}
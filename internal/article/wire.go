@@ -12,34 +12,55 @@ import (
 	"github.com/tsukiyo/mercury/internal/article/repository/cache"
 	"github.com/tsukiyo/mercury/internal/article/repository/dao"
 	"github.com/tsukiyo/mercury/internal/article/service"
+	crontaskRepo "github.com/tsukiyo/mercury/internal/crontask/repository"
+	crontaskDao "github.com/tsukiyo/mercury/internal/crontask/repository/dao"
+	crontaskSvc "github.com/tsukiyo/mercury/internal/crontask/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 var thirdProviderSet = wire.NewSet(
 	ioc.InitLogger,
 	ioc.InitDB,
 	ioc.InitRedis,
+	ioc.InitInvalidator,
+	ioc.InitRLockClient,
 	ioc.InitKafka,
 	ioc.NewSyncProducer,
+	ioc.InitOutboxRelay,
 	ioc.InitEtcdClient,
 	ioc.InitUserRpcClient,
+	ioc.InitInteractiveRpcClient,
+	cursorx.InitCodec,
+	ioc.InitContentFilter,
 )
 
 var svcProviderSet = wire.NewSet(
 	grpc.NewArticleServiceServer,
 	events.NewSaramaSyncProducer,
-	service.NewArticleService,
+	ioc.InitArticleService,
 	repository.NewCachedArticleRepository,
 	dao.NewGORMArticleDAO,
 	cache.NewRedisArticleCache,
+	service.NewSeriesService,
+	repository.NewSeriesRepository,
+	dao.NewGORMSeriesDAO,
+)
+
+var cronProviderSet = wire.NewSet(
+	crontaskSvc.NewTaskService,
+	crontaskRepo.NewPreemptTaskRepository,
+	crontaskDao.NewGORMTaskDAO,
+	ioc.InitScheduler,
 )
 
 func InitAPP() *app.App {
 	wire.Build(
 		thirdProviderSet,
 		svcProviderSet,
+		cronProviderSet,
 		ioc.InitGRPCxServer,
-		wire.Struct(new(app.App), "GRPCServer"),
+		wire.Struct(new(app.App), "GRPCServer", "Scheduler", "OutboxRelay"),
 	)
 	return new(app.App)
 }
@@ -8,7 +8,6 @@ package main
 
 import (
 	"github.com/google/wire"
-
 	"github.com/tsukiyo/mercury/internal/article/events"
 	"github.com/tsukiyo/mercury/internal/article/grpc"
 	"github.com/tsukiyo/mercury/internal/article/ioc"
@@ -16,7 +15,11 @@ import (
 	"github.com/tsukiyo/mercury/internal/article/repository/cache"
 	"github.com/tsukiyo/mercury/internal/article/repository/dao"
 	"github.com/tsukiyo/mercury/internal/article/service"
+	repository2 "github.com/tsukiyo/mercury/internal/crontask/repository"
+	dao2 "github.com/tsukiyo/mercury/internal/crontask/repository/dao"
+	service2 "github.com/tsukiyo/mercury/internal/crontask/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 // Injectors from wire.go:
@@ -26,24 +29,41 @@ func InitAPP() *app.App {
 	db := ioc.InitDB(logger)
 	articleDAO := dao.NewGORMArticleDAO(db)
 	cmdable := ioc.InitRedis()
-	articleCache := cache.NewRedisArticleCache(cmdable)
+	invalidator := ioc.InitInvalidator(cmdable, logger)
+	articleCache := cache.NewRedisArticleCache(cmdable, invalidator, logger)
 	articleRepository := repository.NewCachedArticleRepository(articleDAO, articleCache, logger)
 	client := ioc.InitEtcdClient()
 	userServiceClient := ioc.InitUserRpcClient(client)
 	saramaClient := ioc.InitKafka()
 	syncProducer := ioc.NewSyncProducer(saramaClient)
 	producer := events.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, userServiceClient, producer, logger)
-	articleServiceServer := grpc.NewArticleServiceServer(articleService)
+	codec := cursorx.InitCodec()
+	contentFilter := ioc.InitContentFilter(logger)
+	articleService := ioc.InitArticleService(articleRepository, userServiceClient, producer, codec, contentFilter, logger)
+	seriesDAO := dao.NewGORMSeriesDAO(db)
+	seriesRepository := repository.NewSeriesRepository(seriesDAO, articleCache, logger)
+	interactiveServiceClient := ioc.InitInteractiveRpcClient(client)
+	seriesService := service.NewSeriesService(seriesRepository, interactiveServiceClient, logger)
+	articleServiceServer := grpc.NewArticleServiceServer(articleService, seriesService)
 	server := ioc.InitGRPCxServer(articleServiceServer, logger)
+	taskDAO := dao2.NewGORMTaskDAO(db)
+	taskRepository := repository2.NewPreemptTaskRepository(taskDAO)
+	taskService := service2.NewTaskService(taskRepository, logger)
+	scheduler := ioc.InitScheduler(taskService, articleService, logger)
+	rlockClient := ioc.InitRLockClient(cmdable)
+	relay := ioc.InitOutboxRelay(db, syncProducer, rlockClient, logger)
 	appApp := &app.App{
-		GRPCServer: server,
+		GRPCServer:  server,
+		Scheduler:   scheduler,
+		OutboxRelay: relay,
 	}
 	return appApp
 }
 
 // wire.go:
 
-var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitDB, ioc.InitRedis, ioc.InitKafka, ioc.NewSyncProducer, ioc.InitEtcdClient, ioc.InitUserRpcClient)
+var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitDB, ioc.InitRedis, ioc.InitInvalidator, ioc.InitRLockClient, ioc.InitKafka, ioc.NewSyncProducer, ioc.InitOutboxRelay, ioc.InitEtcdClient, ioc.InitUserRpcClient, ioc.InitInteractiveRpcClient, cursorx.InitCodec, ioc.InitContentFilter)
+
+var svcProviderSet = wire.NewSet(grpc.NewArticleServiceServer, events.NewSaramaSyncProducer, ioc.InitArticleService, repository.NewCachedArticleRepository, dao.NewGORMArticleDAO, cache.NewRedisArticleCache, service.NewSeriesService, repository.NewSeriesRepository, dao.NewGORMSeriesDAO)
 
-var svcProviderSet = wire.NewSet(grpc.NewArticleServiceServer, events.NewSaramaSyncProducer, service.NewArticleService, repository.NewCachedArticleRepository, dao.NewGORMArticleDAO, cache.NewRedisArticleCache)
+var cronProviderSet = wire.NewSet(service2.NewTaskService, repository2.NewPreemptTaskRepository, dao2.NewGORMTaskDAO, ioc.InitScheduler)
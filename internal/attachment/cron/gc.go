@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/attachment/service"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var _ cronx.Task = (*GCJob)(nil)
+
+// GCJob deletes the files no longer referenced from published articles.
+// Files are deleted under row locks, instances running it at the same time are safe.
+type GCJob struct {
+	svc     service.AttachmentService
+	timeout time.Duration
+	l       logger.Logger
+}
+
+func NewGCJob(svc service.AttachmentService, timeout time.Duration, l logger.Logger) *GCJob {
+	return &GCJob{
+		svc:     svc,
+		timeout: timeout,
+		l:       l,
+	}
+}
+
+func (job *GCJob) Name() string {
+	return "attachment_gc"
+}
+
+func (job *GCJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), job.timeout)
+	defer cancel()
+	cnt, err := job.svc.CollectGarbage(ctx)
+	if err != nil {
+		return err
+	}
+	job.l.Info("attachment files collected", logger.Int("count", cnt))
+	return nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+)
+
+type AttachmentStatus uint8
+
+const (
+	AttachmentStatusUnknown AttachmentStatus = iota
+	// AttachmentStatusPending waiting for the file to be uploaded
+	AttachmentStatusPending
+	AttachmentStatusUploaded
+)
+
+func (status AttachmentStatus) ToUint8() uint8 {
+	return uint8(status)
+}
+
+// Attachment is a file uploaded by a user.
+// Files are deduplicated by their hash, attachments of the same content share one stored object.
+type Attachment struct {
+	Id  int64
+	Uid int64
+	// FileId is the stored file shared by attachments of the same hash
+	FileId int64
+	// Name is the original file name
+	Name        string
+	ContentType string
+	Size        int64
+	// SHA256 is hex encoded
+	SHA256 string
+	Status AttachmentStatus
+	// Key of the stored object
+	Key   string
+	Ctime time.Time
+	Utime time.Time
+}
+
+// SignedRequest is a pre-signed request to the object storage.
+type SignedRequest struct {
+	Method   string
+	URL      string
+	Header   map[string]string
+	ExpireAt time.Time
+}
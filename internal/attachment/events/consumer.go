@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/internal/attachment/service"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+const (
+	topicPublishEvent  = "article_publish_event"
+	topicWithdrawEvent = "article_withdraw_event"
+)
+
+type PublishEvent struct {
+	Aid     int64
+	Content string
+	Utime   int64
+}
+
+type WithdrawEvent struct {
+	Aid   int64
+	Utime int64
+}
+
+var _ Consumer = (*ArticleEventConsumer)(nil)
+
+// ArticleEventConsumer tracks the attachments linked from published articles,
+// attachments no article links to are collected after a grace period.
+type ArticleEventConsumer struct {
+	client sarama.Client
+	svc    service.AttachmentService
+	l      logger.Logger
+}
+
+func NewArticleEventConsumer(client sarama.Client, svc service.AttachmentService, l logger.Logger) *ArticleEventConsumer {
+	return &ArticleEventConsumer{
+		client: client,
+		svc:    svc,
+		l:      l,
+	}
+}
+
+func (consumer *ArticleEventConsumer) Start() error {
+	pub, err := sarama.NewConsumerGroupFromClient("attachment_publish", consumer.client)
+	if err != nil {
+		return err
+	}
+	withdraw, err := sarama.NewConsumerGroupFromClient("attachment_withdraw", consumer.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := pub.Consume(context.Background(),
+			[]string{topicPublishEvent},
+			saramax.NewHandler[PublishEvent](consumer.l, consumer.ConsumePublish),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+	go func() {
+		err := withdraw.Consume(context.Background(),
+			[]string{topicWithdrawEvent},
+			saramax.NewHandler[WithdrawEvent](consumer.l, consumer.ConsumeWithdraw),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (consumer *ArticleEventConsumer) ConsumePublish(msg *sarama.ConsumerMessage, evt PublishEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return consumer.svc.UpdateRefs(ctx, evt.Aid, evt.Content, time.UnixMilli(evt.Utime))
+}
+
+func (consumer *ArticleEventConsumer) ConsumeWithdraw(msg *sarama.ConsumerMessage, evt WithdrawEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return consumer.svc.RemoveRefs(ctx, evt.Aid, time.UnixMilli(evt.Utime))
+}
@@ -0,0 +1,5 @@
+package events
+
+type Consumer interface {
+	Start() error
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	attachmentv1 "github.com/tsukiyo/mercury/api/gen/attachment/v1"
+	"github.com/tsukiyo/mercury/internal/attachment/domain"
+	"github.com/tsukiyo/mercury/internal/attachment/service"
+)
+
+type AttachmentServiceServer struct {
+	attachmentv1.UnimplementedAttachmentServiceServer
+
+	svc service.AttachmentService
+}
+
+func NewAttachmentServiceServer(svc service.AttachmentService) *AttachmentServiceServer {
+	return &AttachmentServiceServer{
+		svc: svc,
+	}
+}
+
+func (s *AttachmentServiceServer) Register(server grpc.ServiceRegistrar) {
+	attachmentv1.RegisterAttachmentServiceServer(server, s)
+}
+
+func (s *AttachmentServiceServer) CreateUpload(ctx context.Context, req *attachmentv1.CreateUploadRequest) (*attachmentv1.CreateUploadResponse, error) {
+	atch, upload, err := s.svc.CreateUpload(ctx, domain.Attachment{
+		Uid:         req.GetUid(),
+		Name:        req.GetName(),
+		ContentType: req.GetContentType(),
+		Size:        req.GetSize(),
+		SHA256:      req.GetSha256(),
+	})
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	res := &attachmentv1.CreateUploadResponse{Attachment: s.convertToV(atch)}
+	if upload != nil {
+		res.Upload = &attachmentv1.SignedRequest{
+			Method:   upload.Method,
+			Url:      upload.URL,
+			Headers:  upload.Header,
+			ExpireAt: timestamppb.New(upload.ExpireAt),
+		}
+	}
+	return res, nil
+}
+
+func (s *AttachmentServiceServer) CompleteUpload(ctx context.Context, req *attachmentv1.CompleteUploadRequest) (*attachmentv1.CompleteUploadResponse, error) {
+	atch, err := s.svc.CompleteUpload(ctx, req.GetId(), req.GetUid())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return &attachmentv1.CompleteUploadResponse{Attachment: s.convertToV(atch)}, nil
+}
+
+func (s *AttachmentServiceServer) GetDownloadURL(ctx context.Context, req *attachmentv1.GetDownloadURLRequest) (*attachmentv1.GetDownloadURLResponse, error) {
+	download, err := s.svc.GetDownloadURL(ctx, req.GetId(), req.GetUid())
+	if err != nil {
+		return nil, s.toStatus(err)
+	}
+	return &attachmentv1.GetDownloadURLResponse{
+		Url:      download.URL,
+		ExpireAt: timestamppb.New(download.ExpireAt),
+	}, nil
+}
+
+func (s *AttachmentServiceServer) toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnsupportedType), errors.Is(err, service.ErrFileTooLarge),
+		errors.Is(err, service.ErrInvalidHash), errors.Is(err, service.ErrContentMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrNotUploaded):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		return status.Error(codes.NotFound, "attachment not found")
+	case errors.Is(err, service.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return err
+	}
+}
+
+func (s *AttachmentServiceServer) convertToV(atch domain.Attachment) *attachmentv1.Attachment {
+	return &attachmentv1.Attachment{
+		Id:          atch.Id,
+		Uid:         atch.Uid,
+		Name:        atch.Name,
+		ContentType: atch.ContentType,
+		Size:        atch.Size,
+		Sha256:      atch.SHA256,
+		Status:      attachmentv1.AttachmentStatus(atch.Status),
+		Ctime:       timestamppb.New(atch.Ctime),
+	}
+}
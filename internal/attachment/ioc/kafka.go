@@ -0,0 +1,31 @@
+package ioc
+
+import (
+	"github.com/IBM/sarama"
+	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/internal/attachment/events"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+func InitKafka() sarama.Client {
+	type Config struct {
+		Addrs []string `yaml:"addrs"`
+	}
+	saramaCfg := sarama.NewConfig()
+
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	client, err := sarama.NewClient(cfg.Addrs, saramaCfg)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func NewConsumers(consumer *events.ArticleEventConsumer) []saramax.Consumer {
+	return []saramax.Consumer{consumer}
+}
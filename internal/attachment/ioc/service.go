@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/internal/attachment/repository"
+	"github.com/tsukiyo/mercury/internal/attachment/repository/storage"
+	"github.com/tsukiyo/mercury/internal/attachment/service"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func InitAttachmentService(repo repository.AttachmentRepository, store storage.ObjectStore, l logger.Logger) service.AttachmentService {
+	type Config struct {
+		// MaxSizes maps the allowed content types to their size limits in bytes
+		MaxSizes        map[string]int64 `yaml:"maxSizes"`
+		UploadExpires   time.Duration    `yaml:"uploadExpires"`
+		DownloadExpires time.Duration    `yaml:"downloadExpires"`
+		GracePeriod     time.Duration    `yaml:"gracePeriod"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("attachment", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewAttachmentService(repo, store, service.Config{
+		MaxSizes:        cfg.MaxSizes,
+		UploadExpires:   cfg.UploadExpires,
+		DownloadExpires: cfg.DownloadExpires,
+		GracePeriod:     cfg.GracePeriod,
+	}, l)
+}
@@ -0,0 +1,103 @@
+package ioc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/internal/attachment/repository/storage"
+	"github.com/tsukiyo/mercury/pkg/ginx"
+)
+
+// InitLocalStore returns nil unless storage.backend is local.
+func InitLocalStore() *storage.LocalStore {
+	type Config struct {
+		Backend string `yaml:"backend"`
+		Local   struct {
+			Root    string `yaml:"root"`
+			BaseURL string `yaml:"baseURL"`
+			Key     string `yaml:"key"`
+		} `yaml:"local"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("storage", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.Backend != "local" {
+		return nil
+	}
+	store, err := storage.NewLocalStore(cfg.Local.Root, cfg.Local.BaseURL, []byte(cfg.Local.Key))
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+func InitObjectStore(local *storage.LocalStore) storage.ObjectStore {
+	if local != nil {
+		return local
+	}
+	type Config struct {
+		S3 struct {
+			Region    string `yaml:"region"`
+			Bucket    string `yaml:"bucket"`
+			AccessKey string `yaml:"accessKey"`
+			SecretKey string `yaml:"secretKey"`
+			// Endpoint is set for S3 compatible storages, such as minio
+			Endpoint     string `yaml:"endpoint"`
+			UsePathStyle bool   `yaml:"usePathStyle"`
+		} `yaml:"s3"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("storage", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	opts := s3.Options{
+		Region: cfg.S3.Region,
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{
+				AccessKeyID:     cfg.S3.AccessKey,
+				SecretAccessKey: cfg.S3.SecretKey,
+			}, nil
+		}),
+		UsePathStyle: cfg.S3.UsePathStyle,
+	}
+	if cfg.S3.Endpoint != "" {
+		opts.BaseEndpoint = aws.String(cfg.S3.Endpoint)
+	}
+	return storage.NewS3Store(s3.New(opts), cfg.S3.Bucket)
+}
+
+// InitWebServer serves the local store, there is nothing to serve with S3.
+func InitWebServer(local *storage.LocalStore) *ginx.Server {
+	if local == nil {
+		return nil
+	}
+	type Config struct {
+		Addr    string `yaml:"addr"`
+		BaseURL string `yaml:"baseURL"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("storage.local", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		panic(err)
+	}
+	prefix := u.Path
+	engine := gin.Default()
+	engine.Any(prefix+"/*key", gin.WrapH(http.StripPrefix(prefix, local)))
+	return &ginx.Server{
+		Addr:   cfg.Addr,
+		Engine: engine,
+	}
+}
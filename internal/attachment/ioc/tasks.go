@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+
+	cron2 "github.com/tsukiyo/mercury/internal/attachment/cron"
+	"github.com/tsukiyo/mercury/internal/attachment/service"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func InitGCJob(svc service.AttachmentService, l logger.Logger) *cron2.GCJob {
+	return cron2.NewGCJob(svc, time.Minute*30, l)
+}
+
+func InitTasks(l logger.Logger, gc *cron2.GCJob) *cron.Cron {
+	croj := cron.New(cron.WithSeconds())
+	bdr := cronx.NewCronJobBuilder(prometheus.SummaryOpts{
+		Namespace: "lazywoo",
+		Subsystem: "mercury",
+		Name:      "cron_job",
+		Help:      "metrics cron job",
+	}, l)
+	// every day at 3 am
+	_, err := croj.AddJob("0 0 3 * * ?", bdr.Build(gc))
+	if err != nil {
+		panic(err)
+	}
+	return croj
+}
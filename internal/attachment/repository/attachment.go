@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/attachment/domain"
+	"github.com/tsukiyo/mercury/internal/attachment/repository/dao"
+)
+
+var ErrAttachmentNotFound = dao.ErrAttachmentNotFound
+
+type AttachmentRepository interface {
+	// Create stores the attachment, the file is shared with the attachments of the same hash.
+	// The returned attachment carries the status and key of the stored file.
+	Create(ctx context.Context, atch domain.Attachment) (domain.Attachment, error)
+	GetById(ctx context.Context, id int64) (domain.Attachment, error)
+	// MarkUploaded marks the file of the attachment uploaded, returns false if it is not pending.
+	MarkUploaded(ctx context.Context, atch domain.Attachment) (bool, error)
+	ReplaceRefs(ctx context.Context, articleId int64, version time.Time, attachmentIds []int64) error
+	// IsReferenced reports whether a published article links to the attachment.
+	IsReferenced(ctx context.Context, id int64) (bool, error)
+	DeleteUnreferenced(ctx context.Context, before time.Time, limit int) (int64, error)
+	DeletePendingBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	// FindOrphanFiles returns the keys of files no attachment points to by file id.
+	FindOrphanFiles(ctx context.Context, limit int) (map[int64]string, error)
+	DeleteOrphanFile(ctx context.Context, fileId int64) (bool, error)
+}
+
+var _ AttachmentRepository = (*attachmentRepository)(nil)
+
+type attachmentRepository struct {
+	dao dao.AttachmentDAO
+}
+
+func NewAttachmentRepository(dao dao.AttachmentDAO) AttachmentRepository {
+	return &attachmentRepository{dao: dao}
+}
+
+func (repo *attachmentRepository) Create(ctx context.Context, atch domain.Attachment) (domain.Attachment, error) {
+	entity, file := repo.toEntity(atch)
+	entity, file, err := repo.dao.Create(ctx, entity, file)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return repo.toDomain(entity, file), nil
+}
+
+func (repo *attachmentRepository) GetById(ctx context.Context, id int64) (domain.Attachment, error) {
+	atch, file, err := repo.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return repo.toDomain(atch, file), nil
+}
+
+func (repo *attachmentRepository) MarkUploaded(ctx context.Context, atch domain.Attachment) (bool, error) {
+	return repo.dao.SetFileStatus(ctx, atch.FileId,
+		domain.AttachmentStatusPending.ToUint8(), domain.AttachmentStatusUploaded.ToUint8())
+}
+
+func (repo *attachmentRepository) IsReferenced(ctx context.Context, id int64) (bool, error) {
+	return repo.dao.IsReferenced(ctx, id)
+}
+
+func (repo *attachmentRepository) ReplaceRefs(ctx context.Context, articleId int64, version time.Time, attachmentIds []int64) error {
+	return repo.dao.ReplaceRefs(ctx, articleId, version.UnixMilli(), attachmentIds)
+}
+
+func (repo *attachmentRepository) DeleteUnreferenced(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return repo.dao.DeleteUnreferenced(ctx, before.UnixMilli(), limit)
+}
+
+func (repo *attachmentRepository) DeletePendingBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return repo.dao.DeletePendingBefore(ctx, before.UnixMilli(), limit)
+}
+
+func (repo *attachmentRepository) FindOrphanFiles(ctx context.Context, limit int) (map[int64]string, error) {
+	files, err := repo.dao.FindOrphanFiles(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]string, len(files))
+	for _, file := range files {
+		res[file.Id] = file.Key
+	}
+	return res, nil
+}
+
+func (repo *attachmentRepository) DeleteOrphanFile(ctx context.Context, fileId int64) (bool, error) {
+	return repo.dao.DeleteOrphanFile(ctx, fileId)
+}
+
+func (repo *attachmentRepository) toEntity(atch domain.Attachment) (dao.Attachment, dao.File) {
+	return dao.Attachment{
+			Id:     atch.Id,
+			Uid:    atch.Uid,
+			FileId: atch.FileId,
+			Name:   atch.Name,
+		}, dao.File{
+			Id:          atch.FileId,
+			SHA256:      atch.SHA256,
+			Key:         atch.Key,
+			ContentType: atch.ContentType,
+			Size:        atch.Size,
+			Status:      atch.Status.ToUint8(),
+		}
+}
+
+func (repo *attachmentRepository) toDomain(atch dao.Attachment, file dao.File) domain.Attachment {
+	return domain.Attachment{
+		Id:          atch.Id,
+		Uid:         atch.Uid,
+		FileId:      file.Id,
+		Name:        atch.Name,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		SHA256:      file.SHA256,
+		Status:      domain.AttachmentStatus(file.Status),
+		Key:         file.Key,
+		Ctime:       time.UnixMilli(atch.Ctime),
+		Utime:       time.UnixMilli(atch.Utime),
+	}
+}
@@ -0,0 +1,220 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/attachment/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrAttachmentNotFound = gorm.ErrRecordNotFound
+
+var statusPending = domain.AttachmentStatusPending.ToUint8()
+
+type AttachmentDAO interface {
+	// Create inserts the attachment, file is inserted as well unless a file of the same hash exists,
+	// the stored file is returned.
+	Create(ctx context.Context, atch Attachment, file File) (Attachment, File, error)
+	GetById(ctx context.Context, id int64) (Attachment, File, error)
+	// SetFileStatus moves the file from the status from to the status to, returns false if it is not in from.
+	SetFileStatus(ctx context.Context, fileId int64, from, to uint8) (bool, error)
+	// ReplaceRefs sets the attachments referenced by the article,
+	// nothing changes if a later version of the article has been applied.
+	ReplaceRefs(ctx context.Context, articleId, version int64, attachmentIds []int64) error
+	// IsReferenced reports whether a published article links to the attachment.
+	IsReferenced(ctx context.Context, attachmentId int64) (bool, error)
+	// DeleteUnreferenced deletes at most limit attachments created before ctime that are not referenced,
+	// returns the number deleted.
+	DeleteUnreferenced(ctx context.Context, ctime int64, limit int) (int64, error)
+	// DeletePendingBefore deletes at most limit attachments of files still pending since utime.
+	DeletePendingBefore(ctx context.Context, utime int64, limit int) (int64, error)
+	// FindOrphanFiles returns the files no attachment points to.
+	FindOrphanFiles(ctx context.Context, limit int) ([]File, error)
+	// DeleteOrphanFile deletes the file unless an attachment points to it again, returns whether it is deleted.
+	DeleteOrphanFile(ctx context.Context, id int64) (bool, error)
+}
+
+var _ AttachmentDAO = (*GORMAttachmentDAO)(nil)
+
+type GORMAttachmentDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMAttachmentDAO(db *gorm.DB) AttachmentDAO {
+	return &GORMAttachmentDAO{db: db}
+}
+
+func (dao *GORMAttachmentDAO) Create(ctx context.Context, atch Attachment, file File) (Attachment, File, error) {
+	now := time.Now().UnixMilli()
+	atch.Ctime, atch.Utime = now, now
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var files []File
+		// the garbage collector deletes a file under the same lock
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("sha256 = ?", file.SHA256).
+			Limit(1).
+			Find(&files).Error
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			file = files[0]
+		} else {
+			file.Ctime, file.Utime = now, now
+			if err = tx.Create(&file).Error; err != nil {
+				return err
+			}
+		}
+		atch.FileId = file.Id
+		return tx.Create(&atch).Error
+	})
+	return atch, file, err
+}
+
+func (dao *GORMAttachmentDAO) GetById(ctx context.Context, id int64) (Attachment, File, error) {
+	var (
+		atch Attachment
+		file File
+	)
+	db := dao.db.WithContext(ctx)
+	if err := db.Where("id = ?", id).First(&atch).Error; err != nil {
+		return atch, file, err
+	}
+	err := db.Where("id = ?", atch.FileId).First(&file).Error
+	return atch, file, err
+}
+
+func (dao *GORMAttachmentDAO) SetFileStatus(ctx context.Context, fileId int64, from, to uint8) (bool, error) {
+	res := dao.db.WithContext(ctx).Model(&File{}).
+		Where("id = ? AND status = ?", fileId, from).
+		Updates(map[string]any{
+			"status": to,
+			"utime":  time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (dao *GORMAttachmentDAO) ReplaceRefs(ctx context.Context, articleId, version int64, attachmentIds []int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ArticleVersion{ArticleId: articleId}).Error
+		if err != nil {
+			return err
+		}
+		var ver ArticleVersion
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("article_id = ?", articleId).
+			First(&ver).Error
+		if err != nil {
+			return err
+		}
+		if ver.Utime > version {
+			return nil
+		}
+		err = tx.Model(&ArticleVersion{}).
+			Where("article_id = ?", articleId).
+			Update("utime", version).Error
+		if err != nil {
+			return err
+		}
+		if err = tx.Where("article_id = ?", articleId).Delete(&AttachmentRef{}).Error; err != nil {
+			return err
+		}
+		if len(attachmentIds) == 0 {
+			return nil
+		}
+		refs := make([]AttachmentRef, 0, len(attachmentIds))
+		for _, id := range attachmentIds {
+			refs = append(refs, AttachmentRef{ArticleId: articleId, AttachmentId: id, Ctime: now})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&refs).Error
+	})
+}
+
+func (dao *GORMAttachmentDAO) IsReferenced(ctx context.Context, attachmentId int64) (bool, error) {
+	var refs []AttachmentRef
+	err := dao.db.WithContext(ctx).
+		Select("id").
+		Where("attachment_id = ?", attachmentId).
+		Limit(1).
+		Find(&refs).Error
+	return len(refs) > 0, err
+}
+
+func (dao *GORMAttachmentDAO) DeleteUnreferenced(ctx context.Context, ctime int64, limit int) (int64, error) {
+	db := dao.db.WithContext(ctx)
+	var ids []int64
+	err := db.Model(&Attachment{}).
+		Where("ctime < ?", ctime).
+		Where("NOT EXISTS (?)", db.Model(&AttachmentRef{}).
+			Select("1").
+			Where("attachment_refs.attachment_id = attachments.id")).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	// checked again, the article might have been published in the meantime
+	res := db.Where("id IN ?", ids).
+		Where("NOT EXISTS (?)", db.Model(&AttachmentRef{}).
+			Select("1").
+			Where("attachment_refs.attachment_id = attachments.id")).
+		Delete(&Attachment{})
+	return res.RowsAffected, res.Error
+}
+
+func (dao *GORMAttachmentDAO) DeletePendingBefore(ctx context.Context, utime int64, limit int) (int64, error) {
+	db := dao.db.WithContext(ctx)
+	var ids []int64
+	err := db.Model(&Attachment{}).
+		Joins("JOIN files ON files.id = attachments.file_id").
+		Where("files.status = ? AND files.utime < ?", statusPending, utime).
+		Order("attachments.id").
+		Limit(limit).
+		Pluck("attachments.id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	res := db.Where("id IN ?", ids).Delete(&Attachment{})
+	return res.RowsAffected, res.Error
+}
+
+func (dao *GORMAttachmentDAO) FindOrphanFiles(ctx context.Context, limit int) ([]File, error) {
+	db := dao.db.WithContext(ctx)
+	var files []File
+	err := db.Model(&File{}).
+		Where("NOT EXISTS (?)", db.Model(&Attachment{}).
+			Select("1").
+			Where("attachments.file_id = files.id")).
+		Order("id").
+		Limit(limit).
+		Find(&files).Error
+	return files, err
+}
+
+func (dao *GORMAttachmentDAO) DeleteOrphanFile(ctx context.Context, id int64) (bool, error) {
+	deleted := false
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var files []File
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			Limit(1).
+			Find(&files).Error
+		if err != nil || len(files) == 0 {
+			return err
+		}
+		var cnt int64
+		err = tx.Model(&Attachment{}).Where("file_id = ?", id).Count(&cnt).Error
+		if err != nil || cnt > 0 {
+			return err
+		}
+		res := tx.Where("id = ?", id).Delete(&File{})
+		deleted = res.RowsAffected > 0
+		return res.Error
+	})
+	return deleted, err
+}
@@ -0,0 +1,39 @@
+package dao
+
+// File is a stored object, files are deduplicated by their hash.
+type File struct {
+	Id     int64  `gorm:"primaryKey;autoIncrement"`
+	SHA256 string `gorm:"type:char(64);uniqueIndex"`
+	// Key is unique per file, a file collected and uploaded again gets another key
+	Key         string `gorm:"type:varchar(256)"`
+	ContentType string `gorm:"type:varchar(128)"`
+	Size        int64
+	Status      uint8
+	Ctime       int64
+	Utime       int64
+}
+
+// Attachment is an upload of a file by a user.
+type Attachment struct {
+	Id     int64  `gorm:"primaryKey;autoIncrement"`
+	Uid    int64  `gorm:"index"`
+	FileId int64  `gorm:"index"`
+	Name   string `gorm:"type:varchar(256)"`
+	Ctime  int64  `gorm:"index"`
+	Utime  int64
+}
+
+// AttachmentRef records an attachment referenced by a published article.
+type AttachmentRef struct {
+	Id           int64 `gorm:"primaryKey;autoIncrement"`
+	ArticleId    int64 `gorm:"uniqueIndex:idx_article_attachment"`
+	AttachmentId int64 `gorm:"uniqueIndex:idx_article_attachment;index"`
+	Ctime        int64
+}
+
+// ArticleVersion is the utime of the latest event applied to the references of an article,
+// events of the same article arrive by different topics so older ones are skipped.
+type ArticleVersion struct {
+	ArticleId int64 `gorm:"primaryKey;autoIncrement:false"`
+	Utime     int64
+}
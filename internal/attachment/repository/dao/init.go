@@ -0,0 +1,14 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+)
+
+func InitTable(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&File{},
+		&Attachment{},
+		&AttachmentRef{},
+		&ArticleVersion{},
+	)
+}
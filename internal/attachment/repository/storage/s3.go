@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+var _ ObjectStore = (*S3Store)(nil)
+
+type S3Store struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	bucket  string
+}
+
+func NewS3Store(client *s3.Client, bucket string) *S3Store {
+	return &S3Store{
+		client:  client,
+		presign: s3.NewPresignClient(client),
+		bucket:  bucket,
+	}
+}
+
+// PresignPut signs the content type, size and checksum, S3 rejects an upload of another file.
+func (s *S3Store) PresignPut(ctx context.Context, key string, obj Object, expires time.Duration) (SignedRequest, error) {
+	sum, err := hex.DecodeString(obj.SHA256)
+	if err != nil {
+		return SignedRequest{}, err
+	}
+	req, err := s.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:         aws.String(s.bucket),
+		Key:            aws.String(key),
+		ContentType:    aws.String(obj.ContentType),
+		ContentLength:  aws.Int64(obj.Size),
+		ChecksumSHA256: aws.String(base64.StdEncoding.EncodeToString(sum)),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return SignedRequest{}, err
+	}
+	// set by the http client of the uploader
+	req.SignedHeader.Del("Host")
+	return SignedRequest{
+		Method:   req.Method,
+		URL:      req.URL,
+		Header:   req.SignedHeader,
+		ExpireAt: time.Now().Add(expires),
+	}, nil
+}
+
+func (s *S3Store) PresignGet(ctx context.Context, key string, expires time.Duration) (SignedRequest, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return SignedRequest{}, err
+	}
+	req.SignedHeader.Del("Host")
+	return SignedRequest{
+		Method:   req.Method,
+		URL:      req.URL,
+		Header:   req.SignedHeader,
+		ExpireAt: time.Now().Add(expires),
+	}, nil
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (Object, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return Object{}, s.convertErr(err)
+	}
+	return Object{
+		ContentType: aws.ToString(out.ContentType),
+		Size:        aws.ToInt64(out.ContentLength),
+	}, nil
+}
+
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, s.convertErr(err)
+	}
+	return out.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *S3Store) convertErr(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "NotFound", "NoSuchKey":
+			return ErrObjectNotFound
+		}
+	}
+	return err
+}
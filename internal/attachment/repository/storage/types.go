@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// Object describes an object to upload or a stored one.
+type Object struct {
+	ContentType string
+	Size        int64
+	// SHA256 is hex encoded, it is only used on upload
+	SHA256 string
+}
+
+// SignedRequest is a pre-signed request, the client sends it with Header as is.
+type SignedRequest struct {
+	Method   string
+	URL      string
+	Header   http.Header
+	ExpireAt time.Time
+}
+
+// ObjectStore keeps the uploaded files, clients talk to it directly by pre-signed requests.
+type ObjectStore interface {
+	// PresignPut signs an upload of obj to key, the store rejects an upload not matching obj.
+	PresignPut(ctx context.Context, key string, obj Object, expires time.Duration) (SignedRequest, error)
+	PresignGet(ctx context.Context, key string, expires time.Duration) (SignedRequest, error)
+	// Stat returns ErrObjectNotFound if the object has not been uploaded.
+	Stat(ctx context.Context, key string) (Object, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete succeeds if the object does not exist.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/attachment/domain"
+	"github.com/tsukiyo/mercury/internal/attachment/repository"
+	"github.com/tsukiyo/mercury/internal/attachment/repository/storage"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var (
+	ErrAttachmentNotFound = repository.ErrAttachmentNotFound
+	ErrUnsupportedType    = errors.New("the content type is not allowed")
+	ErrFileTooLarge       = errors.New("the file exceeds the size limit of its content type")
+	ErrInvalidHash        = errors.New("the sha256 must be 64 hex characters")
+	ErrNotUploaded        = errors.New("the file has not been uploaded")
+	ErrContentMismatch    = errors.New("the uploaded file does not match the declared size, hash or content type")
+	ErrPermissionDenied   = errors.New("the attachment belongs to another user")
+	hashRegexp            = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	attachmentLinkRegexp  = regexp.MustCompile(`/attachments/files/(\d+)`)
+	defaultMaxSizes       = map[string]int64{
+		"image/png":       10 << 20,
+		"image/jpeg":      10 << 20,
+		"image/gif":       10 << 20,
+		"image/webp":      10 << 20,
+		"application/pdf": 20 << 20,
+	}
+)
+
+// Config of the attachment service, zero values take the defaults.
+type Config struct {
+	// MaxSizes are the allowed content types and their size limits in bytes.
+	// SVG is not allowed by default, it can carry scripts.
+	MaxSizes map[string]int64
+	// UploadExpires is how long an upload url is valid
+	UploadExpires time.Duration
+	// DownloadExpires is how long a download url is valid
+	DownloadExpires time.Duration
+	// GracePeriod is how long an unreferenced attachment is kept,
+	// it may be used by a draft that has not been published yet.
+	GracePeriod time.Duration
+}
+
+//go:generate mockgen -source=./attachment.go -package=svcmocks -destination=mocks/attachment.mock.go AttachmentService
+type AttachmentService interface {
+	// CreateUpload returns the attachment and where to upload the file,
+	// the request is nil if a file of the same hash has been uploaded.
+	CreateUpload(ctx context.Context, atch domain.Attachment) (domain.Attachment, *domain.SignedRequest, error)
+	// CompleteUpload verifies the uploaded file against what was declared,
+	// a file that does not match is deleted and ErrContentMismatch returned.
+	CompleteUpload(ctx context.Context, id, uid int64) (domain.Attachment, error)
+	// GetDownloadURL signs the file for the uploader, or for anyone once a published article links to it,
+	// a draft may link to it before it is published.
+	GetDownloadURL(ctx context.Context, id, uid int64) (domain.SignedRequest, error)
+	// UpdateRefs records the attachments linked from the content of the published article.
+	UpdateRefs(ctx context.Context, articleId int64, content string, version time.Time) error
+	// RemoveRefs clears the references of the withdrawn article.
+	RemoveRefs(ctx context.Context, articleId int64, version time.Time) error
+	// CollectGarbage deletes attachments unreferenced for the grace period and the files no longer used,
+	// returns the number of files deleted.
+	CollectGarbage(ctx context.Context) (int, error)
+}
+
+var _ AttachmentService = (*attachmentService)(nil)
+
+type attachmentService struct {
+	repo      repository.AttachmentRepository
+	store     storage.ObjectStore
+	cfg       Config
+	l         logger.Logger
+	batchSize int
+}
+
+func NewAttachmentService(repo repository.AttachmentRepository, store storage.ObjectStore, cfg Config, l logger.Logger) AttachmentService {
+	if len(cfg.MaxSizes) == 0 {
+		cfg.MaxSizes = defaultMaxSizes
+	}
+	if cfg.UploadExpires <= 0 {
+		cfg.UploadExpires = 15 * time.Minute
+	}
+	if cfg.DownloadExpires <= 0 {
+		cfg.DownloadExpires = time.Hour
+	}
+	if cfg.GracePeriod <= 0 {
+		cfg.GracePeriod = 30 * 24 * time.Hour
+	}
+	return &attachmentService{
+		repo:      repo,
+		store:     store,
+		cfg:       cfg,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (svc *attachmentService) CreateUpload(ctx context.Context, atch domain.Attachment) (domain.Attachment, *domain.SignedRequest, error) {
+	if err := svc.check(&atch); err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	atch.Status = domain.AttachmentStatusPending
+	// unique for every stored file, a collected file uploaded again does not share the key
+	atch.Key = fmt.Sprintf("%s/%s-%d", atch.SHA256[:2], atch.SHA256, time.Now().UnixNano())
+	atch, err := svc.repo.Create(ctx, atch)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	if atch.Status == domain.AttachmentStatusUploaded {
+		return atch, nil, nil
+	}
+	req, err := svc.store.PresignPut(ctx, atch.Key, storage.Object{
+		ContentType: atch.ContentType,
+		Size:        atch.Size,
+		SHA256:      atch.SHA256,
+	}, svc.cfg.UploadExpires)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	signed := svc.toDomainRequest(req)
+	return atch, &signed, nil
+}
+
+func (svc *attachmentService) CompleteUpload(ctx context.Context, id, uid int64) (domain.Attachment, error) {
+	atch, err := svc.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if atch.Uid != uid {
+		return domain.Attachment{}, ErrPermissionDenied
+	}
+	if atch.Status == domain.AttachmentStatusUploaded {
+		return atch, nil
+	}
+	err = svc.verify(ctx, atch)
+	if errors.Is(err, ErrContentMismatch) {
+		if er := svc.store.Delete(ctx, atch.Key); er != nil {
+			svc.l.Error("failed to delete the mismatched file",
+				logger.String("key", atch.Key), logger.Error(er))
+		}
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	// another attachment of the same file may have completed it already
+	if _, err = svc.repo.MarkUploaded(ctx, atch); err != nil {
+		return domain.Attachment{}, err
+	}
+	atch.Status = domain.AttachmentStatusUploaded
+	return atch, nil
+}
+
+func (svc *attachmentService) GetDownloadURL(ctx context.Context, id, uid int64) (domain.SignedRequest, error) {
+	atch, err := svc.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.SignedRequest{}, err
+	}
+	if atch.Uid != uid {
+		referenced, er := svc.repo.IsReferenced(ctx, id)
+		if er != nil {
+			return domain.SignedRequest{}, er
+		}
+		if !referenced {
+			return domain.SignedRequest{}, ErrPermissionDenied
+		}
+	}
+	if atch.Status != domain.AttachmentStatusUploaded {
+		return domain.SignedRequest{}, ErrNotUploaded
+	}
+	req, err := svc.store.PresignGet(ctx, atch.Key, svc.cfg.DownloadExpires)
+	if err != nil {
+		return domain.SignedRequest{}, err
+	}
+	return svc.toDomainRequest(req), nil
+}
+
+func (svc *attachmentService) UpdateRefs(ctx context.Context, articleId int64, content string, version time.Time) error {
+	return svc.repo.ReplaceRefs(ctx, articleId, version, ExtractAttachmentIds(content))
+}
+
+func (svc *attachmentService) RemoveRefs(ctx context.Context, articleId int64, version time.Time) error {
+	return svc.repo.ReplaceRefs(ctx, articleId, version, nil)
+}
+
+func (svc *attachmentService) CollectGarbage(ctx context.Context) (int, error) {
+	before := time.Now().Add(-svc.cfg.GracePeriod)
+	for _, del := range []func(context.Context, time.Time, int) (int64, error){
+		svc.repo.DeleteUnreferenced,
+		svc.repo.DeletePendingBefore,
+	} {
+		for {
+			n, err := del(ctx, before, svc.batchSize)
+			if err != nil {
+				return 0, err
+			}
+			if n < int64(svc.batchSize) {
+				break
+			}
+		}
+	}
+
+	deleted := 0
+	for {
+		files, err := svc.repo.FindOrphanFiles(ctx, svc.batchSize)
+		if err != nil {
+			return deleted, err
+		}
+		for fileId, key := range files {
+			ok, err := svc.repo.DeleteOrphanFile(ctx, fileId)
+			if err != nil {
+				return deleted, err
+			}
+			if !ok {
+				continue
+			}
+			deleted++
+			// the row goes first, a failure here only leaks the object
+			if err = svc.store.Delete(ctx, key); err != nil {
+				svc.l.Error("failed to delete the object of a collected file",
+					logger.String("key", key), logger.Error(err))
+			}
+		}
+		if len(files) < svc.batchSize {
+			return deleted, nil
+		}
+	}
+}
+
+func (svc *attachmentService) check(atch *domain.Attachment) error {
+	contentType, _, err := mime.ParseMediaType(atch.ContentType)
+	if err != nil {
+		return ErrUnsupportedType
+	}
+	maxSize, ok := svc.cfg.MaxSizes[contentType]
+	if !ok {
+		return ErrUnsupportedType
+	}
+	if atch.Size <= 0 || atch.Size > maxSize {
+		return ErrFileTooLarge
+	}
+	if !hashRegexp.MatchString(atch.SHA256) {
+		return ErrInvalidHash
+	}
+	atch.ContentType = contentType
+	return nil
+}
+
+// verify checks the size and hash of the stored object, and sniffs its content type
+func (svc *attachmentService) verify(ctx context.Context, atch domain.Attachment) error {
+	obj, err := svc.store.Stat(ctx, atch.Key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return ErrNotUploaded
+	}
+	if err != nil {
+		return err
+	}
+	if obj.Size != atch.Size {
+		return ErrContentMismatch
+	}
+	rc, err := svc.store.Open(ctx, atch.Key)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	r := io.TeeReader(io.LimitReader(rc, atch.Size+1), h)
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if _, err = io.Copy(io.Discard, r); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != atch.SHA256 {
+		return ErrContentMismatch
+	}
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if sniffed != atch.ContentType {
+		return ErrContentMismatch
+	}
+	return nil
+}
+
+func (svc *attachmentService) toDomainRequest(req storage.SignedRequest) domain.SignedRequest {
+	header := make(map[string]string, len(req.Header))
+	for k := range req.Header {
+		header[k] = req.Header.Get(k)
+	}
+	return domain.SignedRequest{
+		Method:   req.Method,
+		URL:      req.URL,
+		Header:   header,
+		ExpireAt: req.ExpireAt,
+	}
+}
+
+// ExtractAttachmentIds returns the ids of attachments linked from the content without duplicates.
+func ExtractAttachmentIds(content string) []int64 {
+	matches := attachmentLinkRegexp.FindAllStringSubmatch(content, -1)
+	seen := make(map[int64]struct{}, len(matches))
+	ids := make([]int64, 0, len(matches))
+	for _, m := range matches {
+		id, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./attachment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./attachment.go -package=svcmocks -destination=mocks/attachment.mock.go AttachmentService
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/tsukiyo/mercury/internal/attachment/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttachmentService is a mock of AttachmentService interface.
+type MockAttachmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentServiceMockRecorder
+}
+
+// MockAttachmentServiceMockRecorder is the mock recorder for MockAttachmentService.
+type MockAttachmentServiceMockRecorder struct {
+	mock *MockAttachmentService
+}
+
+// NewMockAttachmentService creates a new mock instance.
+func NewMockAttachmentService(ctrl *gomock.Controller) *MockAttachmentService {
+	mock := &MockAttachmentService{ctrl: ctrl}
+	mock.recorder = &MockAttachmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentService) EXPECT() *MockAttachmentServiceMockRecorder {
+	return m.recorder
+}
+
+// CollectGarbage mocks base method.
+func (m *MockAttachmentService) CollectGarbage(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectGarbage", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectGarbage indicates an expected call of CollectGarbage.
+func (mr *MockAttachmentServiceMockRecorder) CollectGarbage(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectGarbage", reflect.TypeOf((*MockAttachmentService)(nil).CollectGarbage), ctx)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockAttachmentService) CompleteUpload(ctx context.Context, id, uid int64) (domain.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, id, uid)
+	ret0, _ := ret[0].(domain.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockAttachmentServiceMockRecorder) CompleteUpload(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockAttachmentService)(nil).CompleteUpload), ctx, id, uid)
+}
+
+// CreateUpload mocks base method.
+func (m *MockAttachmentService) CreateUpload(ctx context.Context, atch domain.Attachment) (domain.Attachment, *domain.SignedRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, atch)
+	ret0, _ := ret[0].(domain.Attachment)
+	ret1, _ := ret[1].(*domain.SignedRequest)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockAttachmentServiceMockRecorder) CreateUpload(ctx, atch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockAttachmentService)(nil).CreateUpload), ctx, atch)
+}
+
+// GetDownloadURL mocks base method.
+func (m *MockAttachmentService) GetDownloadURL(ctx context.Context, id, uid int64) (domain.SignedRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDownloadURL", ctx, id, uid)
+	ret0, _ := ret[0].(domain.SignedRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDownloadURL indicates an expected call of GetDownloadURL.
+func (mr *MockAttachmentServiceMockRecorder) GetDownloadURL(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDownloadURL", reflect.TypeOf((*MockAttachmentService)(nil).GetDownloadURL), ctx, id, uid)
+}
+
+// RemoveRefs mocks base method.
+func (m *MockAttachmentService) RemoveRefs(ctx context.Context, articleId int64, version time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRefs", ctx, articleId, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRefs indicates an expected call of RemoveRefs.
+func (mr *MockAttachmentServiceMockRecorder) RemoveRefs(ctx, articleId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRefs", reflect.TypeOf((*MockAttachmentService)(nil).RemoveRefs), ctx, articleId, version)
+}
+
+// UpdateRefs mocks base method.
+func (m *MockAttachmentService) UpdateRefs(ctx context.Context, articleId int64, content string, version time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefs", ctx, articleId, content, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRefs indicates an expected call of UpdateRefs.
+func (mr *MockAttachmentServiceMockRecorder) UpdateRefs(ctx, articleId, content, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefs", reflect.TypeOf((*MockAttachmentService)(nil).UpdateRefs), ctx, articleId, content, version)
+}
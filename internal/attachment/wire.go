@@ -0,0 +1,53 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+
+	"github.com/tsukiyo/mercury/internal/attachment/events"
+	"github.com/tsukiyo/mercury/internal/attachment/grpc"
+	"github.com/tsukiyo/mercury/internal/attachment/ioc"
+	"github.com/tsukiyo/mercury/internal/attachment/repository"
+	"github.com/tsukiyo/mercury/internal/attachment/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/app"
+)
+
+var thirdProviderSet = wire.NewSet(
+	ioc.InitLogger,
+	ioc.InitDB,
+	ioc.InitKafka,
+	ioc.InitEtcdClient,
+	ioc.InitLocalStore,
+	ioc.InitObjectStore,
+)
+
+var svcProviderSet = wire.NewSet(
+	ioc.InitAttachmentService,
+	repository.NewAttachmentRepository,
+	dao.NewGORMAttachmentDAO,
+)
+
+var eventsProviderSet = wire.NewSet(
+	events.NewArticleEventConsumer,
+	ioc.NewConsumers,
+)
+
+var cronProviderSet = wire.NewSet(
+	ioc.InitGCJob,
+	ioc.InitTasks,
+)
+
+func InitAPP() *app.App {
+	wire.Build(
+		thirdProviderSet,
+		svcProviderSet,
+		eventsProviderSet,
+		cronProviderSet,
+		grpc.NewAttachmentServiceServer,
+		ioc.InitGRPCxServer,
+		ioc.InitWebServer,
+		wire.Struct(new(app.App), "GRPCServer", "WebServer", "Consumers", "Cron"),
+	)
+	return new(app.App)
+}
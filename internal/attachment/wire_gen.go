@@ -0,0 +1,54 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/tsukiyo/mercury/internal/attachment/events"
+	"github.com/tsukiyo/mercury/internal/attachment/grpc"
+	"github.com/tsukiyo/mercury/internal/attachment/ioc"
+	"github.com/tsukiyo/mercury/internal/attachment/repository"
+	"github.com/tsukiyo/mercury/internal/attachment/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/app"
+)
+
+// Injectors from wire.go:
+
+func InitAPP() *app.App {
+	logger := ioc.InitLogger()
+	db := ioc.InitDB(logger)
+	attachmentDAO := dao.NewGORMAttachmentDAO(db)
+	attachmentRepository := repository.NewAttachmentRepository(attachmentDAO)
+	localStore := ioc.InitLocalStore()
+	objectStore := ioc.InitObjectStore(localStore)
+	attachmentService := ioc.InitAttachmentService(attachmentRepository, objectStore, logger)
+	attachmentServiceServer := grpc.NewAttachmentServiceServer(attachmentService)
+	server := ioc.InitGRPCxServer(attachmentServiceServer, logger)
+	ginxServer := ioc.InitWebServer(localStore)
+	client := ioc.InitKafka()
+	articleEventConsumer := events.NewArticleEventConsumer(client, attachmentService, logger)
+	v := ioc.NewConsumers(articleEventConsumer)
+	gcJob := ioc.InitGCJob(attachmentService, logger)
+	cron := ioc.InitTasks(logger, gcJob)
+	appApp := &app.App{
+		GRPCServer: server,
+		WebServer:  ginxServer,
+		Consumers:  v,
+		Cron:       cron,
+	}
+	return appApp
+}
+
+// wire.go:
+
+var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitDB, ioc.InitKafka, ioc.InitEtcdClient, ioc.InitLocalStore, ioc.InitObjectStore)
+
+var svcProviderSet = wire.NewSet(ioc.InitAttachmentService, repository.NewAttachmentRepository, dao.NewGORMAttachmentDAO)
+
+var eventsProviderSet = wire.NewSet(events.NewArticleEventConsumer, ioc.NewConsumers)
+
+var cronProviderSet = wire.NewSet(ioc.InitGCJob, ioc.InitTasks)
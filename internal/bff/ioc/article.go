@@ -8,9 +8,12 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	"github.com/tsukiyo/mercury/internal/bff/web/client"
+	"github.com/tsukiyo/mercury/pkg/cachex"
+	"github.com/tsukiyo/mercury/pkg/logger"
 )
 
-func InitArticleClient(etcdCli *clientv3.Client) articlev1.ArticleServiceClient {
+func InitArticleClient(etcdCli *clientv3.Client, inv *cachex.Invalidator, l logger.Logger) articlev1.ArticleServiceClient {
 	type Config struct {
 		Target string `json:"target"`
 		Secure bool   `json:"secure"`
@@ -32,5 +35,6 @@ func InitArticleClient(etcdCli *clientv3.Client) articlev1.ArticleServiceClient
 	if err != nil {
 		panic(err)
 	}
-	return articlev1.NewArticleServiceClient(cc)
+	// published articles are read far more than anything else, they are cached locally
+	return client.NewArticleClient(articlev1.NewArticleServiceClient(cc), inv, l)
 }
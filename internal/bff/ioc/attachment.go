@@ -0,0 +1,36 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	attachmentv1 "github.com/tsukiyo/mercury/api/gen/attachment/v1"
+)
+
+func InitAttachmentClient(etcdCli *clientv3.Client) attachmentv1.AttachmentServiceClient {
+	type Config struct {
+		Target string `json:"target"`
+		Secure bool   `json:"secure"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.attachment", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	rs, err := resolver.NewBuilder(etcdCli)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{grpc.WithResolvers(rs)}
+	if !cfg.Secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient(cfg.Target, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return attachmentv1.NewAttachmentServiceClient(cc)
+}
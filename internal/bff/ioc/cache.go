@@ -0,0 +1,19 @@
+package ioc
+
+import (
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tsukiyo/mercury/pkg/cachex"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// invalidationChannel is where the article service broadcasts the changes of the articles cached locally.
+const invalidationChannel = "article:cache:invalidation"
+
+func InitInvalidator(cmd redis.Cmdable, l logger.Logger) *cachex.Invalidator {
+	inv, err := cachex.StartInvalidator(cmd, invalidationChannel, l)
+	if err != nil {
+		panic(err)
+	}
+	return inv
+}
@@ -29,7 +29,7 @@ import (
 	"github.com/tsukiyo/mercury/pkg/logger"
 )
 
-func InitWebServer(limiter ratelimit.Limiter, jwtHdl jwt.Handler, userHdl *web.UserHandler, oAuth2Hdl *web.OAuth2WechatHandler, articleHdl *web.ArticleHandler, commentHdl *web.CommentHandler, logger logger.Logger) *ginx.Server {
+func InitWebServer(limiter ratelimit.Limiter, jwtHdl jwt.Handler, userHdl *web.UserHandler, oAuth2Hdl *web.OAuth2WechatHandler, articleHdl *web.ArticleHandler, commentHdl *web.CommentHandler, attachmentHdl *web.AttachmentHandler, logger logger.Logger) *ginx.Server {
 	gin.SetMode(gin.ReleaseMode)
 	gin.DefaultWriter = io.Discard
 	engine := gin.Default()
@@ -53,6 +53,7 @@ func InitWebServer(limiter ratelimit.Limiter, jwtHdl jwt.Handler, userHdl *web.U
 	oAuth2Hdl.RegisterRoutes(engine)
 	articleHdl.RegisterRoutes(engine)
 	commentHdl.RegisterRoutes(engine)
+	attachmentHdl.RegisterRoutes(engine)
 	web.NewObservabilityHandler().RegisterRoutes(engine)
 	addr := viper.GetString("http.addr")
 	ginx.InitCounterVec(prometheus.CounterOpts{
@@ -1,17 +1,22 @@
 package web
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	commentv1 "github.com/tsukiyo/mercury/api/gen/comment/v1"
 
 	interactivev1 "github.com/tsukiyo/mercury/api/gen/interactive/v1"
 
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	ijwt "github.com/tsukiyo/mercury/internal/bff/web/jwt"
 	"github.com/tsukiyo/mercury/pkg/ginx"
@@ -20,18 +25,27 @@ import (
 
 var _ handler = (*ArticleHandler)(nil)
 
+// maxTagsPerArticle keeps in line with the article service
+const maxTagsPerArticle = 10
+
 type ArticleHandler struct {
 	articleSvc articlev1.ArticleServiceClient
 	intrSvc    interactivev1.InteractiveServiceClient
+	commentSvc commentv1.CommentServiceClient
 	l          logger.Logger
 
 	biz string
 }
 
-func NewArticleHandler(articleSvc articlev1.ArticleServiceClient, intrSvc interactivev1.InteractiveServiceClient, l logger.Logger) *ArticleHandler {
+func NewArticleHandler(articleSvc articlev1.ArticleServiceClient,
+	intrSvc interactivev1.InteractiveServiceClient,
+	commentSvc commentv1.CommentServiceClient,
+	l logger.Logger,
+) *ArticleHandler {
 	return &ArticleHandler{
 		articleSvc: articleSvc,
 		intrSvc:    intrSvc,
+		commentSvc: commentSvc,
 		l:          l,
 		biz:        "article",
 	}
@@ -42,19 +56,49 @@ func (h *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	g.POST("/edit", ginx.WrapReqAndClaim[ArticleReq, ijwt.UserClaims](h.Edit))
 	g.POST("/publish", ginx.WrapReqAndClaim[ArticleReq, ijwt.UserClaims](h.Publish))
 	g.POST("/withdraw", ginx.WrapReqAndClaim[WithdrawReq, ijwt.UserClaims](h.Withdraw))
+	g.POST("/schedule", ginx.WrapReqAndClaim[ScheduleReq, ijwt.UserClaims](h.SchedulePublish))
+	g.POST("/schedule/cancel", ginx.WrapReqAndClaim[CancelScheduleReq, ijwt.UserClaims](h.CancelScheduledPublish))
+	g.POST("/draft/autosave", ginx.WrapReqAndClaim[DraftReq, ijwt.UserClaims](h.AutosaveDraft))
+	g.GET("/draft/:id", ginx.WrapClaims[ijwt.UserClaims](h.Draft))
 
 	// creator
 	g.POST("/list", ginx.WrapReqAndClaim[ListReq, ijwt.UserClaims](h.List))
 	g.GET("/detail/:id", ginx.WrapClaims[ijwt.UserClaims](h.Detail))
 
+	collab := g.Group("/collaborators")
+	collab.POST("/invite", ginx.WrapReqAndClaim[InviteCollaboratorReq, ijwt.UserClaims](h.InviteCollaborator))
+	collab.POST("/accept", ginx.WrapReqAndClaim[CollaboratorReq, ijwt.UserClaims](h.AcceptInvitation))
+	collab.POST("/revoke", ginx.WrapReqAndClaim[CollaboratorReq, ijwt.UserClaims](h.RevokeCollaborator))
+	collab.GET("/:id", ginx.WrapClaims[ijwt.UserClaims](h.ListCollaborators))
+
+	rev := g.Group("/revisions")
+	rev.POST("/list", ginx.WrapReqAndClaim[ListRevisionsReq, ijwt.UserClaims](h.ListRevisions))
+	rev.POST("/detail", ginx.WrapReqAndClaim[RevisionReq, ijwt.UserClaims](h.RevisionDetail))
+	rev.POST("/diff", ginx.WrapReqAndClaim[DiffRevisionsReq, ijwt.UserClaims](h.DiffRevisions))
+	rev.POST("/restore", ginx.WrapReqAndClaim[RevisionReq, ijwt.UserClaims](h.RestoreRevision))
+
 	pub := g.Group("/pub")
 	pub.GET("/:id", ginx.WrapClaims[ijwt.UserClaims](h.PubDetail))
 	pub.POST("/like", ginx.WrapReqAndClaim[LikeReq, ijwt.UserClaims](h.Like))
 	pub.POST("/favorite", ginx.WrapReqAndClaim[FavoriteReq, ijwt.UserClaims](h.Favorite))
+	pub.POST("/tag", ginx.WrapReq[ListPubByTagReq](h.ListPubByTag))
+	pub.POST("/tags/count", ginx.WrapReq[CountTagsReq](h.CountTags))
+
+	h.registerSeriesRoutes(g.Group("/series"))
+	h.registerTrashRoutes(g)
 }
 
 func (h *ArticleHandler) Edit(ctx *gin.Context, req ArticleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if len(req.Tags) > maxTagsPerArticle {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "too many tags",
+		}, fmt.Errorf("too many tags: %d", len(req.Tags))
+	}
 	resp, err := h.articleSvc.Save(ctx, &articlev1.SaveRequest{Article: req.toDTO(uc.Uid)})
+	if res, ok := h.conflictResult(err, req.Title, req.Content, req.Tags, req.Version); ok {
+		return res, err
+	}
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
@@ -63,14 +107,32 @@ func (h *ArticleHandler) Edit(ctx *gin.Context, req ArticleReq, uc ijwt.UserClai
 	}
 
 	return ginx.Result{
-		Data: resp.GetId(),
+		Data: SavedVO{
+			Id:      resp.GetId(),
+			Version: resp.GetVersion(),
+		},
 	}, nil
 }
 
 func (h *ArticleHandler) Publish(ctx *gin.Context, req ArticleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if len(req.Tags) > maxTagsPerArticle {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "too many tags",
+		}, fmt.Errorf("too many tags: %d", len(req.Tags))
+	}
 	resp, err := h.articleSvc.Publish(ctx, &articlev1.PublishRequest{
 		Article: req.toDTO(uc.Uid),
 	})
+	if res, ok := h.conflictResult(err, req.Title, req.Content, req.Tags, req.Version); ok {
+		return res, err
+	}
+	if status.Code(err) == codes.InvalidArgument {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "content rejected",
+		}, err
+	}
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
@@ -102,6 +164,48 @@ func (h *ArticleHandler) Withdraw(ctx *gin.Context, req WithdrawReq, uc ijwt.Use
 	return ginx.Result{Msg: "OK"}, nil
 }
 
+func (h *ArticleHandler) SchedulePublish(ctx *gin.Context, req ScheduleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	publishTime := time.UnixMilli(req.PublishTime)
+	if !publishTime.After(time.Now()) {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "publish time must be in the future",
+		}, nil
+	}
+	_, err := h.articleSvc.SchedulePublish(ctx, &articlev1.SchedulePublishRequest{
+		Id:          req.Id,
+		Uid:         uc.Uid,
+		PublishTime: timestamppb.New(publishTime),
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "content rejected",
+		}, err
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) CancelScheduledPublish(ctx *gin.Context, req CancelScheduleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.CancelScheduledPublish(ctx, &articlev1.CancelScheduledPublishRequest{
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
 func (h *ArticleHandler) List(ctx *gin.Context, req ListReq, uc ijwt.UserClaims) (ginx.Result, error) {
 	if req.Limit > 100 {
 		req.Limit = 100
@@ -112,6 +216,7 @@ func (h *ArticleHandler) List(ctx *gin.Context, req ListReq, uc ijwt.UserClaims)
 		Author: uc.Uid,
 		Offset: req.Offset,
 		Limit:  req.Limit,
+		Cursor: req.Cursor,
 	})
 	if err != nil {
 		return ginx.Result{
@@ -119,19 +224,30 @@ func (h *ArticleHandler) List(ctx *gin.Context, req ListReq, uc ijwt.UserClaims)
 			Msg:  "internal error",
 		}, err
 	}
+	counts := h.commentCounts(ctx, listResp.GetArticles())
 	return ginx.Result{
-		Data: slice.Map[*articlev1.Article, ArticleVO](listResp.Articles, func(idx int, src *articlev1.Article) ArticleVO {
-			return ArticleVO{
-				Id:       src.Id,
-				Title:    src.Title,
-				Abstract: src.Abstract,
-				// Content: src.Content,
-				// Author: src.Author.Name,
-				Status: uint8(src.Status),
-				Ctime:  src.Ctime.AsTime().Format(time.DateTime),
-				Utime:  src.Utime.AsTime().Format(time.DateTime),
-			}
-		}),
+		Data: ArticleListVO{
+			Articles: slice.Map[*articlev1.Article, ArticleVO](listResp.Articles, func(idx int, src *articlev1.Article) ArticleVO {
+				vo := ArticleVO{
+					Id:       src.Id,
+					Title:    src.Title,
+					Abstract: src.Abstract,
+					// Content: src.Content,
+					// Author: src.Author.Name,
+					Status:         uint8(src.Status),
+					Tags:           src.Tags,
+					CommentCnt:     counts[src.Id].GetTotalCnt(),
+					RootCommentCnt: counts[src.Id].GetRootCnt(),
+					Ctime:          src.Ctime.AsTime().Format(time.DateTime),
+					Utime:          src.Utime.AsTime().Format(time.DateTime),
+				}
+				if src.ScheduledAt != nil {
+					vo.ScheduledAt = src.ScheduledAt.AsTime().Format(time.DateTime)
+				}
+				return vo
+			}),
+			Cursor: listResp.GetNextCursor(),
+		},
 	}, nil
 }
 
@@ -145,8 +261,14 @@ func (h *ArticleHandler) Detail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Resu
 		}, err
 	}
 
-	resp, err := h.articleSvc.GetById(ctx, &articlev1.GetByIdRequest{Id: id})
+	resp, err := h.articleSvc.GetById(ctx, &articlev1.GetByIdRequest{Id: id, Uid: uc.Uid})
 	atcl := resp.GetArticle()
+	if status.Code(err) == codes.PermissionDenied {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid params",
+		}, fmt.Errorf("illegal access resources, user_id: %d, %w", uc.Uid, err)
+	}
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
@@ -154,25 +276,312 @@ func (h *ArticleHandler) Detail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Resu
 		}, err
 	}
 
-	if atcl.GetAuthor().GetId() != uc.Uid {
-		return ginx.Result{
-			Code: 4,
-			Msg:  "invalid params",
-		}, fmt.Errorf("illegal access resources, user_id: %d", uc.Uid)
-	}
-
 	return ginx.Result{
 		Data: ArticleVO{
 			Id:      atcl.Id,
 			Title:   atcl.Title,
 			Status:  uint8(atcl.Status),
 			Content: atcl.Content,
+			Tags:    atcl.Tags,
+			Version: atcl.Version,
 			Ctime:   atcl.Ctime.AsTime().Format(time.DateTime),
 			Utime:   atcl.Utime.AsTime().Format(time.DateTime),
 		},
 	}, nil
 }
 
+// AutosaveDraft keeps the draft until the article is saved, it is checked against the saved article like Edit.
+func (h *ArticleHandler) AutosaveDraft(ctx *gin.Context, req DraftReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if len(req.Tags) > maxTagsPerArticle {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "too many tags",
+		}, fmt.Errorf("too many tags: %d", len(req.Tags))
+	}
+	_, err := h.articleSvc.AutosaveDraft(ctx, &articlev1.AutosaveDraftRequest{
+		Draft: &articlev1.Draft{
+			ArticleId:   req.Id,
+			AuthorId:    uc.Uid,
+			Title:       req.Title,
+			Content:     req.Content,
+			Tags:        req.Tags,
+			BaseVersion: req.BaseVersion,
+		},
+	})
+	if res, ok := h.conflictResult(err, req.Title, req.Content, req.Tags, req.BaseVersion); ok {
+		return res, err
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) Draft(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid params",
+		}, err
+	}
+	resp, err := h.articleSvc.GetDraft(ctx, &articlev1.GetDraftRequest{Id: id, AuthorId: uc.Uid})
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{Msg: "no draft"}, nil
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	draft := resp.GetDraft()
+	return ginx.Result{
+		Data: DraftVO{
+			Id:          draft.GetArticleId(),
+			Title:       draft.GetTitle(),
+			Content:     draft.GetContent(),
+			Tags:        draft.GetTags(),
+			BaseVersion: draft.GetBaseVersion(),
+			Utime:       draft.GetUtime().AsTime().Format(time.DateTime),
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) InviteCollaborator(ctx *gin.Context, req InviteCollaboratorReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	role, ok := collaboratorRoles[req.Role]
+	if !ok {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid role",
+		}, fmt.Errorf("invalid collaborator role: %s", req.Role)
+	}
+	_, err := h.articleSvc.InviteCollaborator(ctx, &articlev1.InviteCollaboratorRequest{
+		Id:        req.Id,
+		Uid:       uc.Uid,
+		InviteeId: req.Uid,
+		Role:      role,
+	})
+	if res, ok := collaboratorResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) AcceptInvitation(ctx *gin.Context, req CollaboratorReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.AcceptInvitation(ctx, &articlev1.AcceptInvitationRequest{
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if res, ok := collaboratorResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) RevokeCollaborator(ctx *gin.Context, req CollaboratorReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.RevokeCollaborator(ctx, &articlev1.RevokeCollaboratorRequest{
+		Id:             req.Id,
+		Uid:            uc.Uid,
+		CollaboratorId: req.Uid,
+	})
+	if res, ok := collaboratorResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) ListCollaborators(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid params",
+		}, err
+	}
+	resp, err := h.articleSvc.ListCollaborators(ctx, &articlev1.ListCollaboratorsRequest{Id: id, Uid: uc.Uid})
+	if res, ok := collaboratorResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetCollaborators(), func(idx int, src *articlev1.Collaborator) CollaboratorVO {
+			return CollaboratorVO{
+				Uid:       src.GetUid(),
+				Role:      collaboratorRoleName(src.GetRole()),
+				Accepted:  src.GetStatus() == articlev1.CollaboratorStatus_COLLABORATOR_STATUS_ACCEPTED,
+				InviterId: src.GetInviterId(),
+				Ctime:     src.GetCtime().AsTime().Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+// collaboratorResult reports the errors of managing collaborators, ok is false if err is nil.
+func collaboratorResult(err error) (ginx.Result, bool) {
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{}, false
+	case codes.PermissionDenied:
+		return ginx.Result{Code: 4, Msg: "permission denied"}, true
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "no such invitation"}, true
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "invalid params"}, true
+	default:
+		return ginx.Result{Code: 5, Msg: "internal error"}, true
+	}
+}
+
+func collaboratorRoleName(role articlev1.CollaboratorRole) string {
+	if role == articlev1.CollaboratorRole_COLLABORATOR_ROLE_OWNER {
+		return "owner"
+	}
+	for name, r := range collaboratorRoles {
+		if r == role {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// conflictResult returns what was submitted along with the latest saved article if err is a version conflict.
+func (h *ArticleHandler) conflictResult(err error, title, content string, tags []string, version int64) (ginx.Result, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return ginx.Result{}, false
+	}
+	vo := ConflictVO{
+		Mine: ArticleVO{
+			Title:   title,
+			Content: content,
+			Tags:    tags,
+			Version: version,
+		},
+	}
+	for _, detail := range st.Details() {
+		if atcl, ok := detail.(*articlev1.Article); ok {
+			vo.Mine.Id = atcl.GetId()
+			vo.Theirs = ArticleVO{
+				Id:      atcl.GetId(),
+				Title:   atcl.GetTitle(),
+				Status:  uint8(atcl.GetStatus()),
+				Content: atcl.GetContent(),
+				Tags:    atcl.GetTags(),
+				Version: atcl.GetVersion(),
+				Ctime:   atcl.GetCtime().AsTime().Format(time.DateTime),
+				Utime:   atcl.GetUtime().AsTime().Format(time.DateTime),
+			}
+		}
+	}
+	return ginx.Result{
+		Code: 4,
+		Msg:  "the article has been saved elsewhere",
+		Data: vo,
+	}, true
+}
+
+func (h *ArticleHandler) ListRevisions(ctx *gin.Context, req ListRevisionsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > 100 {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid params",
+		}, nil
+	}
+	resp, err := h.articleSvc.ListRevisions(ctx, &articlev1.ListRevisionsRequest{
+		Id:     req.Id,
+		Uid:    uc.Uid,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*articlev1.ArticleRevision, RevisionVO](resp.GetRevisions(), func(idx int, src *articlev1.ArticleRevision) RevisionVO {
+			return RevisionVO{
+				Id:     src.Id,
+				Title:  src.Title,
+				Status: uint8(src.Status),
+				Ctime:  src.Ctime.AsTime().Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+func (h *ArticleHandler) RevisionDetail(ctx *gin.Context, req RevisionReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.articleSvc.GetRevision(ctx, &articlev1.GetRevisionRequest{
+		Id:         req.Id,
+		RevisionId: req.RevisionId,
+		Uid:        uc.Uid,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	rev := resp.GetRevision()
+	return ginx.Result{
+		Data: RevisionVO{
+			Id:      rev.Id,
+			Title:   rev.Title,
+			Content: rev.Content,
+			Status:  uint8(rev.Status),
+			Ctime:   rev.Ctime.AsTime().Format(time.DateTime),
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) DiffRevisions(ctx *gin.Context, req DiffRevisionsReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.articleSvc.DiffRevisions(ctx, &articlev1.DiffRevisionsRequest{
+		Id:             req.Id,
+		FromRevisionId: req.From,
+		ToRevisionId:   req.To,
+		Uid:            uc.Uid,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	toVO := func(idx int, src *articlev1.DiffLine) DiffLineVO {
+		return DiffLineVO{
+			Op:      src.Op.String(),
+			Content: src.Content,
+		}
+	}
+	return ginx.Result{
+		Data: RevisionDiffVO{
+			From:    resp.GetFromRevisionId(),
+			To:      resp.GetToRevisionId(),
+			Title:   slice.Map[*articlev1.DiffLine, DiffLineVO](resp.GetTitle(), toVO),
+			Content: slice.Map[*articlev1.DiffLine, DiffLineVO](resp.GetContent(), toVO),
+		},
+	}, nil
+}
+
+func (h *ArticleHandler) RestoreRevision(ctx *gin.Context, req RevisionReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.RestoreRevision(ctx, &articlev1.RestoreRevisionRequest{
+		Id:         req.Id,
+		RevisionId: req.RevisionId,
+		Uid:        uc.Uid,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
 func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -187,7 +596,9 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.R
 	var (
 		eg       errgroup.Group
 		atcl     *articlev1.Article
+		nav      *articlev1.SeriesNav
 		intrResp *interactivev1.GetResponse
+		cnt      *commentv1.CommentCount
 	)
 
 	eg.Go(func() error {
@@ -197,6 +608,7 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.R
 			Uid: uc.Uid,
 		})
 		atcl = resp.GetArticle()
+		nav = resp.GetSeries()
 		return er
 	})
 
@@ -210,6 +622,20 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.R
 		return er
 	})
 
+	eg.Go(func() error {
+		// the article is still shown without its comment counts
+		resp, er := h.commentSvc.GetCommentCounts(ctx, &commentv1.GetCommentCountsRequest{
+			Biz:    h.biz,
+			BizIds: []int64{id},
+		})
+		if er != nil {
+			h.l.Error("get comment counts failed", logger.Int64("aid", id), logger.Error(er))
+			return nil
+		}
+		cnt = resp.GetCounts()[id]
+		return nil
+	})
+
 	err = eg.Wait()
 	if err != nil {
 		return ginx.Result{
@@ -222,18 +648,38 @@ func (h *ArticleHandler) PubDetail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.R
 
 	return ginx.Result{
 		Data: ArticleVO{
-			Id:          atcl.Id,
-			Title:       atcl.Title,
-			Content:     atcl.Content,
-			Status:      uint8(atcl.Status),
-			Author:      atcl.Author.Name,
-			LikeCnt:     intr.LikeCnt,
-			FavoriteCnt: intr.FavoriteCnt,
-			ReadCnt:     intr.ReadCnt,
-			Liked:       intr.Liked,
-			Favorited:   intr.Favorited,
-			Ctime:       atcl.Ctime.AsTime().Format(time.DateTime),
-			Utime:       atcl.Utime.AsTime().Format(time.DateTime),
+			Id:      atcl.Id,
+			Title:   atcl.Title,
+			Content: atcl.Content,
+			Status:  uint8(atcl.Status),
+			Author:  atcl.Author.Name,
+			Authors: slice.Map(atcl.Authors, func(idx int, src *articlev1.Author) AuthorVO {
+				return AuthorVO{
+					Id:   src.Id,
+					Name: src.Name,
+				}
+			}),
+			LikeCnt:        intr.LikeCnt,
+			FavoriteCnt:    intr.FavoriteCnt,
+			ReadCnt:        intr.ReadCnt,
+			UniqueReadCnt:  intr.UniqueReadCnt,
+			CommentCnt:     cnt.GetTotalCnt(),
+			RootCommentCnt: cnt.GetRootCnt(),
+			Liked:          intr.Liked,
+			Favorited:      intr.Favorited,
+			Tags:           atcl.Tags,
+			HTML:           atcl.Html,
+			ReadingTime:    atcl.ReadingTime,
+			TOC: slice.Map(atcl.Toc, func(idx int, src *articlev1.TocEntry) TOCEntryVO {
+				return TOCEntryVO{
+					Level:  src.Level,
+					Title:  src.Title,
+					Anchor: src.Anchor,
+				}
+			}),
+			Series: newSeriesNavVO(nav),
+			Ctime:  atcl.Ctime.AsTime().Format(time.DateTime),
+			Utime:  atcl.Utime.AsTime().Format(time.DateTime),
 		},
 	}, nil
 }
@@ -290,3 +736,90 @@ func (h *ArticleHandler) Favorite(ctx *gin.Context, req FavoriteReq, uc ijwt.Use
 	}
 	return ginx.Result{Msg: "OK"}, nil
 }
+
+func (h *ArticleHandler) ListPubByTag(ctx *gin.Context, req ListPubByTagReq) (ginx.Result, error) {
+	if req.Tag == "" {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid params",
+		}, errors.New("empty tag")
+	}
+	if req.Limit > 100 || req.Limit <= 0 {
+		req.Limit = 100
+	}
+	resp, err := h.articleSvc.ListPubByTag(ctx, &articlev1.ListPubByTagRequest{
+		Tag:    req.Tag,
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	counts := h.commentCounts(ctx, resp.GetArticles())
+	return ginx.Result{
+		Data: ArticleListVO{
+			Articles: slice.Map[*articlev1.Article, ArticleVO](resp.GetArticles(), func(idx int, src *articlev1.Article) ArticleVO {
+				return ArticleVO{
+					Id:             src.Id,
+					Title:          src.Title,
+					Abstract:       src.Abstract,
+					Status:         uint8(src.Status),
+					Author:         src.GetAuthor().GetName(),
+					Tags:           src.Tags,
+					CommentCnt:     counts[src.Id].GetTotalCnt(),
+					RootCommentCnt: counts[src.Id].GetRootCnt(),
+					Ctime:          src.Ctime.AsTime().Format(time.DateTime),
+					Utime:          src.Utime.AsTime().Format(time.DateTime),
+				}
+			}),
+			Cursor: resp.GetNextCursor(),
+		},
+	}, nil
+}
+
+// commentCounts returns the comment counts of the articles by their ids in one call,
+// a failure is only logged and the articles are listed with no counts.
+func (h *ArticleHandler) commentCounts(ctx *gin.Context, atcls []*articlev1.Article) map[int64]*commentv1.CommentCount {
+	if len(atcls) == 0 {
+		return nil
+	}
+	ids := slice.Map(atcls, func(idx int, src *articlev1.Article) int64 {
+		return src.GetId()
+	})
+	resp, err := h.commentSvc.GetCommentCounts(ctx, &commentv1.GetCommentCountsRequest{
+		Biz:    h.biz,
+		BizIds: ids,
+	})
+	if err != nil {
+		h.l.Error("get comment counts failed", logger.Int("articles", len(ids)), logger.Error(err))
+		return nil
+	}
+	return resp.GetCounts()
+}
+
+func (h *ArticleHandler) CountTags(ctx *gin.Context, req CountTagsReq) (ginx.Result, error) {
+	if req.Limit > 100 || req.Limit <= 0 {
+		req.Limit = 100
+	}
+	resp, err := h.articleSvc.CountTags(ctx, &articlev1.CountTagsRequest{
+		Tags:  req.Tags,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*articlev1.TagCount, TagCountVO](resp.GetTags(), func(idx int, src *articlev1.TagCount) TagCountVO {
+			return TagCountVO{
+				Tag:   src.Tag,
+				Count: src.Count,
+			}
+		}),
+	}, nil
+}
@@ -11,27 +11,62 @@ type ArticleVO struct {
 	Content  string `json:"content"`
 	Status   uint8  `json:"status"`
 	Author   string `json:"author"`
+	// Authors are the owner followed by the editors, only returned to readers
+	Authors []AuthorVO `json:"authors,omitempty"`
 
 	LikeCnt     int64 `json:"like_cnt"`
 	FavoriteCnt int64 `json:"favorite_cnt"`
 	ReadCnt     int64 `json:"read_cnt"`
+	// UniqueReadCnt approximate signed in readers
+	UniqueReadCnt int64 `json:"unique_read_cnt"`
+	// CommentCnt counts the replies too, RootCommentCnt only the top comments
+	CommentCnt     int64 `json:"comment_cnt"`
+	RootCommentCnt int64 `json:"root_comment_cnt"`
 
 	Liked     bool `json:"liked"`
 	Favorited bool `json:"favorited"`
 
+	ScheduledAt string   `json:"scheduled_at,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Version is sent back on edit to detect a conflicting save
+	Version int64 `json:"version,omitempty"`
+
+	// HTML, ReadingTime and TOC are only returned to readers
+	HTML string `json:"html,omitempty"`
+	// ReadingTime in seconds
+	ReadingTime int64        `json:"reading_time,omitempty"`
+	TOC         []TOCEntryVO `json:"toc,omitempty"`
+	// Series is only returned to readers of an article in a series
+	Series *SeriesNavVO `json:"series,omitempty"`
+	// TrashedAt is only returned in the trash
+	TrashedAt string `json:"trashed_at,omitempty"`
+
 	Ctime string `json:"ctime"`
 	Utime string `json:"utime"`
 }
 
+type TOCEntryVO struct {
+	Level  int32  `json:"level"`
+	Title  string `json:"title"`
+	Anchor string `json:"anchor"`
+}
+
 type ListReq struct {
+	// Offset is only used without Cursor.
+	// Deprecated: use Cursor.
 	Offset int32 `json:"offset"`
 	Limit  int32 `json:"limit"`
+	// Cursor returned by the previous page, empty for the first page
+	Cursor string `json:"cursor"`
 }
 
 type ArticleReq struct {
-	Id      int64  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Id      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+	// Version of the article being edited, 0 overwrites whatever has been saved
+	Version int64 `json:"version"`
 }
 
 func (req ArticleReq) toDTO(uid int64) *articlev1.Article {
@@ -39,12 +74,116 @@ func (req ArticleReq) toDTO(uid int64) *articlev1.Article {
 		Id:      req.Id,
 		Title:   req.Title,
 		Content: req.Content,
+		Tags:    req.Tags,
+		Version: req.Version,
 		Author: &articlev1.Author{
 			Id: uid,
 		},
 	}
 }
 
+type SavedVO struct {
+	Id int64 `json:"id"`
+	// Version to send with the next edit
+	Version int64 `json:"version"`
+}
+
+// ConflictVO is returned when the article has been saved since the version being edited,
+// Mine is what was submitted and Theirs is the latest saved article, the client merges them.
+type ConflictVO struct {
+	Mine   ArticleVO `json:"mine"`
+	Theirs ArticleVO `json:"theirs"`
+}
+
+type DraftReq struct {
+	// Id of the saved article, an article is saved before its draft is autosaved
+	Id      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+	// BaseVersion is the version of the article being edited
+	BaseVersion int64 `json:"base_version"`
+}
+
+type DraftVO struct {
+	Id          int64    `json:"id"`
+	Title       string   `json:"title"`
+	Content     string   `json:"content"`
+	Tags        []string `json:"tags,omitempty"`
+	BaseVersion int64    `json:"base_version"`
+	Utime       string   `json:"utime"`
+}
+
+type ScheduleReq struct {
+	Id int64 `json:"id"`
+	// PublishTime unix timestamp in milliseconds
+	PublishTime int64 `json:"publish_time"`
+}
+
+type CancelScheduleReq struct {
+	Id int64 `json:"id"`
+}
+
+type ListPubByTagReq struct {
+	Tag string `json:"tag"`
+	// Cursor returned by the previous page, empty for the first page
+	Cursor string `json:"cursor"`
+	Limit  int32  `json:"limit"`
+}
+
+type ArticleListVO struct {
+	Articles []ArticleVO `json:"articles"`
+	// Cursor is empty when there are no more articles
+	Cursor string `json:"cursor"`
+}
+
+type CountTagsReq struct {
+	Tags  []string `json:"tags"`
+	Limit int32    `json:"limit"`
+}
+
+type TagCountVO struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+type RevisionVO struct {
+	Id      int64  `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content,omitempty"`
+	Status  uint8  `json:"status"`
+	Ctime   string `json:"ctime"`
+}
+
+type DiffLineVO struct {
+	Op      string `json:"op"`
+	Content string `json:"content"`
+}
+
+type RevisionDiffVO struct {
+	From    int64        `json:"from"`
+	To      int64        `json:"to"`
+	Title   []DiffLineVO `json:"title"`
+	Content []DiffLineVO `json:"content"`
+}
+
+type ListRevisionsReq struct {
+	Id     int64 `json:"id"`
+	Offset int32 `json:"offset"`
+	Limit  int32 `json:"limit"`
+}
+
+type RevisionReq struct {
+	Id         int64 `json:"id"`
+	RevisionId int64 `json:"revision_id"`
+}
+
+type DiffRevisionsReq struct {
+	Id   int64 `json:"id"`
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
 type LikeReq struct {
 	Id   int64 `json:"id"`
 	Like bool  `json:"like"`
@@ -55,3 +194,37 @@ type FavoriteReq struct {
 	Fid      int64 `json:"fid"`
 	Favorite bool  `json:"favorite"`
 }
+
+type AuthorVO struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// collaboratorRoles are the roles a user could be invited with
+var collaboratorRoles = map[string]articlev1.CollaboratorRole{
+	"editor": articlev1.CollaboratorRole_COLLABORATOR_ROLE_EDITOR,
+	"viewer": articlev1.CollaboratorRole_COLLABORATOR_ROLE_VIEWER,
+}
+
+type InviteCollaboratorReq struct {
+	Id  int64 `json:"id"`
+	Uid int64 `json:"uid"`
+	// Role is editor or viewer
+	Role string `json:"role"`
+}
+
+// CollaboratorReq accepts the invitation to the article,
+// or revokes the collaborator, which is the user themselves to decline or leave.
+type CollaboratorReq struct {
+	Id  int64 `json:"id"`
+	Uid int64 `json:"uid"`
+}
+
+type CollaboratorVO struct {
+	Uid int64 `json:"uid"`
+	// Role is owner, editor or viewer
+	Role      string `json:"role"`
+	Accepted  bool   `json:"accepted"`
+	InviterId int64  `json:"inviter_id,omitempty"`
+	Ctime     string `json:"ctime"`
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	attachmentv1 "github.com/tsukiyo/mercury/api/gen/attachment/v1"
+	ijwt "github.com/tsukiyo/mercury/internal/bff/web/jwt"
+	"github.com/tsukiyo/mercury/pkg/ginx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var _ handler = (*AttachmentHandler)(nil)
+
+type AttachmentHandler struct {
+	attachmentSvc attachmentv1.AttachmentServiceClient
+	l             logger.Logger
+}
+
+func NewAttachmentHandler(attachmentSvc attachmentv1.AttachmentServiceClient, l logger.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentSvc: attachmentSvc,
+		l:             l,
+	}
+}
+
+func (h *AttachmentHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/attachments")
+	g.POST("/upload", ginx.WrapReqAndClaim[CreateUploadReq](h.CreateUpload))
+	g.POST("/complete", ginx.WrapReqAndClaim[CompleteUploadReq](h.CompleteUpload))
+	// the link put in articles, a file is public once a published article links to it
+	g.GET("/files/:id", h.Download)
+}
+
+// CreateUpload returns where to upload the file, the client puts the file there and completes the upload.
+func (h *AttachmentHandler) CreateUpload(ctx *gin.Context, req CreateUploadReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.attachmentSvc.CreateUpload(ctx, &attachmentv1.CreateUploadRequest{
+		Uid:         uc.Uid,
+		Name:        req.Name,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		Sha256:      req.SHA256,
+	})
+	if err != nil {
+		return h.errResult(err), err
+	}
+	vo := CreateUploadVO{Attachment: h.toAttachmentVO(resp.GetAttachment())}
+	if upload := resp.GetUpload(); upload != nil {
+		vo.Upload = &SignedRequestVO{
+			Method:   upload.GetMethod(),
+			URL:      upload.GetUrl(),
+			Headers:  upload.GetHeaders(),
+			ExpireAt: upload.GetExpireAt().AsTime().UnixMilli(),
+		}
+	}
+	return ginx.Result{Data: vo}, nil
+}
+
+func (h *AttachmentHandler) CompleteUpload(ctx *gin.Context, req CompleteUploadReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.attachmentSvc.CompleteUpload(ctx, &attachmentv1.CompleteUploadRequest{
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Result{Data: h.toAttachmentVO(resp.GetAttachment())}, nil
+}
+
+// Download redirects to a pre-signed url of the file.
+func (h *AttachmentHandler) Download(ctx *gin.Context) {
+	val, _ := ctx.Get("user")
+	uc, ok := val.(*ijwt.UserClaims)
+	if !ok {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	resp, err := h.attachmentSvc.GetDownloadURL(ctx, &attachmentv1.GetDownloadURLRequest{Id: id, Uid: uc.Uid})
+	switch status.Code(err) {
+	case codes.OK:
+		ctx.Redirect(http.StatusFound, resp.GetUrl())
+	// the files of others are not told apart from the missing ones
+	case codes.NotFound, codes.FailedPrecondition, codes.PermissionDenied:
+		ctx.AbortWithStatus(http.StatusNotFound)
+	default:
+		h.l.Error("failed to get the download url",
+			logger.Int64("id", id), logger.Error(err))
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	}
+}
+
+func (h *AttachmentHandler) errResult(err error) ginx.Result {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.InvalidArgument, codes.PermissionDenied, codes.NotFound, codes.FailedPrecondition:
+		return ginx.Result{
+			Code: 4,
+			Msg:  st.Message(),
+		}
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}
+	}
+}
+
+func (h *AttachmentHandler) toAttachmentVO(atch *attachmentv1.Attachment) AttachmentVO {
+	return AttachmentVO{
+		Id:          atch.GetId(),
+		Name:        atch.GetName(),
+		ContentType: atch.GetContentType(),
+		Size:        atch.GetSize(),
+		SHA256:      atch.GetSha256(),
+		Uploaded:    atch.GetStatus() == attachmentv1.AttachmentStatus_ATTACHMENT_STATUS_UPLOADED,
+		URL:         "/attachments/files/" + strconv.FormatInt(atch.GetId(), 10),
+		Ctime:       atch.GetCtime().AsTime().Format(time.DateTime),
+	}
+}
@@ -0,0 +1,38 @@
+package web
+
+type CreateUploadReq struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// SHA256 is the hex encoded hash of the file
+	SHA256 string `json:"sha256"`
+}
+
+type CompleteUploadReq struct {
+	Id int64 `json:"id"`
+}
+
+type AttachmentVO struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+	Uploaded    bool   `json:"uploaded"`
+	// URL is the link to put in the article
+	URL   string `json:"url"`
+	Ctime string `json:"ctime"`
+}
+
+type SignedRequestVO struct {
+	Method   string            `json:"method"`
+	URL      string            `json:"url"`
+	Headers  map[string]string `json:"headers"`
+	ExpireAt int64             `json:"expire_at"`
+}
+
+type CreateUploadVO struct {
+	Attachment AttachmentVO `json:"attachment"`
+	// Upload is nil if the same file has been uploaded before
+	Upload *SignedRequestVO `json:"upload,omitempty"`
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	"github.com/tsukiyo/mercury/pkg/cachex"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// ArticleClient serves the published articles from the local cache,
+// the article service broadcasts the changes for the cached ones to be dropped.
+type ArticleClient struct {
+	articlev1.ArticleServiceClient
+	pubs *cachex.TwoLevel[*articlev1.GetPublishedByIdResponse]
+	l    logger.Logger
+}
+
+func NewArticleClient(remote articlev1.ArticleServiceClient, inv *cachex.Invalidator, l logger.Logger) *ArticleClient {
+	local := cachex.NewLocalCache[*articlev1.GetPublishedByIdResponse](64<<20, time.Minute)
+	return &ArticleClient{
+		ArticleServiceClient: remote,
+		pubs:                 cachex.NewTwoLevel[*articlev1.GetPublishedByIdResponse](local, nil, inv, l),
+		l:                    l,
+	}
+}
+
+func (c *ArticleClient) GetPublishedById(ctx context.Context, in *articlev1.GetPublishedByIdRequest, opts ...grpc.CallOption) (*articlev1.GetPublishedByIdResponse, error) {
+	hit := true
+	// the key of the published article in the article service, whose changes are broadcast
+	key := fmt.Sprintf("article:reader:%d", in.GetId())
+	resp, err := c.pubs.Get(ctx, key, func(ctx context.Context) (*articlev1.GetPublishedByIdResponse, error) {
+		hit = false
+		return c.ArticleServiceClient.GetPublishedById(ctx, in, opts...)
+	})
+	if err != nil || !hit {
+		return resp, err
+	}
+	// the article service counts the reads it serves, the ones served locally are reported
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, er := c.ArticleServiceClient.RecordRead(ctx, &articlev1.RecordReadRequest{
+			Id:  in.GetId(),
+			Uid: in.GetUid(),
+		})
+		if er != nil {
+			c.l.Error("record read failed", logger.Int64("aid", in.GetId()), logger.Error(er))
+		}
+	}()
+	return resp, nil
+}
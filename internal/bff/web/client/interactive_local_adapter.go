@@ -6,6 +6,7 @@ import (
 	"github.com/tsukiyo/mercury/internal/interactive/domain"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	interactivev1 "github.com/tsukiyo/mercury/api/gen/interactive/v1"
 	"github.com/tsukiyo/mercury/internal/interactive/service"
@@ -67,6 +68,119 @@ func (i *InteractiveLocalAdapter) GetByIds(ctx context.Context, in *interactivev
 	return &interactivev1.GetByIdsResponse{Interactives: res}, nil
 }
 
+func (i *InteractiveLocalAdapter) CreateFavorites(ctx context.Context, in *interactivev1.CreateFavoritesRequest, opts ...grpc.CallOption) (*interactivev1.CreateFavoritesResponse, error) {
+	id, err := i.svc.CreateFavorites(ctx, i.favoritesToDomain(in.GetFavorites()))
+	return &interactivev1.CreateFavoritesResponse{Id: id}, err
+}
+
+func (i *InteractiveLocalAdapter) UpdateFavorites(ctx context.Context, in *interactivev1.UpdateFavoritesRequest, opts ...grpc.CallOption) (*interactivev1.UpdateFavoritesResponse, error) {
+	err := i.svc.UpdateFavorites(ctx, i.favoritesToDomain(in.GetFavorites()))
+	return &interactivev1.UpdateFavoritesResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) DeleteFavorites(ctx context.Context, in *interactivev1.DeleteFavoritesRequest, opts ...grpc.CallOption) (*interactivev1.DeleteFavoritesResponse, error) {
+	err := i.svc.DeleteFavorites(ctx, in.GetId(), in.GetUid())
+	return &interactivev1.DeleteFavoritesResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) ListFavorites(ctx context.Context, in *interactivev1.ListFavoritesRequest, opts ...grpc.CallOption) (*interactivev1.ListFavoritesResponse, error) {
+	fs, err := i.svc.ListFavorites(ctx, in.GetUid(), in.GetViewerUid())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*interactivev1.Favorites, 0, len(fs))
+	for _, f := range fs {
+		res = append(res, i.favoritesToDTO(f))
+	}
+	return &interactivev1.ListFavoritesResponse{Favorites: res}, nil
+}
+
+func (i *InteractiveLocalAdapter) ListFavoriteItems(ctx context.Context, in *interactivev1.ListFavoriteItemsRequest, opts ...grpc.CallOption) (*interactivev1.ListFavoriteItemsResponse, error) {
+	items, next, err := i.svc.ListFavoriteItems(ctx, in.GetFid(), in.GetViewerUid(), in.GetCursor(), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*interactivev1.FavoriteItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, i.favoriteItemToDTO(item))
+	}
+	return &interactivev1.ListFavoriteItemsResponse{Items: res, NextCursor: next}, nil
+}
+
+func (i *InteractiveLocalAdapter) MoveFavoriteItems(ctx context.Context, in *interactivev1.MoveFavoriteItemsRequest, opts ...grpc.CallOption) (*interactivev1.MoveFavoriteItemsResponse, error) {
+	moved, err := i.svc.MoveFavoriteItems(ctx, in.GetUid(), in.GetFromFid(), in.GetToFid(), in.GetItemIds())
+	return &interactivev1.MoveFavoriteItemsResponse{Moved: moved}, err
+}
+
+func (i *InteractiveLocalAdapter) ListLiked(ctx context.Context, in *interactivev1.ListLikedRequest, opts ...grpc.CallOption) (*interactivev1.ListLikedResponse, error) {
+	records, next, err := i.svc.ListLiked(ctx, in.GetUid(), in.GetBiz(), in.GetCursor(), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactivev1.ListLikedResponse{Records: i.activityRecordsToDTO(records), NextCursor: next}, nil
+}
+
+func (i *InteractiveLocalAdapter) ListReadHistory(ctx context.Context, in *interactivev1.ListReadHistoryRequest, opts ...grpc.CallOption) (*interactivev1.ListReadHistoryResponse, error) {
+	records, next, err := i.svc.ListReadHistory(ctx, in.GetUid(), in.GetBiz(), in.GetCursor(), int(in.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &interactivev1.ListReadHistoryResponse{Records: i.activityRecordsToDTO(records), NextCursor: next}, nil
+}
+
+func (i *InteractiveLocalAdapter) ClearReadHistory(ctx context.Context, in *interactivev1.ClearReadHistoryRequest, opts ...grpc.CallOption) (*interactivev1.ClearReadHistoryResponse, error) {
+	err := i.svc.ClearReadHistory(ctx, in.GetUid(), in.GetBiz())
+	return &interactivev1.ClearReadHistoryResponse{}, err
+}
+
+func (i *InteractiveLocalAdapter) activityRecordsToDTO(records []domain.ActivityRecord) []*interactivev1.ActivityRecord {
+	res := make([]*interactivev1.ActivityRecord, 0, len(records))
+	for _, r := range records {
+		res = append(res, &interactivev1.ActivityRecord{
+			Biz:   r.Biz,
+			BizId: r.BizId,
+			Utime: timestamppb.New(r.Utime),
+		})
+	}
+	return res
+}
+
+func (i *InteractiveLocalAdapter) favoritesToDomain(f *interactivev1.Favorites) domain.Favorites {
+	return domain.Favorites{
+		Id:         f.GetId(),
+		Uid:        f.GetUid(),
+		Name:       f.GetName(),
+		Visibility: domain.FavoritesVisibility(f.GetVisibility()),
+	}
+}
+
+func (i *InteractiveLocalAdapter) favoritesToDTO(f domain.Favorites) *interactivev1.Favorites {
+	covers := make([]*interactivev1.FavoriteItem, 0, len(f.Covers))
+	for _, item := range f.Covers {
+		covers = append(covers, i.favoriteItemToDTO(item))
+	}
+	return &interactivev1.Favorites{
+		Id:         f.Id,
+		Uid:        f.Uid,
+		Name:       f.Name,
+		Visibility: interactivev1.FavoritesVisibility(f.Visibility),
+		ItemCnt:    f.ItemCnt,
+		Covers:     covers,
+		Ctime:      timestamppb.New(f.Ctime),
+		Utime:      timestamppb.New(f.Utime),
+	}
+}
+
+func (i *InteractiveLocalAdapter) favoriteItemToDTO(item domain.FavoriteItem) *interactivev1.FavoriteItem {
+	return &interactivev1.FavoriteItem{
+		Id:    item.Id,
+		Fid:   item.Fid,
+		Biz:   item.Biz,
+		BizId: item.BizId,
+		Ctime: timestamppb.New(item.Ctime),
+	}
+}
+
 func (i *InteractiveLocalAdapter) toDTO(intr domain.Interactive) *interactivev1.Interactive {
 	return &interactivev1.Interactive{
 		Biz:         intr.Biz,
@@ -76,5 +190,8 @@ func (i *InteractiveLocalAdapter) toDTO(intr domain.Interactive) *interactivev1.
 		FavoriteCnt: intr.FavoriteCnt,
 		Liked:       intr.Liked,
 		Favorited:   intr.Favorited,
+
+		UniqueReadCnt:       intr.UniqueReadCnt,
+		WeeklyUniqueReadCnt: intr.WeeklyUniqueReadCnt,
 	}
 }
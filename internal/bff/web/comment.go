@@ -4,7 +4,9 @@ import (
 	"strconv"
 	"time"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
@@ -13,17 +15,21 @@ import (
 	"github.com/tsukiyo/mercury/pkg/ginx"
 
 	commentv1 "github.com/tsukiyo/mercury/api/gen/comment/v1"
+	interactivev1 "github.com/tsukiyo/mercury/api/gen/interactive/v1"
 )
 
 var _ handler = (*CommentHandler)(nil)
 
 type CommentHandler struct {
 	commentSvc commentv1.CommentServiceClient
+	// intrSvc records the likes of the comments
+	intrSvc interactivev1.InteractiveServiceClient
 }
 
-func NewCommentHandler(commentSvc commentv1.CommentServiceClient) *CommentHandler {
+func NewCommentHandler(commentSvc commentv1.CommentServiceClient, intrSvc interactivev1.InteractiveServiceClient) *CommentHandler {
 	return &CommentHandler{
 		commentSvc: commentSvc,
+		intrSvc:    intrSvc,
 	}
 }
 
@@ -31,16 +37,29 @@ func (c *CommentHandler) RegisterRoutes(server *gin.Engine) {
 	g := server.Group("/comments")
 	g.POST("/list", ginx.WrapReqAndClaim[GetCommentListReq](c.GetCommentList))
 	g.POST("/delete", ginx.WrapReqAndClaim[DeleteCommentReq](c.DeleteComment))
+	g.POST("/edit", ginx.WrapReqAndClaim[EditCommentReq](c.EditComment))
+	g.POST("/edits", ginx.WrapReqAndClaim[GetEditHistoryReq](c.GetEditHistory))
 	g.POST("/create", ginx.WrapReqAndClaim[CreateCommentReq](c.CreateComment))
 	g.POST("/reply", ginx.WrapReqAndClaim[GetMoreRepliesRequest](c.GetMoreReplies))
+	g.POST("/like", ginx.WrapReqAndClaim[LikeReq](c.Like))
 }
 
 func (c *CommentHandler) GetCommentList(ctx *gin.Context, req GetCommentListReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	sort, ok := commentSorts[req.Sort]
+	if !ok {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid sort",
+		}, nil
+	}
 	resp, err := c.commentSvc.GetCommentList(ctx, &commentv1.GetCommentListRequest{
-		Biz:   req.Biz,
-		BizId: req.BizId,
-		MinId: req.MinId,
-		Limit: req.Limit,
+		Biz:    req.Biz,
+		BizId:  req.BizId,
+		MinId:  req.MinId,
+		Limit:  req.Limit,
+		Cursor: req.Cursor,
+		Uid:    uc.Uid,
+		Sort:   sort,
 	})
 	if err != nil {
 		return ginx.Result{
@@ -49,31 +68,106 @@ func (c *CommentHandler) GetCommentList(ctx *gin.Context, req GetCommentListReq,
 		}, err
 	}
 	return ginx.Result{
-		Data: slice.Map[*commentv1.Comment, CommentVO](resp.Comments, func(idx int, src *commentv1.Comment) CommentVO {
-			return CommentVO{
-				Id:      src.Id,
-				Uid:     src.Uid,
-				Biz:     src.Biz,
-				BizId:   src.BizId,
-				Content: src.Content,
-				Ctime:   src.Ctime.AsTime().Format(time.DateTime),
-				Utime:   src.Utime.AsTime().Format(time.DateTime),
-			}
-		}),
+		Data: CommentListVO{
+			Comments: slice.Map[*commentv1.Comment, CommentVO](resp.Comments, func(idx int, src *commentv1.Comment) CommentVO {
+				return CommentVO{
+					Id:      src.Id,
+					Uid:     src.Uid,
+					Biz:     src.Biz,
+					BizId:   src.BizId,
+					Content: src.Content,
+					Status:  uint8(src.Status),
+					LikeCnt: src.LikeCnt,
+					Edited:  src.Edited,
+					Deleted: src.Deleted,
+					Ctime:   src.Ctime.AsTime().Format(time.DateTime),
+					Utime:   src.Utime.AsTime().Format(time.DateTime),
+				}
+			}),
+			Cursor: resp.GetNextCursor(),
+		},
 	}, nil
 }
 
 func (c *CommentHandler) DeleteComment(ctx *gin.Context, req DeleteCommentReq, uc ijwt.UserClaims) (ginx.Result, error) {
 	gCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("user", strconv.FormatInt(uc.Uid, 10)))
 	_, err := c.commentSvc.DeleteComment(gCtx, &commentv1.DeleteCommentRequest{
-		Id: req.Id,
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{Msg: "OK"}, nil
+	case codes.NotFound:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "no such comment",
+		}, err
+	case codes.PermissionDenied:
+		return ginx.Result{
+			Code: 4,
+			Msg:  "only the commentator or the author could delete the comment",
+		}, err
+	default:
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+}
+
+func (c *CommentHandler) EditComment(ctx *gin.Context, req EditCommentReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := c.commentSvc.EditComment(ctx, &commentv1.EditCommentRequest{
+		Id:      req.Id,
+		Uid:     uc.Uid,
+		Content: req.Content,
 	})
-	return ginx.Result{}, err
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "no such comment",
+		}, err
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	// the author is told the edited comment is held, as on creation
+	return ginx.Result{Data: uint8(resp.GetStatus())}, nil
+}
+
+func (c *CommentHandler) GetEditHistory(ctx *gin.Context, req GetEditHistoryReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := c.commentSvc.GetEditHistory(ctx, &commentv1.GetEditHistoryRequest{
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "no such comment",
+		}, err
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*commentv1.CommentEdit, CommentEditVO](resp.GetEdits(), func(idx int, src *commentv1.CommentEdit) CommentEditVO {
+			return CommentEditVO{
+				Content: src.GetContent(),
+				Ctime:   src.GetCtime().AsTime().Format(time.DateTime),
+			}
+		}),
+	}, nil
 }
 
 func (c *CommentHandler) CreateComment(ctx *gin.Context, req CreateCommentReq, uc ijwt.UserClaims) (ginx.Result, error) {
 	gCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("user", strconv.FormatInt(uc.Uid, 10)))
-	_, err := c.commentSvc.CreateComment(gCtx, &commentv1.CreateCommentRequest{
+	resp, err := c.commentSvc.CreateComment(gCtx, &commentv1.CreateCommentRequest{
 		Comment: &commentv1.Comment{
 			Uid:     uc.Uid,
 			Biz:     req.Biz,
@@ -87,30 +181,73 @@ func (c *CommentHandler) CreateComment(ctx *gin.Context, req CreateCommentReq, u
 			},
 		},
 	})
-	return ginx.Result{}, err
+	if err != nil {
+		return ginx.Result{}, err
+	}
+	// the author is told the comment is held instead of finding it missing
+	return ginx.Result{Data: uint8(resp.GetStatus())}, nil
 }
 
 func (c *CommentHandler) GetMoreReplies(ctx *gin.Context, req GetMoreRepliesRequest, uc ijwt.UserClaims) (ginx.Result, error) {
 	gCtx := metadata.NewOutgoingContext(ctx, metadata.Pairs("user", strconv.FormatInt(uc.Uid, 10)))
 	resp, err := c.commentSvc.GetMoreReplies(gCtx, &commentv1.GetMoreRepliesRequest{
-		Rid:   req.Rid,
-		MaxId: req.MaxID,
-		Limit: req.Limit,
+		Rid:    req.Rid,
+		MaxId:  req.MaxID,
+		Limit:  req.Limit,
+		Cursor: req.Cursor,
+		Uid:    uc.Uid,
 	})
 	if err != nil {
 		return ginx.Result{}, err
 	}
 	return ginx.Result{
-		Data: slice.Map[*commentv1.Comment, CommentVO](resp.Replies, func(idx int, src *commentv1.Comment) CommentVO {
-			return CommentVO{
-				Id:      src.Id,
-				Uid:     src.Uid,
-				Biz:     src.Biz,
-				BizId:   src.BizId,
-				Content: src.Content,
-				Ctime:   src.Ctime.AsTime().Format(time.DateTime),
-				Utime:   src.Utime.AsTime().Format(time.DateTime),
-			}
-		}),
+		Data: CommentListVO{
+			Comments: slice.Map[*commentv1.Comment, CommentVO](resp.Replies, func(idx int, src *commentv1.Comment) CommentVO {
+				return CommentVO{
+					Id:      src.Id,
+					Uid:     src.Uid,
+					Biz:     src.Biz,
+					BizId:   src.BizId,
+					Content: src.Content,
+					Status:  uint8(src.Status),
+					LikeCnt: src.LikeCnt,
+					Edited:  src.Edited,
+					Deleted: src.Deleted,
+					Ctime:   src.Ctime.AsTime().Format(time.DateTime),
+					Utime:   src.Utime.AsTime().Format(time.DateTime),
+				}
+			}),
+			Cursor: resp.GetNextCursor(),
+		},
 	}, nil
 }
+
+func (c *CommentHandler) Like(ctx *gin.Context, req LikeReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	var err error
+	if req.Like {
+		_, err = c.intrSvc.Like(ctx, &interactivev1.LikeRequest{
+			Biz:   "comment",
+			BizId: req.Id,
+			Uid:   uc.Uid,
+		})
+	} else {
+		_, err = c.intrSvc.CancelLike(ctx, &interactivev1.CancelLikeRequest{
+			Biz:   "comment",
+			BizId: req.Id,
+			Uid:   uc.Uid,
+		})
+	}
+	if status.Code(err) == codes.NotFound {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "no such comment",
+		}, err
+	}
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
@@ -1,11 +1,19 @@
 package web
 
+import commentv1 "github.com/tsukiyo/mercury/api/gen/comment/v1"
+
 type CommentVO struct {
-	Id       int64  `json:"id"`
-	Uid      int64  `json:"uid"`
-	Biz      string `json:"biz"`
-	BizId    int64  `json:"biz_id"`
-	Content  string `json:"content"`
+	Id      int64  `json:"id"`
+	Uid     int64  `json:"uid"`
+	Biz     string `json:"biz"`
+	BizId   int64  `json:"biz_id"`
+	Content string `json:"content"`
+	// Status 1 approved, 2 held for review and 3 rejected, only the author sees the ones not approved
+	Status  uint8 `json:"status"`
+	LikeCnt int64 `json:"like_cnt"`
+	Edited  bool  `json:"edited"`
+	// Deleted the comment is a placeholder kept for its replies
+	Deleted  bool   `json:"deleted"`
 	RootID   int64  `json:"root_id"`
 	ParentID int64  `json:"parent_id"`
 	Ctime    string `json:"ctime"`
@@ -15,8 +23,27 @@ type CommentVO struct {
 type GetCommentListReq struct {
 	Biz   string `json:"biz"`
 	BizId int64  `json:"biz_id"`
-	MinId int64  `json:"min_id"`
-	Limit int64  `json:"limit"`
+	// MinId is only used without Cursor.
+	// Deprecated: use Cursor.
+	MinId int64 `json:"min_id"`
+	Limit int64 `json:"limit"`
+	// Cursor returned by the previous page, empty for the first page
+	Cursor string `json:"cursor"`
+	// Sort is newest, oldest or hot, newest by default
+	Sort string `json:"sort"`
+}
+
+var commentSorts = map[string]commentv1.CommentSort{
+	"":       commentv1.CommentSort_COMMENT_SORT_NEWEST,
+	"newest": commentv1.CommentSort_COMMENT_SORT_NEWEST,
+	"oldest": commentv1.CommentSort_COMMENT_SORT_OLDEST,
+	"hot":    commentv1.CommentSort_COMMENT_SORT_HOT,
+}
+
+type CommentListVO struct {
+	Comments []CommentVO `json:"comments"`
+	// Cursor is empty when there are no more comments
+	Cursor string `json:"cursor"`
 }
 
 type DeleteCommentReq struct {
@@ -24,6 +51,21 @@ type DeleteCommentReq struct {
 	Uid int64
 }
 
+type EditCommentReq struct {
+	Id      int64  `json:"id"`
+	Content string `json:"content"`
+}
+
+type GetEditHistoryReq struct {
+	Id int64 `json:"id"`
+}
+
+type CommentEditVO struct {
+	// Content is the content before the edit
+	Content string `json:"content"`
+	Ctime   string `json:"ctime"`
+}
+
 type CreateCommentReq struct {
 	Id       int64  `json:"id"`
 	Uid      int64  `json:"uid"`
@@ -37,7 +79,11 @@ type CreateCommentReq struct {
 }
 
 type GetMoreRepliesRequest struct {
-	Rid   int64 `json:"rid"`
+	Rid int64 `json:"rid"`
+	// MaxID is only used without Cursor.
+	// Deprecated: use Cursor.
 	MaxID int64 `json:"max_id"`
 	Limit int64 `json:"limit"`
+	// Cursor returned by the previous page, empty for the first page
+	Cursor string `json:"cursor"`
 }
@@ -0,0 +1,194 @@
+package web
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	ijwt "github.com/tsukiyo/mercury/internal/bff/web/jwt"
+	"github.com/tsukiyo/mercury/pkg/ginx"
+)
+
+func (h *ArticleHandler) registerSeriesRoutes(g *gin.RouterGroup) {
+	g.POST("/create", ginx.WrapReqAndClaim[SeriesReq, ijwt.UserClaims](h.CreateSeries))
+	g.POST("/update", ginx.WrapReqAndClaim[SeriesReq, ijwt.UserClaims](h.UpdateSeries))
+	g.POST("/delete", ginx.WrapReqAndClaim[DeleteSeriesReq, ijwt.UserClaims](h.DeleteSeries))
+	g.POST("/articles/add", ginx.WrapReqAndClaim[SeriesArticleReq, ijwt.UserClaims](h.AddSeriesArticle))
+	g.POST("/articles/remove", ginx.WrapReqAndClaim[SeriesArticleReq, ijwt.UserClaims](h.RemoveSeriesArticle))
+	g.POST("/reorder", ginx.WrapReqAndClaim[ReorderSeriesReq, ijwt.UserClaims](h.ReorderSeries))
+	g.GET("/:id", ginx.WrapClaims[ijwt.UserClaims](h.SeriesDetail))
+	g.GET("/author/:id", ginx.WrapClaims[ijwt.UserClaims](h.ListSeries))
+}
+
+func (h *ArticleHandler) CreateSeries(ctx *gin.Context, req SeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	resp, err := h.articleSvc.CreateSeries(ctx, &articlev1.CreateSeriesRequest{Series: req.toDTO(uc.Uid)})
+	if res, ok := seriesResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Data: resp.GetId()}, nil
+}
+
+func (h *ArticleHandler) UpdateSeries(ctx *gin.Context, req SeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.UpdateSeries(ctx, &articlev1.UpdateSeriesRequest{Series: req.toDTO(uc.Uid)})
+	if res, ok := seriesResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) DeleteSeries(ctx *gin.Context, req DeleteSeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.DeleteSeries(ctx, &articlev1.DeleteSeriesRequest{Id: req.Id, Uid: uc.Uid})
+	if res, ok := seriesResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) AddSeriesArticle(ctx *gin.Context, req SeriesArticleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.AddSeriesArticle(ctx, &articlev1.AddSeriesArticleRequest{
+		Id:        req.Id,
+		Uid:       uc.Uid,
+		ArticleId: req.ArticleId,
+	})
+	if res, ok := seriesResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) RemoveSeriesArticle(ctx *gin.Context, req SeriesArticleReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.RemoveSeriesArticle(ctx, &articlev1.RemoveSeriesArticleRequest{
+		Id:        req.Id,
+		Uid:       uc.Uid,
+		ArticleId: req.ArticleId,
+	})
+	if res, ok := seriesResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) ReorderSeries(ctx *gin.Context, req ReorderSeriesReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.ReorderSeries(ctx, &articlev1.ReorderSeriesRequest{
+		Id:         req.Id,
+		Uid:        uc.Uid,
+		ArticleIds: req.ArticleIds,
+	})
+	if res, ok := seriesResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) SeriesDetail(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid params",
+		}, err
+	}
+	resp, err := h.articleSvc.GetSeries(ctx, &articlev1.GetSeriesRequest{Id: id})
+	if res, ok := seriesResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Data: newSeriesVO(resp.GetSeries())}, nil
+}
+
+func (h *ArticleHandler) ListSeries(ctx *gin.Context, uc ijwt.UserClaims) (ginx.Result, error) {
+	authorId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "invalid params",
+		}, err
+	}
+	resp, err := h.articleSvc.ListSeries(ctx, &articlev1.ListSeriesRequest{AuthorId: authorId})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.GetSeries(), func(idx int, src *articlev1.Series) SeriesVO {
+			return newSeriesVO(src)
+		}),
+	}, nil
+}
+
+// seriesResult reports the errors of managing series, ok is false if err is nil.
+func seriesResult(err error) (ginx.Result, bool) {
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{}, false
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "no such series or article"}, true
+	case codes.FailedPrecondition:
+		return ginx.Result{Code: 4, Msg: status.Convert(err).Message()}, true
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "invalid params"}, true
+	default:
+		return ginx.Result{Code: 5, Msg: "internal error"}, true
+	}
+}
+
+func (req SeriesReq) toDTO(uid int64) *articlev1.Series {
+	return &articlev1.Series{
+		Id:          req.Id,
+		Author:      &articlev1.Author{Id: uid},
+		Title:       req.Title,
+		Description: req.Description,
+	}
+}
+
+func newSeriesVO(s *articlev1.Series) SeriesVO {
+	return SeriesVO{
+		Id:          s.GetId(),
+		AuthorId:    s.GetAuthor().GetId(),
+		Title:       s.GetTitle(),
+		Description: s.GetDescription(),
+		Articles: slice.Map(s.GetArticles(), func(idx int, src *articlev1.SeriesArticle) SeriesArticleVO {
+			return newSeriesArticleVO(src)
+		}),
+		Ctime: s.GetCtime().AsTime().Format(time.DateTime),
+		Utime: s.GetUtime().AsTime().Format(time.DateTime),
+	}
+}
+
+func newSeriesArticleVO(atcl *articlev1.SeriesArticle) SeriesArticleVO {
+	return SeriesArticleVO{
+		Id:          atcl.GetId(),
+		Title:       atcl.GetTitle(),
+		Abstract:    atcl.GetAbstract(),
+		ReadCnt:     atcl.GetReadCnt(),
+		LikeCnt:     atcl.GetLikeCnt(),
+		FavoriteCnt: atcl.GetFavoriteCnt(),
+		Utime:       atcl.GetUtime().AsTime().Format(time.DateTime),
+	}
+}
+
+// newSeriesNavVO returns nil if the article is not in a series.
+func newSeriesNavVO(nav *articlev1.SeriesNav) *SeriesNavVO {
+	if nav == nil {
+		return nil
+	}
+	res := &SeriesNavVO{
+		Id:    nav.GetSeriesId(),
+		Title: nav.GetSeriesTitle(),
+	}
+	if nav.GetPrev() != nil {
+		prev := newSeriesArticleVO(nav.GetPrev())
+		res.Prev = &prev
+	}
+	if nav.GetNext() != nil {
+		next := newSeriesArticleVO(nav.GetNext())
+		res.Next = &next
+	}
+	return res
+}
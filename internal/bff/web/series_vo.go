@@ -0,0 +1,52 @@
+package web
+
+type SeriesReq struct {
+	// Id is left zero to create a series
+	Id          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type DeleteSeriesReq struct {
+	Id int64 `json:"id"`
+}
+
+type SeriesArticleReq struct {
+	Id        int64 `json:"id"`
+	ArticleId int64 `json:"article_id"`
+}
+
+type ReorderSeriesReq struct {
+	Id int64 `json:"id"`
+	// ArticleIds are all the articles of the series in the new order
+	ArticleIds []int64 `json:"article_ids"`
+}
+
+type SeriesVO struct {
+	Id          int64  `json:"id"`
+	AuthorId    int64  `json:"author_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Articles are only returned with the detail of the series
+	Articles []SeriesArticleVO `json:"articles,omitempty"`
+	Ctime    string            `json:"ctime"`
+	Utime    string            `json:"utime"`
+}
+
+type SeriesArticleVO struct {
+	Id          int64  `json:"id"`
+	Title       string `json:"title"`
+	Abstract    string `json:"abstract"`
+	ReadCnt     int64  `json:"read_cnt"`
+	LikeCnt     int64  `json:"like_cnt"`
+	FavoriteCnt int64  `json:"favorite_cnt"`
+	Utime       string `json:"utime"`
+}
+
+// SeriesNavVO leads to the previous and next articles in the series, which are nil at either end.
+type SeriesNavVO struct {
+	Id    int64            `json:"id"`
+	Title string           `json:"title"`
+	Prev  *SeriesArticleVO `json:"prev,omitempty"`
+	Next  *SeriesArticleVO `json:"next,omitempty"`
+}
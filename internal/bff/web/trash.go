@@ -0,0 +1,101 @@
+package web
+
+import (
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	ijwt "github.com/tsukiyo/mercury/internal/bff/web/jwt"
+	"github.com/tsukiyo/mercury/pkg/ginx"
+)
+
+func (h *ArticleHandler) registerTrashRoutes(g *gin.RouterGroup) {
+	g.POST("/delete", ginx.WrapReqAndClaim[TrashReq, ijwt.UserClaims](h.Delete))
+	g.POST("/restore", ginx.WrapReqAndClaim[TrashReq, ijwt.UserClaims](h.Restore))
+	g.POST("/trash", ginx.WrapReqAndClaim[ListTrashReq, ijwt.UserClaims](h.ListTrash))
+}
+
+// TrashReq moves the article to the trash or takes it out.
+type TrashReq struct {
+	Id int64 `json:"id"`
+}
+
+type ListTrashReq struct {
+	Offset int32 `json:"offset"`
+	Limit  int32 `json:"limit"`
+}
+
+func (h *ArticleHandler) Delete(ctx *gin.Context, req TrashReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.Delete(ctx, &articlev1.DeleteRequest{
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if res, ok := trashResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) Restore(ctx *gin.Context, req TrashReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	_, err := h.articleSvc.Restore(ctx, &articlev1.RestoreRequest{
+		Id:  req.Id,
+		Uid: uc.Uid,
+	})
+	if res, ok := trashResult(err); ok {
+		return res, err
+	}
+	return ginx.Result{Msg: "OK"}, nil
+}
+
+func (h *ArticleHandler) ListTrash(ctx *gin.Context, req ListTrashReq, uc ijwt.UserClaims) (ginx.Result, error) {
+	if req.Limit > 100 {
+		req.Limit = 100
+	} else if req.Limit < 0 {
+		req.Limit = 0
+	}
+	resp, err := h.articleSvc.ListTrash(ctx, &articlev1.ListTrashRequest{
+		Uid:    uc.Uid,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return ginx.Result{
+			Code: 5,
+			Msg:  "internal error",
+		}, err
+	}
+	return ginx.Result{
+		Data: slice.Map[*articlev1.Article, ArticleVO](resp.GetArticles(), func(idx int, src *articlev1.Article) ArticleVO {
+			return ArticleVO{
+				Id:        src.GetId(),
+				Title:     src.GetTitle(),
+				Abstract:  src.GetAbstract(),
+				Status:    uint8(src.GetStatus()),
+				Tags:      src.GetTags(),
+				TrashedAt: src.GetTrashedAt().AsTime().Format(time.DateTime),
+				Ctime:     src.GetCtime().AsTime().Format(time.DateTime),
+				Utime:     src.GetUtime().AsTime().Format(time.DateTime),
+			}
+		}),
+	}, nil
+}
+
+// trashResult reports the errors of moving articles in and out of the trash, ok is false if err is nil.
+func trashResult(err error) (ginx.Result, bool) {
+	switch status.Code(err) {
+	case codes.OK:
+		return ginx.Result{}, false
+	case codes.PermissionDenied:
+		return ginx.Result{Code: 4, Msg: "permission denied"}, true
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "no such article in the trash"}, true
+	case codes.FailedPrecondition:
+		return ginx.Result{Code: 4, Msg: "the article is in the trash"}, true
+	default:
+		return ginx.Result{Code: 5, Msg: "internal error"}, true
+	}
+}
@@ -14,6 +14,7 @@ import (
 var thirdProviderSet = wire.NewSet(
 	ioc.InitLogger,
 	ioc.InitRedis,
+	ioc.InitInvalidator,
 	ioc.InitEtcdClient,
 )
 
@@ -23,6 +24,7 @@ var hdlProviderSet = wire.NewSet(
 	web.NewOAuth2Handler,
 	web.NewArticleHandler,
 	web.NewCommentHandler,
+	web.NewAttachmentHandler,
 )
 
 var cliProviderSet = wire.NewSet(
@@ -32,6 +34,7 @@ var cliProviderSet = wire.NewSet(
 	ioc.InitArticleClient,
 	ioc.InitInteractiveClient,
 	ioc.InitCommentClient,
+	ioc.InitAttachmentClient,
 )
 
 func InitAPP() *app.App {
@@ -8,7 +8,6 @@ package main
 
 import (
 	"github.com/google/wire"
-
 	"github.com/tsukiyo/mercury/internal/bff/ioc"
 	"github.com/tsukiyo/mercury/internal/bff/web"
 	"github.com/tsukiyo/mercury/internal/bff/web/jwt"
@@ -29,12 +28,15 @@ func InitAPP() *app.App {
 	oauth2ServiceClient := ioc.InitOAuth2Client(client)
 	wechatHandlerConfig := ioc.InitWechatHandlerConfig()
 	oAuth2WechatHandler := web.NewOAuth2Handler(oauth2ServiceClient, userServiceClient, wechatHandlerConfig, handler)
-	articleServiceClient := ioc.InitArticleClient(client)
+	invalidator := ioc.InitInvalidator(cmdable, logger)
+	articleServiceClient := ioc.InitArticleClient(client, invalidator, logger)
 	interactiveServiceClient := ioc.InitInteractiveClient(client)
-	articleHandler := web.NewArticleHandler(articleServiceClient, interactiveServiceClient, logger)
 	commentServiceClient := ioc.InitCommentClient(client)
-	commentHandler := web.NewCommentHandler(commentServiceClient)
-	server := ioc.InitWebServer(limiter, handler, userHandler, oAuth2WechatHandler, articleHandler, commentHandler, logger)
+	articleHandler := web.NewArticleHandler(articleServiceClient, interactiveServiceClient, commentServiceClient, logger)
+	commentHandler := web.NewCommentHandler(commentServiceClient, interactiveServiceClient)
+	attachmentServiceClient := ioc.InitAttachmentClient(client)
+	attachmentHandler := web.NewAttachmentHandler(attachmentServiceClient, logger)
+	server := ioc.InitWebServer(limiter, handler, userHandler, oAuth2WechatHandler, articleHandler, commentHandler, attachmentHandler, logger)
 	appApp := &app.App{
 		WebServer: server,
 	}
@@ -43,8 +45,8 @@ func InitAPP() *app.App {
 
 // wire.go:
 
-var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitRedis, ioc.InitEtcdClient)
+var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitRedis, ioc.InitInvalidator, ioc.InitEtcdClient)
 
-var hdlProviderSet = wire.NewSet(web.NewUserHandler, jwt.NewRedisJWTHandler, web.NewOAuth2Handler, web.NewArticleHandler, web.NewCommentHandler)
+var hdlProviderSet = wire.NewSet(web.NewUserHandler, jwt.NewRedisJWTHandler, web.NewOAuth2Handler, web.NewArticleHandler, web.NewCommentHandler, web.NewAttachmentHandler)
 
-var cliProviderSet = wire.NewSet(ioc.InitUserClient, ioc.InitCaptchaClient, ioc.InitOAuth2Client, ioc.InitArticleClient, ioc.InitInteractiveClient, ioc.InitCommentClient)
+var cliProviderSet = wire.NewSet(ioc.InitUserClient, ioc.InitCaptchaClient, ioc.InitOAuth2Client, ioc.InitArticleClient, ioc.InitInteractiveClient, ioc.InitCommentClient, ioc.InitAttachmentClient)
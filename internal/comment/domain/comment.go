@@ -1,13 +1,55 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"regexp"
+	"time"
+)
+
+type CommentStatus uint8
+
+const (
+	CommentStatusUnknown CommentStatus = iota
+	CommentStatusApproved
+	// CommentStatusPending the comment is held for review, only its author sees it
+	CommentStatusPending
+	// CommentStatusRejected the comment failed review, only its author sees it
+	CommentStatusRejected
+)
+
+// CommentSort is the order of the top comments.
+type CommentSort uint8
+
+const (
+	CommentSortNewest CommentSort = iota
+	CommentSortOldest
+	// CommentSortHot the comments liked more and written later go first
+	CommentSortHot
+)
+
+// maxMentions bounds the users notified by one comment
+const maxMentions = 10
+
+// mentionPattern matches @nickname not preceded by a letter or a digit, so an email is not a mention,
+// the nick name ends at a space or a punctuation
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])@([\p{L}\p{N}_-]{1,32})`)
+
+// hotGravity is how long a comment has to be younger to outrank one liked 10 times more
+const hotGravity = 12.5 * 60 * 60
 
 type Comment struct {
-	ID            int64     `json:"id"`
-	Commentator   User      `json:"user"`
-	Biz           string    `json:"biz"`
-	BizID         int64     `json:"biz_id"`
-	Content       string    `json:"content"`
+	ID          int64         `json:"id"`
+	Commentator User          `json:"user"`
+	Biz         string        `json:"biz"`
+	BizID       int64         `json:"biz_id"`
+	Content     string        `json:"content"`
+	Status      CommentStatus `json:"status"`
+	LikeCnt     int64         `json:"like_cnt"`
+	Edited      bool          `json:"edited"`
+	// Deleted the comment is kept for its replies, with DeletedContent as its content
+	Deleted bool `json:"deleted"`
+	// Reason is what the moderation matched when the comment was held
+	Reason        string    `json:"reason"`
 	RootComment   *Comment  `json:"root_comment"`
 	ParentComment *Comment  `json:"parent_comment"`
 	Children      []Comment `json:"children"`
@@ -15,7 +57,48 @@ type Comment struct {
 	UTime         time.Time `json:"utime"`
 }
 
+// DeletedContent is the content of a deleted comment
+const DeletedContent = "this comment was deleted"
+
+// CommentEdit is a content of the comment replaced by an edit, CTime is when it was replaced.
+type CommentEdit struct {
+	CommentID int64
+	Content   string
+	CTime     time.Time
+}
+
+// CommentCount is the number of the approved comments not deleted of a resource.
+type CommentCount struct {
+	// RootCnt is the number of the top comments
+	RootCnt  int64
+	TotalCnt int64
+}
+
 type User struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
 }
+
+// HotScore decays by time instead of being recalculated, the order of two comments only changes when they are liked,
+// so the scores could be kept in a sorted set.
+func (c Comment) HotScore() float64 {
+	return math.Log10(float64(max(c.LikeCnt, 1))) + float64(c.CTime.Unix())/hotGravity
+}
+
+// Mentions returns the nick names mentioned in the content in order, without duplicates.
+func (c Comment) Mentions() []string {
+	matches := mentionPattern.FindAllStringSubmatch(c.Content, -1)
+	res := make([]string, 0, min(len(matches), maxMentions))
+	seen := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		if len(res) == maxMentions {
+			break
+		}
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		res = append(res, m[1])
+	}
+	return res
+}
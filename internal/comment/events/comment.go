@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/sarama"
+)
+
+const TopicCommentCreatedEvent = "comment_created_event"
+
+// CommentCreatedEvent is sent once a comment is visible to everyone,
+// at creation or once it is approved by review.
+type CommentCreatedEvent struct {
+	Id    int64
+	Biz   string
+	BizId int64
+	// Uid is the author of the comment
+	Uid int64
+	// RootId, ParentId and their authors are 0 for a top comment
+	RootId    int64
+	RootUid   int64
+	ParentId  int64
+	ParentUid int64
+	// MentionedUids are the users mentioned by @nickname, the nick names shared by several users are left out
+	MentionedUids []int64
+	// Ctime unix timestamp in milliseconds
+	Ctime int64
+}
+
+var _ Producer = (*SaramaProducer)(nil)
+
+type SaramaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaProducer{
+		producer: producer,
+	}
+}
+
+func (p *SaramaProducer) ProduceCommentCreatedEvent(ctx context.Context, evt CommentCreatedEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicCommentCreatedEvent,
+		Key:   sarama.StringEncoder(fmt.Sprintf("%s:%d", evt.Biz, evt.BizId)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/internal/comment/repository"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+const topicDeleteEvent = "article_delete_event"
+
+// DeleteEvent is sent once an article is purged from the trash.
+type DeleteEvent struct {
+	Aid      int64
+	AuthorId int64
+	Utime    int64
+}
+
+var _ Consumer = (*ArticleDeleteEventConsumer)(nil)
+
+// ArticleDeleteEventConsumer deletes the comments of the purged articles.
+type ArticleDeleteEventConsumer struct {
+	client sarama.Client
+	repo   repository.CommentRepository
+	l      logger.Logger
+}
+
+func NewArticleDeleteEventConsumer(client sarama.Client, repo repository.CommentRepository, l logger.Logger) *ArticleDeleteEventConsumer {
+	return &ArticleDeleteEventConsumer{
+		client: client,
+		repo:   repo,
+		l:      l,
+	}
+}
+
+func (consumer *ArticleDeleteEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("comment_article_delete", consumer.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicDeleteEvent},
+			saramax.NewHandler[DeleteEvent](consumer.l, consumer.Consume),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (consumer *ArticleDeleteEventConsumer) Consume(msg *sarama.ConsumerMessage, evt DeleteEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return consumer.repo.DeleteByBiz(ctx, "article", evt.Aid)
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/internal/comment/repository"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+const topicLikeEvent = "interactive_like_event"
+
+// LikeEvent is sent by the interactive service once a like is added or cancelled.
+type LikeEvent struct {
+	Biz   string
+	BizId int64
+	Uid   int64
+	// Delta is 1 for a like and -1 for a cancelled one
+	Delta int64
+	Utime int64
+}
+
+var _ Consumer = (*LikeEventConsumer)(nil)
+
+// LikeEventConsumer counts the likes of the comments to rank them.
+type LikeEventConsumer struct {
+	client sarama.Client
+	repo   repository.CommentRepository
+	l      logger.Logger
+}
+
+func NewLikeEventConsumer(client sarama.Client, repo repository.CommentRepository, l logger.Logger) *LikeEventConsumer {
+	return &LikeEventConsumer{
+		client: client,
+		repo:   repo,
+		l:      l,
+	}
+}
+
+func (consumer *LikeEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("comment_like", consumer.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicLikeEvent},
+			saramax.NewBatchHandler[LikeEvent](consumer.l, consumer.BatchConsume),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (consumer *LikeEventConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, evts []LikeEvent) error {
+	// the likes of a comment in the batch are counted in one update
+	deltas := make(map[int64]int64, len(evts))
+	for _, evt := range evts {
+		if evt.Biz != "comment" {
+			continue
+		}
+		deltas[evt.BizId] += evt.Delta
+	}
+	if len(deltas) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return consumer.repo.AddLikeCnt(ctx, deltas)
+}
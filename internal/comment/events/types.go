@@ -0,0 +1,11 @@
+package events
+
+import "context"
+
+type Consumer interface {
+	Start() error
+}
+
+type Producer interface {
+	ProduceCommentCreatedEvent(ctx context.Context, evt CommentCreatedEvent) error
+}
@@ -2,7 +2,7 @@ package grpc
 
 import (
 	"context"
-	"math"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,22 +31,85 @@ func (c *CommentServiceServer) Register(srv *grpc.Server) {
 }
 
 func (c *CommentServiceServer) GetCommentList(ctx context.Context, request *commentv1.GetCommentListRequest) (*commentv1.GetCommentListResponse, error) {
-	minID := request.GetMinId()
-	if minID <= 0 {
-		minID = math.MaxInt64
+	var (
+		bizComments []domain.Comment
+		next        string
+		err         error
+	)
+	// min_id is kept for the callers not moved to cursor yet
+	if minID := request.GetMinId(); minID > 0 && request.GetCursor() == "" && request.GetSort() == commentv1.CommentSort_COMMENT_SORT_NEWEST {
+		bizComments, err = c.svc.GetCommentList(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), minID, request.GetLimit())
+	} else {
+		bizComments, next, err = c.svc.GetCommentListByCursor(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(),
+			domain.CommentSort(request.GetSort()), request.GetCursor(), request.GetLimit())
 	}
-	bizComments, err := c.svc.GetCommentList(ctx, request.GetBiz(), request.GetBizId(), minID, request.GetLimit())
 	if err != nil {
 		return nil, err
 	}
 	return &commentv1.GetCommentListResponse{
-		Comments: c.toDTO(bizComments),
+		Comments:   c.toDTO(bizComments),
+		NextCursor: next,
 	}, nil
 }
 
 func (c *CommentServiceServer) DeleteComment(ctx context.Context, request *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
-	err := c.svc.DeleteComment(ctx, request.GetId())
-	return &commentv1.DeleteCommentResponse{}, err
+	err := c.svc.DeleteComment(ctx, request.GetId(), request.GetUid())
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound):
+		return nil, status.Error(codes.NotFound, "no such comment")
+	case errors.Is(err, service.ErrPermissionDenied):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &commentv1.DeleteCommentResponse{}, nil
+}
+
+func (c *CommentServiceServer) EditComment(ctx context.Context, request *commentv1.EditCommentRequest) (*commentv1.EditCommentResponse, error) {
+	st, err := c.svc.EditComment(ctx, request.GetId(), request.GetUid(), request.GetContent())
+	if errors.Is(err, service.ErrCommentNotFound) {
+		return nil, status.Error(codes.NotFound, "no such comment of the user")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.EditCommentResponse{Status: commentv1.CommentStatus(st)}, nil
+}
+
+func (c *CommentServiceServer) GetCommentCounts(ctx context.Context, request *commentv1.GetCommentCountsRequest) (*commentv1.GetCommentCountsResponse, error) {
+	counts, err := c.svc.GetCommentCounts(ctx, request.GetBiz(), request.GetBizIds())
+	if errors.Is(err, service.ErrTooManyIds) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]*commentv1.CommentCount, len(counts))
+	for id, cnt := range counts {
+		res[id] = &commentv1.CommentCount{
+			RootCnt:  cnt.RootCnt,
+			TotalCnt: cnt.TotalCnt,
+		}
+	}
+	return &commentv1.GetCommentCountsResponse{Counts: res}, nil
+}
+
+func (c *CommentServiceServer) GetEditHistory(ctx context.Context, request *commentv1.GetEditHistoryRequest) (*commentv1.GetEditHistoryResponse, error) {
+	edits, err := c.svc.GetEditHistory(ctx, request.GetId(), request.GetUid())
+	if errors.Is(err, service.ErrCommentNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*commentv1.CommentEdit, 0, len(edits))
+	for _, edit := range edits {
+		res = append(res, &commentv1.CommentEdit{
+			Content: edit.Content,
+			Ctime:   timestamppb.New(edit.CTime),
+		})
+	}
+	return &commentv1.GetEditHistoryResponse{Edits: res}, nil
 }
 
 func (c *CommentServiceServer) CreateComment(ctx context.Context, request *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
@@ -54,20 +117,75 @@ func (c *CommentServiceServer) CreateComment(ctx context.Context, request *comme
 	if comment.RootComment == nil && comment.ParentComment != nil || comment.ParentComment == nil && comment.RootComment != nil {
 		return &commentv1.CreateCommentResponse{}, status.Error(codes.InvalidArgument, "invalid args")
 	}
-	err := c.svc.CreateComment(ctx, c.toDomain(comment))
-	return &commentv1.CreateCommentResponse{}, err
+	st, err := c.svc.CreateComment(ctx, c.toDomain(comment))
+	return &commentv1.CreateCommentResponse{Status: commentv1.CommentStatus(st)}, err
+}
+
+func (c *CommentServiceServer) GetCommentByIds(ctx context.Context, request *commentv1.GetCommentByIdsRequest) (*commentv1.GetCommentByIdsResponse, error) {
+	comments, err := c.svc.GetCommentByIds(ctx, request.GetIds())
+	if err != nil {
+		return nil, err
+	}
+	return &commentv1.GetCommentByIdsResponse{Comments: c.toDTO(comments)}, nil
 }
 
 func (c *CommentServiceServer) GetMoreReplies(ctx context.Context, request *commentv1.GetMoreRepliesRequest) (*commentv1.GetMoreRepliesResponse, error) {
-	comments, err := c.svc.GetMoreReplies(ctx, request.GetRid(), request.GetMaxId(), request.GetLimit())
+	var (
+		comments []domain.Comment
+		next     string
+		err      error
+	)
+	// max_id is kept for the callers not moved to cursor yet
+	if maxID := request.GetMaxId(); maxID > 0 && request.GetCursor() == "" {
+		comments, err = c.svc.GetMoreReplies(ctx, request.GetRid(), request.GetUid(), maxID, request.GetLimit())
+	} else {
+		comments, next, err = c.svc.GetMoreRepliesByCursor(ctx, request.GetRid(), request.GetUid(), request.GetCursor(), request.GetLimit())
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &commentv1.GetMoreRepliesResponse{
-		Replies: c.toDTO(comments),
+		Replies:    c.toDTO(comments),
+		NextCursor: next,
 	}, nil
 }
 
+func (c *CommentServiceServer) ListPendingComments(ctx context.Context, request *commentv1.ListPendingCommentsRequest) (*commentv1.ListPendingCommentsResponse, error) {
+	comments, next, err := c.svc.ListPendingComments(ctx, request.GetCursor(), request.GetLimit())
+	if err != nil {
+		return nil, toReviewStatus(err)
+	}
+	dtoComments := c.toDTO(comments)
+	// the reason is only for the reviewers
+	for i, comment := range comments {
+		dtoComments[i].Reason = comment.Reason
+	}
+	return &commentv1.ListPendingCommentsResponse{
+		Comments:   dtoComments,
+		NextCursor: next,
+	}, nil
+}
+
+func (c *CommentServiceServer) ReviewComment(ctx context.Context, request *commentv1.ReviewCommentRequest) (*commentv1.ReviewCommentResponse, error) {
+	err := c.svc.ReviewComment(ctx, request.GetId(), request.GetApproved())
+	if err != nil {
+		return nil, toReviewStatus(err)
+	}
+	return &commentv1.ReviewCommentResponse{}, nil
+}
+
+// toReviewStatus reports the errors of the review queue with their codes.
+func toReviewStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound):
+		return status.Error(codes.NotFound, "the comment is not pending")
+	case errors.Is(err, service.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
+
 func (c *CommentServiceServer) toDTO(bizComments []domain.Comment) []*commentv1.Comment {
 	dtoComments := make([]*commentv1.Comment, 0, len(bizComments))
 	for _, bizComment := range bizComments {
@@ -77,6 +195,10 @@ func (c *CommentServiceServer) toDTO(bizComments []domain.Comment) []*commentv1.
 			Biz:     bizComment.Biz,
 			BizId:   bizComment.BizID,
 			Content: bizComment.Content,
+			Status:  commentv1.CommentStatus(bizComment.Status),
+			LikeCnt: bizComment.LikeCnt,
+			Edited:  bizComment.Edited,
+			Deleted: bizComment.Deleted,
 			Ctime:   timestamppb.New(bizComment.CTime),
 			Utime:   timestamppb.New(bizComment.UTime),
 		}
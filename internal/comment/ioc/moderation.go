@@ -0,0 +1,15 @@
+package ioc
+
+import (
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/moderation"
+)
+
+// InitContentFilter builds the filter chain from "moderation" in the config and reloads its rules once the config changes.
+func InitContentFilter(l logger.Logger) moderation.ContentFilter {
+	kw, err := moderation.NewReloadingKeywordFilter("moderation", l)
+	if err != nil {
+		panic(err)
+	}
+	return moderation.Chain{kw}
+}
@@ -0,0 +1,37 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	userv1 "github.com/tsukiyo/mercury/api/gen/user/v1"
+)
+
+func InitUserRpcClient(etcdCli *clientv3.Client) userv1.UserServiceClient {
+	type config struct {
+		Target string `yaml:"target"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg config
+	err := viper.UnmarshalKey("grpc.client.user", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	rs, err := resolver.NewBuilder(etcdCli)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{grpc.WithResolvers(rs)}
+	if !cfg.Secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	conn, err := grpc.NewClient(cfg.Target, opts...)
+	if err != nil {
+		panic(err)
+	}
+	client := userv1.NewUserServiceClient(conn)
+	return client
+}
@@ -24,6 +24,8 @@ func initViper() {
 	if err != nil {
 		panic(err)
 	}
+	// the moderation rules are reloaded once the config changes
+	viper.WatchConfig()
 }
 
 func initLogger() {
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed lua/zadd_if_present.lua
+var luaZAddIfPresent string
+
+// ErrKeyNotExist the hot order of the resource is not cached and has to be rebuilt
+var ErrKeyNotExist = errors.New("hot comments not cached")
+
+// hotPlaceholder keeps the order of a resource without comments cached, it is never returned
+const hotPlaceholder = "0"
+
+//go:generate mockgen -source=./hot.go -package=cachemocks -destination=mocks/hot.mock.go CommentHotCache
+type CommentHotCache interface {
+	// Range returns the ids of the top comments from offset hottest first, ErrKeyNotExist if the order is not cached.
+	Range(ctx context.Context, biz string, bizId int64, offset, limit int) ([]int64, error)
+	// Rebuild replaces the order of the resource with the scores by the id of the comment.
+	Rebuild(ctx context.Context, biz string, bizId int64, scores map[int64]float64) error
+	// SetIfPresent sets the scores of the comments if the order of the resource is cached.
+	SetIfPresent(ctx context.Context, biz string, bizId int64, scores map[int64]float64) error
+	Remove(ctx context.Context, biz string, bizId int64, ids ...int64) error
+	Del(ctx context.Context, biz string, bizId int64) error
+}
+
+var _ CommentHotCache = (*RedisCommentHotCache)(nil)
+
+// RedisCommentHotCache keeps the top comments of a resource in a sorted set scored by domain.Comment.HotScore.
+type RedisCommentHotCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisCommentHotCache(client redis.Cmdable) CommentHotCache {
+	return &RedisCommentHotCache{
+		client:     client,
+		expiration: time.Hour * 24,
+	}
+}
+
+func (cache *RedisCommentHotCache) key(biz string, bizId int64) string {
+	return fmt.Sprintf("comment:hot:%s:%d", biz, bizId)
+}
+
+func (cache *RedisCommentHotCache) Range(ctx context.Context, biz string, bizId int64, offset, limit int) ([]int64, error) {
+	key := cache.key(biz, bizId)
+	var (
+		exists  *redis.IntCmd
+		members *redis.StringSliceCmd
+	)
+	_, err := cache.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		exists = pipe.Exists(ctx, key)
+		members = pipe.ZRevRange(ctx, key, int64(offset), int64(offset+limit-1))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if exists.Val() == 0 {
+		return nil, ErrKeyNotExist
+	}
+	vals := members.Val()
+	ids := make([]int64, 0, len(vals))
+	for _, v := range vals {
+		if v == hotPlaceholder {
+			continue
+		}
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (cache *RedisCommentHotCache) Rebuild(ctx context.Context, biz string, bizId int64, scores map[int64]float64) error {
+	key := cache.key(biz, bizId)
+	members := make([]redis.Z, 0, len(scores)+1)
+	members = append(members, redis.Z{Score: math.Inf(-1), Member: hotPlaceholder})
+	for id, score := range scores {
+		members = append(members, redis.Z{Score: score, Member: strconv.FormatInt(id, 10)})
+	}
+	// readers never see a half built order
+	_, err := cache.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, cache.expiration)
+		return nil
+	})
+	return err
+}
+
+func (cache *RedisCommentHotCache) SetIfPresent(ctx context.Context, biz string, bizId int64, scores map[int64]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(scores)*2)
+	for id, score := range scores {
+		args = append(args, strconv.FormatFloat(score, 'f', -1, 64), strconv.FormatInt(id, 10))
+	}
+	return cache.client.Eval(ctx, luaZAddIfPresent, []string{cache.key(biz, bizId)}, args...).Err()
+}
+
+func (cache *RedisCommentHotCache) Remove(ctx context.Context, biz string, bizId int64, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, strconv.FormatInt(id, 10))
+	}
+	return cache.client.ZRem(ctx, cache.key(biz, bizId), members...).Err()
+}
+
+func (cache *RedisCommentHotCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return cache.client.Del(ctx, cache.key(biz, bizId)).Err()
+}
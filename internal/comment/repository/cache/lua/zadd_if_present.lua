@@ -0,0 +1,7 @@
+local key = KEYS[1]
+
+if redis.call("EXISTS", key) == 0 then
+    return 0
+end
+-- ARGV holds pairs of the score and the member
+return redis.call("ZADD", key, unpack(ARGV))
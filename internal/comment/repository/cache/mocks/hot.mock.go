@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./hot.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./hot.go -package=cachemocks -destination=mocks/hot.mock.go CommentHotCache
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommentHotCache is a mock of CommentHotCache interface.
+type MockCommentHotCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentHotCacheMockRecorder
+}
+
+// MockCommentHotCacheMockRecorder is the mock recorder for MockCommentHotCache.
+type MockCommentHotCacheMockRecorder struct {
+	mock *MockCommentHotCache
+}
+
+// NewMockCommentHotCache creates a new mock instance.
+func NewMockCommentHotCache(ctrl *gomock.Controller) *MockCommentHotCache {
+	mock := &MockCommentHotCache{ctrl: ctrl}
+	mock.recorder = &MockCommentHotCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentHotCache) EXPECT() *MockCommentHotCacheMockRecorder {
+	return m.recorder
+}
+
+// Del mocks base method.
+func (m *MockCommentHotCache) Del(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockCommentHotCacheMockRecorder) Del(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockCommentHotCache)(nil).Del), ctx, biz, bizId)
+}
+
+// Range mocks base method.
+func (m *MockCommentHotCache) Range(ctx context.Context, biz string, bizId int64, offset, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Range", ctx, biz, bizId, offset, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Range indicates an expected call of Range.
+func (mr *MockCommentHotCacheMockRecorder) Range(ctx, biz, bizId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Range", reflect.TypeOf((*MockCommentHotCache)(nil).Range), ctx, biz, bizId, offset, limit)
+}
+
+// Rebuild mocks base method.
+func (m *MockCommentHotCache) Rebuild(ctx context.Context, biz string, bizId int64, scores map[int64]float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild", ctx, biz, bizId, scores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebuild indicates an expected call of Rebuild.
+func (mr *MockCommentHotCacheMockRecorder) Rebuild(ctx, biz, bizId, scores any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockCommentHotCache)(nil).Rebuild), ctx, biz, bizId, scores)
+}
+
+// Remove mocks base method.
+func (m *MockCommentHotCache) Remove(ctx context.Context, biz string, bizId int64, ids ...int64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, biz, bizId}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Remove", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCommentHotCacheMockRecorder) Remove(ctx, biz, bizId any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, biz, bizId}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCommentHotCache)(nil).Remove), varargs...)
+}
+
+// SetIfPresent mocks base method.
+func (m *MockCommentHotCache) SetIfPresent(ctx context.Context, biz string, bizId int64, scores map[int64]float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIfPresent", ctx, biz, bizId, scores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIfPresent indicates an expected call of SetIfPresent.
+func (mr *MockCommentHotCacheMockRecorder) SetIfPresent(ctx, biz, bizId, scores any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfPresent", reflect.TypeOf((*MockCommentHotCache)(nil).SetIfPresent), ctx, biz, bizId, scores)
+}
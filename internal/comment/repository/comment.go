@@ -1,45 +1,156 @@
 package repository
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"errors"
+	"slices"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/tsukiyo/mercury/internal/comment/domain"
+	"github.com/tsukiyo/mercury/internal/comment/repository/cache"
 	"github.com/tsukiyo/mercury/internal/comment/repository/dao"
 	"github.com/tsukiyo/mercury/pkg/logger"
 )
 
+var ErrCommentNotFound = dao.ErrCommentNotFound
+
 type CommentRepository interface {
+	// FindByBiz returns the top comments with their first replies, the ones not approved are only returned to their author viewer.
 	FindByBiz(ctx context.Context, biz string,
-		bizId, minID, limit int64) ([]domain.Comment, error)
+		bizId, viewer, minID, limit int64) ([]domain.Comment, error)
+	// FindByBizAsc returns the top comments with id greater than maxID earliest first, with their first replies.
+	FindByBizAsc(ctx context.Context, biz string,
+		bizId, viewer, maxID, limit int64) ([]domain.Comment, error)
+	// FindHot returns the approved top comments from offset hottest first, with their first replies.
+	// The order is cached and rebuilt from the database when it is missing.
+	FindHot(ctx context.Context, biz string, bizId, viewer int64, offset, limit int) ([]domain.Comment, error)
+	// DeleteComment tombstones the comment, ErrCommentNotFound is returned if it is deleted already.
 	DeleteComment(ctx context.Context, comment domain.Comment) error
-	CreateComment(ctx context.Context, comment domain.Comment) error
+	// EditComment replaces the content, the status and the reason of a comment of comment.Commentator not deleted.
+	EditComment(ctx context.Context, comment domain.Comment) error
+	GetEdits(ctx context.Context, id int64) ([]domain.CommentEdit, error)
+	// CreateComment returns the id of the comment.
+	CreateComment(ctx context.Context, comment domain.Comment) (int64, error)
 	GetCommentByIds(ctx context.Context, id []int64) ([]domain.Comment, error)
-	GetMoreReplies(ctx context.Context, rid int64, id int64, limit int64) ([]domain.Comment, error)
+	GetMoreReplies(ctx context.Context, rid, viewer int64, id int64, limit int64) ([]domain.Comment, error)
+	DeleteByBiz(ctx context.Context, biz string, bizId int64) error
+	FindPending(ctx context.Context, maxID int64, limit int) ([]domain.Comment, error)
+	Review(ctx context.Context, id int64, status domain.CommentStatus) error
+	// AddLikeCnt adds the like count deltas by the id of the comment.
+	AddLikeCnt(ctx context.Context, deltas map[int64]int64) error
+	// GetCounts returns the comment counts by the id of the resource, the ones never commented are counted 0.
+	GetCounts(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.CommentCount, error)
 }
 
 var _ CommentRepository = (*commentRepository)(nil)
 
 type commentRepository struct {
 	dao dao.CommentDAO
+	hot cache.CommentHotCache
 	l   logger.Logger
 }
 
-func NewCommentRepository(dao dao.CommentDAO, l logger.Logger) CommentRepository {
+func NewCommentRepository(dao dao.CommentDAO, hot cache.CommentHotCache, l logger.Logger) CommentRepository {
 	return &commentRepository{
 		dao: dao,
+		hot: hot,
 		l:   l,
 	}
 }
 
-func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId, minID, limit int64) ([]domain.Comment, error) {
-	dbComments, err := c.dao.FindByBiz(ctx, biz, bizId, minID, limit)
+func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId, viewer, minID, limit int64) ([]domain.Comment, error) {
+	dbComments, err := c.dao.FindByBiz(ctx, biz, bizId, viewer, minID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return c.withReplies(ctx, dbComments, viewer)
+}
+
+func (c *commentRepository) FindByBizAsc(ctx context.Context, biz string, bizId, viewer, maxID, limit int64) ([]domain.Comment, error) {
+	dbComments, err := c.dao.FindByBizAsc(ctx, biz, bizId, viewer, maxID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return c.withReplies(ctx, dbComments, viewer)
+}
+
+func (c *commentRepository) FindHot(ctx context.Context, biz string, bizId, viewer int64, offset, limit int) ([]domain.Comment, error) {
+	if offset < 0 || limit <= 0 {
+		// ZRevRange would return the whole ranking
+		return []domain.Comment{}, nil
+	}
+	ids, err := c.hot.Range(ctx, biz, bizId, offset, limit)
+	if err != nil {
+		if !errors.Is(err, cache.ErrKeyNotExist) {
+			c.l.Error("get hot comments from cache failed",
+				logger.String("biz", biz),
+				logger.Int64("biz_id", bizId),
+				logger.Error(err))
+		}
+		ids, err = c.rebuildHot(ctx, biz, bizId, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(ids) == 0 {
+		return []domain.Comment{}, nil
+	}
+	dbComments, err := c.dao.FindOneByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	// in the order of the ids, the ones deleted meanwhile are skipped
+	found := make(map[int64]dao.Comment, len(dbComments))
+	for _, cmt := range dbComments {
+		found[cmt.ID] = cmt
+	}
+	ordered := make([]dao.Comment, 0, len(ids))
+	for _, id := range ids {
+		if cmt, ok := found[id]; ok {
+			ordered = append(ordered, cmt)
+		}
+	}
+	return c.withReplies(ctx, ordered, viewer)
+}
+
+// rebuildHot ranks the approved top comments of the resource, caches the order and returns the page of it.
+func (c *commentRepository) rebuildHot(ctx context.Context, biz string, bizId int64, offset, limit int) ([]int64, error) {
+	tops, err := c.dao.FindApprovedTops(ctx, biz, bizId)
 	if err != nil {
 		return nil, err
 	}
+	scores := make(map[int64]float64, len(tops))
+	for _, top := range tops {
+		scores[top.ID] = c.toDomain(top).HotScore()
+	}
+	err = c.hot.Rebuild(ctx, biz, bizId, scores)
+	if err != nil {
+		// the page is still served from the ranking in memory
+		c.l.Error("rebuild hot comments failed",
+			logger.String("biz", biz),
+			logger.Int64("biz_id", bizId),
+			logger.Error(err))
+	}
+	slices.SortFunc(tops, func(a, b dao.Comment) int {
+		return cmp.Or(cmp.Compare(scores[b.ID], scores[a.ID]), cmp.Compare(b.ID, a.ID))
+	})
+	if offset < 0 || limit <= 0 || offset >= len(tops) {
+		return nil, nil
+	}
+	tops = tops[offset:min(offset+limit, len(tops))]
+	ids := make([]int64, 0, len(tops))
+	for _, top := range tops {
+		ids = append(ids, top.ID)
+	}
+	return ids, nil
+}
+
+// withReplies loads the first replies of each top comment, unless the service is downgraded.
+func (c *commentRepository) withReplies(ctx context.Context, dbComments []dao.Comment, viewer int64) ([]domain.Comment, error) {
 	bizComments := make([]domain.Comment, len(dbComments))
 	var eg errgroup.Group
 	downgraded := ctx.Value("downgraded") == "true"
@@ -54,7 +165,7 @@ func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId, mi
 		eg.Go(func() error {
 			// show only three
 			bizComments[idx].Children = make([]domain.Comment, 0, 3)
-			rs, err := c.dao.FindRepliesByPid(ctx, bizComments[idx].ID, 0, 3)
+			rs, err := c.dao.FindRepliesByPid(ctx, bizComments[idx].ID, viewer, 0, 3)
 			if err != nil {
 				c.l.Error("get child comment failed", logger.Error(err))
 				return nil
@@ -69,11 +180,117 @@ func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId, mi
 }
 
 func (c *commentRepository) DeleteComment(ctx context.Context, comment domain.Comment) error {
-	return c.dao.Delete(ctx, c.toEntity(comment))
+	found, err := c.dao.FindOneByIDs(ctx, []int64{comment.ID})
+	if err != nil {
+		return err
+	}
+	err = c.dao.Delete(ctx, c.toEntity(comment))
+	if err != nil || len(found) == 0 || found[0].PID.Valid {
+		return err
+	}
+	// the replies are never in the hot order, and a tombstone with replies keeps its place to reach them
+	replies, err := c.dao.FindRepliesByRid(ctx, comment.ID, 0, 0, 1)
+	if err != nil {
+		c.l.Error("find replies of deleted comment failed", logger.Int64("id", comment.ID), logger.Error(err))
+		return nil
+	}
+	if len(replies) > 0 {
+		return nil
+	}
+	err = c.hot.Remove(ctx, found[0].Biz, found[0].BizID, comment.ID)
+	if err != nil {
+		c.l.Error("remove hot comment failed", logger.Int64("id", comment.ID), logger.Error(err))
+	}
+	return nil
+}
+
+func (c *commentRepository) EditComment(ctx context.Context, comment domain.Comment) error {
+	err := c.dao.Edit(ctx, c.toEntity(comment))
+	if err != nil {
+		return err
+	}
+	found, err := c.dao.FindOneByIDs(ctx, []int64{comment.ID})
+	if err != nil || len(found) == 0 {
+		c.l.Error("find edited comment failed", logger.Int64("id", comment.ID), logger.Error(err))
+		return nil
+	}
+	edited := found[0]
+	if domain.CommentStatus(edited.Status) == domain.CommentStatusApproved {
+		c.addHot(ctx, edited)
+		return nil
+	}
+	// held by the moderation again, out of the hot order until it is approved
+	if !edited.PID.Valid {
+		err = c.hot.Remove(ctx, edited.Biz, edited.BizID, edited.ID)
+		if err != nil {
+			c.l.Error("remove hot comment failed", logger.Int64("id", edited.ID), logger.Error(err))
+		}
+	}
+	return nil
+}
+
+func (c *commentRepository) GetEdits(ctx context.Context, id int64) ([]domain.CommentEdit, error) {
+	edits, err := c.dao.FindEdits(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.CommentEdit, 0, len(edits))
+	for _, edit := range edits {
+		res = append(res, domain.CommentEdit{
+			CommentID: edit.CID,
+			Content:   edit.Content,
+			CTime:     time.UnixMilli(edit.Ctime),
+		})
+	}
+	return res, nil
 }
 
-func (c *commentRepository) CreateComment(ctx context.Context, comment domain.Comment) error {
-	return c.dao.Insert(ctx, c.toEntity(comment))
+func (c *commentRepository) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	err := c.dao.DeleteByBiz(ctx, biz, bizId)
+	if err != nil {
+		return err
+	}
+	return c.hot.Del(ctx, biz, bizId)
+}
+
+func (c *commentRepository) CreateComment(ctx context.Context, comment domain.Comment) (int64, error) {
+	entity := c.toEntity(comment)
+	id, err := c.dao.Insert(ctx, entity)
+	if err != nil {
+		return 0, err
+	}
+	entity.ID = id
+	c.addHot(ctx, entity)
+	return id, nil
+}
+
+// addHot puts the approved top comments into the hot order if it is cached,
+// a failure is only logged, the order is rebuilt once it expires.
+func (c *commentRepository) addHot(ctx context.Context, comments ...dao.Comment) {
+	type bizKey struct {
+		biz   string
+		bizId int64
+	}
+	scores := make(map[bizKey]map[int64]float64)
+	for _, cmt := range comments {
+		if cmt.PID.Valid || domain.CommentStatus(cmt.Status) != domain.CommentStatusApproved {
+			continue
+		}
+		key := bizKey{biz: cmt.Biz, bizId: cmt.BizID}
+		if scores[key] == nil {
+			scores[key] = make(map[int64]float64)
+		}
+		scores[key][cmt.ID] = c.toDomain(cmt).HotScore()
+	}
+	for key, s := range scores {
+		err := c.hot.SetIfPresent(ctx, key.biz, key.bizId, s)
+		if err != nil {
+			c.l.Error("set hot comments failed",
+				logger.String("biz", key.biz),
+				logger.Int64("biz_id", key.bizId),
+				logger.Error(err))
+		}
+	}
 }
 
 func (c *commentRepository) GetCommentByIds(ctx context.Context, id []int64) ([]domain.Comment, error) {
@@ -89,8 +306,20 @@ func (c *commentRepository) GetCommentByIds(ctx context.Context, id []int64) ([]
 	return comments, nil
 }
 
-func (c *commentRepository) GetMoreReplies(ctx context.Context, rid int64, id int64, limit int64) ([]domain.Comment, error) {
-	comments, err := c.dao.FindRepliesByRid(ctx, rid, id, limit)
+func (c *commentRepository) GetMoreReplies(ctx context.Context, rid, viewer int64, id int64, limit int64) ([]domain.Comment, error) {
+	comments, err := c.dao.FindRepliesByRid(ctx, rid, viewer, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Comment, 0, len(comments))
+	for _, comment := range comments {
+		res = append(res, c.toDomain(comment))
+	}
+	return res, nil
+}
+
+func (c *commentRepository) FindPending(ctx context.Context, maxID int64, limit int) ([]domain.Comment, error) {
+	comments, err := c.dao.FindPending(ctx, maxID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +330,47 @@ func (c *commentRepository) GetMoreReplies(ctx context.Context, rid int64, id in
 	return res, nil
 }
 
+func (c *commentRepository) Review(ctx context.Context, id int64, status domain.CommentStatus) error {
+	err := c.dao.Review(ctx, id, uint8(status))
+	if err != nil || status != domain.CommentStatusApproved {
+		return err
+	}
+	found, err := c.dao.FindOneByIDs(ctx, []int64{id})
+	if err != nil {
+		c.l.Error("find approved comment failed", logger.Int64("id", id), logger.Error(err))
+		return nil
+	}
+	c.addHot(ctx, found...)
+	return nil
+}
+
+func (c *commentRepository) AddLikeCnt(ctx context.Context, deltas map[int64]int64) error {
+	changed, err := c.dao.BatchAddLikeCnt(ctx, deltas)
+	if err != nil {
+		return err
+	}
+	c.addHot(ctx, changed...)
+	return nil
+}
+
+func (c *commentRepository) GetCounts(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.CommentCount, error) {
+	counts, err := c.dao.FindCounts(ctx, biz, bizIds)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.CommentCount, len(bizIds))
+	for _, id := range bizIds {
+		res[id] = domain.CommentCount{}
+	}
+	for _, cnt := range counts {
+		res[cnt.BizID] = domain.CommentCount{
+			RootCnt:  cnt.RootCnt,
+			TotalCnt: cnt.TotalCnt,
+		}
+	}
+	return res, nil
+}
+
 func (c *commentRepository) toDomain(dbComment dao.Comment) domain.Comment {
 	bizComment := domain.Comment{
 		ID: dbComment.ID,
@@ -110,9 +380,17 @@ func (c *commentRepository) toDomain(dbComment dao.Comment) domain.Comment {
 		Biz:     dbComment.Biz,
 		BizID:   dbComment.BizID,
 		Content: dbComment.Content,
+		Status:  domain.CommentStatus(dbComment.Status),
+		Reason:  dbComment.Reason,
+		LikeCnt: dbComment.LikeCnt,
+		Edited:  dbComment.Edited,
+		Deleted: dbComment.Deleted,
 		CTime:   time.UnixMilli(dbComment.Ctime),
 		UTime:   time.UnixMilli(dbComment.Utime),
 	}
+	if dbComment.Deleted {
+		bizComment.Content = domain.DeletedContent
+	}
 	if dbComment.RootID.Valid {
 		bizComment.RootComment = &domain.Comment{
 			ID: dbComment.RootID.Int64,
@@ -133,6 +411,8 @@ func (c *commentRepository) toEntity(bizComment domain.Comment) dao.Comment {
 		Biz:     bizComment.Biz,
 		BizID:   bizComment.BizID,
 		Content: bizComment.Content,
+		Status:  uint8(bizComment.Status),
+		Reason:  bizComment.Reason,
 	}
 	if bizComment.RootComment != nil && bizComment.RootComment.ID != 0 {
 		dbComment.RootID = sql.NullInt64{
@@ -3,23 +3,55 @@ package dao
 import (
 	"context"
 	"database/sql"
+	"slices"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tsukiyo/mercury/internal/comment/domain"
 )
 
+var ErrCommentNotFound = gorm.ErrRecordNotFound
+
 //go:generate mockgen -source=./comment.go -package=daomocks -destination=mocks/comment.mock.go CommentDAO
 type CommentDAO interface {
-	Insert(ctx context.Context, u Comment) error
-	// FindByBiz return first level comment
+	// Insert returns the id of the comment.
+	Insert(ctx context.Context, u Comment) (int64, error)
+	// FindByBiz return first level comment, the ones not approved are only returned to their author viewer.
 	FindByBiz(ctx context.Context, biz string,
-		bizId, minID, limit int64) ([]Comment, error)
+		bizId, viewer, minID, limit int64) ([]Comment, error)
+	// FindByBizAsc returns first level comment with id greater than maxID earliest first.
+	FindByBizAsc(ctx context.Context, biz string,
+		bizId, viewer, maxID, limit int64) ([]Comment, error)
+	// FindApprovedTops returns the id, the like count and the ctime of all the approved first level comment,
+	// to rank them. The deleted ones are returned only if they have approved replies.
+	FindApprovedTops(ctx context.Context, biz string, bizId int64) ([]Comment, error)
 	// FindCommentList if Comment's id = 0, return first level comment.
 	// Otherwise, return the corresponding comment and all its replies
 	FindCommentList(ctx context.Context, u Comment) ([]Comment, error)
-	FindRepliesByPid(ctx context.Context, pid int64, offset, limit int) ([]Comment, error)
+	// FindRepliesByPid returns the replies with id less than maxID, latest first, maxID 0 means the latest ones.
+	FindRepliesByPid(ctx context.Context, pid, viewer int64, maxID int64, limit int) ([]Comment, error)
+	// Delete tombstones the comment, its content and its edit history are removed and its replies are kept.
+	// ErrCommentNotFound is returned if it is deleted already.
 	Delete(ctx context.Context, u Comment) error
+	// Edit replaces the content, the status and the reason of a comment of u.UID not deleted,
+	// and keeps the content replaced in the edit history. ErrCommentNotFound is returned if there is no such comment.
+	Edit(ctx context.Context, u Comment) error
+	// FindEdits returns the edit history of the comment latest first.
+	FindEdits(ctx context.Context, cid int64) ([]CommentEdit, error)
 	FindOneByIDs(ctx context.Context, id []int64) ([]Comment, error)
-	FindRepliesByRid(ctx context.Context, rid int64, id int64, limit int64) ([]Comment, error)
+	FindRepliesByRid(ctx context.Context, rid, viewer int64, id int64, limit int64) ([]Comment, error)
+	// DeleteByBiz deletes all the comments of the resource with their edit history.
+	DeleteByBiz(ctx context.Context, biz string, bizId int64) error
+	// FindPending returns the comments held for review with id less than maxID, latest first, maxID 0 means the latest ones.
+	FindPending(ctx context.Context, maxID int64, limit int) ([]Comment, error)
+	// Review sets the status of a pending comment, ErrCommentNotFound is returned if it is not pending.
+	Review(ctx context.Context, id int64, status uint8) error
+	// BatchAddLikeCnt adds the like count deltas by the id of the comment, and returns the comments changed.
+	BatchAddLikeCnt(ctx context.Context, deltas map[int64]int64) ([]Comment, error)
+	// FindCounts returns the comment counts of the resources, the ones never commented are left out.
+	FindCounts(ctx context.Context, biz string, bizIds []int64) ([]CommentCount, error)
 }
 
 var _ CommentDAO = (*commentDAO)(nil)
@@ -34,19 +66,52 @@ func NewCommentDAO(db *gorm.DB) CommentDAO {
 	}
 }
 
-func (c *commentDAO) Insert(ctx context.Context, u Comment) error {
-	return c.db.WithContext(ctx).Create(&u).Error
+func (c *commentDAO) Insert(ctx context.Context, u Comment) (int64, error) {
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&u).Error
+		if err != nil {
+			return err
+		}
+		return c.addCount(tx, u, 1)
+	})
+	return u.ID, err
 }
 
-func (c *commentDAO) FindByBiz(ctx context.Context, biz string, bizId, minID, limit int64) ([]Comment, error) {
+func (c *commentDAO) FindByBiz(ctx context.Context, biz string, bizId, viewer, minID, limit int64) ([]Comment, error) {
 	var comments []Comment
 	err := c.db.WithContext(ctx).
 		Where("biz = ? AND biz_id = ? AND id < ? AND pid IS NULL", biz, bizId, minID).
+		Where(c.visibleTo(viewer)).
+		Order("id DESC").
 		Limit(int(limit)).
 		Find(&comments).Error
 	return comments, err
 }
 
+func (c *commentDAO) FindByBizAsc(ctx context.Context, biz string, bizId, viewer, maxID, limit int64) ([]Comment, error) {
+	var comments []Comment
+	err := c.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND id > ? AND pid IS NULL", biz, bizId, maxID).
+		Where(c.visibleTo(viewer)).
+		Order("id ASC").
+		Limit(int(limit)).
+		Find(&comments).Error
+	return comments, err
+}
+
+func (c *commentDAO) FindApprovedTops(ctx context.Context, biz string, bizId int64) ([]Comment, error) {
+	var comments []Comment
+	err := c.db.WithContext(ctx).
+		Select("id", "like_cnt", "ctime").
+		Where("biz = ? AND biz_id = ? AND pid IS NULL AND status = ?", biz, bizId, domain.CommentStatusApproved).
+		// the tombstones keep their replies reachable
+		Where("deleted = ? OR EXISTS (?)", false, c.db.Table("`comments` AS r").
+			Select("1").
+			Where("r.root_id = comments.id AND r.status = ?", domain.CommentStatusApproved)).
+		Find(&comments).Error
+	return comments, err
+}
+
 func (c *commentDAO) FindCommentList(ctx context.Context, u Comment) ([]Comment, error) {
 	var res []Comment
 	builder := c.db.WithContext(ctx)
@@ -59,39 +124,232 @@ func (c *commentDAO) FindCommentList(ctx context.Context, u Comment) ([]Comment,
 	return res, err
 }
 
-func (c *commentDAO) FindRepliesByPid(ctx context.Context, pid int64, offset, limit int) ([]Comment, error) {
+func (c *commentDAO) FindRepliesByPid(ctx context.Context, pid, viewer int64, maxID int64, limit int) ([]Comment, error) {
 	var res []Comment
-	err := c.db.WithContext(ctx).
-		Where("pid = ?", pid).
+	query := c.db.WithContext(ctx).Where("pid = ?", pid).Where(c.visibleTo(viewer))
+	if maxID > 0 {
+		query = query.Where("id < ?", maxID)
+	}
+	err := query.
 		Order("id DESC").
-		Offset(offset).
 		Limit(limit).
 		Find(&res).Error
 	return res, err
 }
 
 func (c *commentDAO) Delete(ctx context.Context, u Comment) error {
-	return c.db.WithContext(ctx).Delete(&Comment{
-		ID: u.ID,
-	}).Error
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var old Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted = ?", u.ID, false).
+			First(&old).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Comment{}).
+			Where("id = ?", u.ID).
+			Updates(map[string]any{
+				"deleted": true,
+				"content": "",
+				"reason":  "",
+				"utime":   time.Now().UnixMilli(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = c.addCount(tx, old, -1)
+		if err != nil {
+			return err
+		}
+		return tx.Where("cid = ?", u.ID).Delete(&CommentEdit{}).Error
+	})
+}
+
+func (c *commentDAO) Edit(ctx context.Context, u Comment) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var old Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND uid = ? AND deleted = ?", u.ID, u.UID, false).
+			First(&old).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		err = tx.Create(&CommentEdit{
+			CID:     old.ID,
+			Content: old.Content,
+			Ctime:   now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Comment{}).
+			Where("id = ?", u.ID).
+			Updates(map[string]any{
+				"content": u.Content,
+				"status":  u.Status,
+				"reason":  u.Reason,
+				"edited":  true,
+				"utime":   now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		// counted again if the moderation holds or passes it this time
+		err = c.addCount(tx, old, -1)
+		if err != nil {
+			return err
+		}
+		old.Status = u.Status
+		return c.addCount(tx, old, 1)
+	})
+}
+
+func (c *commentDAO) FindEdits(ctx context.Context, cid int64) ([]CommentEdit, error) {
+	var res []CommentEdit
+	err := c.db.WithContext(ctx).Where("cid = ?", cid).Order("id DESC").Find(&res).Error
+	return res, err
 }
 
 func (c *commentDAO) FindOneByIDs(ctx context.Context, id []int64) ([]Comment, error) {
 	var res []Comment
-	err := c.db.WithContext(ctx).Where("id IN ?", id).First(&res).Error
+	err := c.db.WithContext(ctx).Where("id IN ?", id).Find(&res).Error
 	return res, err
 }
 
-func (c *commentDAO) FindRepliesByRid(ctx context.Context, rid int64, id int64, limit int64) ([]Comment, error) {
+func (c *commentDAO) FindRepliesByRid(ctx context.Context, rid, viewer int64, id int64, limit int64) ([]Comment, error) {
 	var res []Comment
 	err := c.db.WithContext(ctx).
 		Where("root_id = ? AND id > ?", rid, id).
+		Where(c.visibleTo(viewer)).
 		Order("id ASC").
 		Limit(int(limit)).
 		Find(&res).Error
 	return res, err
 }
 
+func (c *commentDAO) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("cid IN (?)", tx.Model(&Comment{}).Select("id").Where("biz = ? AND biz_id = ?", biz, bizId)).
+			Delete(&CommentEdit{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&CommentCount{}).Error
+		if err != nil {
+			return err
+		}
+		// the replies reference their parents, which are older, so the newest are deleted first
+		return tx.Exec("DELETE FROM `comments` WHERE `biz` = ? AND `biz_id` = ? ORDER BY `id` DESC", biz, bizId).Error
+	})
+}
+
+func (c *commentDAO) FindPending(ctx context.Context, maxID int64, limit int) ([]Comment, error) {
+	var res []Comment
+	query := c.db.WithContext(ctx).Where("status = ?", domain.CommentStatusPending)
+	if maxID > 0 {
+		query = query.Where("id < ?", maxID)
+	}
+	err := query.
+		Order("id DESC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (c *commentDAO) Review(ctx context.Context, id int64, status uint8) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pending Comment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ? AND deleted = ?", id, domain.CommentStatusPending, false).
+			First(&pending).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Comment{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"status": status,
+				"utime":  time.Now().UnixMilli(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		pending.Status = status
+		return c.addCount(tx, pending, 1)
+	})
+}
+
+func (c *commentDAO) BatchAddLikeCnt(ctx context.Context, deltas map[int64]int64) ([]Comment, error) {
+	ids := make([]int64, 0, len(deltas))
+	for id, delta := range deltas {
+		if delta != 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	// rows are locked in the order of id, so concurrent batches could not deadlock
+	slices.Sort(ids)
+	var res []Comment
+	err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		for _, id := range ids {
+			err := tx.Model(&Comment{}).
+				Where("id = ?", id).
+				Updates(map[string]any{
+					"like_cnt": gorm.Expr("GREATEST(`like_cnt` + ?, 0)", deltas[id]),
+					"utime":    now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Where("id IN ?", ids).Find(&res).Error
+	})
+	return res, err
+}
+
+func (c *commentDAO) FindCounts(ctx context.Context, biz string, bizIds []int64) ([]CommentCount, error) {
+	var res []CommentCount
+	err := c.db.WithContext(ctx).
+		Where("biz = ? AND biz_id IN ?", biz, bizIds).
+		Find(&res).Error
+	return res, err
+}
+
+// addCount adds delta to the counts of the resource of the comment, only the approved comments not deleted are counted.
+func (c *commentDAO) addCount(tx *gorm.DB, cmt Comment, delta int64) error {
+	if cmt.Deleted || cmt.Status != uint8(domain.CommentStatusApproved) {
+		return nil
+	}
+	var rootDelta int64
+	if !cmt.PID.Valid {
+		rootDelta = delta
+	}
+	now := time.Now().UnixMilli()
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"root_cnt":  gorm.Expr("GREATEST(`root_cnt` + ?, 0)", rootDelta),
+			"total_cnt": gorm.Expr("GREATEST(`total_cnt` + ?, 0)", delta),
+			"utime":     now,
+		}),
+	}).Create(&CommentCount{
+		Biz:      cmt.Biz,
+		BizID:    cmt.BizID,
+		RootCnt:  max(rootDelta, 0),
+		TotalCnt: max(delta, 0),
+		Ctime:    now,
+		Utime:    now,
+	}).Error
+}
+
+// visibleTo filters the comments approved or written by the viewer, 0 means an anonymous viewer.
+func (c *commentDAO) visibleTo(viewer int64) *gorm.DB {
+	return c.db.Where("status = ? OR uid = ?", domain.CommentStatusApproved, viewer)
+}
+
 type Comment struct {
 	ID    int64  `gorm:"column:id;primaryKey" json:"id"`
 	UID   int64  `gorm:"column:uid;index" json:"uid"`
@@ -101,8 +359,39 @@ type Comment struct {
 	RootID sql.NullInt64 `gorm:"column:root_id;index" json:"root_id"`
 	// PID parent comment ID
 	PID           sql.NullInt64 `gorm:"column:pid;index" json:"pid"`
-	ParentComment *Comment      `gorm:"ForeignKey:PID;AssociationForeignKey:ID;constraint:OnDelete:CASCADE" json:"parent_comment"`
+	ParentComment *Comment      `gorm:"ForeignKey:PID;AssociationForeignKey:ID" json:"parent_comment"`
 	Content       string        `gorm:"type:text;column:content" json:"content"`
-	Ctime         int64         `gorm:"column:ctime;" json:"ctime"`
-	Utime         int64         `gorm:"column:utime;" json:"utime"`
+	// Status the comments written before moderation are approved by the default
+	Status uint8 `gorm:"column:status;default:1;index" json:"status"`
+	// Reason what the moderation matched when the comment was held
+	Reason string `gorm:"type:text;column:reason" json:"reason"`
+	// LikeCnt is counted from the like events of the interactive service
+	LikeCnt int64 `gorm:"column:like_cnt" json:"like_cnt"`
+	Edited  bool  `gorm:"column:edited;default:false" json:"edited"`
+	// Deleted the comment is a tombstone kept for its replies, without its content
+	Deleted bool  `gorm:"column:deleted;default:false" json:"deleted"`
+	Ctime   int64 `gorm:"column:ctime;" json:"ctime"`
+	Utime   int64 `gorm:"column:utime;" json:"utime"`
+}
+
+// CommentCount is the number of the approved comments not deleted of a resource,
+// kept along with the comments in the same transactions.
+type CommentCount struct {
+	ID    int64  `gorm:"column:id;primaryKey" json:"id"`
+	Biz   string `gorm:"column:biz;type:varchar(128);uniqueIndex:biz_type_id" json:"biz"`
+	BizID int64  `gorm:"column:biz_id;uniqueIndex:biz_type_id" json:"biz_id"`
+	// RootCnt is the number of the top comments
+	RootCnt  int64 `gorm:"column:root_cnt" json:"root_cnt"`
+	TotalCnt int64 `gorm:"column:total_cnt" json:"total_cnt"`
+	Ctime    int64 `gorm:"column:ctime;" json:"ctime"`
+	Utime    int64 `gorm:"column:utime;" json:"utime"`
+}
+
+// CommentEdit is a content of the comment replaced by an edit.
+type CommentEdit struct {
+	ID      int64  `gorm:"column:id;primaryKey" json:"id"`
+	CID     int64  `gorm:"column:cid;index" json:"cid"`
+	Content string `gorm:"type:text;column:content" json:"content"`
+	// Ctime is when the content was replaced
+	Ctime int64 `gorm:"column:ctime;" json:"ctime"`
 }
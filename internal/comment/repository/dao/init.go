@@ -1,7 +1,52 @@
 package dao
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/tsukiyo/mercury/internal/comment/domain"
+	"github.com/tsukiyo/mercury/pkg/gormx"
+)
 
 func InitTable(db *gorm.DB) error {
-	return db.AutoMigrate(&Comment{})
+	err := db.AutoMigrate(&Comment{}, &CommentEdit{}, &CommentCount{})
+	if err != nil {
+		return err
+	}
+	err = dropParentCascade(db)
+	if err != nil {
+		return err
+	}
+	// the comments written before the counts were kept are counted once,
+	// the counts found are recomputed so a backfill run again leaves them right
+	return gormx.RunOnce(db, "comment_counts", func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		return tx.Exec("INSERT INTO `comment_counts` (`biz`, `biz_id`, `root_cnt`, `total_cnt`, `ctime`, `utime`) ? "+
+			"ON DUPLICATE KEY UPDATE `root_cnt` = VALUES(`root_cnt`), `total_cnt` = VALUES(`total_cnt`), `utime` = VALUES(`utime`)",
+			tx.Model(&Comment{}).
+				Select("`biz`, `biz_id`, SUM(`pid` IS NULL), COUNT(*), ?, ?", now, now).
+				Where("status = ? AND deleted = ?", domain.CommentStatusApproved, false).
+				Group("`biz`, `biz_id`"),
+		).Error
+	})
+}
+
+// dropParentCascade recreates the foreign key of the replies on their parents if it was created cascading,
+// AutoMigrate keeps an existing constraint as it is. Deleting a comment must not take its replies with it.
+func dropParentCascade(db *gorm.DB) error {
+	var rule string
+	err := db.Raw("SELECT `DELETE_RULE` FROM `information_schema`.`REFERENTIAL_CONSTRAINTS` "+
+		"WHERE `CONSTRAINT_SCHEMA` = DATABASE() AND `TABLE_NAME` = ? AND `CONSTRAINT_NAME` = ?",
+		// named by GORM after the table and the relationship
+		"comments", "fk_comments_parent_comment").Scan(&rule).Error
+	if err != nil || rule != "CASCADE" {
+		return err
+	}
+	m := db.Migrator()
+	err = m.DropConstraint(&Comment{}, "ParentComment")
+	if err != nil {
+		return err
+	}
+	return m.CreateConstraint(&Comment{}, "ParentComment")
 }
@@ -40,6 +40,21 @@ func (m *MockCommentDAO) EXPECT() *MockCommentDAOMockRecorder {
 	return m.recorder
 }
 
+// BatchAddLikeCnt mocks base method.
+func (m *MockCommentDAO) BatchAddLikeCnt(ctx context.Context, deltas map[int64]int64) ([]dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchAddLikeCnt", ctx, deltas)
+	ret0, _ := ret[0].([]dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchAddLikeCnt indicates an expected call of BatchAddLikeCnt.
+func (mr *MockCommentDAOMockRecorder) BatchAddLikeCnt(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchAddLikeCnt", reflect.TypeOf((*MockCommentDAO)(nil).BatchAddLikeCnt), ctx, deltas)
+}
+
 // Delete mocks base method.
 func (m *MockCommentDAO) Delete(ctx context.Context, u dao.Comment) error {
 	m.ctrl.T.Helper()
@@ -54,19 +69,77 @@ func (mr *MockCommentDAOMockRecorder) Delete(ctx, u any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentDAO)(nil).Delete), ctx, u)
 }
 
+// DeleteByBiz mocks base method.
+func (m *MockCommentDAO) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBiz", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBiz indicates an expected call of DeleteByBiz.
+func (mr *MockCommentDAOMockRecorder) DeleteByBiz(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBiz", reflect.TypeOf((*MockCommentDAO)(nil).DeleteByBiz), ctx, biz, bizId)
+}
+
+// Edit mocks base method.
+func (m *MockCommentDAO) Edit(ctx context.Context, u dao.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockCommentDAOMockRecorder) Edit(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockCommentDAO)(nil).Edit), ctx, u)
+}
+
+// FindApprovedTops mocks base method.
+func (m *MockCommentDAO) FindApprovedTops(ctx context.Context, biz string, bizId int64) ([]dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApprovedTops", ctx, biz, bizId)
+	ret0, _ := ret[0].([]dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApprovedTops indicates an expected call of FindApprovedTops.
+func (mr *MockCommentDAOMockRecorder) FindApprovedTops(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApprovedTops", reflect.TypeOf((*MockCommentDAO)(nil).FindApprovedTops), ctx, biz, bizId)
+}
+
 // FindByBiz mocks base method.
-func (m *MockCommentDAO) FindByBiz(ctx context.Context, biz string, bizId, minID, limit int64) ([]dao.Comment, error) {
+func (m *MockCommentDAO) FindByBiz(ctx context.Context, biz string, bizId, viewer, minID, limit int64) ([]dao.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByBiz", ctx, biz, bizId, minID, limit)
+	ret := m.ctrl.Call(m, "FindByBiz", ctx, biz, bizId, viewer, minID, limit)
 	ret0, _ := ret[0].([]dao.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByBiz indicates an expected call of FindByBiz.
-func (mr *MockCommentDAOMockRecorder) FindByBiz(ctx, biz, bizId, minID, limit any) *gomock.Call {
+func (mr *MockCommentDAOMockRecorder) FindByBiz(ctx, biz, bizId, viewer, minID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBiz", reflect.TypeOf((*MockCommentDAO)(nil).FindByBiz), ctx, biz, bizId, viewer, minID, limit)
+}
+
+// FindByBizAsc mocks base method.
+func (m *MockCommentDAO) FindByBizAsc(ctx context.Context, biz string, bizId, viewer, maxID, limit int64) ([]dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBizAsc", ctx, biz, bizId, viewer, maxID, limit)
+	ret0, _ := ret[0].([]dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBizAsc indicates an expected call of FindByBizAsc.
+func (mr *MockCommentDAOMockRecorder) FindByBizAsc(ctx, biz, bizId, viewer, maxID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBiz", reflect.TypeOf((*MockCommentDAO)(nil).FindByBiz), ctx, biz, bizId, minID, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBizAsc", reflect.TypeOf((*MockCommentDAO)(nil).FindByBizAsc), ctx, biz, bizId, viewer, maxID, limit)
 }
 
 // FindCommentList mocks base method.
@@ -84,6 +157,36 @@ func (mr *MockCommentDAOMockRecorder) FindCommentList(ctx, u any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCommentList", reflect.TypeOf((*MockCommentDAO)(nil).FindCommentList), ctx, u)
 }
 
+// FindCounts mocks base method.
+func (m *MockCommentDAO) FindCounts(ctx context.Context, biz string, bizIds []int64) ([]dao.CommentCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCounts", ctx, biz, bizIds)
+	ret0, _ := ret[0].([]dao.CommentCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCounts indicates an expected call of FindCounts.
+func (mr *MockCommentDAOMockRecorder) FindCounts(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCounts", reflect.TypeOf((*MockCommentDAO)(nil).FindCounts), ctx, biz, bizIds)
+}
+
+// FindEdits mocks base method.
+func (m *MockCommentDAO) FindEdits(ctx context.Context, cid int64) ([]dao.CommentEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEdits", ctx, cid)
+	ret0, _ := ret[0].([]dao.CommentEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEdits indicates an expected call of FindEdits.
+func (mr *MockCommentDAOMockRecorder) FindEdits(ctx, cid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEdits", reflect.TypeOf((*MockCommentDAO)(nil).FindEdits), ctx, cid)
+}
+
 // FindOneByIDs mocks base method.
 func (m *MockCommentDAO) FindOneByIDs(ctx context.Context, id []int64) ([]dao.Comment, error) {
 	m.ctrl.T.Helper()
@@ -99,42 +202,58 @@ func (mr *MockCommentDAOMockRecorder) FindOneByIDs(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDs", reflect.TypeOf((*MockCommentDAO)(nil).FindOneByIDs), ctx, id)
 }
 
+// FindPending mocks base method.
+func (m *MockCommentDAO) FindPending(ctx context.Context, maxID int64, limit int) ([]dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPending", ctx, maxID, limit)
+	ret0, _ := ret[0].([]dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPending indicates an expected call of FindPending.
+func (mr *MockCommentDAOMockRecorder) FindPending(ctx, maxID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPending", reflect.TypeOf((*MockCommentDAO)(nil).FindPending), ctx, maxID, limit)
+}
+
 // FindRepliesByPid mocks base method.
-func (m *MockCommentDAO) FindRepliesByPid(ctx context.Context, pid int64, offset, limit int) ([]dao.Comment, error) {
+func (m *MockCommentDAO) FindRepliesByPid(ctx context.Context, pid, viewer, maxID int64, limit int) ([]dao.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindRepliesByPid", ctx, pid, offset, limit)
+	ret := m.ctrl.Call(m, "FindRepliesByPid", ctx, pid, viewer, maxID, limit)
 	ret0, _ := ret[0].([]dao.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindRepliesByPid indicates an expected call of FindRepliesByPid.
-func (mr *MockCommentDAOMockRecorder) FindRepliesByPid(ctx, pid, offset, limit any) *gomock.Call {
+func (mr *MockCommentDAOMockRecorder) FindRepliesByPid(ctx, pid, viewer, maxID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRepliesByPid", reflect.TypeOf((*MockCommentDAO)(nil).FindRepliesByPid), ctx, pid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRepliesByPid", reflect.TypeOf((*MockCommentDAO)(nil).FindRepliesByPid), ctx, pid, viewer, maxID, limit)
 }
 
 // FindRepliesByRid mocks base method.
-func (m *MockCommentDAO) FindRepliesByRid(ctx context.Context, rid, id, limit int64) ([]dao.Comment, error) {
+func (m *MockCommentDAO) FindRepliesByRid(ctx context.Context, rid, viewer, id, limit int64) ([]dao.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindRepliesByRid", ctx, rid, id, limit)
+	ret := m.ctrl.Call(m, "FindRepliesByRid", ctx, rid, viewer, id, limit)
 	ret0, _ := ret[0].([]dao.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindRepliesByRid indicates an expected call of FindRepliesByRid.
-func (mr *MockCommentDAOMockRecorder) FindRepliesByRid(ctx, rid, id, limit any) *gomock.Call {
+func (mr *MockCommentDAOMockRecorder) FindRepliesByRid(ctx, rid, viewer, id, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRepliesByRid", reflect.TypeOf((*MockCommentDAO)(nil).FindRepliesByRid), ctx, rid, id, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRepliesByRid", reflect.TypeOf((*MockCommentDAO)(nil).FindRepliesByRid), ctx, rid, viewer, id, limit)
 }
 
 // Insert mocks base method.
-func (m *MockCommentDAO) Insert(ctx context.Context, u dao.Comment) error {
+func (m *MockCommentDAO) Insert(ctx context.Context, u dao.Comment) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Insert", ctx, u)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Insert indicates an expected call of Insert.
@@ -142,3 +261,17 @@ func (mr *MockCommentDAOMockRecorder) Insert(ctx, u any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCommentDAO)(nil).Insert), ctx, u)
 }
+
+// Review mocks base method.
+func (m *MockCommentDAO) Review(ctx context.Context, id int64, status uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockCommentDAOMockRecorder) Review(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockCommentDAO)(nil).Review), ctx, id, status)
+}
@@ -2,52 +2,424 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
 
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	userv1 "github.com/tsukiyo/mercury/api/gen/user/v1"
 	"github.com/tsukiyo/mercury/internal/comment/domain"
+	"github.com/tsukiyo/mercury/internal/comment/events"
 	"github.com/tsukiyo/mercury/internal/comment/repository"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/moderation"
 )
 
+var (
+	ErrInvalidCursor   = cursorx.ErrInvalidCursor
+	ErrCommentNotFound = repository.ErrCommentNotFound
+	// ErrPermissionDenied the user is neither the commentator nor the author of the resource commented
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+const scopePending = "comment:pending"
+
+// maxCountIds bounds the resources counted at once, a page of articles is far less
+const maxCountIds = 200
+
+// ErrTooManyIds more than maxCountIds resources are counted at once
+var ErrTooManyIds = errors.New("too many ids")
+
+// maxListLimit bounds the comments of a page, the limits asked for are clamped to [1, maxListLimit]
+const maxListLimit = 100
+
 type CommentService interface {
-	GetCommentList(ctx context.Context, biz string, bizId, minID, limit int64) ([]domain.Comment, error)
-	DeleteComment(ctx context.Context, id int64) error
-	CreateComment(ctx context.Context, comment domain.Comment) error
-	GetMoreReplies(ctx context.Context, rid int64, maxID int64, limit int64) ([]domain.Comment, error)
+	// the listing methods return the comments not approved only to their author viewer.
+
+	// GetCommentList returns top comments with id less than minID.
+	// Deprecated: use GetCommentListByCursor.
+	GetCommentList(ctx context.Context, biz string, bizId, viewer, minID, limit int64) ([]domain.Comment, error)
+	// GetCommentListByCursor pages top comments in the order, an empty cursor means the first page.
+	// The returned cursor is empty when there are no more comments, and is only accepted in the same order.
+	// The hot order only has the approved comments.
+	GetCommentListByCursor(ctx context.Context, biz string, bizId, viewer int64, sort domain.CommentSort, cursor string, limit int64) ([]domain.Comment, string, error)
+	// DeleteComment tombstones the comment, its replies are kept.
+	// Only the commentator or the author of the article could delete it, ErrPermissionDenied is returned otherwise.
+	DeleteComment(ctx context.Context, id, uid int64) error
+	// EditComment moderates the content again before replacing it, and returns the status the comment is saved with.
+	// ErrCommentNotFound is returned unless the comment is written by the user and not deleted.
+	EditComment(ctx context.Context, id, uid int64, content string) (domain.CommentStatus, error)
+	// GetEditHistory returns the contents replaced by the edits of the comment latest first,
+	// ErrCommentNotFound if the comment is deleted or not approved and viewer is not its commentator.
+	GetEditHistory(ctx context.Context, id, viewer int64) ([]domain.CommentEdit, error)
+	// CreateComment moderates the content before saving the comment and returns the status it is saved with.
+	// The parent author, the users mentioned and the author of the resource are notified once the comment is approved.
+	CreateComment(ctx context.Context, comment domain.Comment) (domain.CommentStatus, error)
+	// GetCommentByIds returns the comments found, missing ids are skipped.
+	GetCommentByIds(ctx context.Context, ids []int64) ([]domain.Comment, error)
+	// GetMoreReplies returns replies of the root comment with id greater than maxID.
+	// Deprecated: use GetMoreRepliesByCursor.
+	GetMoreReplies(ctx context.Context, rid, viewer int64, maxID int64, limit int64) ([]domain.Comment, error)
+	// GetMoreRepliesByCursor pages replies of the root comment earliest first.
+	GetMoreRepliesByCursor(ctx context.Context, rid, viewer int64, cursor string, limit int64) ([]domain.Comment, string, error)
+	// DeleteByBiz deletes the comments of the resource once the resource is deleted.
+	DeleteByBiz(ctx context.Context, biz string, bizId int64) error
+	// AddLikeCnt adds the like count deltas by the id of the comment, the likes are recorded by the interactive service.
+	AddLikeCnt(ctx context.Context, deltas map[int64]int64) error
+	// GetCommentCounts returns the comment counts by the id of the resource, at most maxCountIds resources at once.
+	GetCommentCounts(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.CommentCount, error)
+
+	// admin
+
+	// ListPendingComments pages the comments held for review latest first.
+	ListPendingComments(ctx context.Context, cursor string, limit int64) ([]domain.Comment, string, error)
+	// ReviewComment approves or rejects a pending comment, ErrCommentNotFound is returned if it is not pending.
+	ReviewComment(ctx context.Context, id int64, approved bool) error
 }
 
 var _ CommentService = (*commentService)(nil)
 
 type commentService struct {
-	repo repository.CommentRepository
+	repo       repository.CommentRepository
+	codec      *cursorx.Codec
+	filter     moderation.ContentFilter
+	userSvc    userv1.UserServiceClient
+	articleSvc articlev1.ArticleServiceClient
+	producer   events.Producer
+	l          logger.Logger
 }
 
-func NewCommentService(repo repository.CommentRepository) CommentService {
+func NewCommentService(repo repository.CommentRepository,
+	codec *cursorx.Codec,
+	filter moderation.ContentFilter,
+	userSvc userv1.UserServiceClient,
+	articleSvc articlev1.ArticleServiceClient,
+	producer events.Producer,
+	l logger.Logger,
+) CommentService {
 	return &commentService{
-		repo: repo,
+		repo:       repo,
+		codec:      codec,
+		filter:     filter,
+		userSvc:    userSvc,
+		articleSvc: articleSvc,
+		producer:   producer,
+		l:          l,
 	}
 }
 
-func (c *commentService) GetCommentList(ctx context.Context, biz string, bizId, minID, limit int64) ([]domain.Comment, error) {
-	list, err := c.repo.FindByBiz(ctx, biz, bizId, minID, limit)
+func (c *commentService) GetCommentList(ctx context.Context, biz string, bizId, viewer, minID, limit int64) ([]domain.Comment, error) {
+	limit = clampLimit(limit)
+	list, err := c.repo.FindByBiz(ctx, biz, bizId, viewer, minID, limit)
 	if err != nil {
 		return nil, err
 	}
 	return list, nil
 }
 
-func (c *commentService) DeleteComment(ctx context.Context, id int64) error {
+func (c *commentService) GetCommentListByCursor(ctx context.Context, biz string, bizId, viewer int64, sort domain.CommentSort, cursor string, limit int64) ([]domain.Comment, string, error) {
+	limit = clampLimit(limit)
+	switch sort {
+	case domain.CommentSortOldest:
+		return c.listOldest(ctx, biz, bizId, viewer, cursor, limit)
+	case domain.CommentSortHot:
+		return c.listHot(ctx, biz, bizId, viewer, cursor, limit)
+	}
+	scope := fmt.Sprintf("comment:list:%s:%d", biz, bizId)
+	cur, err := c.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	minID := cur.Id
+	if minID <= 0 {
+		minID = math.MaxInt64
+	}
+	list, err := c.repo.FindByBiz(ctx, biz, bizId, viewer, minID, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return list, c.nextCursor(scope, list, limit), nil
+}
+
+func (c *commentService) listOldest(ctx context.Context, biz string, bizId, viewer int64, cursor string, limit int64) ([]domain.Comment, string, error) {
+	scope := fmt.Sprintf("comment:list:oldest:%s:%d", biz, bizId)
+	cur, err := c.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	list, err := c.repo.FindByBizAsc(ctx, biz, bizId, viewer, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return list, c.nextCursor(scope, list, limit), nil
+}
+
+// listHot pages by the offset in the hot order, the comments moving up or down while paging could be seen twice or missed.
+func (c *commentService) listHot(ctx context.Context, biz string, bizId, viewer int64, cursor string, limit int64) ([]domain.Comment, string, error) {
+	scope := fmt.Sprintf("comment:list:hot:%s:%d", biz, bizId)
+	cur, err := c.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	offset := cur.Id
+	list, err := c.repo.FindHot(ctx, biz, bizId, viewer, int(offset), int(limit))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(list)) < limit {
+		return list, "", nil
+	}
+	return list, c.codec.Encode(scope, cursorx.Cursor{Id: offset + limit}), nil
+}
+
+func (c *commentService) DeleteComment(ctx context.Context, id, uid int64) error {
+	found, err := c.repo.GetCommentByIds(ctx, []int64{id})
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 || found[0].Deleted {
+		return ErrCommentNotFound
+	}
+	comment := found[0]
+	if comment.Commentator.ID != uid {
+		authorId, err := c.findAuthor(ctx, comment.Biz, comment.BizID)
+		if err != nil {
+			return err
+		}
+		if authorId == 0 || authorId != uid {
+			return ErrPermissionDenied
+		}
+	}
 	return c.repo.DeleteComment(ctx, domain.Comment{
 		ID: id,
 	})
 }
 
-func (c *commentService) CreateComment(ctx context.Context, comment domain.Comment) error {
-	return c.repo.CreateComment(ctx, comment)
+// findAuthor returns the owner of the resource commented, 0 if nobody owns it.
+func (c *commentService) findAuthor(ctx context.Context, biz string, bizId int64) (int64, error) {
+	if biz != "article" {
+		return 0, nil
+	}
+	resp, err := c.articleSvc.ExistsPublished(ctx, &articlev1.ExistsPublishedRequest{Id: bizId})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetAuthorId(), nil
+}
+
+func (c *commentService) EditComment(ctx context.Context, id, uid int64, content string) (domain.CommentStatus, error) {
+	status, reason := c.moderate(ctx, content)
+	err := c.repo.EditComment(ctx, domain.Comment{
+		ID:          id,
+		Commentator: domain.User{ID: uid},
+		Content:     content,
+		Status:      status,
+		Reason:      reason,
+	})
+	if err != nil {
+		return domain.CommentStatusUnknown, err
+	}
+	return status, nil
+}
+
+func (c *commentService) GetEditHistory(ctx context.Context, id, viewer int64) ([]domain.CommentEdit, error) {
+	found, err := c.repo.GetCommentByIds(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 || found[0].Deleted {
+		return nil, ErrCommentNotFound
+	}
+	// as in the listings, the contents held or rejected are only shown to their commentator
+	if found[0].Status != domain.CommentStatusApproved && found[0].Commentator.ID != viewer {
+		return nil, ErrCommentNotFound
+	}
+	return c.repo.GetEdits(ctx, id)
+}
+
+func (c *commentService) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	return c.repo.DeleteByBiz(ctx, biz, bizId)
+}
+
+func (c *commentService) CreateComment(ctx context.Context, comment domain.Comment) (domain.CommentStatus, error) {
+	comment.Status, comment.Reason = c.moderate(ctx, comment.Content)
+	id, err := c.repo.CreateComment(ctx, comment)
+	if err != nil {
+		return domain.CommentStatusUnknown, err
+	}
+	if comment.Status == domain.CommentStatusApproved {
+		comment.ID, comment.CTime = id, time.Now()
+		c.produceCreatedEvent(ctx, comment)
+	}
+	return comment.Status, nil
+}
+
+// moderate returns the status the content is saved with and what the moderation matched.
+func (c *commentService) moderate(ctx context.Context, content string) (domain.CommentStatus, string) {
+	status := domain.CommentStatusApproved
+	res, err := c.filter.Filter(ctx, content)
+	switch {
+	case err != nil:
+		// held instead of failing the comment, an admin decides on it later
+		c.l.Error("moderate comment failed", logger.Error(err))
+		status = domain.CommentStatusPending
+	case res.Verdict == moderation.VerdictReview:
+		status = domain.CommentStatusPending
+	case res.Verdict == moderation.VerdictReject:
+		// saved still, so its author sees it as it was written
+		status = domain.CommentStatusRejected
+	}
+	return status, strings.Join(res.Hits, ", ")
+}
+
+// produceCreatedEvent notifies of a comment visible to everyone,
+// a failure is only logged, the comment is kept without notifying anyone.
+func (c *commentService) produceCreatedEvent(ctx context.Context, comment domain.Comment) {
+	evt := events.CommentCreatedEvent{
+		Id:    comment.ID,
+		Biz:   comment.Biz,
+		BizId: comment.BizID,
+		Uid:   comment.Commentator.ID,
+		Ctime: comment.CTime.UnixMilli(),
+	}
+	var ids []int64
+	if comment.RootComment != nil {
+		evt.RootId = comment.RootComment.ID
+		ids = append(ids, evt.RootId)
+	}
+	if comment.ParentComment != nil {
+		evt.ParentId = comment.ParentComment.ID
+		ids = append(ids, evt.ParentId)
+	}
+	if len(ids) > 0 {
+		found, err := c.repo.GetCommentByIds(ctx, ids)
+		if err != nil {
+			c.l.Error("find replied comments failed", logger.Int64("id", comment.ID), logger.Error(err))
+		}
+		for _, cmt := range found {
+			if cmt.ID == evt.RootId {
+				evt.RootUid = cmt.Commentator.ID
+			}
+			if cmt.ID == evt.ParentId {
+				evt.ParentUid = cmt.Commentator.ID
+			}
+		}
+	}
+	evt.MentionedUids = c.resolveMentions(ctx, comment)
+	err := c.producer.ProduceCommentCreatedEvent(ctx, evt)
+	if err != nil {
+		c.l.Error("produce comment created event failed", logger.Int64("id", comment.ID), logger.Error(err))
+	}
+}
+
+// resolveMentions looks the users mentioned up by their nick names,
+// a nick name shared by several users is ambiguous and nobody is notified of it.
+func (c *commentService) resolveMentions(ctx context.Context, comment domain.Comment) []int64 {
+	names := comment.Mentions()
+	if len(names) == 0 {
+		return nil
+	}
+	resp, err := c.userSvc.FindByNickNames(ctx, &userv1.FindByNickNamesRequest{NickNames: names})
+	if err != nil {
+		c.l.Error("find mentioned users failed", logger.Int64("id", comment.ID), logger.Error(err))
+		return nil
+	}
+	// nick names are compared case insensitively, as the database does
+	uids := make(map[string][]int64, len(names))
+	for _, u := range resp.GetUsers() {
+		name := strings.ToLower(u.GetNickName())
+		uids[name] = append(uids[name], u.GetId())
+	}
+	res := make([]int64, 0, len(names))
+	for _, name := range names {
+		if found := uids[strings.ToLower(name)]; len(found) == 1 {
+			res = append(res, found[0])
+		}
+	}
+	return res
 }
 
 func (c *commentService) GetCommentByIds(ctx context.Context, id []int64) ([]domain.Comment, error) {
 	return c.repo.GetCommentByIds(ctx, id)
 }
 
-func (c *commentService) GetMoreReplies(ctx context.Context, rid int64, maxID int64, limit int64) ([]domain.Comment, error) {
-	return c.repo.GetMoreReplies(ctx, rid, maxID, limit)
+func (c *commentService) GetMoreReplies(ctx context.Context, rid, viewer int64, maxID int64, limit int64) ([]domain.Comment, error) {
+	limit = clampLimit(limit)
+	return c.repo.GetMoreReplies(ctx, rid, viewer, maxID, limit)
+}
+
+func (c *commentService) GetMoreRepliesByCursor(ctx context.Context, rid, viewer int64, cursor string, limit int64) ([]domain.Comment, string, error) {
+	limit = clampLimit(limit)
+	scope := fmt.Sprintf("comment:replies:%d", rid)
+	cur, err := c.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	replies, err := c.repo.GetMoreReplies(ctx, rid, viewer, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return replies, c.nextCursor(scope, replies, limit), nil
+}
+
+func (c *commentService) AddLikeCnt(ctx context.Context, deltas map[int64]int64) error {
+	return c.repo.AddLikeCnt(ctx, deltas)
+}
+
+func (c *commentService) GetCommentCounts(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.CommentCount, error) {
+	if len(bizIds) > maxCountIds {
+		return nil, ErrTooManyIds
+	}
+	if len(bizIds) == 0 {
+		return map[int64]domain.CommentCount{}, nil
+	}
+	return c.repo.GetCounts(ctx, biz, bizIds)
+}
+
+func (c *commentService) ListPendingComments(ctx context.Context, cursor string, limit int64) ([]domain.Comment, string, error) {
+	limit = clampLimit(limit)
+	cur, err := c.codec.Decode(scopePending, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	comments, err := c.repo.FindPending(ctx, cur.Id, int(limit))
+	if err != nil {
+		return nil, "", err
+	}
+	return comments, c.nextCursor(scopePending, comments, limit), nil
+}
+
+func (c *commentService) ReviewComment(ctx context.Context, id int64, approved bool) error {
+	status := domain.CommentStatusRejected
+	if approved {
+		status = domain.CommentStatusApproved
+	}
+	err := c.repo.Review(ctx, id, status)
+	if err != nil || !approved {
+		return err
+	}
+	found, err := c.repo.GetCommentByIds(ctx, []int64{id})
+	if err != nil {
+		c.l.Error("find approved comment failed", logger.Int64("id", id), logger.Error(err))
+		return nil
+	}
+	for _, comment := range found {
+		c.produceCreatedEvent(ctx, comment)
+	}
+	return nil
+}
+
+func clampLimit(limit int64) int64 {
+	return min(max(limit, 1), maxListLimit)
+}
+
+// nextCursor points at the last comment of a full page, a shorter page is the last one.
+func (c *commentService) nextCursor(scope string, comments []domain.Comment, limit int64) string {
+	if len(comments) == 0 || int64(len(comments)) < limit {
+		return ""
+	}
+	return c.codec.Encode(scope, cursorx.Cursor{Id: comments[len(comments)-1].ID})
 }
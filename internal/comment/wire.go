@@ -5,17 +5,28 @@ package main
 import (
 	"github.com/google/wire"
 
+	"github.com/tsukiyo/mercury/internal/comment/events"
 	"github.com/tsukiyo/mercury/internal/comment/grpc"
 	"github.com/tsukiyo/mercury/internal/comment/ioc"
 	"github.com/tsukiyo/mercury/internal/comment/repository"
+	"github.com/tsukiyo/mercury/internal/comment/repository/cache"
 	"github.com/tsukiyo/mercury/internal/comment/repository/dao"
 	"github.com/tsukiyo/mercury/internal/comment/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 var thirdProviderSet = wire.NewSet(
 	ioc.InitLogger,
 	ioc.InitDB,
+	cursorx.InitCodec,
+	ioc.InitKafka,
+	ioc.InitContentFilter,
+	ioc.InitRedis,
+	ioc.InitEtcdClient,
+	ioc.InitUserRpcClient,
+	ioc.InitArticleRpcClient,
+	ioc.NewSyncProducer,
 )
 
 var serviceProviderSet = wire.NewSet(
@@ -23,14 +34,23 @@ var serviceProviderSet = wire.NewSet(
 	service.NewCommentService,
 	repository.NewCommentRepository,
 	dao.NewCommentDAO,
+	cache.NewRedisCommentHotCache,
+)
+
+var eventsProviderSet = wire.NewSet(
+	events.NewArticleDeleteEventConsumer,
+	events.NewLikeEventConsumer,
+	events.NewSaramaProducer,
+	ioc.NewConsumers,
 )
 
 func InitAPP() *app.App {
 	wire.Build(
 		thirdProviderSet,
 		serviceProviderSet,
+		eventsProviderSet,
 		ioc.InitGRPCxServer,
-		wire.Struct(new(app.App), "GRPCServer"),
+		wire.Struct(new(app.App), "GRPCServer", "Consumers"),
 	)
 	return new(app.App)
 }
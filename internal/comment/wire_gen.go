@@ -8,12 +8,15 @@ package main
 
 import (
 	"github.com/google/wire"
+	"github.com/tsukiyo/mercury/internal/comment/events"
 	"github.com/tsukiyo/mercury/internal/comment/grpc"
 	"github.com/tsukiyo/mercury/internal/comment/ioc"
 	"github.com/tsukiyo/mercury/internal/comment/repository"
+	"github.com/tsukiyo/mercury/internal/comment/repository/cache"
 	"github.com/tsukiyo/mercury/internal/comment/repository/dao"
 	"github.com/tsukiyo/mercury/internal/comment/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 // Injectors from wire.go:
@@ -22,18 +25,34 @@ func InitAPP() *app.App {
 	logger := ioc.InitLogger()
 	db := ioc.InitDB(logger)
 	commentDAO := dao.NewCommentDAO(db)
-	commentRepository := repository.NewCommentRepository(commentDAO, logger)
-	commentService := service.NewCommentService(commentRepository)
+	cmdable := ioc.InitRedis()
+	commentHotCache := cache.NewRedisCommentHotCache(cmdable)
+	commentRepository := repository.NewCommentRepository(commentDAO, commentHotCache, logger)
+	codec := cursorx.InitCodec()
+	contentFilter := ioc.InitContentFilter(logger)
+	client := ioc.InitEtcdClient()
+	userServiceClient := ioc.InitUserRpcClient(client)
+	articleServiceClient := ioc.InitArticleRpcClient(client)
+	saramaClient := ioc.InitKafka()
+	syncProducer := ioc.NewSyncProducer(saramaClient)
+	producer := events.NewSaramaProducer(syncProducer)
+	commentService := service.NewCommentService(commentRepository, codec, contentFilter, userServiceClient, articleServiceClient, producer, logger)
 	commentServiceServer := grpc.NewCommentServiceServer(commentService)
 	server := ioc.InitGRPCxServer(commentServiceServer, logger)
+	articleDeleteEventConsumer := events.NewArticleDeleteEventConsumer(saramaClient, commentRepository, logger)
+	likeEventConsumer := events.NewLikeEventConsumer(saramaClient, commentRepository, logger)
+	v := ioc.NewConsumers(articleDeleteEventConsumer, likeEventConsumer)
 	appApp := &app.App{
 		GRPCServer: server,
+		Consumers:  v,
 	}
 	return appApp
 }
 
 // wire.go:
 
-var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitDB)
+var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitDB, cursorx.InitCodec, ioc.InitKafka, ioc.InitContentFilter, ioc.InitRedis, ioc.InitEtcdClient, ioc.InitUserRpcClient, ioc.InitArticleRpcClient, ioc.NewSyncProducer)
 
-var serviceProviderSet = wire.NewSet(grpc.NewCommentServiceServer, service.NewCommentService, repository.NewCommentRepository, dao.NewCommentDAO)
+var serviceProviderSet = wire.NewSet(grpc.NewCommentServiceServer, service.NewCommentService, repository.NewCommentRepository, dao.NewCommentDAO, cache.NewRedisCommentHotCache)
+
+var eventsProviderSet = wire.NewSet(events.NewArticleDeleteEventConsumer, events.NewLikeEventConsumer, events.NewSaramaProducer, ioc.NewConsumers)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -14,6 +15,10 @@ const (
 	taskStatusEnd
 )
 
+// taskExpiredThreshold a running task whose utime has not been renewed
+// within the threshold is regarded as abandoned and can be preempted again
+const taskExpiredThreshold = time.Minute
+
 type Task struct {
 	Id         int64  `gorm:"primaryKey,autoIncrement"`
 	Name       string `gorm:"unique"`
@@ -49,11 +54,13 @@ func (dao *GORMTaskDAO) Preempt(ctx context.Context) (Task, error) {
 	db := dao.db.WithContext(ctx)
 	for {
 		now := time.Now().UnixMilli()
-		// get task
+		// get task, including the ones abandoned by crashed instances
 		var task Task
+		expired := now - taskExpiredThreshold.Milliseconds()
 		err := db.WithContext(ctx).
 			Model(&Task{}).
-			Where("next_time <= ? AND status = ?", now, taskStatusWaiting).
+			Where("(next_time <= ? AND status = ?) OR (status = ? AND utime <= ?)",
+				now, taskStatusWaiting, taskStatusRunning, expired).
 			First(&task).Error
 		if err != nil {
 			return Task{}, err
@@ -69,7 +76,7 @@ func (dao *GORMTaskDAO) Preempt(ctx context.Context) (Task, error) {
 				"status":  taskStatusRunning,
 			})
 		if res.Error != nil {
-			return Task{}, err
+			return Task{}, res.Error
 		}
 		// preempt success
 		if res.RowsAffected == 1 {
@@ -109,8 +116,15 @@ func (dao *GORMTaskDAO) UpdateUtime(ctx context.Context, id int64) error {
 		}).Error
 }
 
+// Insert does nothing if the task with the same name exists,
+// so it is safe to register tasks on every startup.
 func (dao *GORMTaskDAO) Insert(ctx context.Context, tsk Task) error {
 	now := time.Now().UnixMilli()
 	tsk.Ctime, tsk.Utime = now, now
-	return dao.db.WithContext(ctx).Create(&tsk).Error
+	if tsk.Status == taskStatusUnknown {
+		tsk.Status = taskStatusWaiting
+	}
+	return dao.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&tsk).Error
 }
@@ -27,8 +27,10 @@ type taskService struct {
 
 func NewTaskService(repo repository.TaskRepository, l logger.Logger) TaskService {
 	return &taskService{
-		repo: repo,
-		l:    l,
+		repo:                   repo,
+		l:                      l,
+		refreshInterval:        time.Second * 10,
+		refreshMaxFailureCount: 3,
 	}
 }
 
@@ -39,9 +41,15 @@ func (svc *taskService) Preempt(ctx context.Context) (domain.Task, error) {
 	}
 
 	ticker := time.NewTicker(svc.refreshInterval)
+	done := make(chan struct{})
 	go func() {
 		failedCnt := 0
-		for range ticker.C {
+		for {
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
 			if svc.refresh(tsk.Id) != nil {
 				failedCnt++
 			} else {
@@ -58,6 +66,7 @@ func (svc *taskService) Preempt(ctx context.Context) (domain.Task, error) {
 
 	tsk.CancelFunc = func() {
 		ticker.Stop()
+		close(done)
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
 		er := svc.repo.Release(ctx, tsk.Id)
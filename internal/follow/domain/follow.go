@@ -1,6 +1,7 @@
 package domain
 
 type Relation struct {
+	Id       int64
 	Followee int64 // 被关注者
 	Follower int64 // 关注者
 }
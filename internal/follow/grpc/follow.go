@@ -39,7 +39,17 @@ func (f *FollowServiceServer) CancelFollow(ctx context.Context, request *followv
 }
 
 func (f *FollowServiceServer) GetFollowee(ctx context.Context, request *followv1.GetFolloweeRequest) (*followv1.GetFolloweeResponse, error) {
-	relationList, err := f.svc.GetFollowee(ctx, request.Follower, request.Offset, request.Limit)
+	var (
+		relationList []domain.Relation
+		next         string
+		err          error
+	)
+	// offset is kept for the callers not moved to cursor yet
+	if request.GetOffset() > 0 && request.GetCursor() == "" {
+		relationList, err = f.svc.GetFollowee(ctx, request.Follower, request.GetOffset(), request.Limit)
+	} else {
+		relationList, next, err = f.svc.GetFolloweeByCursor(ctx, request.Follower, request.GetCursor(), request.Limit)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -49,11 +59,22 @@ func (f *FollowServiceServer) GetFollowee(ctx context.Context, request *followv1
 	}
 	return &followv1.GetFolloweeResponse{
 		FollowRelation: res,
+		NextCursor:     next,
 	}, nil
 }
 
 func (f *FollowServiceServer) GetFollower(ctx context.Context, request *followv1.GetFollowerRequest) (*followv1.GetFollowerResponse, error) {
-	relationList, err := f.svc.GetFollower(ctx, request.Followee, request.Offset, request.Limit)
+	var (
+		relationList []domain.Relation
+		next         string
+		err          error
+	)
+	// offset is kept for the callers not moved to cursor yet
+	if request.GetOffset() > 0 && request.GetCursor() == "" {
+		relationList, err = f.svc.GetFollower(ctx, request.Followee, request.GetOffset(), request.Limit)
+	} else {
+		relationList, next, err = f.svc.GetFollowerByCursor(ctx, request.Followee, request.GetCursor(), request.Limit)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +84,7 @@ func (f *FollowServiceServer) GetFollower(ctx context.Context, request *followv1
 	}
 	return &followv1.GetFollowerResponse{
 		FollowRelation: res,
+		NextCursor:     next,
 	}, nil
 }
 
@@ -88,6 +110,7 @@ func (f *FollowServiceServer) GetFollowStatics(ctx context.Context, request *fol
 
 func (f *FollowServiceServer) convertRelationToVO(relation domain.Relation) *followv1.Relation {
 	return &followv1.Relation{
+		Id:       relation.Id,
 		Follower: relation.Follower,
 		Followee: relation.Followee,
 	}
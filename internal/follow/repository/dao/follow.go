@@ -39,6 +39,33 @@ func (dao *GORMFollowDAO) FollowerRelationList(ctx context.Context, follower int
 	return res, err
 }
 
+func (dao *GORMFollowDAO) FolloweeRelationListBefore(ctx context.Context, follower int64, id, limit int64) ([]Relation, error) {
+	var res []Relation
+	err := before(dao.db.WithContext(ctx).Model(&Relation{}), id).
+		Where("follower = ? AND status = ?", follower, RelationStatusActive).
+		Order("id DESC").
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDAO) FollowerRelationListBefore(ctx context.Context, followee int64, id, limit int64) ([]Relation, error) {
+	var res []Relation
+	err := before(dao.db.WithContext(ctx).Model(&Relation{}), id).
+		Where("followee = ? AND status = ?", followee, RelationStatusActive).
+		Order("id DESC").
+		Limit(int(limit)).
+		Find(&res).Error
+	return res, err
+}
+
+func before(query *gorm.DB, id int64) *gorm.DB {
+	if id <= 0 {
+		return query
+	}
+	return query.Where("id < ?", id)
+}
+
 func (dao *GORMFollowDAO) GetRelationDetail(ctx context.Context, r Relation) (Relation, error) {
 	var res Relation
 	err := dao.db.WithContext(ctx).Model(&Relation{}).
@@ -5,6 +5,10 @@ import "context"
 type FollowDAO interface {
 	FolloweeRelationList(ctx context.Context, follower int64, offset, limit int64) ([]Relation, error)
 	FollowerRelationList(ctx context.Context, follower int64, offset, limit int64) ([]Relation, error)
+	// FolloweeRelationListBefore returns relations ordered by id descending with id less than the given one,
+	// id 0 means the first page.
+	FolloweeRelationListBefore(ctx context.Context, follower int64, id, limit int64) ([]Relation, error)
+	FollowerRelationListBefore(ctx context.Context, followee int64, id, limit int64) ([]Relation, error)
 	GetRelationDetail(ctx context.Context, r Relation) (Relation, error)
 	CreateRelation(ctx context.Context, r Relation) error
 	UpdateStatus(ctx context.Context, followee, follower int64, status uint8) error
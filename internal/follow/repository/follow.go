@@ -61,6 +61,22 @@ func (repo *CachedFollowRepository) GetFollower(ctx context.Context, followee in
 	return repo.genRelationList(list), nil
 }
 
+func (repo *CachedFollowRepository) GetFolloweeBefore(ctx context.Context, follower int64, id, limit int64) ([]domain.Relation, error) {
+	list, err := repo.dao.FolloweeRelationListBefore(ctx, follower, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.genRelationList(list), nil
+}
+
+func (repo *CachedFollowRepository) GetFollowerBefore(ctx context.Context, followee int64, id, limit int64) ([]domain.Relation, error) {
+	list, err := repo.dao.FollowerRelationListBefore(ctx, followee, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.genRelationList(list), nil
+}
+
 func (repo *CachedFollowRepository) GetRelation(ctx context.Context, r domain.Relation) (domain.Relation, error) {
 	res, err := repo.dao.GetRelationDetail(ctx, repo.toEntity(r))
 	if err != nil {
@@ -94,6 +110,7 @@ func (repo *CachedFollowRepository) GetStatics(ctx context.Context, uid int64) (
 
 func (repo *CachedFollowRepository) toDomain(r dao.Relation) domain.Relation {
 	return domain.Relation{
+		Id:       r.ID,
 		Followee: r.Followee,
 		Follower: r.Follower,
 	}
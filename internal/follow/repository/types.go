@@ -11,6 +11,9 @@ type FollowRepository interface {
 	InactiveFollowRelation(ctx context.Context, r domain.Relation) error
 	GetFollowee(ctx context.Context, follower int64, offset, limit int64) ([]domain.Relation, error)
 	GetFollower(ctx context.Context, followee int64, offset, limit int64) ([]domain.Relation, error)
+	// GetFolloweeBefore continues after the relation of the given id, id 0 means the first page.
+	GetFolloweeBefore(ctx context.Context, follower int64, id, limit int64) ([]domain.Relation, error)
+	GetFollowerBefore(ctx context.Context, followee int64, id, limit int64) ([]domain.Relation, error)
 	GetRelation(ctx context.Context, r domain.Relation) (domain.Relation, error)
 	GetStatics(ctx context.Context, uid int64) (domain.Statics, error)
 }
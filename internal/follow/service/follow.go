@@ -5,13 +5,30 @@ import (
 
 	"github.com/tsukiyo/mercury/internal/follow/domain"
 	"github.com/tsukiyo/mercury/internal/follow/repository"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+)
+
+var ErrInvalidCursor = cursorx.ErrInvalidCursor
+
+// scopes of cursors, a cursor is only accepted by the list issuing it
+const (
+	scopeFollowee = "follow:followee"
+	scopeFollower = "follow:follower"
 )
 
 type FollowService interface {
 	Follow(ctx context.Context, followee, follower int64) error
 	CancelFollow(ctx context.Context, followee, follower int64) error
+	// GetFollowee pages by offset.
+	// Deprecated: use GetFolloweeByCursor.
 	GetFollowee(ctx context.Context, follower int64, offset, limit int64) ([]domain.Relation, error)
+	// GetFollower pages by offset.
+	// Deprecated: use GetFollowerByCursor.
 	GetFollower(ctx context.Context, followee int64, offset, limit int64) ([]domain.Relation, error)
+	// GetFolloweeByCursor pages the latest relations first, an empty cursor means the first page.
+	// The returned cursor is empty when there are no more relations.
+	GetFolloweeByCursor(ctx context.Context, follower int64, cursor string, limit int64) ([]domain.Relation, string, error)
+	GetFollowerByCursor(ctx context.Context, followee int64, cursor string, limit int64) ([]domain.Relation, string, error)
 	GetRelation(ctx context.Context, followee, follower int64) (domain.Relation, error)
 	GetStatics(ctx context.Context, uid int64) (domain.Statics, error)
 }
@@ -19,12 +36,14 @@ type FollowService interface {
 var _ FollowService = (*followService)(nil)
 
 type followService struct {
-	repo repository.FollowRepository
+	repo  repository.FollowRepository
+	codec *cursorx.Codec
 }
 
-func NewFollowService(repo repository.FollowRepository) FollowService {
+func NewFollowService(repo repository.FollowRepository, codec *cursorx.Codec) FollowService {
 	return &followService{
-		repo: repo,
+		repo:  repo,
+		codec: codec,
 	}
 }
 
@@ -50,6 +69,38 @@ func (f followService) GetFollower(ctx context.Context, followee int64, offset,
 	return f.repo.GetFollower(ctx, followee, offset, limit)
 }
 
+func (f followService) GetFolloweeByCursor(ctx context.Context, follower int64, cursor string, limit int64) ([]domain.Relation, string, error) {
+	cur, err := f.codec.Decode(scopeFollowee, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	list, err := f.repo.GetFolloweeBefore(ctx, follower, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return list, f.nextCursor(scopeFollowee, list, limit), nil
+}
+
+func (f followService) GetFollowerByCursor(ctx context.Context, followee int64, cursor string, limit int64) ([]domain.Relation, string, error) {
+	cur, err := f.codec.Decode(scopeFollower, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	list, err := f.repo.GetFollowerBefore(ctx, followee, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return list, f.nextCursor(scopeFollower, list, limit), nil
+}
+
+// nextCursor points at the last relation of a full page, a shorter page is the last one.
+func (f followService) nextCursor(scope string, list []domain.Relation, limit int64) string {
+	if len(list) == 0 || int64(len(list)) < limit {
+		return ""
+	}
+	return f.codec.Encode(scope, cursorx.Cursor{Id: list[len(list)-1].Id})
+}
+
 func (f followService) GetRelation(ctx context.Context, followee, follower int64) (domain.Relation, error) {
 	return f.repo.GetRelation(ctx, domain.Relation{
 		Followee: followee,
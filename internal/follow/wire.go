@@ -12,12 +12,14 @@ import (
 	"github.com/tsukiyo/mercury/internal/follow/repository/dao"
 	"github.com/tsukiyo/mercury/internal/follow/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 var thirdProviderSet = wire.NewSet(
 	ioc.InitDB,
 	ioc.InitLogger,
 	ioc.InitRedis,
+	cursorx.InitCodec,
 )
 
 var svcProviderSet = wire.NewSet(
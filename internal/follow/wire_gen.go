@@ -8,7 +8,6 @@ package main
 
 import (
 	"github.com/google/wire"
-
 	"github.com/tsukiyo/mercury/internal/follow/grpc"
 	"github.com/tsukiyo/mercury/internal/follow/ioc"
 	"github.com/tsukiyo/mercury/internal/follow/repository"
@@ -16,6 +15,7 @@ import (
 	"github.com/tsukiyo/mercury/internal/follow/repository/dao"
 	"github.com/tsukiyo/mercury/internal/follow/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 // Injectors from wire.go:
@@ -27,7 +27,8 @@ func InitAPP() *app.App {
 	cmdable := ioc.InitRedis()
 	followCache := cache.NewRedisFollowCache(cmdable)
 	followRepository := repository.NewCachedFollowRepository(followDAO, followCache, logger)
-	followService := service.NewFollowService(followRepository)
+	codec := cursorx.InitCodec()
+	followService := service.NewFollowService(followRepository, codec)
 	followServiceServer := grpc.NewFollowServiceServer(followService)
 	server := ioc.InitGRPCxServer(followServiceServer, logger)
 	appApp := &app.App{
@@ -38,6 +39,6 @@ func InitAPP() *app.App {
 
 // wire.go:
 
-var thirdProviderSet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitRedis)
+var thirdProviderSet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitRedis, cursorx.InitCodec)
 
 var svcProviderSet = wire.NewSet(grpc.NewFollowServiceServer, service.NewFollowService, repository.NewCachedFollowRepository, dao.NewGORMFollowDAO, cache.NewRedisFollowCache)
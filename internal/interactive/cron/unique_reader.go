@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/interactive/repository"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var _ cronx.Task = (*UniqueReaderJob)(nil)
+
+// UniqueReaderJob persists the unique read counts of the articles read recently.
+// The counts are overwritten, instances running it at the same time write the same numbers.
+type UniqueReaderJob struct {
+	repo    repository.UniqueReaderRepository
+	timeout time.Duration
+	l       logger.Logger
+}
+
+func NewUniqueReaderJob(repo repository.UniqueReaderRepository, timeout time.Duration, l logger.Logger) *UniqueReaderJob {
+	return &UniqueReaderJob{
+		repo:    repo,
+		timeout: timeout,
+		l:       l,
+	}
+}
+
+func (job *UniqueReaderJob) Name() string {
+	return "interactive_unique_reader"
+}
+
+func (job *UniqueReaderJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), job.timeout)
+	defer cancel()
+	cnt, err := job.repo.SyncUniqueReadCnt(ctx, "article")
+	if err != nil {
+		return err
+	}
+	job.l.Info("unique read counts synced", logger.Int("count", cnt))
+	return nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "strings"
+
+// Action is what users could do on a resource, a biz type allows a set of them.
+type Action uint8
+
+const (
+	ActionRead Action = 1 << iota
+	ActionLike
+	ActionFavorite
+)
+
+var actionNames = []struct {
+	action Action
+	name   string
+}{
+	{ActionRead, "read"},
+	{ActionLike, "like"},
+	{ActionFavorite, "favorite"},
+}
+
+// ParseAction parses the name of an action, it returns false for unknown names.
+func ParseAction(name string) (Action, bool) {
+	for _, a := range actionNames {
+		if a.name == strings.ToLower(name) {
+			return a.action, true
+		}
+	}
+	return 0, false
+}
+
+// Has reports whether all the actions in other are in the set.
+func (a Action) Has(other Action) bool {
+	return a&other == other
+}
+
+func (a Action) String() string {
+	names := make([]string, 0, len(actionNames))
+	for _, an := range actionNames {
+		if a.Has(an.action) {
+			names = append(names, an.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
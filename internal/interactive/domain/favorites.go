@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+type FavoritesVisibility uint8
+
+const (
+	FavoritesVisibilityUnknown FavoritesVisibility = iota
+	// FavoritesVisibilityPrivate the folder is only seen by its owner
+	FavoritesVisibilityPrivate
+	FavoritesVisibilityPublic
+)
+
+func (v FavoritesVisibility) Valid() bool {
+	return v == FavoritesVisibilityPrivate || v == FavoritesVisibilityPublic
+}
+
+// Favorites is a folder of the resources a user favorited.
+type Favorites struct {
+	Id         int64
+	Uid        int64
+	Name       string
+	Visibility FavoritesVisibility
+	ItemCnt    int64
+	// Covers are the latest items, only filled in the folder list
+	Covers []FavoriteItem
+	Ctime  time.Time
+	Utime  time.Time
+}
+
+type FavoriteItem struct {
+	Id    int64
+	Fid   int64
+	Uid   int64
+	Biz   string
+	BizId int64
+	Ctime time.Time
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ActivityRecord is a resource in the timeline of a user, like what the user liked or read.
+type ActivityRecord struct {
+	Biz   string
+	BizId int64
+	// Utime is when the user liked or read the resource last
+	Utime time.Time
+	// Id is the id of the like, the records of the read history have none
+	Id int64
+}
+
+// Read is a read of the resource by a signed in user.
+type Read struct {
+	BizId int64
+	Uid   int64
+	Ctime time.Time
+}
@@ -1,11 +1,15 @@
 package domain
 
 type Interactive struct {
-	Biz         string `json:"biz"`
-	BizId       int64  `json:"biz_id"`
-	ReadCnt     int64  `json:"read_cnt"`
-	LikeCnt     int64  `json:"like_cnt"`
-	FavoriteCnt int64  `json:"favorite_cnt"`
-	Liked       bool   `json:"liked"`
-	Favorited   bool   `json:"favorited"`
+	Biz     string `json:"biz"`
+	BizId   int64  `json:"biz_id"`
+	ReadCnt int64  `json:"read_cnt"`
+	// UniqueReadCnt and WeeklyUniqueReadCnt are approximate distinct readers, in total and in the last 7 days,
+	// they are synced from redis regularly and fall behind ReadCnt a little
+	UniqueReadCnt       int64 `json:"unique_read_cnt"`
+	WeeklyUniqueReadCnt int64 `json:"weekly_unique_read_cnt"`
+	LikeCnt             int64 `json:"like_cnt"`
+	FavoriteCnt         int64 `json:"favorite_cnt"`
+	Liked               bool  `json:"liked"`
+	Favorited           bool  `json:"favorited"`
 }
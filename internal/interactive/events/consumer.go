@@ -6,6 +6,7 @@ import (
 
 	"github.com/IBM/sarama"
 
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
 	"github.com/tsukiyo/mercury/internal/interactive/repository"
 
 	"github.com/tsukiyo/mercury/pkg/logger"
@@ -49,7 +50,8 @@ func (consumer *InteractiveReadEventConsumer) Start() error {
 		err := cg.Consume(context.Background(),
 			[]string{topicReadEvent},
 			// saramax.NewHandler[ReadEvent](consumer.l, consumer.Consume),
-			saramax.NewBatchHandler[ReadEvent](consumer.l, consumer.BatchConsume),
+			// reads of a viral article are merged into one update per flush
+			saramax.NewAggregateHandler[ReadEvent](consumer.l, consumer.newAggregator),
 		)
 		if err != nil {
 			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
@@ -102,3 +104,43 @@ func (consumer *InteractiveReadEventConsumer) BatchConsume(msgs []*sarama.Consum
 	}
 	return nil
 }
+
+func (consumer *InteractiveReadEventConsumer) newAggregator() saramax.Aggregator[ReadEvent] {
+	return &readCntAggregator{
+		repo:   consumer.repo,
+		deltas: make(map[int64]int64),
+	}
+}
+
+// readCntAggregator counts the reads of each article in a partition until they are flushed.
+type readCntAggregator struct {
+	repo   repository.InteractiveRepository
+	deltas map[int64]int64
+	n      int
+}
+
+func (agg *readCntAggregator) Add(msg *sarama.ConsumerMessage, evt ReadEvent) {
+	agg.deltas[evt.Aid]++
+	agg.n++
+}
+
+func (agg *readCntAggregator) Len() int {
+	return agg.n
+}
+
+func (agg *readCntAggregator) Flush(ctx context.Context) error {
+	if len(agg.deltas) == 0 {
+		return nil
+	}
+	deltas := make([]domain.Interactive, 0, len(agg.deltas))
+	for aid, cnt := range agg.deltas {
+		deltas = append(deltas, domain.Interactive{Biz: "article", BizId: aid, ReadCnt: cnt})
+	}
+	err := agg.repo.AddCnt(ctx, deltas)
+	if err != nil {
+		return err
+	}
+	clear(agg.deltas)
+	agg.n = 0
+	return nil
+}
@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM/sarama"
+)
+
+const TopicLikeEvent = "interactive_like_event"
+
+// LikeEvent is sent once a user likes a resource or cancels the like,
+// the services owning the resources keep their own orders by it.
+type LikeEvent struct {
+	Biz   string
+	BizId int64
+	Uid   int64
+	// Delta is 1 for a like and -1 for a cancelled one
+	Delta int64
+	// Utime unix timestamp in milliseconds
+	Utime int64
+}
+
+var _ Producer = (*SaramaProducer)(nil)
+
+type SaramaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaProducer{
+		producer: producer,
+	}
+}
+
+func (p *SaramaProducer) ProduceLikeEvent(ctx context.Context, evt LikeEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicLikeEvent,
+		// the events of a resource are kept in order in one partition
+		Key:   sarama.StringEncoder(fmt.Sprintf("%s:%d", evt.Biz, evt.BizId)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
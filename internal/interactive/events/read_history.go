@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/repository"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+var _ Consumer = (*ReadHistoryConsumer)(nil)
+
+// ReadHistoryConsumer builds the read history of the users from the read events,
+// it consumes in its own group so the history never holds back the read counts.
+type ReadHistoryConsumer struct {
+	client sarama.Client
+	repo   repository.HistoryRepository
+	l      logger.Logger
+}
+
+func NewReadHistoryConsumer(client sarama.Client,
+	repo repository.HistoryRepository,
+	l logger.Logger,
+) *ReadHistoryConsumer {
+	return &ReadHistoryConsumer{
+		client: client,
+		repo:   repo,
+		l:      l,
+	}
+}
+
+func (consumer *ReadHistoryConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_read_history", consumer.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicReadEvent},
+			saramax.NewBatchHandler[ReadEvent](consumer.l, consumer.BatchConsume),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+
+	return err
+}
+
+func (consumer *ReadHistoryConsumer) BatchConsume(msgs []*sarama.ConsumerMessage,
+	evts []ReadEvent,
+) error {
+	// the reads of a user in the batch are written at once
+	records := make(map[int64][]domain.ActivityRecord, len(evts))
+	for i, evt := range evts {
+		// anonymous reads have no history
+		if evt.Uid <= 0 {
+			continue
+		}
+		// the event is stamped when the article is read, consuming late does not change the read time
+		readAt := msgs[i].Timestamp
+		if readAt.IsZero() {
+			readAt = time.Now()
+		}
+		records[evt.Uid] = append(records[evt.Uid], domain.ActivityRecord{
+			Biz:   "article",
+			BizId: evt.Aid,
+			Utime: readAt,
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for uid, rs := range records {
+		err := consumer.repo.AddReadHistory(ctx, uid, "article", rs)
+		if err != nil {
+			consumer.l.Error("add read history failed",
+				logger.Int64("uid", uid),
+				logger.Error(err),
+			)
+		}
+	}
+	return nil
+}
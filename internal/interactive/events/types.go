@@ -1,5 +1,11 @@
 package events
 
+import "context"
+
 type Consumer interface {
 	Start() error
 }
+
+type Producer interface {
+	ProduceLikeEvent(ctx context.Context, evt LikeEvent) error
+}
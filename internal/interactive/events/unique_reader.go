@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/repository"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+var _ Consumer = (*UniqueReaderConsumer)(nil)
+
+// UniqueReaderConsumer logs the readers of the articles from the read events,
+// the counts are persisted by the UniqueReaderJob.
+type UniqueReaderConsumer struct {
+	client sarama.Client
+	repo   repository.UniqueReaderRepository
+	l      logger.Logger
+}
+
+func NewUniqueReaderConsumer(client sarama.Client,
+	repo repository.UniqueReaderRepository,
+	l logger.Logger,
+) *UniqueReaderConsumer {
+	return &UniqueReaderConsumer{
+		client: client,
+		repo:   repo,
+		l:      l,
+	}
+}
+
+func (consumer *UniqueReaderConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_unique_reader", consumer.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicReadEvent},
+			saramax.NewBatchHandler[ReadEvent](consumer.l, consumer.BatchConsume),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+
+	return err
+}
+
+func (consumer *UniqueReaderConsumer) BatchConsume(msgs []*sarama.ConsumerMessage,
+	evts []ReadEvent,
+) error {
+	reads := make([]domain.Read, 0, len(evts))
+	for i, evt := range evts {
+		// anonymous readers could not be told apart
+		if evt.Uid <= 0 {
+			continue
+		}
+		// the read is logged into the day it happened, however late it is consumed
+		readAt := msgs[i].Timestamp
+		if readAt.IsZero() {
+			readAt = time.Now()
+		}
+		reads = append(reads, domain.Read{
+			BizId: evt.Aid,
+			Uid:   evt.Uid,
+			Ctime: readAt,
+		})
+	}
+	if len(reads) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return consumer.repo.AddReads(ctx, "article", reads)
+}
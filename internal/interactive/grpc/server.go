@@ -2,9 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/tsukiyo/mercury/internal/interactive/domain"
 
 	"google.golang.org/grpc/codes"
@@ -31,7 +36,7 @@ func (srv *InteractiveServiceServer) Register(server *grpc.Server) {
 func (srv *InteractiveServiceServer) IncrReadCnt(ctx context.Context, request *interactivev1.IncrReadCntRequest) (*interactivev1.IncrReadCntResponse, error) {
 	err := srv.svc.IncrReadCnt(ctx, request.GetBiz(), request.GetBizId())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err, request.GetBiz())
 	}
 	return &interactivev1.IncrReadCntResponse{}, nil
 }
@@ -42,7 +47,7 @@ func (srv *InteractiveServiceServer) Like(ctx context.Context, request *interact
 	}
 	err := srv.svc.Like(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err, request.GetBiz())
 	}
 	return &interactivev1.LikeResponse{}, nil
 }
@@ -50,15 +55,19 @@ func (srv *InteractiveServiceServer) Like(ctx context.Context, request *interact
 func (srv *InteractiveServiceServer) CancelLike(ctx context.Context, request *interactivev1.CancelLikeRequest) (*interactivev1.CancelLikeResponse, error) {
 	err := srv.svc.CancelLike(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err, request.GetBiz())
 	}
 	return &interactivev1.CancelLikeResponse{}, nil
 }
 
 func (srv *InteractiveServiceServer) Favorite(ctx context.Context, request *interactivev1.FavoriteRequest) (*interactivev1.FavoriteResponse, error) {
 	err := srv.svc.Favorite(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetFid())
+	if errors.Is(err, service.ErrFavoritesNotFound) {
+		// the folder is not owned by the user
+		return nil, favoritesStatus(err)
+	}
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err, request.GetBiz())
 	}
 	return &interactivev1.FavoriteResponse{}, nil
 }
@@ -66,7 +75,7 @@ func (srv *InteractiveServiceServer) Favorite(ctx context.Context, request *inte
 func (srv *InteractiveServiceServer) CancelFavorite(ctx context.Context, request *interactivev1.CancelFavoriteRequest) (*interactivev1.CancelFavoriteResponse, error) {
 	err := srv.svc.CancelFavorite(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetFid())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err, request.GetBiz())
 	}
 	return &interactivev1.CancelFavoriteResponse{}, nil
 }
@@ -74,7 +83,7 @@ func (srv *InteractiveServiceServer) CancelFavorite(ctx context.Context, request
 func (srv *InteractiveServiceServer) Get(ctx context.Context, request *interactivev1.GetRequest) (*interactivev1.GetResponse, error) {
 	intr, err := srv.svc.Get(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err, request.GetBiz())
 	}
 	return &interactivev1.GetResponse{
 		Interactive: srv.toDTO(intr),
@@ -84,7 +93,7 @@ func (srv *InteractiveServiceServer) Get(ctx context.Context, request *interacti
 func (srv *InteractiveServiceServer) GetByIds(ctx context.Context, request *interactivev1.GetByIdsRequest) (*interactivev1.GetByIdsResponse, error) {
 	res, err := srv.svc.GetByIds(ctx, request.GetBiz(), request.GetBizIds())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err, request.GetBiz())
 	}
 
 	m := make(map[int64]*interactivev1.Interactive, len(res))
@@ -95,6 +104,137 @@ func (srv *InteractiveServiceServer) GetByIds(ctx context.Context, request *inte
 	return &interactivev1.GetByIdsResponse{Interactives: m}, nil
 }
 
+func (srv *InteractiveServiceServer) CreateFavorites(ctx context.Context, request *interactivev1.CreateFavoritesRequest) (*interactivev1.CreateFavoritesResponse, error) {
+	id, err := srv.svc.CreateFavorites(ctx, srv.favoritesToDomain(request.GetFavorites()))
+	if err != nil {
+		return nil, favoritesStatus(err)
+	}
+	return &interactivev1.CreateFavoritesResponse{Id: id}, nil
+}
+
+func (srv *InteractiveServiceServer) UpdateFavorites(ctx context.Context, request *interactivev1.UpdateFavoritesRequest) (*interactivev1.UpdateFavoritesResponse, error) {
+	err := srv.svc.UpdateFavorites(ctx, srv.favoritesToDomain(request.GetFavorites()))
+	if err != nil {
+		return nil, favoritesStatus(err)
+	}
+	return &interactivev1.UpdateFavoritesResponse{}, nil
+}
+
+func (srv *InteractiveServiceServer) DeleteFavorites(ctx context.Context, request *interactivev1.DeleteFavoritesRequest) (*interactivev1.DeleteFavoritesResponse, error) {
+	err := srv.svc.DeleteFavorites(ctx, request.GetId(), request.GetUid())
+	if err != nil {
+		return nil, favoritesStatus(err)
+	}
+	return &interactivev1.DeleteFavoritesResponse{}, nil
+}
+
+func (srv *InteractiveServiceServer) ListFavorites(ctx context.Context, request *interactivev1.ListFavoritesRequest) (*interactivev1.ListFavoritesResponse, error) {
+	fs, err := srv.svc.ListFavorites(ctx, request.GetUid(), request.GetViewerUid())
+	if err != nil {
+		return nil, err
+	}
+	return &interactivev1.ListFavoritesResponse{
+		Favorites: slice.Map(fs, func(idx int, src domain.Favorites) *interactivev1.Favorites {
+			return srv.favoritesToDTO(src)
+		}),
+	}, nil
+}
+
+func (srv *InteractiveServiceServer) ListFavoriteItems(ctx context.Context, request *interactivev1.ListFavoriteItemsRequest) (*interactivev1.ListFavoriteItemsResponse, error) {
+	items, next, err := srv.svc.ListFavoriteItems(ctx, request.GetFid(), request.GetViewerUid(), request.GetCursor(), int(request.GetLimit()))
+	if err != nil {
+		return nil, favoritesStatus(err)
+	}
+	return &interactivev1.ListFavoriteItemsResponse{
+		Items: slice.Map(items, func(idx int, src domain.FavoriteItem) *interactivev1.FavoriteItem {
+			return srv.favoriteItemToDTO(src)
+		}),
+		NextCursor: next,
+	}, nil
+}
+
+func (srv *InteractiveServiceServer) MoveFavoriteItems(ctx context.Context, request *interactivev1.MoveFavoriteItemsRequest) (*interactivev1.MoveFavoriteItemsResponse, error) {
+	moved, err := srv.svc.MoveFavoriteItems(ctx, request.GetUid(), request.GetFromFid(), request.GetToFid(), request.GetItemIds())
+	if err != nil {
+		return nil, favoritesStatus(err)
+	}
+	return &interactivev1.MoveFavoriteItemsResponse{Moved: moved}, nil
+}
+
+func (srv *InteractiveServiceServer) ListLiked(ctx context.Context, request *interactivev1.ListLikedRequest) (*interactivev1.ListLikedResponse, error) {
+	records, next, err := srv.svc.ListLiked(ctx, request.GetUid(), request.GetBiz(), request.GetCursor(), int(request.GetLimit()))
+	if err != nil {
+		return nil, historyStatus(err, request.GetBiz())
+	}
+	return &interactivev1.ListLikedResponse{
+		Records:    srv.activityRecordsToDTO(records),
+		NextCursor: next,
+	}, nil
+}
+
+func (srv *InteractiveServiceServer) ListReadHistory(ctx context.Context, request *interactivev1.ListReadHistoryRequest) (*interactivev1.ListReadHistoryResponse, error) {
+	records, next, err := srv.svc.ListReadHistory(ctx, request.GetUid(), request.GetBiz(), request.GetCursor(), int(request.GetLimit()))
+	if err != nil {
+		return nil, historyStatus(err, request.GetBiz())
+	}
+	return &interactivev1.ListReadHistoryResponse{
+		Records:    srv.activityRecordsToDTO(records),
+		NextCursor: next,
+	}, nil
+}
+
+func (srv *InteractiveServiceServer) ClearReadHistory(ctx context.Context, request *interactivev1.ClearReadHistoryRequest) (*interactivev1.ClearReadHistoryResponse, error) {
+	err := srv.svc.ClearReadHistory(ctx, request.GetUid(), request.GetBiz())
+	if err != nil {
+		return nil, toStatus(err, request.GetBiz())
+	}
+	return &interactivev1.ClearReadHistoryResponse{}, nil
+}
+
+func (srv *InteractiveServiceServer) activityRecordsToDTO(records []domain.ActivityRecord) []*interactivev1.ActivityRecord {
+	return slice.Map(records, func(idx int, src domain.ActivityRecord) *interactivev1.ActivityRecord {
+		return &interactivev1.ActivityRecord{
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Utime: timestamppb.New(src.Utime),
+		}
+	})
+}
+
+func (srv *InteractiveServiceServer) favoritesToDomain(f *interactivev1.Favorites) domain.Favorites {
+	return domain.Favorites{
+		Id:         f.GetId(),
+		Uid:        f.GetUid(),
+		Name:       f.GetName(),
+		Visibility: domain.FavoritesVisibility(f.GetVisibility()),
+	}
+}
+
+func (srv *InteractiveServiceServer) favoritesToDTO(f domain.Favorites) *interactivev1.Favorites {
+	return &interactivev1.Favorites{
+		Id:         f.Id,
+		Uid:        f.Uid,
+		Name:       f.Name,
+		Visibility: interactivev1.FavoritesVisibility(f.Visibility),
+		ItemCnt:    f.ItemCnt,
+		Covers: slice.Map(f.Covers, func(idx int, src domain.FavoriteItem) *interactivev1.FavoriteItem {
+			return srv.favoriteItemToDTO(src)
+		}),
+		Ctime: timestamppb.New(f.Ctime),
+		Utime: timestamppb.New(f.Utime),
+	}
+}
+
+func (srv *InteractiveServiceServer) favoriteItemToDTO(item domain.FavoriteItem) *interactivev1.FavoriteItem {
+	return &interactivev1.FavoriteItem{
+		Id:    item.Id,
+		Fid:   item.Fid,
+		Biz:   item.Biz,
+		BizId: item.BizId,
+		Ctime: timestamppb.New(item.Ctime),
+	}
+}
+
 func (srv *InteractiveServiceServer) toDTO(intr domain.Interactive) *interactivev1.Interactive {
 	return &interactivev1.Interactive{
 		Biz:         intr.Biz,
@@ -104,5 +244,54 @@ func (srv *InteractiveServiceServer) toDTO(intr domain.Interactive) *interactive
 		FavoriteCnt: intr.FavoriteCnt,
 		Liked:       intr.Liked,
 		Favorited:   intr.Favorited,
+
+		UniqueReadCnt:       intr.UniqueReadCnt,
+		WeeklyUniqueReadCnt: intr.WeeklyUniqueReadCnt,
+	}
+}
+
+func favoritesStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrFavoritesNotFound):
+		return status.Error(codes.NotFound, "favorites not found")
+	case errors.Is(err, service.ErrInvalidFavorites), errors.Is(err, service.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
+
+func historyStatus(err error, biz string) error {
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return toStatus(err, biz)
+}
+
+// toStatus turns the errors of the biz registry to statuses with an ErrorInfo,
+// the reason tells the callers why the biz is rejected.
+func toStatus(err error, biz string) error {
+	var (
+		code   codes.Code
+		reason string
+	)
+	switch {
+	case errors.Is(err, service.ErrUnknownBiz):
+		code, reason = codes.InvalidArgument, "UNKNOWN_BIZ"
+	case errors.Is(err, service.ErrActionNotAllowed):
+		code, reason = codes.FailedPrecondition, "ACTION_NOT_ALLOWED"
+	case errors.Is(err, service.ErrBizNotFound):
+		code, reason = codes.NotFound, "BIZ_NOT_FOUND"
+	default:
+		return err
+	}
+	st, er := status.New(code, err.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   "interactive",
+		Metadata: map[string]string{"biz": biz},
+	})
+	if er != nil {
+		return status.Error(code, err.Error())
 	}
+	return st.Err()
 }
@@ -0,0 +1,15 @@
+package startup
+
+import (
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/interactive/repository"
+	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func InitCntAggregator(intrDAO dao.InteractiveDAO, l logger.Logger) *repository.CntAggregator {
+	agg := repository.NewCntAggregator(intrDAO, 100, 100*time.Millisecond, l)
+	agg.Start()
+	return agg
+}
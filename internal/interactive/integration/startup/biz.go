@@ -0,0 +1,20 @@
+package startup
+
+import (
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/service"
+)
+
+// InitBizRegistry registers the biz types without checking the resources exist.
+func InitBizRegistry() *service.BizRegistry {
+	return service.NewBizRegistry(
+		service.BizType{
+			Name:    "article",
+			Actions: domain.ActionRead | domain.ActionLike | domain.ActionFavorite,
+		},
+		service.BizType{
+			Name:    "comment",
+			Actions: domain.ActionLike,
+		},
+	)
+}
@@ -0,0 +1,7 @@
+package startup
+
+import "github.com/tsukiyo/mercury/pkg/cursorx"
+
+func InitCursorCodec() *cursorx.Codec {
+	return cursorx.NewCodec([]byte("mercury-interactive-cursor-test-key"))
+}
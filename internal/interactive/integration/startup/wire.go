@@ -5,6 +5,7 @@ package startup
 import (
 	"github.com/google/wire"
 
+	"github.com/tsukiyo/mercury/internal/interactive/events"
 	"github.com/tsukiyo/mercury/internal/interactive/grpc"
 	repository2 "github.com/tsukiyo/mercury/internal/interactive/repository"
 	"github.com/tsukiyo/mercury/internal/interactive/repository/cache"
@@ -17,18 +18,27 @@ var thirdProvider = wire.NewSet(
 	InitTestDB,
 	InitLog,
 	InitKafka,
+	InitCursorCodec,
+	NewSyncProducer,
 )
 
 var interactiveSvcProvider = wire.NewSet(
 	service2.NewInteractiveService,
+	events.NewSaramaProducer,
 	repository2.NewCachedInteractiveRepository,
+	repository2.NewCachedFavoritesRepository,
+	repository2.NewHistoryRepository,
 	dao2.NewGORMInteractiveDAO,
+	dao2.NewGORMFavoritesDAO,
+	dao2.NewRedisReadHistoryDAO,
 	cache.NewRedisInteractiveCache,
+	InitBizRegistry,
+	InitCntAggregator,
 )
 
 func InitInteractiveService() service2.InteractiveService {
 	wire.Build(thirdProvider, interactiveSvcProvider)
-	return service2.NewInteractiveService(nil, nil)
+	return service2.NewInteractiveService(nil, nil, nil, nil, nil, nil, nil)
 }
 
 func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
@@ -8,7 +8,7 @@ package startup
 
 import (
 	"github.com/google/wire"
-
+	"github.com/tsukiyo/mercury/internal/interactive/events"
 	"github.com/tsukiyo/mercury/internal/interactive/grpc"
 	"github.com/tsukiyo/mercury/internal/interactive/repository"
 	"github.com/tsukiyo/mercury/internal/interactive/repository/cache"
@@ -22,10 +22,20 @@ func InitInteractiveService() service.InteractiveService {
 	gormDB := InitTestDB()
 	interactiveDAO := dao.NewGORMInteractiveDAO(gormDB)
 	cmdable := InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
 	logger := InitLog()
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable, logger)
+	cntAggregator := InitCntAggregator(interactiveDAO, logger)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, cntAggregator, logger)
+	favoritesDAO := dao.NewGORMFavoritesDAO(gormDB)
+	favoritesRepository := repository.NewCachedFavoritesRepository(favoritesDAO, interactiveCache, logger)
+	readHistoryDAO := dao.NewRedisReadHistoryDAO(cmdable)
+	historyRepository := repository.NewHistoryRepository(interactiveDAO, readHistoryDAO)
+	bizRegistry := InitBizRegistry()
+	codec := InitCursorCodec()
+	client := InitKafka()
+	syncProducer := NewSyncProducer(client)
+	producer := events.NewSaramaProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, favoritesRepository, historyRepository, bizRegistry, codec, producer, logger)
 	return interactiveService
 }
 
@@ -33,10 +43,20 @@ func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
 	gormDB := InitTestDB()
 	interactiveDAO := dao.NewGORMInteractiveDAO(gormDB)
 	cmdable := InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
 	logger := InitLog()
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable, logger)
+	cntAggregator := InitCntAggregator(interactiveDAO, logger)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, cntAggregator, logger)
+	favoritesDAO := dao.NewGORMFavoritesDAO(gormDB)
+	favoritesRepository := repository.NewCachedFavoritesRepository(favoritesDAO, interactiveCache, logger)
+	readHistoryDAO := dao.NewRedisReadHistoryDAO(cmdable)
+	historyRepository := repository.NewHistoryRepository(interactiveDAO, readHistoryDAO)
+	bizRegistry := InitBizRegistry()
+	codec := InitCursorCodec()
+	client := InitKafka()
+	syncProducer := NewSyncProducer(client)
+	producer := events.NewSaramaProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, favoritesRepository, historyRepository, bizRegistry, codec, producer, logger)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	return interactiveServiceServer
 }
@@ -48,6 +68,10 @@ var thirdProvider = wire.NewSet(
 	InitTestDB,
 	InitLog,
 	InitKafka,
+	InitCursorCodec,
+	NewSyncProducer,
 )
 
-var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepository, dao.NewGORMInteractiveDAO, cache.NewRedisInteractiveCache)
+var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, events.NewSaramaProducer, repository.NewCachedInteractiveRepository, repository.NewCachedFavoritesRepository, repository.NewHistoryRepository, dao.NewGORMInteractiveDAO, dao.NewGORMFavoritesDAO, dao.NewRedisReadHistoryDAO, cache.NewRedisInteractiveCache, InitBizRegistry,
+	InitCntAggregator,
+)
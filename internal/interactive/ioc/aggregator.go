@@ -0,0 +1,37 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/internal/interactive/repository"
+	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// InitCntAggregator starts writing the counts behind, they are flushed every interval or once size resources are pending.
+// The app closes it on shutdown to flush what is left, see NewClosers.
+func InitCntAggregator(intrDAO dao.InteractiveDAO, l logger.Logger) *repository.CntAggregator {
+	type Config struct {
+		Size     int           `yaml:"size"`
+		Interval time.Duration `yaml:"interval"`
+	}
+	cfg := Config{
+		Size:     1000,
+		Interval: time.Second,
+	}
+	err := viper.UnmarshalKey("counter.flush", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	agg := repository.NewCntAggregator(intrDAO, cfg.Size, cfg.Interval, l)
+	agg.Start()
+	return agg
+}
+
+// NewClosers lists what the app closes once the gRPC server has stopped taking likes.
+func NewClosers(agg *repository.CntAggregator) []app.Closer {
+	return []app.Closer{agg}
+}
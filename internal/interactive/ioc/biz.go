@@ -0,0 +1,72 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	commentv1 "github.com/tsukiyo/mercury/api/gen/comment/v1"
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/service"
+)
+
+// InitBizRegistry registers the article and comment biz types, the actions allowed could be changed by "biz" in the config,
+// e.g. "comment: [like, favorite]".
+func InitBizRegistry(atclCli articlev1.ArticleServiceClient, cmtCli commentv1.CommentServiceClient) *service.BizRegistry {
+	types := map[string]service.BizType{
+		"article": {
+			Name:    "article",
+			Actions: domain.ActionRead | domain.ActionLike | domain.ActionFavorite,
+			Checker: service.ExistenceCheckerFunc(func(ctx context.Context, bizId int64) (bool, error) {
+				resp, err := atclCli.ExistsPublished(ctx, &articlev1.ExistsPublishedRequest{Id: bizId})
+				if err != nil {
+					return false, err
+				}
+				return resp.GetExists(), nil
+			}),
+		},
+		"comment": {
+			Name:    "comment",
+			Actions: domain.ActionLike,
+			Checker: service.ExistenceCheckerFunc(func(ctx context.Context, bizId int64) (bool, error) {
+				resp, err := cmtCli.GetCommentByIds(ctx, &commentv1.GetCommentByIdsRequest{Ids: []int64{bizId}})
+				if err != nil {
+					return false, err
+				}
+				// the comments held by the moderation or deleted could not be liked
+				comments := resp.GetComments()
+				return len(comments) > 0 && !comments[0].GetDeleted() &&
+					comments[0].GetStatus() == commentv1.CommentStatus_COMMENT_STATUS_APPROVED, nil
+			}),
+		},
+	}
+
+	var cfg map[string][]string
+	err := viper.UnmarshalKey("biz", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	for name, actions := range cfg {
+		t, ok := types[name]
+		if !ok {
+			panic(fmt.Errorf("biz %q has no existence checker", name))
+		}
+		t.Actions = 0
+		for _, a := range actions {
+			action, ok := domain.ParseAction(a)
+			if !ok {
+				panic(fmt.Errorf("unknown action %q of biz %q", a, name))
+			}
+			t.Actions |= action
+		}
+		types[name] = t
+	}
+
+	res := make([]service.BizType, 0, len(types))
+	for _, t := range types {
+		res = append(res, t)
+	}
+	return service.NewBizRegistry(res...)
+}
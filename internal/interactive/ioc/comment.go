@@ -0,0 +1,37 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/resolver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	commentv1 "github.com/tsukiyo/mercury/api/gen/comment/v1"
+)
+
+func InitCommentRpcClient(etcdCli *clientv3.Client) commentv1.CommentServiceClient {
+	type config struct {
+		Target string `yaml:"target"`
+		Secure bool   `yaml:"secure"`
+	}
+	var cfg config
+	err := viper.UnmarshalKey("grpc.client.comment", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	rs, err := resolver.NewBuilder(etcdCli)
+	if err != nil {
+		panic(err)
+	}
+	opts := []grpc.DialOption{grpc.WithResolvers(rs)}
+	if !cfg.Secure {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	conn, err := grpc.NewClient(cfg.Target, opts...)
+	if err != nil {
+		panic(err)
+	}
+	client := commentv1.NewCommentServiceClient(conn)
+	return client
+}
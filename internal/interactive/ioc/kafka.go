@@ -37,6 +37,11 @@ func NewSyncProducer(client sarama.Client) sarama.SyncProducer {
 	return syncProducer
 }
 
-func NewConsumers(consumer *events.InteractiveReadEventConsumer, fix *migratorEvt.Consumer[dao.Interactive]) []saramax.Consumer {
-	return []saramax.Consumer{consumer, fix}
+func NewConsumers(consumer *events.InteractiveReadEventConsumer,
+	historyConsumer *events.ReadHistoryConsumer,
+	uniqueReaderConsumer *events.UniqueReaderConsumer,
+	deleteConsumer *events.ArticleDeleteEventConsumer,
+	fix *migratorEvt.Consumer[dao.Interactive],
+) []saramax.Consumer {
+	return []saramax.Consumer{consumer, historyConsumer, uniqueReaderConsumer, deleteConsumer, fix}
 }
@@ -3,6 +3,8 @@ package ioc
 import (
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/pkg/redisx/hook/metrics"
 )
 
 //go:generate mockgen -package=redismocks -destination=../internal/repository/mocks/cache/redis/cmdable.mock.go github.com/redis/go-redis/v9 Cmdable
@@ -21,5 +23,7 @@ func InitRedis() redis.Cmdable {
 		Password: cfg.Password,
 		DB:       cfg.DB,
 	})
+	// hit and miss of the read-through caches are labelled by the hook
+	cmd.AddHook(metrics.NewPrometheusHook("lazywoo", "mercury", "instance-0", "redis_cmd"))
 	return cmd
 }
@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+
+	cron2 "github.com/tsukiyo/mercury/internal/interactive/cron"
+	"github.com/tsukiyo/mercury/internal/interactive/repository"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func InitUniqueReaderJob(repo repository.UniqueReaderRepository, l logger.Logger) *cron2.UniqueReaderJob {
+	return cron2.NewUniqueReaderJob(repo, time.Minute*5, l)
+}
+
+func InitTasks(l logger.Logger, uniqueReader *cron2.UniqueReaderJob) *cron.Cron {
+	croj := cron.New(cron.WithSeconds())
+	bdr := cronx.NewCronJobBuilder(prometheus.SummaryOpts{
+		Namespace: "lazywoo",
+		Subsystem: "mercury",
+		Name:      "cron_job",
+		Help:      "metrics cron job",
+	}, l)
+	// every 10 minutes
+	_, err := croj.AddJob("0 */10 * * * ?", bdr.Build(uniqueReader))
+	if err != nil {
+		panic(err)
+	}
+	return croj
+}
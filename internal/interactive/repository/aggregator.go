@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+type cntKey struct {
+	biz   string
+	bizId int64
+}
+
+// CntAggregator merges count increments of the resources in memory and writes them behind,
+// one statement per flush instead of one transaction per like.
+// What is pending is written on Close, only the increments of one interval are lost if the process crashes,
+// the likes themselves are kept.
+type CntAggregator struct {
+	dao      dao.InteractiveDAO
+	mu       sync.Mutex
+	deltas   map[cntKey]dao.Interactive
+	size     int
+	interval time.Duration
+	// full wakes the flush loop up once size resources are pending
+	full chan struct{}
+	// cancel stops the flush loop started by Start, done is closed after its last flush
+	cancel context.CancelFunc
+	done   chan struct{}
+	l      logger.Logger
+}
+
+func NewCntAggregator(intrDAO dao.InteractiveDAO, size int, interval time.Duration, l logger.Logger) *CntAggregator {
+	return &CntAggregator{
+		dao:      intrDAO,
+		deltas:   make(map[cntKey]dao.Interactive),
+		size:     size,
+		interval: interval,
+		full:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		l:        l,
+	}
+}
+
+// Add merges the counts of delta into the pending ones of the resource.
+func (agg *CntAggregator) Add(delta dao.Interactive) {
+	agg.mu.Lock()
+	agg.merge(delta)
+	n := len(agg.deltas)
+	agg.mu.Unlock()
+	if n >= agg.size {
+		select {
+		case agg.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Start runs the flush loop in the background until Close.
+func (agg *CntAggregator) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	agg.cancel = cancel
+	go func() {
+		defer close(agg.done)
+		agg.run(ctx)
+	}()
+}
+
+// Close stops the flush loop and waits for what is pending to be written,
+// the Add calls are expected to have returned before.
+func (agg *CntAggregator) Close(ctx context.Context) error {
+	if agg.cancel == nil {
+		return nil
+	}
+	agg.cancel()
+	select {
+	case <-agg.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run flushes on the interval or once enough resources are pending, until ctx is done.
+func (agg *CntAggregator) run(ctx context.Context) {
+	ticker := time.NewTicker(agg.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-agg.full:
+		case <-ctx.Done():
+			// what is pending is written before leaving
+			agg.flush(context.Background())
+			return
+		}
+		agg.flush(ctx)
+	}
+}
+
+func (agg *CntAggregator) flush(ctx context.Context) {
+	agg.mu.Lock()
+	pending := agg.deltas
+	agg.deltas = make(map[cntKey]dao.Interactive, len(pending))
+	agg.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	deltas := make([]dao.Interactive, 0, len(pending))
+	for _, d := range pending {
+		deltas = append(deltas, d)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	err := agg.dao.BatchAddCnt(ctx, deltas)
+	if err != nil {
+		agg.l.Error("flush counts failed, retry in the next flush",
+			logger.Int("pending", len(deltas)),
+			logger.Error(err),
+		)
+		// merged back, the increments added meanwhile are kept too
+		agg.mu.Lock()
+		for _, d := range deltas {
+			agg.merge(d)
+		}
+		agg.mu.Unlock()
+	}
+}
+
+func (agg *CntAggregator) merge(delta dao.Interactive) {
+	key := cntKey{biz: delta.Biz, bizId: delta.BizId}
+	d, ok := agg.deltas[key]
+	if !ok {
+		d = dao.Interactive{Biz: delta.Biz, BizId: delta.BizId}
+	}
+	d.ReadCnt += delta.ReadCnt
+	d.LikeCnt += delta.LikeCnt
+	d.FavoriteCnt += delta.FavoriteCnt
+	agg.deltas[key] = d
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/pkg/cachex"
+	"github.com/tsukiyo/mercury/pkg/logger"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -18,10 +20,22 @@ var luaIncrCnt string
 //go:embed lua/batch_incr_cnt.lua
 var luaBatchIncrCnt string
 
+//go:embed lua/add_cnt.lua
+var luaAddCnt string
+
+//go:embed lua/set_cnt.lua
+var luaSetCnt string
+
 const (
 	fieldReadCnt     = "read_cnt"
 	fieldLikeCnt     = "like_cnt"
 	fieldFavoriteCnt = "favorite_cnt"
+	// the unique read counts are set by the sync job, not increased
+	fieldUniqueReadCnt       = "unique_read_cnt"
+	fieldWeeklyUniqueReadCnt = "weekly_unique_read_cnt"
+	// fieldDelta and fieldExpiry decide when to refresh the counts early
+	fieldDelta  = "delta"
+	fieldExpiry = "expiry"
 )
 
 //go:generate mockgen -source=./interactive.go -package=cachemocks -destination=mocks/interactive.mock.go InteractiveCache
@@ -32,19 +46,31 @@ type InteractiveCache interface {
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrFavoriteCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrFavoriteCntIfPresent(ctx context.Context, biz string, bizId int64) error
-	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
+	// BatchAddCntIfPresent adds the counts of each delta to the cached resources.
+	BatchAddCntIfPresent(ctx context.Context, deltas []domain.Interactive) error
+	// BatchSetUniqueReadCntIfPresent overwrites the unique read counts of the cached resources.
+	BatchSetUniqueReadCntIfPresent(ctx context.Context, intrs []domain.Interactive) error
+	// Get reads through the cache, load is called once for concurrent misses of the resource.
+	Get(ctx context.Context, biz string, bizId int64, load cachex.Loader[domain.Interactive]) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error
+	Del(ctx context.Context, biz string, bizId int64) error
 }
 
 var _ InteractiveCache = (*RedisInteractiveCache)(nil)
 
 type RedisInteractiveCache struct {
 	client redis.Cmdable
+	intrs  *cachex.ReadThrough[domain.Interactive]
 }
 
-func NewRedisInteractiveCache(client redis.Cmdable) InteractiveCache {
+func NewRedisInteractiveCache(client redis.Cmdable, l logger.Logger) InteractiveCache {
 	return &RedisInteractiveCache{
 		client: client,
+		intrs: cachex.NewReadThrough[domain.Interactive](hashStore{client: client}, "interactive", cachex.Options{
+			TTL:    time.Minute * 15,
+			Jitter: time.Minute * 3,
+			Beta:   1,
+		}, l),
 	}
 }
 
@@ -80,34 +106,104 @@ func (cache *RedisInteractiveCache) DecrFavoriteCntIfPresent(ctx context.Context
 	return cache.client.Eval(ctx, luaIncrCnt, []string{cache.key(biz, bizId)}, fieldFavoriteCnt, -1).Err()
 }
 
-func (cache *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
-	cnts, err := cache.client.HMGet(ctx, cache.key(biz, bizId), fieldReadCnt, fieldLikeCnt, fieldFavoriteCnt).Result()
-	if err != nil {
-		return domain.Interactive{}, err
-	}
-
-	var intr domain.Interactive
-	if cnts[0] == nil || cnts[1] == nil || cnts[2] == nil {
-		return domain.Interactive{}, ErrKeyNotExist
-	}
+func (cache *RedisInteractiveCache) BatchAddCntIfPresent(ctx context.Context, deltas []domain.Interactive) error {
+	_, err := cache.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, d := range deltas {
+			args := make([]any, 0, 6)
+			for _, c := range []struct {
+				field string
+				delta int64
+			}{
+				{fieldReadCnt, d.ReadCnt},
+				{fieldLikeCnt, d.LikeCnt},
+				{fieldFavoriteCnt, d.FavoriteCnt},
+			} {
+				if c.delta != 0 {
+					args = append(args, c.field, c.delta)
+				}
+			}
+			if len(args) > 0 {
+				pipe.Eval(ctx, luaAddCnt, []string{cache.key(d.Biz, d.BizId)}, args...)
+			}
+		}
+		return nil
+	})
+	return err
+}
 
-	intr.ReadCnt, _ = strconv.ParseInt(cnts[0].(string), 10, 64)
-	intr.LikeCnt, _ = strconv.ParseInt(cnts[1].(string), 10, 64)
-	intr.FavoriteCnt, _ = strconv.ParseInt(cnts[2].(string), 10, 64)
+func (cache *RedisInteractiveCache) BatchSetUniqueReadCntIfPresent(ctx context.Context, intrs []domain.Interactive) error {
+	_, err := cache.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, intr := range intrs {
+			pipe.Eval(ctx, luaSetCnt, []string{cache.key(intr.Biz, intr.BizId)},
+				fieldUniqueReadCnt, intr.UniqueReadCnt,
+				fieldWeeklyUniqueReadCnt, intr.WeeklyUniqueReadCnt,
+			)
+		}
+		return nil
+	})
+	return err
+}
 
-	return intr, nil
+func (cache *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64, load cachex.Loader[domain.Interactive]) (domain.Interactive, error) {
+	return cache.intrs.Get(ctx, cache.key(biz, bizId), load)
 }
 
 func (cache *RedisInteractiveCache) Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error {
-	key := cache.key(biz, bizId)
-	err := cache.client.HMSet(ctx, key,
-		fieldReadCnt, intr.ReadCnt,
-		fieldLikeCnt, intr.LikeCnt,
-		fieldFavoriteCnt, intr.FavoriteCnt,
-	).Err()
+	return cache.intrs.Set(ctx, cache.key(biz, bizId), intr)
+}
+
+func (cache *RedisInteractiveCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return cache.intrs.Del(ctx, cache.key(biz, bizId))
+}
+
+var _ cachex.Store[domain.Interactive] = hashStore{}
+
+// hashStore keeps the counts in a hash, so that the lua scripts increase them in place.
+type hashStore struct {
+	client redis.Cmdable
+}
+
+func (s hashStore) Get(ctx context.Context, key string) (cachex.Entry[domain.Interactive], error) {
+	var e cachex.Entry[domain.Interactive]
+	vals, err := s.client.HMGet(ctx, key, fieldReadCnt, fieldLikeCnt, fieldFavoriteCnt, fieldDelta, fieldExpiry,
+		fieldUniqueReadCnt, fieldWeeklyUniqueReadCnt).Result()
 	if err != nil {
-		return err
+		return e, err
 	}
+	if vals[0] == nil || vals[1] == nil || vals[2] == nil {
+		return e, ErrKeyNotExist
+	}
+	nums := make([]int64, len(vals))
+	for i, val := range vals {
+		// the counts cached before refreshing early was added have no delta and expiry,
+		// and the ones cached before unique readers were counted have no unique read counts
+		if str, ok := val.(string); ok {
+			nums[i], _ = strconv.ParseInt(str, 10, 64)
+		}
+	}
+	e.Val.ReadCnt, e.Val.LikeCnt, e.Val.FavoriteCnt = nums[0], nums[1], nums[2]
+	e.Delta, e.Expiry = nums[3], nums[4]
+	e.Val.UniqueReadCnt, e.Val.WeeklyUniqueReadCnt = nums[5], nums[6]
+	return e, nil
+}
+
+func (s hashStore) Set(ctx context.Context, key string, e cachex.Entry[domain.Interactive], ttl time.Duration) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HMSet(ctx, key,
+			fieldReadCnt, e.Val.ReadCnt,
+			fieldLikeCnt, e.Val.LikeCnt,
+			fieldFavoriteCnt, e.Val.FavoriteCnt,
+			fieldDelta, e.Delta,
+			fieldExpiry, e.Expiry,
+			fieldUniqueReadCnt, e.Val.UniqueReadCnt,
+			fieldWeeklyUniqueReadCnt, e.Val.WeeklyUniqueReadCnt,
+		)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
 
-	return cache.client.Expire(ctx, key, time.Minute*15).Err()
+func (s hashStore) Del(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
 }
@@ -0,0 +1,10 @@
+local key = KEYS[1]
+
+if redis.call("EXISTS", key) == 0 then
+    return 0
+end
+-- ARGV holds pairs of the count field and its delta
+for i = 1, #ARGV, 2 do
+    redis.call("HINCRBY", key, ARGV[i], tonumber(ARGV[i + 1]))
+end
+return 1
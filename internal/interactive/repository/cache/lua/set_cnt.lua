@@ -0,0 +1,8 @@
+local key = KEYS[1]
+
+if redis.call("EXISTS", key) == 0 then
+    return 0
+end
+-- ARGV holds pairs of the count field and its value
+redis.call("HSET", key, unpack(ARGV))
+return 1
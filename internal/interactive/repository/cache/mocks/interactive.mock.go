@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	domain "github.com/tsukiyo/mercury/internal/interactive/domain"
+	cachex "github.com/tsukiyo/mercury/pkg/cachex"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +41,20 @@ func (m *MockInteractiveCache) EXPECT() *MockInteractiveCacheMockRecorder {
 	return m.recorder
 }
 
+// BatchAddCntIfPresent mocks base method.
+func (m *MockInteractiveCache) BatchAddCntIfPresent(ctx context.Context, deltas []domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchAddCntIfPresent", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchAddCntIfPresent indicates an expected call of BatchAddCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) BatchAddCntIfPresent(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchAddCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchAddCntIfPresent), ctx, deltas)
+}
+
 // BatchIncrReadCntIfPresent mocks base method.
 func (m *MockInteractiveCache) BatchIncrReadCntIfPresent(ctx context.Context, biz string, bizIds []int64) error {
 	m.ctrl.T.Helper()
@@ -54,6 +69,20 @@ func (mr *MockInteractiveCacheMockRecorder) BatchIncrReadCntIfPresent(ctx, biz,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchIncrReadCntIfPresent), ctx, biz, bizIds)
 }
 
+// BatchSetUniqueReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) BatchSetUniqueReadCntIfPresent(ctx context.Context, intrs []domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchSetUniqueReadCntIfPresent", ctx, intrs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchSetUniqueReadCntIfPresent indicates an expected call of BatchSetUniqueReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) BatchSetUniqueReadCntIfPresent(ctx, intrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSetUniqueReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchSetUniqueReadCntIfPresent), ctx, intrs)
+}
+
 // DecrFavoriteCntIfPresent mocks base method.
 func (m *MockInteractiveCache) DecrFavoriteCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
@@ -82,19 +111,33 @@ func (mr *MockInteractiveCacheMockRecorder) DecrLikeCntIfPresent(ctx, biz, bizId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLikeCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrLikeCntIfPresent), ctx, biz, bizId)
 }
 
+// Del mocks base method.
+func (m *MockInteractiveCache) Del(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockInteractiveCacheMockRecorder) Del(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockInteractiveCache)(nil).Del), ctx, biz, bizId)
+}
+
 // Get mocks base method.
-func (m *MockInteractiveCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+func (m *MockInteractiveCache) Get(ctx context.Context, biz string, bizId int64, load cachex.Loader[domain.Interactive]) (domain.Interactive, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId)
+	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId, load)
 	ret0, _ := ret[0].(domain.Interactive)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockInteractiveCacheMockRecorder) Get(ctx, biz, bizId any) *gomock.Call {
+func (mr *MockInteractiveCacheMockRecorder) Get(ctx, biz, bizId, load any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveCache)(nil).Get), ctx, biz, bizId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveCache)(nil).Get), ctx, biz, bizId, load)
 }
 
 // IncrFavoriteCntIfPresent mocks base method.
@@ -0,0 +1,204 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+)
+
+var ErrFavoritesNotFound = gorm.ErrRecordNotFound
+
+//go:generate mockgen -source=./favorites.go -package=daomocks -destination=mocks/favorites.mock.go FavoritesDAO
+type FavoritesDAO interface {
+	Insert(ctx context.Context, f Favorites) (int64, error)
+	// Update updates the name and the visibility of the folder owned by f.Uid.
+	Update(ctx context.Context, f Favorites) error
+	FindById(ctx context.Context, id int64) (Favorites, error)
+	// FindByUid returns the folders of the user oldest first, publicOnly leaves the private ones out.
+	FindByUid(ctx context.Context, uid int64, publicOnly bool) ([]Favorites, error)
+	// Delete deletes the folder with its items and returns the items which were favorited.
+	Delete(ctx context.Context, id, uid int64) ([]FavoriteItem, error)
+	// FindItems returns favorited items in the folder with id less than maxId latest first, 0 means from the latest.
+	FindItems(ctx context.Context, fid, maxId int64, limit int) ([]FavoriteItem, error)
+	// FindCovers returns the latest limit items of each folder.
+	FindCovers(ctx context.Context, fids []int64, limit int) (map[int64][]FavoriteItem, error)
+	// MoveItems moves the items of the user from a folder to another and returns how many were moved.
+	MoveItems(ctx context.Context, uid, from, to int64, ids []int64) (int64, error)
+}
+
+var _ FavoritesDAO = (*GORMFavoritesDAO)(nil)
+
+type GORMFavoritesDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMFavoritesDAO(db *gorm.DB) FavoritesDAO {
+	return &GORMFavoritesDAO{
+		db: db,
+	}
+}
+
+func (dao *GORMFavoritesDAO) Insert(ctx context.Context, f Favorites) (int64, error) {
+	now := time.Now().UnixMilli()
+	f.Ctime, f.Utime = now, now
+	err := dao.db.WithContext(ctx).Create(&f).Error
+	return f.Id, err
+}
+
+func (dao *GORMFavoritesDAO) Update(ctx context.Context, f Favorites) error {
+	res := dao.db.WithContext(ctx).Model(&Favorites{}).
+		Where("id = ? AND uid = ?", f.Id, f.Uid).
+		Updates(map[string]any{
+			"name":       f.Name,
+			"visibility": f.Visibility,
+			"utime":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrFavoritesNotFound
+	}
+	return nil
+}
+
+func (dao *GORMFavoritesDAO) FindById(ctx context.Context, id int64) (Favorites, error) {
+	var f Favorites
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&f).Error
+	return f, err
+}
+
+func (dao *GORMFavoritesDAO) FindByUid(ctx context.Context, uid int64, publicOnly bool) ([]Favorites, error) {
+	var res []Favorites
+	tx := dao.db.WithContext(ctx).Where("uid = ?", uid)
+	if publicOnly {
+		tx = tx.Where("visibility = ?", domain.FavoritesVisibilityPublic)
+	}
+	err := tx.Order("id ASC").Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFavoritesDAO) Delete(ctx context.Context, id, uid int64) ([]FavoriteItem, error) {
+	var items []FavoriteItem
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Favorites{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrFavoritesNotFound
+		}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("fid = ? AND status = ?", id, 1).
+			Find(&items).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		for _, item := range items {
+			err = tx.Model(&Interactive{}).
+				Where("biz = ? AND biz_id = ?", item.Biz, item.BizId).
+				Updates(map[string]any{
+					"favorite_cnt": gorm.Expr("`favorite_cnt` - 1"),
+					"utime":        now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Where("fid = ?", id).Delete(&FavoriteItem{}).Error
+	})
+	return items, err
+}
+
+func (dao *GORMFavoritesDAO) FindItems(ctx context.Context, fid, maxId int64, limit int) ([]FavoriteItem, error) {
+	var res []FavoriteItem
+	tx := dao.db.WithContext(ctx).Where("fid = ? AND status = ?", fid, 1)
+	if maxId > 0 {
+		tx = tx.Where("id < ?", maxId)
+	}
+	err := tx.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFavoritesDAO) FindCovers(ctx context.Context, fids []int64, limit int) (map[int64][]FavoriteItem, error) {
+	res := make(map[int64][]FavoriteItem, len(fids))
+	// a user has a few folders, one query each is cheaper than ranking all the items
+	for _, fid := range fids {
+		items, err := dao.FindItems(ctx, fid, 0, limit)
+		if err != nil {
+			return nil, err
+		}
+		res[fid] = items
+	}
+	return res, nil
+}
+
+func (dao *GORMFavoritesDAO) MoveItems(ctx context.Context, uid, from, to int64, ids []int64) (int64, error) {
+	var moved int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var cnt int64
+		err := tx.Model(&Favorites{}).
+			Where("id IN ? AND uid = ?", []int64{from, to}, uid).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt != 2 {
+			return ErrFavoritesNotFound
+		}
+		now := time.Now().UnixMilli()
+		res := tx.Model(&FavoriteItem{}).
+			Where("id IN ? AND uid = ? AND fid = ? AND status = ?", ids, uid, from, 1).
+			Updates(map[string]any{
+				"fid":   to,
+				"utime": now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		moved = res.RowsAffected
+		if moved == 0 {
+			return nil
+		}
+		err = incrFavoritesItemCnt(tx, from, -moved)
+		if err != nil {
+			return err
+		}
+		return incrFavoritesItemCnt(tx, to, moved)
+	})
+	return moved, err
+}
+
+// incrFavoritesItemCnt changes the item count of the folder, fid 0 means the item is in no folder.
+func incrFavoritesItemCnt(tx *gorm.DB, fid, delta int64) error {
+	if fid == 0 {
+		return nil
+	}
+	return tx.Model(&Favorites{}).
+		Where("id = ?", fid).
+		Updates(map[string]any{
+			"item_cnt": gorm.Expr("`item_cnt` + ?", delta),
+			"utime":    time.Now().UnixMilli(),
+		}).Error
+}
+
+// checkFavorites returns ErrFavoritesNotFound if the folder is not owned by the user.
+func checkFavorites(tx *gorm.DB, fid, uid int64) error {
+	if fid == 0 {
+		return nil
+	}
+	var cnt int64
+	err := tx.Model(&Favorites{}).Where("id = ? AND uid = ?", fid, uid).Count(&cnt).Error
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return ErrFavoritesNotFound
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package dao
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"time"
 
 	"github.com/tsukiyo/mercury/pkg/migrator"
@@ -20,6 +22,9 @@ type Interactive struct {
 	ReadCnt     int64
 	FavoriteCnt int64
 	LikeCnt     int64
+	// distinct readers counted by HyperLogLog in redis, synced by a cron job
+	UniqueReadCnt       int64
+	WeeklyUniqueReadCnt int64
 
 	Ctime int64
 	Utime int64
@@ -37,18 +42,23 @@ func (i Interactive) Equal(dst migrator.Entity) bool {
 type Like struct {
 	Id    int64  `gorm:"primaryKey,autoIncrement"`
 	BizId int64  `gorm:"uniqueIndex:biz_type_id_uid"`
-	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_uid"`
-	Uid   int64  `gorm:"uniqueIndex:biz_type_id_uid"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_uid;index:uid_biz_utime,priority:2"`
+	Uid   int64  `gorm:"uniqueIndex:biz_type_id_uid;index:uid_biz_utime,priority:1"`
 	// 0-unliked, 1-liked
 	Status uint8
 	Ctime  int64
-	Utime  int64
+	// liked last, the likes of a user are listed by it
+	Utime int64 `gorm:"index:uid_biz_utime,priority:3"`
 }
 
 type Favorites struct {
 	Id   int64  `gorm:"primaryKey,autoIncrement"`
 	Name string `gorm:"type=varchar(1024)"`
-	Uid  int64
+	Uid  int64  `gorm:"index"`
+	// 1-private, 2-public
+	Visibility uint8
+	// favorited items in the folder
+	ItemCnt int64
 
 	Ctime int64
 	Utime int64
@@ -70,15 +80,29 @@ type FavoriteItem struct {
 //go:generate mockgen -source=./interactive.go -package=daomocks -destination=mocks/interactive.mock.go InteractiveDAO
 type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
-	InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) error
+	// InsertLikeInfo returns false if the user liked the resource already,
+	// the like count is not changed here but added through BatchAddCnt.
+	InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) (bool, error)
 	GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (Like, error)
-	DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) error
+	// FindLiked returns the likes of the user after (utime, id) latest first, zero utime means from the latest.
+	FindLiked(ctx context.Context, uid int64, biz string, utime, id int64, limit int) ([]Like, error)
+	// DeleteLikeInfo returns false if the user did not like the resource.
+	DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) (bool, error)
 	Get(ctx context.Context, biz string, bizId int64) (Interactive, error)
-	InsertFavoriteItem(ctx context.Context, ci FavoriteItem) error
-	DelFavoriteItem(ctx context.Context, ci FavoriteItem) error
+	// InsertFavoriteItem favorites the item in the folder ci.Fid, or moves it there if it is in another folder.
+	// It returns false if the item was favorited already, the counts of the resource are not changed then.
+	InsertFavoriteItem(ctx context.Context, ci FavoriteItem) (bool, error)
+	// DelFavoriteItem returns false if the item was not favorited.
+	DelFavoriteItem(ctx context.Context, ci FavoriteItem) (bool, error)
 	GetFavoriteInfo(ctx context.Context, biz string, bizId, uid int64) (FavoriteItem, error)
 	BatchIncrReadCnt(ctx context.Context, biz string, ids []int64) error
+	// BatchAddCnt adds the counts of each delta to the resource in one statement.
+	BatchAddCnt(ctx context.Context, deltas []Interactive) error
+	// BatchSetUniqueReadCnt overwrites the unique read counts of the resources.
+	BatchSetUniqueReadCnt(ctx context.Context, intrs []Interactive) error
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+	// DeleteByBiz deletes the counts, likes and favorite items of the resource.
+	DeleteByBiz(ctx context.Context, biz string, bizId int64) error
 }
 
 var _ InteractiveDAO = (*GORMInteractiveDAO)(nil)
@@ -113,40 +137,28 @@ func (dao *GORMInteractiveDAO) incrReadCnt(tx *gorm.DB, biz string, bizId int64)
 	}).Error
 }
 
-func (dao *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) error {
+func (dao *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
 	now := time.Now().UnixMilli()
-	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]any{
-				"status": 1,
-				"utime":  now,
-			}),
-		}).Create(&Like{
-			Uid:    uid,
-			BizId:  bizId,
-			Biz:    biz,
-			Status: 1,
-			Ctime:  now,
-			Utime:  now,
-		}).Error
-		if err != nil {
-			return err
-		}
-
-		return tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]any{
-				"like_cnt": gorm.Expr("`like_cnt` + 1"),
-				"utime":    now,
-			}),
-		}).Create(&Interactive{
-			LikeCnt: 1,
-			Biz:     biz,
-			BizId:   bizId,
-			Ctime:   now,
-			Utime:   now,
-		}).Error
+	db := dao.db.WithContext(ctx)
+	res := db.Model(&Like{}).
+		Where("biz = ? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uid, 0).
+		Updates(map[string]any{
+			"status": 1,
+			"utime":  now,
+		})
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.RowsAffected > 0, res.Error
+	}
+	// no rows affected if it is liked already, or liked concurrently
+	res = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&Like{
+		Uid:    uid,
+		BizId:  bizId,
+		Biz:    biz,
+		Status: 1,
+		Ctime:  now,
+		Utime:  now,
 	})
-	return err
+	return res.RowsAffected > 0, res.Error
 }
 
 func (dao *GORMInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (Like, error) {
@@ -157,33 +169,24 @@ func (dao *GORMInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizI
 	return like, err
 }
 
-func (dao *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) error {
-	now := time.Now().UnixMilli()
-	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(&Like{}).
-			Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
-			Updates(map[string]any{
-				"status": 0,
-				"utime":  now,
-			}).Error
-		if err != nil {
-			return err
-		}
+func (dao *GORMInteractiveDAO) FindLiked(ctx context.Context, uid int64, biz string, utime, id int64, limit int) ([]Like, error) {
+	var likes []Like
+	tx := dao.db.WithContext(ctx).Where("uid = ? AND biz = ? AND status = ?", uid, biz, 1)
+	if utime > 0 {
+		tx = tx.Where("(utime < ? OR (utime = ? AND id < ?))", utime, utime, id)
+	}
+	err := tx.Order("utime DESC, id DESC").Limit(limit).Find(&likes).Error
+	return likes, err
+}
 
-		return tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]any{
-				"like_cnt": gorm.Expr("`like_cnt` - 1"),
-				"utime":    now,
-			}),
-		}).Create(&Interactive{
-			Biz:     biz,
-			BizId:   bizId,
-			LikeCnt: 0,
-			Ctime:   now,
-			Utime:   now,
-		}).Error
-	})
-	return err
+func (dao *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	res := dao.db.WithContext(ctx).Model(&Like{}).
+		Where("biz = ? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uid, 1).
+		Updates(map[string]any{
+			"status": 0,
+			"utime":  time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
 }
 
 func (dao *GORMInteractiveDAO) Get(ctx context.Context, biz string, bizId int64) (Interactive, error) {
@@ -194,12 +197,43 @@ func (dao *GORMInteractiveDAO) Get(ctx context.Context, biz string, bizId int64)
 	return intr, err
 }
 
-func (dao *GORMInteractiveDAO) InsertFavoriteItem(ctx context.Context, ci FavoriteItem) error {
+func (dao *GORMInteractiveDAO) InsertFavoriteItem(ctx context.Context, ci FavoriteItem) (bool, error) {
 	now := time.Now().UnixMilli()
-	ci.Ctime, ci.Utime = now, now
-	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Clauses(clause.OnConflict{
+	ci.Ctime, ci.Utime, ci.Status = now, now, 1
+	var added bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := checkFavorites(tx, ci.Fid, ci.Uid)
+		if err != nil {
+			return err
+		}
+		var old FavoriteItem
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("biz = ? AND biz_id = ? AND uid = ?", ci.Biz, ci.BizId, ci.Uid).
+			First(&old).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && old.Status == 1 {
+			if old.Fid == ci.Fid {
+				return nil
+			}
+			err = tx.Model(&old).Updates(map[string]any{
+				"fid":   ci.Fid,
+				"utime": now,
+			}).Error
+			if err != nil {
+				return err
+			}
+			err = incrFavoritesItemCnt(tx, old.Fid, -1)
+			if err != nil {
+				return err
+			}
+			return incrFavoritesItemCnt(tx, ci.Fid, 1)
+		}
+
+		err = tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]any{
+				"fid":    ci.Fid,
 				"utime":  now,
 				"status": 1,
 			}),
@@ -207,7 +241,11 @@ func (dao *GORMInteractiveDAO) InsertFavoriteItem(ctx context.Context, ci Favori
 		if err != nil {
 			return err
 		}
-
+		err = incrFavoritesItemCnt(tx, ci.Fid, 1)
+		if err != nil {
+			return err
+		}
+		added = true
 		return tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]any{
 				"favorite_cnt": gorm.Expr("`favorite_cnt` + 1"),
@@ -221,41 +259,49 @@ func (dao *GORMInteractiveDAO) InsertFavoriteItem(ctx context.Context, ci Favori
 			Utime:       now,
 		}).Error
 	})
+	return added && err == nil, err
 }
 
-func (dao *GORMInteractiveDAO) DelFavoriteItem(ctx context.Context, ci FavoriteItem) error {
+func (dao *GORMInteractiveDAO) DelFavoriteItem(ctx context.Context, ci FavoriteItem) (bool, error) {
 	now := time.Now().UnixMilli()
-	ci.Ctime, ci.Utime = now, now
-	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(&FavoriteItem{}).
-			Where("biz = ? AND biz_id = ? AND uid = ?", ci.Biz, ci.BizId, ci.BizId).
-			Updates(map[string]any{
-				"status": 0,
-				"utime":  now,
-			}).Error
+	var removed bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var old FavoriteItem
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("biz = ? AND biz_id = ? AND uid = ? AND status = ?", ci.Biz, ci.BizId, ci.Uid, 1).
+			First(&old).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-
-		return tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]any{
+		err = tx.Model(&old).Updates(map[string]any{
+			"status": 0,
+			"utime":  now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = incrFavoritesItemCnt(tx, old.Fid, -1)
+		if err != nil {
+			return err
+		}
+		removed = true
+		return tx.Model(&Interactive{}).
+			Where("biz = ? AND biz_id = ?", ci.Biz, ci.BizId).
+			Updates(map[string]any{
 				"favorite_cnt": gorm.Expr("`favorite_cnt` - 1"),
 				"utime":        now,
-			}),
-		}).Create(&Interactive{
-			Biz:         ci.Biz,
-			BizId:       ci.BizId,
-			FavoriteCnt: 0,
-			Ctime:       now,
-			Utime:       now,
-		}).Error
+			}).Error
 	})
+	return removed && err == nil, err
 }
 
 func (dao *GORMInteractiveDAO) GetFavoriteInfo(ctx context.Context, biz string, bizId, uid int64) (FavoriteItem, error) {
 	var favoriteItem FavoriteItem
 	err := dao.db.WithContext(ctx).
-		Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
+		Where("biz = ? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uid, 1).
 		First(&favoriteItem).Error
 	return favoriteItem, err
 }
@@ -272,8 +318,79 @@ func (dao *GORMInteractiveDAO) BatchIncrReadCnt(ctx context.Context, biz string,
 	})
 }
 
+func (dao *GORMInteractiveDAO) BatchAddCnt(ctx context.Context, deltas []Interactive) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	rows := make([]Interactive, len(deltas))
+	copy(rows, deltas)
+	// rows are locked in the same order by concurrent flushes, so they never deadlock
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Biz != rows[j].Biz {
+			return rows[i].Biz < rows[j].Biz
+		}
+		return rows[i].BizId < rows[j].BizId
+	})
+	for i := range rows {
+		rows[i].Id, rows[i].Ctime, rows[i].Utime = 0, now, now
+	}
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_cnt":     gorm.Expr("`read_cnt` + VALUES(`read_cnt`)"),
+			"like_cnt":     gorm.Expr("`like_cnt` + VALUES(`like_cnt`)"),
+			"favorite_cnt": gorm.Expr("`favorite_cnt` + VALUES(`favorite_cnt`)"),
+			"utime":        now,
+		}),
+	}).CreateInBatches(rows, 500).Error
+}
+
+func (dao *GORMInteractiveDAO) BatchSetUniqueReadCnt(ctx context.Context, intrs []Interactive) error {
+	if len(intrs) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	rows := make([]Interactive, 0, len(intrs))
+	for _, intr := range intrs {
+		rows = append(rows, Interactive{
+			Biz:                 intr.Biz,
+			BizId:               intr.BizId,
+			UniqueReadCnt:       intr.UniqueReadCnt,
+			WeeklyUniqueReadCnt: intr.WeeklyUniqueReadCnt,
+			Ctime:               now,
+			Utime:               now,
+		})
+	}
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"unique_read_cnt", "weekly_unique_read_cnt", "utime"}),
+	}).CreateInBatches(rows, 500).Error
+}
+
 func (dao *GORMInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error) {
 	var intrs []Interactive
 	err := dao.db.WithContext(ctx).Where("biz = ? AND id IN ?", biz, ids).Find(&intrs).Error
 	return intrs, err
 }
+
+func (dao *GORMInteractiveDAO) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var items []FavoriteItem
+		err := tx.Where("biz = ? AND biz_id = ? AND status = ?", biz, bizId, 1).Find(&items).Error
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			err = incrFavoritesItemCnt(tx, item.Fid, -1)
+			if err != nil {
+				return err
+			}
+		}
+		for _, model := range []any{&Interactive{}, &Like{}, &FavoriteItem{}} {
+			err := tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
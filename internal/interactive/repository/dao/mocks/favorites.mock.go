@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./favorites.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./favorites.go -package=daomocks -destination=mocks/favorites.mock.go FavoritesDAO
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFavoritesDAO is a mock of FavoritesDAO interface.
+type MockFavoritesDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoritesDAOMockRecorder
+}
+
+// MockFavoritesDAOMockRecorder is the mock recorder for MockFavoritesDAO.
+type MockFavoritesDAOMockRecorder struct {
+	mock *MockFavoritesDAO
+}
+
+// NewMockFavoritesDAO creates a new mock instance.
+func NewMockFavoritesDAO(ctrl *gomock.Controller) *MockFavoritesDAO {
+	mock := &MockFavoritesDAO{ctrl: ctrl}
+	mock.recorder = &MockFavoritesDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoritesDAO) EXPECT() *MockFavoritesDAOMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFavoritesDAO) Delete(ctx context.Context, id, uid int64) ([]dao.FavoriteItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, uid)
+	ret0, _ := ret[0].([]dao.FavoriteItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFavoritesDAOMockRecorder) Delete(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFavoritesDAO)(nil).Delete), ctx, id, uid)
+}
+
+// FindById mocks base method.
+func (m *MockFavoritesDAO) FindById(ctx context.Context, id int64) (dao.Favorites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(dao.Favorites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockFavoritesDAOMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFavoritesDAO)(nil).FindById), ctx, id)
+}
+
+// FindByUid mocks base method.
+func (m *MockFavoritesDAO) FindByUid(ctx context.Context, uid int64, publicOnly bool) ([]dao.Favorites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUid", ctx, uid, publicOnly)
+	ret0, _ := ret[0].([]dao.Favorites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUid indicates an expected call of FindByUid.
+func (mr *MockFavoritesDAOMockRecorder) FindByUid(ctx, uid, publicOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUid", reflect.TypeOf((*MockFavoritesDAO)(nil).FindByUid), ctx, uid, publicOnly)
+}
+
+// FindCovers mocks base method.
+func (m *MockFavoritesDAO) FindCovers(ctx context.Context, fids []int64, limit int) (map[int64][]dao.FavoriteItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCovers", ctx, fids, limit)
+	ret0, _ := ret[0].(map[int64][]dao.FavoriteItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCovers indicates an expected call of FindCovers.
+func (mr *MockFavoritesDAOMockRecorder) FindCovers(ctx, fids, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCovers", reflect.TypeOf((*MockFavoritesDAO)(nil).FindCovers), ctx, fids, limit)
+}
+
+// FindItems mocks base method.
+func (m *MockFavoritesDAO) FindItems(ctx context.Context, fid, maxId int64, limit int) ([]dao.FavoriteItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItems", ctx, fid, maxId, limit)
+	ret0, _ := ret[0].([]dao.FavoriteItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItems indicates an expected call of FindItems.
+func (mr *MockFavoritesDAOMockRecorder) FindItems(ctx, fid, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItems", reflect.TypeOf((*MockFavoritesDAO)(nil).FindItems), ctx, fid, maxId, limit)
+}
+
+// Insert mocks base method.
+func (m *MockFavoritesDAO) Insert(ctx context.Context, f dao.Favorites) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, f)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockFavoritesDAOMockRecorder) Insert(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFavoritesDAO)(nil).Insert), ctx, f)
+}
+
+// MoveItems mocks base method.
+func (m *MockFavoritesDAO) MoveItems(ctx context.Context, uid, from, to int64, ids []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItems", ctx, uid, from, to, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveItems indicates an expected call of MoveItems.
+func (mr *MockFavoritesDAOMockRecorder) MoveItems(ctx, uid, from, to, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItems", reflect.TypeOf((*MockFavoritesDAO)(nil).MoveItems), ctx, uid, from, to, ids)
+}
+
+// Update mocks base method.
+func (m *MockFavoritesDAO) Update(ctx context.Context, f dao.Favorites) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFavoritesDAOMockRecorder) Update(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFavoritesDAO)(nil).Update), ctx, f)
+}
@@ -40,6 +40,20 @@ func (m *MockInteractiveDAO) EXPECT() *MockInteractiveDAOMockRecorder {
 	return m.recorder
 }
 
+// BatchAddCnt mocks base method.
+func (m *MockInteractiveDAO) BatchAddCnt(ctx context.Context, deltas []dao.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchAddCnt", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchAddCnt indicates an expected call of BatchAddCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchAddCnt(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchAddCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchAddCnt), ctx, deltas)
+}
+
 // BatchIncrReadCnt mocks base method.
 func (m *MockInteractiveDAO) BatchIncrReadCnt(ctx context.Context, biz string, ids []int64) error {
 	m.ctrl.T.Helper()
@@ -54,34 +68,79 @@ func (mr *MockInteractiveDAOMockRecorder) BatchIncrReadCnt(ctx, biz, ids any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchIncrReadCnt), ctx, biz, ids)
 }
 
-// DelFavoriteItem mocks base method.
-func (m *MockInteractiveDAO) DelFavoriteItem(ctx context.Context, ci dao.FavoriteItem) error {
+// BatchSetUniqueReadCnt mocks base method.
+func (m *MockInteractiveDAO) BatchSetUniqueReadCnt(ctx context.Context, intrs []dao.Interactive) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DelFavoriteItem", ctx, ci)
+	ret := m.ctrl.Call(m, "BatchSetUniqueReadCnt", ctx, intrs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// BatchSetUniqueReadCnt indicates an expected call of BatchSetUniqueReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchSetUniqueReadCnt(ctx, intrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSetUniqueReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchSetUniqueReadCnt), ctx, intrs)
+}
+
+// DelFavoriteItem mocks base method.
+func (m *MockInteractiveDAO) DelFavoriteItem(ctx context.Context, ci dao.FavoriteItem) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelFavoriteItem", ctx, ci)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DelFavoriteItem indicates an expected call of DelFavoriteItem.
 func (mr *MockInteractiveDAOMockRecorder) DelFavoriteItem(ctx, ci any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelFavoriteItem", reflect.TypeOf((*MockInteractiveDAO)(nil).DelFavoriteItem), ctx, ci)
 }
 
-// DeleteLikeInfo mocks base method.
-func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) error {
+// DeleteByBiz mocks base method.
+func (m *MockInteractiveDAO) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLikeInfo", ctx, biz, bizId, uid)
+	ret := m.ctrl.Call(m, "DeleteByBiz", ctx, biz, bizId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeleteByBiz indicates an expected call of DeleteByBiz.
+func (mr *MockInteractiveDAOMockRecorder) DeleteByBiz(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteByBiz), ctx, biz, bizId)
+}
+
+// DeleteLikeInfo mocks base method.
+func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeInfo", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteLikeInfo indicates an expected call of DeleteLikeInfo.
 func (mr *MockInteractiveDAOMockRecorder) DeleteLikeInfo(ctx, biz, bizId, uid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteLikeInfo), ctx, biz, bizId, uid)
 }
 
+// FindLiked mocks base method.
+func (m *MockInteractiveDAO) FindLiked(ctx context.Context, uid int64, biz string, utime, id int64, limit int) ([]dao.Like, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLiked", ctx, uid, biz, utime, id, limit)
+	ret0, _ := ret[0].([]dao.Like)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLiked indicates an expected call of FindLiked.
+func (mr *MockInteractiveDAOMockRecorder) FindLiked(ctx, uid, biz, utime, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLiked", reflect.TypeOf((*MockInteractiveDAO)(nil).FindLiked), ctx, uid, biz, utime, id, limit)
+}
+
 // Get mocks base method.
 func (m *MockInteractiveDAO) Get(ctx context.Context, biz string, bizId int64) (dao.Interactive, error) {
 	m.ctrl.T.Helper()
@@ -157,11 +216,12 @@ func (mr *MockInteractiveDAOMockRecorder) IncrReadCnt(ctx, biz, bizId any) *gomo
 }
 
 // InsertFavoriteItem mocks base method.
-func (m *MockInteractiveDAO) InsertFavoriteItem(ctx context.Context, ci dao.FavoriteItem) error {
+func (m *MockInteractiveDAO) InsertFavoriteItem(ctx context.Context, ci dao.FavoriteItem) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InsertFavoriteItem", ctx, ci)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // InsertFavoriteItem indicates an expected call of InsertFavoriteItem.
@@ -171,11 +231,12 @@ func (mr *MockInteractiveDAOMockRecorder) InsertFavoriteItem(ctx, ci any) *gomoc
 }
 
 // InsertLikeInfo mocks base method.
-func (m *MockInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) error {
+func (m *MockInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InsertLikeInfo", ctx, biz, bizId, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // InsertLikeInfo indicates an expected call of InsertLikeInfo.
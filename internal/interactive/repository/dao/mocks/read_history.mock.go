@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./read_history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./read_history.go -package=daomocks -destination=mocks/read_history.mock.go ReadHistoryDAO
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadHistoryDAO is a mock of ReadHistoryDAO interface.
+type MockReadHistoryDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadHistoryDAOMockRecorder
+}
+
+// MockReadHistoryDAOMockRecorder is the mock recorder for MockReadHistoryDAO.
+type MockReadHistoryDAOMockRecorder struct {
+	mock *MockReadHistoryDAO
+}
+
+// NewMockReadHistoryDAO creates a new mock instance.
+func NewMockReadHistoryDAO(ctrl *gomock.Controller) *MockReadHistoryDAO {
+	mock := &MockReadHistoryDAO{ctrl: ctrl}
+	mock.recorder = &MockReadHistoryDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadHistoryDAO) EXPECT() *MockReadHistoryDAOMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockReadHistoryDAO) Clear(ctx context.Context, uid int64, biz string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid, biz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockReadHistoryDAOMockRecorder) Clear(ctx, uid, biz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockReadHistoryDAO)(nil).Clear), ctx, uid, biz)
+}
+
+// Find mocks base method.
+func (m *MockReadHistoryDAO) Find(ctx context.Context, uid int64, biz string, maxUtime, maxBizId int64, limit int) ([]dao.ReadRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, uid, biz, maxUtime, maxBizId, limit)
+	ret0, _ := ret[0].([]dao.ReadRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockReadHistoryDAOMockRecorder) Find(ctx, uid, biz, maxUtime, maxBizId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockReadHistoryDAO)(nil).Find), ctx, uid, biz, maxUtime, maxBizId, limit)
+}
+
+// Insert mocks base method.
+func (m *MockReadHistoryDAO) Insert(ctx context.Context, uid int64, biz string, records ...dao.ReadRecord) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, uid, biz}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Insert", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockReadHistoryDAOMockRecorder) Insert(ctx, uid, biz any, records ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, uid, biz}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockReadHistoryDAO)(nil).Insert), varargs...)
+}
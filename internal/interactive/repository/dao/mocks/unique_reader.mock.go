@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./unique_reader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./unique_reader.go -package=daomocks -destination=mocks/unique_reader.mock.go UniqueReaderDAO
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dao "github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUniqueReaderDAO is a mock of UniqueReaderDAO interface.
+type MockUniqueReaderDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockUniqueReaderDAOMockRecorder
+}
+
+// MockUniqueReaderDAOMockRecorder is the mock recorder for MockUniqueReaderDAO.
+type MockUniqueReaderDAOMockRecorder struct {
+	mock *MockUniqueReaderDAO
+}
+
+// NewMockUniqueReaderDAO creates a new mock instance.
+func NewMockUniqueReaderDAO(ctrl *gomock.Controller) *MockUniqueReaderDAO {
+	mock := &MockUniqueReaderDAO{ctrl: ctrl}
+	mock.recorder = &MockUniqueReaderDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUniqueReaderDAO) EXPECT() *MockUniqueReaderDAOMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockUniqueReaderDAO) Count(ctx context.Context, biz string, bizIds []int64, now time.Time) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, biz, bizIds, now)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockUniqueReaderDAOMockRecorder) Count(ctx, biz, bizIds, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUniqueReaderDAO)(nil).Count), ctx, biz, bizIds, now)
+}
+
+// FindRecent mocks base method.
+func (m *MockUniqueReaderDAO) FindRecent(ctx context.Context, biz string, since time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecent", ctx, biz, since)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecent indicates an expected call of FindRecent.
+func (mr *MockUniqueReaderDAOMockRecorder) FindRecent(ctx, biz, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecent", reflect.TypeOf((*MockUniqueReaderDAO)(nil).FindRecent), ctx, biz, since)
+}
+
+// Insert mocks base method.
+func (m *MockUniqueReaderDAO) Insert(ctx context.Context, biz string, reads ...dao.UniqueRead) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, biz}
+	for _, a := range reads {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Insert", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUniqueReaderDAOMockRecorder) Insert(ctx, biz any, reads ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, biz}, reads...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUniqueReaderDAO)(nil).Insert), varargs...)
+}
@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// readHistorySize the latest reads kept for a user in each biz
+	readHistorySize = 500
+	// readHistoryRetention reads older than it are dropped
+	readHistoryRetention = 90 * 24 * time.Hour
+)
+
+// ReadRecord is a resource in the read history, Utime is when it was read last.
+type ReadRecord struct {
+	BizId int64
+	Utime int64
+}
+
+//go:generate mockgen -source=./read_history.go -package=daomocks -destination=mocks/read_history.mock.go ReadHistoryDAO
+type ReadHistoryDAO interface {
+	// Insert records the reads of the user, a resource read again is moved to the front instead of being added twice.
+	Insert(ctx context.Context, uid int64, biz string, records ...ReadRecord) error
+	// Find returns the records read before the record of (maxUtime, maxBizId) latest first, 0 means from the latest.
+	// Records read at the same time are ordered by the decimal bizId in reverse, the order the sorted set keeps them.
+	Find(ctx context.Context, uid int64, biz string, maxUtime, maxBizId int64, limit int) ([]ReadRecord, error)
+	Clear(ctx context.Context, uid int64, biz string) error
+}
+
+var _ ReadHistoryDAO = (*RedisReadHistoryDAO)(nil)
+
+// RedisReadHistoryDAO keeps the history of a user in a sorted set of the resources scored by the read time,
+// so reading a resource again only updates its score.
+type RedisReadHistoryDAO struct {
+	client redis.Cmdable
+}
+
+func NewRedisReadHistoryDAO(client redis.Cmdable) ReadHistoryDAO {
+	return &RedisReadHistoryDAO{
+		client: client,
+	}
+}
+
+func (dao *RedisReadHistoryDAO) key(uid int64, biz string) string {
+	return fmt.Sprintf("interactive:read_history:%s:%d", biz, uid)
+}
+
+func (dao *RedisReadHistoryDAO) Insert(ctx context.Context, uid int64, biz string, records ...ReadRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	key := dao.key(uid, biz)
+	members := make([]redis.Z, 0, len(records))
+	for _, r := range records {
+		members = append(members, redis.Z{
+			Score:  float64(r.Utime),
+			Member: strconv.FormatInt(r.BizId, 10),
+		})
+	}
+	expired := time.Now().Add(-readHistoryRetention).UnixMilli()
+	_, err := dao.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		// GT keeps the latest read time of events consumed out of order
+		pipe.ZAddGT(ctx, key, members...)
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(expired, 10))
+		pipe.ZRemRangeByRank(ctx, key, 0, -readHistorySize-1)
+		pipe.Expire(ctx, key, readHistoryRetention)
+		return nil
+	})
+	return err
+}
+
+func (dao *RedisReadHistoryDAO) Find(ctx context.Context, uid int64, biz string, maxUtime, maxBizId int64, limit int) ([]ReadRecord, error) {
+	key := dao.key(uid, biz)
+	if maxUtime <= 0 {
+		zs, err := dao.client.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   "+inf",
+			Count: int64(limit),
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+		return dao.toRecords(zs)
+	}
+
+	score := strconv.FormatInt(maxUtime, 10)
+	var same, older *redis.ZSliceCmd
+	_, err := dao.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		// reads at the same millisecond as the cursor, few of them, those after it are filtered below
+		same = pipe.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+			Min: score,
+			Max: score,
+		})
+		older = pipe.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   "(" + score,
+			Count: int64(limit),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	last := strconv.FormatInt(maxBizId, 10)
+	zs := make([]redis.Z, 0, limit)
+	for _, z := range same.Val() {
+		if z.Member.(string) < last {
+			zs = append(zs, z)
+		}
+	}
+	zs = append(zs, older.Val()...)
+	return dao.toRecords(zs[:min(len(zs), limit)])
+}
+
+func (dao *RedisReadHistoryDAO) toRecords(zs []redis.Z) ([]ReadRecord, error) {
+	res := make([]ReadRecord, 0, len(zs))
+	for _, z := range zs {
+		bizId, err := strconv.ParseInt(z.Member.(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ReadRecord{BizId: bizId, Utime: int64(z.Score)})
+	}
+	return res, nil
+}
+
+func (dao *RedisReadHistoryDAO) Clear(ctx context.Context, uid int64, biz string) error {
+	return dao.client.Del(ctx, dao.key(uid, biz)).Err()
+}
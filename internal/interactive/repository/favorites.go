@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/repository/cache"
+	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var ErrFavoritesNotFound = dao.ErrFavoritesNotFound
+
+//go:generate mockgen -source=./favorites.go -package=repomocks -destination=mocks/favorites.mock.go FavoritesRepository
+type FavoritesRepository interface {
+	CreateFavorites(ctx context.Context, f domain.Favorites) (int64, error)
+	// UpdateFavorites returns ErrFavoritesNotFound if the folder is not owned by f.Uid.
+	UpdateFavorites(ctx context.Context, f domain.Favorites) error
+	GetFavorites(ctx context.Context, id int64) (domain.Favorites, error)
+	// ListFavorites returns the folders of the user with at most coverCnt latest items each.
+	ListFavorites(ctx context.Context, uid int64, publicOnly bool, coverCnt int) ([]domain.Favorites, error)
+	// DeleteFavorites deletes the folder with its items, the favorite counts of the items are decreased.
+	DeleteFavorites(ctx context.Context, id, uid int64) error
+	ListItems(ctx context.Context, fid, maxId int64, limit int) ([]domain.FavoriteItem, error)
+	MoveItems(ctx context.Context, uid, from, to int64, ids []int64) (int64, error)
+}
+
+var _ FavoritesRepository = (*CachedFavoritesRepository)(nil)
+
+type CachedFavoritesRepository struct {
+	dao   dao.FavoritesDAO
+	cache cache.InteractiveCache
+	l     logger.Logger
+}
+
+func NewCachedFavoritesRepository(dao dao.FavoritesDAO, cache cache.InteractiveCache, l logger.Logger) FavoritesRepository {
+	return &CachedFavoritesRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (repo *CachedFavoritesRepository) CreateFavorites(ctx context.Context, f domain.Favorites) (int64, error) {
+	return repo.dao.Insert(ctx, repo.toEntity(f))
+}
+
+func (repo *CachedFavoritesRepository) UpdateFavorites(ctx context.Context, f domain.Favorites) error {
+	return repo.dao.Update(ctx, repo.toEntity(f))
+}
+
+func (repo *CachedFavoritesRepository) GetFavorites(ctx context.Context, id int64) (domain.Favorites, error) {
+	f, err := repo.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Favorites{}, err
+	}
+	return repo.toDomain(f), nil
+}
+
+func (repo *CachedFavoritesRepository) ListFavorites(ctx context.Context, uid int64, publicOnly bool, coverCnt int) ([]domain.Favorites, error) {
+	fs, err := repo.dao.FindByUid(ctx, uid, publicOnly)
+	if err != nil {
+		return nil, err
+	}
+	fids := slice.Map(fs, func(idx int, src dao.Favorites) int64 {
+		return src.Id
+	})
+	covers, err := repo.dao.FindCovers(ctx, fids, coverCnt)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(fs, func(idx int, src dao.Favorites) domain.Favorites {
+		f := repo.toDomain(src)
+		f.Covers = slice.Map(covers[src.Id], func(idx int, src dao.FavoriteItem) domain.FavoriteItem {
+			return repo.itemToDomain(src)
+		})
+		return f
+	}), nil
+}
+
+func (repo *CachedFavoritesRepository) DeleteFavorites(ctx context.Context, id, uid int64) error {
+	items, err := repo.dao.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		er := repo.cache.DecrFavoriteCntIfPresent(ctx, item.Biz, item.BizId)
+		if er != nil {
+			repo.l.Error("decrease cached favorite count failed",
+				logger.String("biz", item.Biz),
+				logger.Int64("biz_id", item.BizId),
+				logger.Error(er),
+			)
+		}
+	}
+	return nil
+}
+
+func (repo *CachedFavoritesRepository) ListItems(ctx context.Context, fid, maxId int64, limit int) ([]domain.FavoriteItem, error) {
+	items, err := repo.dao.FindItems(ctx, fid, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(items, func(idx int, src dao.FavoriteItem) domain.FavoriteItem {
+		return repo.itemToDomain(src)
+	}), nil
+}
+
+func (repo *CachedFavoritesRepository) MoveItems(ctx context.Context, uid, from, to int64, ids []int64) (int64, error) {
+	return repo.dao.MoveItems(ctx, uid, from, to, ids)
+}
+
+func (repo *CachedFavoritesRepository) toEntity(f domain.Favorites) dao.Favorites {
+	return dao.Favorites{
+		Id:         f.Id,
+		Name:       f.Name,
+		Uid:        f.Uid,
+		Visibility: uint8(f.Visibility),
+	}
+}
+
+func (repo *CachedFavoritesRepository) toDomain(f dao.Favorites) domain.Favorites {
+	return domain.Favorites{
+		Id:         f.Id,
+		Uid:        f.Uid,
+		Name:       f.Name,
+		Visibility: domain.FavoritesVisibility(f.Visibility),
+		ItemCnt:    f.ItemCnt,
+		Ctime:      time.UnixMilli(f.Ctime),
+		Utime:      time.UnixMilli(f.Utime),
+	}
+}
+
+func (repo *CachedFavoritesRepository) itemToDomain(item dao.FavoriteItem) domain.FavoriteItem {
+	return domain.FavoriteItem{
+		Id:    item.Id,
+		Fid:   item.Fid,
+		Uid:   item.Uid,
+		Biz:   item.Biz,
+		BizId: item.BizId,
+		Ctime: time.UnixMilli(item.Ctime),
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+)
+
+//go:generate mockgen -source=./history.go -package=repomocks -destination=mocks/history.mock.go HistoryRepository
+type HistoryRepository interface {
+	// ListLiked returns what the user liked before the cursor record latest first, the zero record means from the latest.
+	ListLiked(ctx context.Context, uid int64, biz string, cursor domain.ActivityRecord, limit int) ([]domain.ActivityRecord, error)
+	// AddReadHistory records the resources the user read, Utime of each record is when it was read.
+	AddReadHistory(ctx context.Context, uid int64, biz string, records []domain.ActivityRecord) error
+	// ListReadHistory returns what the user read before the cursor record latest first, the zero record means from the latest.
+	ListReadHistory(ctx context.Context, uid int64, biz string, cursor domain.ActivityRecord, limit int) ([]domain.ActivityRecord, error)
+	ClearReadHistory(ctx context.Context, uid int64, biz string) error
+}
+
+var _ HistoryRepository = (*historyRepository)(nil)
+
+type historyRepository struct {
+	intrDAO dao.InteractiveDAO
+	readDAO dao.ReadHistoryDAO
+}
+
+func NewHistoryRepository(intrDAO dao.InteractiveDAO, readDAO dao.ReadHistoryDAO) HistoryRepository {
+	return &historyRepository{
+		intrDAO: intrDAO,
+		readDAO: readDAO,
+	}
+}
+
+func (repo *historyRepository) ListLiked(ctx context.Context, uid int64, biz string, cursor domain.ActivityRecord, limit int) ([]domain.ActivityRecord, error) {
+	var utime int64
+	if !cursor.Utime.IsZero() {
+		utime = cursor.Utime.UnixMilli()
+	}
+	likes, err := repo.intrDAO.FindLiked(ctx, uid, biz, utime, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(likes, func(idx int, src dao.Like) domain.ActivityRecord {
+		return domain.ActivityRecord{
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Utime: time.UnixMilli(src.Utime),
+			Id:    src.Id,
+		}
+	}), nil
+}
+
+func (repo *historyRepository) AddReadHistory(ctx context.Context, uid int64, biz string, records []domain.ActivityRecord) error {
+	return repo.readDAO.Insert(ctx, uid, biz, slice.Map(records, func(idx int, src domain.ActivityRecord) dao.ReadRecord {
+		return dao.ReadRecord{
+			BizId: src.BizId,
+			Utime: src.Utime.UnixMilli(),
+		}
+	})...)
+}
+
+func (repo *historyRepository) ListReadHistory(ctx context.Context, uid int64, biz string, cursor domain.ActivityRecord, limit int) ([]domain.ActivityRecord, error) {
+	var utime int64
+	if !cursor.Utime.IsZero() {
+		utime = cursor.Utime.UnixMilli()
+	}
+	records, err := repo.readDAO.Find(ctx, uid, biz, utime, cursor.BizId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(records, func(idx int, src dao.ReadRecord) domain.ActivityRecord {
+		return domain.ActivityRecord{
+			Biz:   biz,
+			BizId: src.BizId,
+			Utime: time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (repo *historyRepository) ClearReadHistory(ctx context.Context, uid int64, biz string) error {
+	return repo.readDAO.Clear(ctx, uid, biz)
+}
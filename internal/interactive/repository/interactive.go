@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tsukiyo/mercury/internal/interactive/domain"
 
@@ -18,14 +19,22 @@ type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context,
 		biz string, bizId int64) error
 	BatchIncrReadCnt(ctx context.Context, biz string, bizIds []int64) error
-	IncrLike(ctx context.Context, biz string, bizId, uid int64) error
-	DecrLike(ctx context.Context, biz string, bizId, uid int64) error
+	// AddCnt adds the counts of each delta to the resource, it fails only if the database is not written,
+	// so a failed call could be retried without counting twice.
+	AddCnt(ctx context.Context, deltas []domain.Interactive) error
+	// IncrLike records the like and reports whether it is new, the like count is written behind by the CntAggregator.
+	IncrLike(ctx context.Context, biz string, bizId, uid int64) (bool, error)
+	// DecrLike cancels the like and reports whether there was one.
+	DecrLike(ctx context.Context, biz string, bizId, uid int64) (bool, error)
+	// AddFavoriteItem favorites the resource in the folder fid, or moves it there, fid 0 keeps it out of any folder.
 	AddFavoriteItem(ctx context.Context, biz string, bizId, uid int64, fid int64) error
 	DelFavoriteItem(ctx context.Context, biz string, bizId, uid int64, fid int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	Favorited(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	// DeleteByBiz removes everything recorded on the resource once it is deleted.
+	DeleteByBiz(ctx context.Context, biz string, bizId int64) error
 }
 
 var _ InteractiveRepository = (*CachedInteractiveRepository)(nil)
@@ -33,13 +42,19 @@ var _ InteractiveRepository = (*CachedInteractiveRepository)(nil)
 type CachedInteractiveRepository struct {
 	dao   dao.InteractiveDAO
 	cache cache.InteractiveCache
+	agg   *CntAggregator
 	l     logger.Logger
 }
 
-func NewCachedInteractiveRepository(dao dao.InteractiveDAO, cache cache.InteractiveCache, l logger.Logger) InteractiveRepository {
+func NewCachedInteractiveRepository(dao dao.InteractiveDAO,
+	cache cache.InteractiveCache,
+	agg *CntAggregator,
+	l logger.Logger,
+) InteractiveRepository {
 	return &CachedInteractiveRepository{
 		dao:   dao,
 		cache: cache,
+		agg:   agg,
 		l:     l,
 	}
 }
@@ -51,6 +66,9 @@ func (repo *CachedInteractiveRepository) entityToDomain(intr dao.Interactive) do
 		ReadCnt:     intr.ReadCnt,
 		LikeCnt:     intr.LikeCnt,
 		FavoriteCnt: intr.FavoriteCnt,
+
+		UniqueReadCnt:       intr.UniqueReadCnt,
+		WeeklyUniqueReadCnt: intr.WeeklyUniqueReadCnt,
 	}
 }
 
@@ -70,69 +88,83 @@ func (repo *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, b
 	return repo.cache.BatchIncrReadCntIfPresent(ctx, biz, bizIds)
 }
 
-func (repo *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId, uid int64) error {
-	err := repo.dao.InsertLikeInfo(ctx, biz, bizId, uid)
+func (repo *CachedInteractiveRepository) AddCnt(ctx context.Context, deltas []domain.Interactive) error {
+	err := repo.dao.BatchAddCnt(ctx, slice.Map(deltas, func(idx int, src domain.Interactive) dao.Interactive {
+		return dao.Interactive{
+			Biz:         src.Biz,
+			BizId:       src.BizId,
+			ReadCnt:     src.ReadCnt,
+			LikeCnt:     src.LikeCnt,
+			FavoriteCnt: src.FavoriteCnt,
+		}
+	}))
 	if err != nil {
 		return err
 	}
-	return repo.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
+	err = repo.cache.BatchAddCntIfPresent(ctx, deltas)
+	if err != nil {
+		// the cached counts fall behind until they expire
+		repo.l.Error("add cached counts failed", logger.Error(err))
+	}
+	return nil
 }
 
-func (repo *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId, uid int64) error {
-	err := repo.dao.DeleteLikeInfo(ctx, biz, bizId, uid)
-	if err != nil {
-		return err
+func (repo *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	liked, err := repo.dao.InsertLikeInfo(ctx, biz, bizId, uid)
+	if err != nil || !liked {
+		return false, err
+	}
+	repo.agg.Add(dao.Interactive{Biz: biz, BizId: bizId, LikeCnt: 1})
+	return true, repo.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
+}
+
+func (repo *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	unliked, err := repo.dao.DeleteLikeInfo(ctx, biz, bizId, uid)
+	if err != nil || !unliked {
+		return false, err
 	}
-	return repo.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
+	repo.agg.Add(dao.Interactive{Biz: biz, BizId: bizId, LikeCnt: -1})
+	return true, repo.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
 }
 
 func (repo *CachedInteractiveRepository) AddFavoriteItem(ctx context.Context, biz string, bizId, uid int64, fid int64) error {
-	err := repo.dao.InsertFavoriteItem(ctx, dao.FavoriteItem{
+	added, err := repo.dao.InsertFavoriteItem(ctx, dao.FavoriteItem{
 		Biz:   biz,
 		BizId: bizId,
 		Uid:   uid,
 		Fid:   fid,
 	})
-	if err != nil {
+	if err != nil || !added {
 		return err
 	}
 	return repo.cache.IncrFavoriteCntIfPresent(ctx, biz, bizId)
 }
 
 func (repo *CachedInteractiveRepository) DelFavoriteItem(ctx context.Context, biz string, bizId, uid int64, fid int64) error {
-	err := repo.dao.DelFavoriteItem(ctx, dao.FavoriteItem{
+	removed, err := repo.dao.DelFavoriteItem(ctx, dao.FavoriteItem{
 		Biz:   biz,
 		BizId: bizId,
 		Uid:   uid,
 		Fid:   fid,
 	})
-	if err != nil {
+	if err != nil || !removed {
 		return err
 	}
 	return repo.cache.DecrFavoriteCntIfPresent(ctx, biz, bizId)
 }
 
 func (repo *CachedInteractiveRepository) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
-	intr, err := repo.cache.Get(ctx, biz, bizId)
-	if err == nil {
-		return intr, nil
-	}
-	dbIntr, err := repo.dao.Get(ctx, biz, bizId)
-	if err != nil {
-		return domain.Interactive{}, err
-	}
-	intr = repo.entityToDomain(dbIntr)
-	go func() {
-		er := repo.cache.Set(ctx, biz, bizId, intr)
-		if er != nil {
-			repo.l.Error("write back redis failed",
-				logger.String("biz", biz),
-				logger.Int64("biz_id", bizId),
-				logger.Error(er),
-			)
+	return repo.cache.Get(ctx, biz, bizId, func(ctx context.Context) (domain.Interactive, error) {
+		dbIntr, err := repo.dao.Get(ctx, biz, bizId)
+		if errors.Is(err, dao.ErrRecordNotFound) {
+			// nobody has interacted with the resource yet, the zero counts are cached and increased in place
+			return domain.Interactive{Biz: biz, BizId: bizId}, nil
+		}
+		if err != nil {
+			return domain.Interactive{}, err
 		}
-	}()
-	return intr, err
+		return repo.entityToDomain(dbIntr), nil
+	})
 }
 
 func (repo *CachedInteractiveRepository) Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error) {
@@ -168,3 +200,11 @@ func (repo *CachedInteractiveRepository) GetByIds(ctx context.Context, biz strin
 		return repo.entityToDomain(src)
 	}), nil
 }
+
+func (repo *CachedInteractiveRepository) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	err := repo.dao.DeleteByBiz(ctx, biz, bizId)
+	if err != nil {
+		return err
+	}
+	return repo.cache.Del(ctx, biz, bizId)
+}
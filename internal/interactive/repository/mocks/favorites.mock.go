@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./favorites.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./favorites.go -package=repomocks -destination=mocks/favorites.mock.go FavoritesRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/tsukiyo/mercury/internal/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFavoritesRepository is a mock of FavoritesRepository interface.
+type MockFavoritesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoritesRepositoryMockRecorder
+}
+
+// MockFavoritesRepositoryMockRecorder is the mock recorder for MockFavoritesRepository.
+type MockFavoritesRepositoryMockRecorder struct {
+	mock *MockFavoritesRepository
+}
+
+// NewMockFavoritesRepository creates a new mock instance.
+func NewMockFavoritesRepository(ctrl *gomock.Controller) *MockFavoritesRepository {
+	mock := &MockFavoritesRepository{ctrl: ctrl}
+	mock.recorder = &MockFavoritesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoritesRepository) EXPECT() *MockFavoritesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateFavorites mocks base method.
+func (m *MockFavoritesRepository) CreateFavorites(ctx context.Context, f domain.Favorites) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFavorites", ctx, f)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFavorites indicates an expected call of CreateFavorites.
+func (mr *MockFavoritesRepositoryMockRecorder) CreateFavorites(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFavorites", reflect.TypeOf((*MockFavoritesRepository)(nil).CreateFavorites), ctx, f)
+}
+
+// DeleteFavorites mocks base method.
+func (m *MockFavoritesRepository) DeleteFavorites(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFavorites", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFavorites indicates an expected call of DeleteFavorites.
+func (mr *MockFavoritesRepositoryMockRecorder) DeleteFavorites(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFavorites", reflect.TypeOf((*MockFavoritesRepository)(nil).DeleteFavorites), ctx, id, uid)
+}
+
+// GetFavorites mocks base method.
+func (m *MockFavoritesRepository) GetFavorites(ctx context.Context, id int64) (domain.Favorites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavorites", ctx, id)
+	ret0, _ := ret[0].(domain.Favorites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavorites indicates an expected call of GetFavorites.
+func (mr *MockFavoritesRepositoryMockRecorder) GetFavorites(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavorites", reflect.TypeOf((*MockFavoritesRepository)(nil).GetFavorites), ctx, id)
+}
+
+// ListFavorites mocks base method.
+func (m *MockFavoritesRepository) ListFavorites(ctx context.Context, uid int64, publicOnly bool, coverCnt int) ([]domain.Favorites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFavorites", ctx, uid, publicOnly, coverCnt)
+	ret0, _ := ret[0].([]domain.Favorites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFavorites indicates an expected call of ListFavorites.
+func (mr *MockFavoritesRepositoryMockRecorder) ListFavorites(ctx, uid, publicOnly, coverCnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavorites", reflect.TypeOf((*MockFavoritesRepository)(nil).ListFavorites), ctx, uid, publicOnly, coverCnt)
+}
+
+// ListItems mocks base method.
+func (m *MockFavoritesRepository) ListItems(ctx context.Context, fid, maxId int64, limit int) ([]domain.FavoriteItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, fid, maxId, limit)
+	ret0, _ := ret[0].([]domain.FavoriteItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockFavoritesRepositoryMockRecorder) ListItems(ctx, fid, maxId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockFavoritesRepository)(nil).ListItems), ctx, fid, maxId, limit)
+}
+
+// MoveItems mocks base method.
+func (m *MockFavoritesRepository) MoveItems(ctx context.Context, uid, from, to int64, ids []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItems", ctx, uid, from, to, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveItems indicates an expected call of MoveItems.
+func (mr *MockFavoritesRepositoryMockRecorder) MoveItems(ctx, uid, from, to, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItems", reflect.TypeOf((*MockFavoritesRepository)(nil).MoveItems), ctx, uid, from, to, ids)
+}
+
+// UpdateFavorites mocks base method.
+func (m *MockFavoritesRepository) UpdateFavorites(ctx context.Context, f domain.Favorites) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFavorites", ctx, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFavorites indicates an expected call of UpdateFavorites.
+func (mr *MockFavoritesRepositoryMockRecorder) UpdateFavorites(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorites", reflect.TypeOf((*MockFavoritesRepository)(nil).UpdateFavorites), ctx, f)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./history.go -package=repomocks -destination=mocks/history.mock.go HistoryRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/tsukiyo/mercury/internal/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryRepository is a mock of HistoryRepository interface.
+type MockHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepositoryMockRecorder
+}
+
+// MockHistoryRepositoryMockRecorder is the mock recorder for MockHistoryRepository.
+type MockHistoryRepositoryMockRecorder struct {
+	mock *MockHistoryRepository
+}
+
+// NewMockHistoryRepository creates a new mock instance.
+func NewMockHistoryRepository(ctrl *gomock.Controller) *MockHistoryRepository {
+	mock := &MockHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepository) EXPECT() *MockHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddReadHistory mocks base method.
+func (m *MockHistoryRepository) AddReadHistory(ctx context.Context, uid int64, biz string, records []domain.ActivityRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReadHistory", ctx, uid, biz, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReadHistory indicates an expected call of AddReadHistory.
+func (mr *MockHistoryRepositoryMockRecorder) AddReadHistory(ctx, uid, biz, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReadHistory", reflect.TypeOf((*MockHistoryRepository)(nil).AddReadHistory), ctx, uid, biz, records)
+}
+
+// ClearReadHistory mocks base method.
+func (m *MockHistoryRepository) ClearReadHistory(ctx context.Context, uid int64, biz string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearReadHistory", ctx, uid, biz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearReadHistory indicates an expected call of ClearReadHistory.
+func (mr *MockHistoryRepositoryMockRecorder) ClearReadHistory(ctx, uid, biz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearReadHistory", reflect.TypeOf((*MockHistoryRepository)(nil).ClearReadHistory), ctx, uid, biz)
+}
+
+// ListLiked mocks base method.
+func (m *MockHistoryRepository) ListLiked(ctx context.Context, uid int64, biz string, cursor domain.ActivityRecord, limit int) ([]domain.ActivityRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLiked", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.ActivityRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLiked indicates an expected call of ListLiked.
+func (mr *MockHistoryRepositoryMockRecorder) ListLiked(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLiked", reflect.TypeOf((*MockHistoryRepository)(nil).ListLiked), ctx, uid, biz, cursor, limit)
+}
+
+// ListReadHistory mocks base method.
+func (m *MockHistoryRepository) ListReadHistory(ctx context.Context, uid int64, biz string, cursor domain.ActivityRecord, limit int) ([]domain.ActivityRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReadHistory", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.ActivityRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReadHistory indicates an expected call of ListReadHistory.
+func (mr *MockHistoryRepositoryMockRecorder) ListReadHistory(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReadHistory", reflect.TypeOf((*MockHistoryRepository)(nil).ListReadHistory), ctx, uid, biz, cursor, limit)
+}
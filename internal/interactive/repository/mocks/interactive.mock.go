@@ -40,6 +40,20 @@ func (m *MockInteractiveRepository) EXPECT() *MockInteractiveRepositoryMockRecor
 	return m.recorder
 }
 
+// AddCnt mocks base method.
+func (m *MockInteractiveRepository) AddCnt(ctx context.Context, deltas []domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCnt", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCnt indicates an expected call of AddCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) AddCnt(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).AddCnt), ctx, deltas)
+}
+
 // AddFavoriteItem mocks base method.
 func (m *MockInteractiveRepository) AddFavoriteItem(ctx context.Context, biz string, bizId, uid, fid int64) error {
 	m.ctrl.T.Helper()
@@ -69,11 +83,12 @@ func (mr *MockInteractiveRepositoryMockRecorder) BatchIncrReadCnt(ctx, biz, bizI
 }
 
 // DecrLike mocks base method.
-func (m *MockInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId, uid int64) error {
+func (m *MockInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DecrLike", ctx, biz, bizId, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DecrLike indicates an expected call of DecrLike.
@@ -96,6 +111,20 @@ func (mr *MockInteractiveRepositoryMockRecorder) DelFavoriteItem(ctx, biz, bizId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelFavoriteItem", reflect.TypeOf((*MockInteractiveRepository)(nil).DelFavoriteItem), ctx, biz, bizId, uid, fid)
 }
 
+// DeleteByBiz mocks base method.
+func (m *MockInteractiveRepository) DeleteByBiz(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBiz", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBiz indicates an expected call of DeleteByBiz.
+func (mr *MockInteractiveRepositoryMockRecorder) DeleteByBiz(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBiz", reflect.TypeOf((*MockInteractiveRepository)(nil).DeleteByBiz), ctx, biz, bizId)
+}
+
 // Favorited mocks base method.
 func (m *MockInteractiveRepository) Favorited(ctx context.Context, biz string, id, uid int64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -142,11 +171,12 @@ func (mr *MockInteractiveRepositoryMockRecorder) GetByIds(ctx, biz, ids any) *go
 }
 
 // IncrLike mocks base method.
-func (m *MockInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId, uid int64) error {
+func (m *MockInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IncrLike", ctx, biz, bizId, uid)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // IncrLike indicates an expected call of IncrLike.
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./unique_reader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./unique_reader.go -package=repomocks -destination=mocks/unique_reader.mock.go UniqueReaderRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/tsukiyo/mercury/internal/interactive/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUniqueReaderRepository is a mock of UniqueReaderRepository interface.
+type MockUniqueReaderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUniqueReaderRepositoryMockRecorder
+}
+
+// MockUniqueReaderRepositoryMockRecorder is the mock recorder for MockUniqueReaderRepository.
+type MockUniqueReaderRepositoryMockRecorder struct {
+	mock *MockUniqueReaderRepository
+}
+
+// NewMockUniqueReaderRepository creates a new mock instance.
+func NewMockUniqueReaderRepository(ctrl *gomock.Controller) *MockUniqueReaderRepository {
+	mock := &MockUniqueReaderRepository{ctrl: ctrl}
+	mock.recorder = &MockUniqueReaderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUniqueReaderRepository) EXPECT() *MockUniqueReaderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddReads mocks base method.
+func (m *MockUniqueReaderRepository) AddReads(ctx context.Context, biz string, reads []domain.Read) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReads", ctx, biz, reads)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReads indicates an expected call of AddReads.
+func (mr *MockUniqueReaderRepositoryMockRecorder) AddReads(ctx, biz, reads any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReads", reflect.TypeOf((*MockUniqueReaderRepository)(nil).AddReads), ctx, biz, reads)
+}
+
+// SyncUniqueReadCnt mocks base method.
+func (m *MockUniqueReaderRepository) SyncUniqueReadCnt(ctx context.Context, biz string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncUniqueReadCnt", ctx, biz)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncUniqueReadCnt indicates an expected call of SyncUniqueReadCnt.
+func (mr *MockUniqueReaderRepositoryMockRecorder) SyncUniqueReadCnt(ctx, biz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUniqueReadCnt", reflect.TypeOf((*MockUniqueReaderRepository)(nil).SyncUniqueReadCnt), ctx, biz)
+}
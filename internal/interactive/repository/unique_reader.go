@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/repository/cache"
+	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// uniqueReaderSyncBatch the resources counted and written in one round
+const uniqueReaderSyncBatch = 500
+
+//go:generate mockgen -source=./unique_reader.go -package=repomocks -destination=mocks/unique_reader.mock.go UniqueReaderRepository
+type UniqueReaderRepository interface {
+	AddReads(ctx context.Context, biz string, reads []domain.Read) error
+	// SyncUniqueReadCnt persists the unique read counts of the resources read in the last 8 days
+	// and returns how many were synced, the weekly counts of the others have dropped to 0 already.
+	SyncUniqueReadCnt(ctx context.Context, biz string) (int, error)
+}
+
+var _ UniqueReaderRepository = (*uniqueReaderRepository)(nil)
+
+type uniqueReaderRepository struct {
+	intrDAO   dao.InteractiveDAO
+	readerDAO dao.UniqueReaderDAO
+	cache     cache.InteractiveCache
+	l         logger.Logger
+}
+
+func NewUniqueReaderRepository(intrDAO dao.InteractiveDAO,
+	readerDAO dao.UniqueReaderDAO,
+	cache cache.InteractiveCache,
+	l logger.Logger,
+) UniqueReaderRepository {
+	return &uniqueReaderRepository{
+		intrDAO:   intrDAO,
+		readerDAO: readerDAO,
+		cache:     cache,
+		l:         l,
+	}
+}
+
+func (repo *uniqueReaderRepository) AddReads(ctx context.Context, biz string, reads []domain.Read) error {
+	return repo.readerDAO.Insert(ctx, biz, slice.Map(reads, func(idx int, src domain.Read) dao.UniqueRead {
+		return dao.UniqueRead{
+			BizId: src.BizId,
+			Uid:   src.Uid,
+			Ctime: src.Ctime.UnixMilli(),
+		}
+	})...)
+}
+
+func (repo *uniqueReaderRepository) SyncUniqueReadCnt(ctx context.Context, biz string) (int, error) {
+	now := time.Now()
+	// a day more than the week, so the weekly count of a resource no longer read is synced down to 0 once
+	bizIds, err := repo.readerDAO.FindRecent(ctx, biz, now.AddDate(0, 0, -8))
+	if err != nil {
+		return 0, err
+	}
+	for start := 0; start < len(bizIds); start += uniqueReaderSyncBatch {
+		end := min(start+uniqueReaderSyncBatch, len(bizIds))
+		intrs, err := repo.readerDAO.Count(ctx, biz, bizIds[start:end], now)
+		if err != nil {
+			return start, err
+		}
+		err = repo.intrDAO.BatchSetUniqueReadCnt(ctx, intrs)
+		if err != nil {
+			return start, err
+		}
+		err = repo.cache.BatchSetUniqueReadCntIfPresent(ctx, slice.Map(intrs, func(idx int, src dao.Interactive) domain.Interactive {
+			return domain.Interactive{
+				Biz:                 src.Biz,
+				BizId:               src.BizId,
+				UniqueReadCnt:       src.UniqueReadCnt,
+				WeeklyUniqueReadCnt: src.WeeklyUniqueReadCnt,
+			}
+		}))
+		if err != nil {
+			// the cached counts catch up once they expire
+			repo.l.Error("set cached unique read counts failed",
+				logger.String("biz", biz),
+				logger.Error(err),
+			)
+		}
+	}
+	return len(bizIds), nil
+}
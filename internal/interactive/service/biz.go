@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+)
+
+var (
+	ErrUnknownBiz       = errors.New("unknown biz")
+	ErrActionNotAllowed = errors.New("the action is not allowed on the biz")
+	ErrBizNotFound      = errors.New("the resource of the biz does not exist")
+)
+
+// ExistenceChecker checks the resource exists in the service owning the biz.
+type ExistenceChecker interface {
+	Exists(ctx context.Context, bizId int64) (bool, error)
+}
+
+type ExistenceCheckerFunc func(ctx context.Context, bizId int64) (bool, error)
+
+func (f ExistenceCheckerFunc) Exists(ctx context.Context, bizId int64) (bool, error) {
+	return f(ctx, bizId)
+}
+
+// BizType is a kind of resource users interact with.
+type BizType struct {
+	Name    string
+	Actions domain.Action
+	// Checker is nil if the resources are not checked
+	Checker ExistenceChecker
+}
+
+// BizRegistry keeps the biz types the interactive service accepts.
+type BizRegistry struct {
+	types map[string]BizType
+}
+
+func NewBizRegistry(types ...BizType) *BizRegistry {
+	r := &BizRegistry{types: make(map[string]BizType, len(types))}
+	for _, t := range types {
+		r.types[t.Name] = t
+	}
+	return r
+}
+
+// Known returns ErrUnknownBiz if biz is not registered.
+func (r *BizRegistry) Known(biz string) error {
+	if _, ok := r.types[biz]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownBiz, biz)
+	}
+	return nil
+}
+
+// Allow returns ErrUnknownBiz or ErrActionNotAllowed if the action could not be done on biz.
+func (r *BizRegistry) Allow(biz string, action domain.Action) error {
+	t, ok := r.types[biz]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownBiz, biz)
+	}
+	if !t.Actions.Has(action) {
+		return fmt.Errorf("%w: %s on %q", ErrActionNotAllowed, action, biz)
+	}
+	return nil
+}
+
+// Check is Allow plus checking the resource exists, it returns ErrBizNotFound if not.
+func (r *BizRegistry) Check(ctx context.Context, biz string, bizId int64, action domain.Action) error {
+	if err := r.Allow(biz, action); err != nil {
+		return err
+	}
+	checker := r.types[biz].Checker
+	if checker == nil {
+		return nil
+	}
+	ok, err := checker.Exists(ctx, bizId)
+	if err != nil {
+		return fmt.Errorf("check %s %d exists: %w", biz, bizId, err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s %d", ErrBizNotFound, biz, bizId)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/repository"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+)
+
+const (
+	maxFavoritesNameLen = 64
+	// favoritesCoverCnt items are shown on each folder in the folder list
+	favoritesCoverCnt = 3
+	maxFavoritesPage  = 100
+)
+
+var (
+	ErrFavoritesNotFound = repository.ErrFavoritesNotFound
+	ErrInvalidFavorites  = errors.New("the name of the folder is empty or too long, or the visibility is unknown")
+	ErrInvalidCursor     = cursorx.ErrInvalidCursor
+)
+
+func (svc *interactiveService) CreateFavorites(ctx context.Context, f domain.Favorites) (int64, error) {
+	if err := svc.validateFavorites(f); err != nil {
+		return 0, err
+	}
+	return svc.favRepo.CreateFavorites(ctx, f)
+}
+
+func (svc *interactiveService) UpdateFavorites(ctx context.Context, f domain.Favorites) error {
+	if err := svc.validateFavorites(f); err != nil {
+		return err
+	}
+	return svc.favRepo.UpdateFavorites(ctx, f)
+}
+
+func (svc *interactiveService) DeleteFavorites(ctx context.Context, id, uid int64) error {
+	return svc.favRepo.DeleteFavorites(ctx, id, uid)
+}
+
+func (svc *interactiveService) ListFavorites(ctx context.Context, uid, viewer int64) ([]domain.Favorites, error) {
+	return svc.favRepo.ListFavorites(ctx, uid, uid != viewer, favoritesCoverCnt)
+}
+
+func (svc *interactiveService) ListFavoriteItems(ctx context.Context, fid, viewer int64, cursor string, limit int) ([]domain.FavoriteItem, string, error) {
+	f, err := svc.favRepo.GetFavorites(ctx, fid)
+	if err != nil {
+		return nil, "", err
+	}
+	if f.Visibility != domain.FavoritesVisibilityPublic && f.Uid != viewer {
+		return nil, "", ErrFavoritesNotFound
+	}
+	scope := fmt.Sprintf("interactive:favorites:%d", fid)
+	cur, err := svc.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = max(1, min(limit, maxFavoritesPage))
+	items, err := svc.favRepo.ListItems(ctx, fid, cur.Id, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	// a shorter page is the last one
+	if len(items) < limit {
+		return items, "", nil
+	}
+	return items, svc.codec.Encode(scope, cursorx.Cursor{Id: items[len(items)-1].Id}), nil
+}
+
+func (svc *interactiveService) MoveFavoriteItems(ctx context.Context, uid, from, to int64, itemIds []int64) (int64, error) {
+	if from == to || len(itemIds) == 0 {
+		return 0, nil
+	}
+	return svc.favRepo.MoveItems(ctx, uid, from, to, itemIds)
+}
+
+func (svc *interactiveService) validateFavorites(f domain.Favorites) error {
+	n := utf8.RuneCountInString(f.Name)
+	if n == 0 || n > maxFavoritesNameLen || !f.Visibility.Valid() {
+		return ErrInvalidFavorites
+	}
+	return nil
+}
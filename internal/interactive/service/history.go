@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+)
+
+const maxHistoryPage = 100
+
+func (svc *interactiveService) ListLiked(ctx context.Context, uid int64, biz, cursor string, limit int) ([]domain.ActivityRecord, string, error) {
+	if err := svc.registry.Known(biz); err != nil {
+		return nil, "", err
+	}
+	scope := fmt.Sprintf("interactive:liked:%s:%d", biz, uid)
+	cur, err := svc.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = max(1, min(limit, maxHistoryPage))
+	records, err := svc.histRepo.ListLiked(ctx, uid, biz, domain.ActivityRecord{Utime: cur.Utime, Id: cur.Id}, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	return records, svc.nextActivityCursor(scope, records, limit), nil
+}
+
+func (svc *interactiveService) ListReadHistory(ctx context.Context, uid int64, biz, cursor string, limit int) ([]domain.ActivityRecord, string, error) {
+	if err := svc.registry.Known(biz); err != nil {
+		return nil, "", err
+	}
+	scope := fmt.Sprintf("interactive:read_history:%s:%d", biz, uid)
+	cur, err := svc.codec.Decode(scope, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = max(1, min(limit, maxHistoryPage))
+	// the records of the read history have no id, reads at the same time are told apart by the resource
+	records, err := svc.histRepo.ListReadHistory(ctx, uid, biz, domain.ActivityRecord{Utime: cur.Utime, BizId: cur.Id}, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(records) < limit {
+		return records, "", nil
+	}
+	last := records[len(records)-1]
+	return records, svc.codec.Encode(scope, cursorx.Cursor{Utime: last.Utime, Id: last.BizId}), nil
+}
+
+func (svc *interactiveService) ClearReadHistory(ctx context.Context, uid int64, biz string) error {
+	if err := svc.registry.Known(biz); err != nil {
+		return err
+	}
+	return svc.histRepo.ClearReadHistory(ctx, uid, biz)
+}
+
+// nextActivityCursor points at the last record of a full page, a shorter page is the last one.
+func (svc *interactiveService) nextActivityCursor(scope string, records []domain.ActivityRecord, limit int) string {
+	if len(records) < limit {
+		return ""
+	}
+	last := records[len(records)-1]
+	return svc.codec.Encode(scope, cursorx.Cursor{Utime: last.Utime, Id: last.Id})
+}
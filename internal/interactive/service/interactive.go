@@ -2,13 +2,16 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/tsukiyo/mercury/internal/interactive/domain"
+	"github.com/tsukiyo/mercury/internal/interactive/events"
 
 	"github.com/tsukiyo/mercury/internal/interactive/repository"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 	"github.com/tsukiyo/mercury/pkg/logger"
 )
 
@@ -21,41 +24,131 @@ type InteractiveService interface {
 	CancelFavorite(ctx context.Context, biz string, bizId, uid, fid int64) error
 	Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interactive, error)
 	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+
+	// favorites folders
+
+	CreateFavorites(ctx context.Context, f domain.Favorites) (int64, error)
+	// UpdateFavorites renames the folder or changes its visibility.
+	UpdateFavorites(ctx context.Context, f domain.Favorites) error
+	// DeleteFavorites deletes the folder of the user along with the items in it.
+	DeleteFavorites(ctx context.Context, id, uid int64) error
+	// ListFavorites returns the folders of the user with their latest items as covers,
+	// the private ones are left out unless the viewer is the user.
+	ListFavorites(ctx context.Context, uid, viewer int64) ([]domain.Favorites, error)
+	// ListFavoriteItems pages the items in the folder latest first, an empty cursor means the first page.
+	// A private folder is reported as not found to anyone but its owner.
+	ListFavoriteItems(ctx context.Context, fid, viewer int64, cursor string, limit int) ([]domain.FavoriteItem, string, error)
+	// MoveFavoriteItems moves the items of the user between two of the user's folders and returns how many were moved.
+	MoveFavoriteItems(ctx context.Context, uid, from, to int64, itemIds []int64) (int64, error)
+
+	// timelines of a user
+
+	// ListLiked pages what the user liked in the biz latest first, an empty cursor means the first page.
+	ListLiked(ctx context.Context, uid int64, biz, cursor string, limit int) ([]domain.ActivityRecord, string, error)
+	// ListReadHistory pages what the user read in the biz, a resource read again is listed once at its last read.
+	ListReadHistory(ctx context.Context, uid int64, biz, cursor string, limit int) ([]domain.ActivityRecord, string, error)
+	ClearReadHistory(ctx context.Context, uid int64, biz string) error
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
-	l    logger.Logger
+	repo     repository.InteractiveRepository
+	favRepo  repository.FavoritesRepository
+	histRepo repository.HistoryRepository
+	registry *BizRegistry
+	codec    *cursorx.Codec
+	producer events.Producer
+	l        logger.Logger
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository, l logger.Logger) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository,
+	favRepo repository.FavoritesRepository,
+	histRepo repository.HistoryRepository,
+	registry *BizRegistry,
+	codec *cursorx.Codec,
+	producer events.Producer,
+	l logger.Logger,
+) InteractiveService {
 	return &interactiveService{
-		repo: repo,
-		l:    l,
+		repo:     repo,
+		favRepo:  favRepo,
+		histRepo: histRepo,
+		registry: registry,
+		codec:    codec,
+		producer: producer,
+		l:        l,
 	}
 }
 
 func (svc *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	// reads are counted without checking the resource, they are far more than likes
+	if err := svc.registry.Allow(biz, domain.ActionRead); err != nil {
+		return err
+	}
 	return svc.repo.IncrReadCnt(ctx, biz, bizId)
 }
 
 func (svc *interactiveService) Like(ctx context.Context, biz string, bizId int64, uid int64) error {
-	return svc.repo.IncrLike(ctx, biz, bizId, uid)
+	if err := svc.registry.Check(ctx, biz, bizId, domain.ActionLike); err != nil {
+		return err
+	}
+	liked, err := svc.repo.IncrLike(ctx, biz, bizId, uid)
+	if err != nil || !liked {
+		return err
+	}
+	svc.produceLikeEvent(ctx, biz, bizId, uid, 1)
+	return nil
 }
 
 func (svc *interactiveService) CancelLike(ctx context.Context, biz string, bizId int64, uid int64) error {
-	return svc.repo.DecrLike(ctx, biz, bizId, uid)
+	// the resource may be gone, the like is cancelled anyway
+	if err := svc.registry.Allow(biz, domain.ActionLike); err != nil {
+		return err
+	}
+	unliked, err := svc.repo.DecrLike(ctx, biz, bizId, uid)
+	if err != nil || !unliked {
+		return err
+	}
+	svc.produceLikeEvent(ctx, biz, bizId, uid, -1)
+	return nil
+}
+
+// produceLikeEvent tells the owner of the resource the like count changed,
+// the like is kept if the event is lost, the order of the owner falls behind a little.
+func (svc *interactiveService) produceLikeEvent(ctx context.Context, biz string, bizId, uid, delta int64) {
+	err := svc.producer.ProduceLikeEvent(ctx, events.LikeEvent{
+		Biz:   biz,
+		BizId: bizId,
+		Uid:   uid,
+		Delta: delta,
+		Utime: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		svc.l.Error("produce like event failed",
+			logger.String("biz", biz),
+			logger.Int64("biz_id", bizId),
+			logger.Error(err),
+		)
+	}
 }
 
 func (svc *interactiveService) Favorite(ctx context.Context, biz string, bizId, uid, fid int64) error {
+	if err := svc.registry.Check(ctx, biz, bizId, domain.ActionFavorite); err != nil {
+		return err
+	}
 	return svc.repo.AddFavoriteItem(ctx, biz, bizId, uid, fid)
 }
 
 func (svc *interactiveService) CancelFavorite(ctx context.Context, biz string, bizId, uid, fid int64) error {
+	if err := svc.registry.Allow(biz, domain.ActionFavorite); err != nil {
+		return err
+	}
 	return svc.repo.DelFavoriteItem(ctx, biz, bizId, uid, fid)
 }
 
 func (svc *interactiveService) Get(ctx context.Context, biz string, bizId, uid int64) (domain.Interactive, error) {
+	if err := svc.registry.Known(biz); err != nil {
+		return domain.Interactive{}, err
+	}
 	var (
 		intr             domain.Interactive
 		liked, favorited bool
@@ -95,6 +188,9 @@ func (svc *interactiveService) Get(ctx context.Context, biz string, bizId, uid i
 }
 
 func (svc *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	if err := svc.registry.Known(biz); err != nil {
+		return nil, err
+	}
 	intrs, err := svc.repo.GetByIds(ctx, biz, bizIds)
 	if err != nil {
 		return nil, err
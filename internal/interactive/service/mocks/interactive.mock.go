@@ -68,6 +68,49 @@ func (mr *MockInteractiveServiceMockRecorder) CancelLike(ctx, biz, bizId, uid an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLike", reflect.TypeOf((*MockInteractiveService)(nil).CancelLike), ctx, biz, bizId, uid)
 }
 
+// ClearReadHistory mocks base method.
+func (m *MockInteractiveService) ClearReadHistory(ctx context.Context, uid int64, biz string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearReadHistory", ctx, uid, biz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearReadHistory indicates an expected call of ClearReadHistory.
+func (mr *MockInteractiveServiceMockRecorder) ClearReadHistory(ctx, uid, biz any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearReadHistory", reflect.TypeOf((*MockInteractiveService)(nil).ClearReadHistory), ctx, uid, biz)
+}
+
+// CreateFavorites mocks base method.
+func (m *MockInteractiveService) CreateFavorites(ctx context.Context, f domain.Favorites) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFavorites", ctx, f)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFavorites indicates an expected call of CreateFavorites.
+func (mr *MockInteractiveServiceMockRecorder) CreateFavorites(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFavorites", reflect.TypeOf((*MockInteractiveService)(nil).CreateFavorites), ctx, f)
+}
+
+// DeleteFavorites mocks base method.
+func (m *MockInteractiveService) DeleteFavorites(ctx context.Context, id, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFavorites", ctx, id, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFavorites indicates an expected call of DeleteFavorites.
+func (mr *MockInteractiveServiceMockRecorder) DeleteFavorites(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFavorites", reflect.TypeOf((*MockInteractiveService)(nil).DeleteFavorites), ctx, id, uid)
+}
+
 // Favorite mocks base method.
 func (m *MockInteractiveService) Favorite(ctx context.Context, biz string, bizId, uid, fid int64) error {
 	m.ctrl.T.Helper()
@@ -139,3 +182,95 @@ func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, bizId, uid any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, bizId, uid)
 }
+
+// ListFavoriteItems mocks base method.
+func (m *MockInteractiveService) ListFavoriteItems(ctx context.Context, fid, viewer int64, cursor string, limit int) ([]domain.FavoriteItem, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFavoriteItems", ctx, fid, viewer, cursor, limit)
+	ret0, _ := ret[0].([]domain.FavoriteItem)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFavoriteItems indicates an expected call of ListFavoriteItems.
+func (mr *MockInteractiveServiceMockRecorder) ListFavoriteItems(ctx, fid, viewer, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavoriteItems", reflect.TypeOf((*MockInteractiveService)(nil).ListFavoriteItems), ctx, fid, viewer, cursor, limit)
+}
+
+// ListFavorites mocks base method.
+func (m *MockInteractiveService) ListFavorites(ctx context.Context, uid, viewer int64) ([]domain.Favorites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFavorites", ctx, uid, viewer)
+	ret0, _ := ret[0].([]domain.Favorites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFavorites indicates an expected call of ListFavorites.
+func (mr *MockInteractiveServiceMockRecorder) ListFavorites(ctx, uid, viewer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavorites", reflect.TypeOf((*MockInteractiveService)(nil).ListFavorites), ctx, uid, viewer)
+}
+
+// ListLiked mocks base method.
+func (m *MockInteractiveService) ListLiked(ctx context.Context, uid int64, biz, cursor string, limit int) ([]domain.ActivityRecord, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLiked", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.ActivityRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLiked indicates an expected call of ListLiked.
+func (mr *MockInteractiveServiceMockRecorder) ListLiked(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLiked", reflect.TypeOf((*MockInteractiveService)(nil).ListLiked), ctx, uid, biz, cursor, limit)
+}
+
+// ListReadHistory mocks base method.
+func (m *MockInteractiveService) ListReadHistory(ctx context.Context, uid int64, biz, cursor string, limit int) ([]domain.ActivityRecord, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReadHistory", ctx, uid, biz, cursor, limit)
+	ret0, _ := ret[0].([]domain.ActivityRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListReadHistory indicates an expected call of ListReadHistory.
+func (mr *MockInteractiveServiceMockRecorder) ListReadHistory(ctx, uid, biz, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReadHistory", reflect.TypeOf((*MockInteractiveService)(nil).ListReadHistory), ctx, uid, biz, cursor, limit)
+}
+
+// MoveFavoriteItems mocks base method.
+func (m *MockInteractiveService) MoveFavoriteItems(ctx context.Context, uid, from, to int64, itemIds []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFavoriteItems", ctx, uid, from, to, itemIds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveFavoriteItems indicates an expected call of MoveFavoriteItems.
+func (mr *MockInteractiveServiceMockRecorder) MoveFavoriteItems(ctx, uid, from, to, itemIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFavoriteItems", reflect.TypeOf((*MockInteractiveService)(nil).MoveFavoriteItems), ctx, uid, from, to, itemIds)
+}
+
+// UpdateFavorites mocks base method.
+func (m *MockInteractiveService) UpdateFavorites(ctx context.Context, f domain.Favorites) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFavorites", ctx, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFavorites indicates an expected call of UpdateFavorites.
+func (mr *MockInteractiveServiceMockRecorder) UpdateFavorites(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorites", reflect.TypeOf((*MockInteractiveService)(nil).UpdateFavorites), ctx, f)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
 	"github.com/tsukiyo/mercury/internal/interactive/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 var thirdProvider = wire.NewSet(
@@ -25,13 +26,26 @@ var thirdProvider = wire.NewSet(
 	ioc.InitKafka,
 	ioc.InitLogger,
 	ioc.NewSyncProducer,
+	ioc.InitEtcdClient,
+	ioc.InitArticleRpcClient,
+	ioc.InitCommentRpcClient,
+	cursorx.InitCodec,
 )
 
 var interactiveSvcProvider = wire.NewSet(
 	service.NewInteractiveService,
+	events.NewSaramaProducer,
 	repository.NewCachedInteractiveRepository,
+	repository.NewCachedFavoritesRepository,
+	repository.NewHistoryRepository,
+	repository.NewUniqueReaderRepository,
 	dao.NewGORMInteractiveDAO,
+	dao.NewGORMFavoritesDAO,
+	dao.NewRedisReadHistoryDAO,
+	dao.NewRedisUniqueReaderDAO,
 	cache.NewRedisInteractiveCache,
+	ioc.InitBizRegistry,
+	ioc.InitCntAggregator,
 )
 
 var migratorSet = wire.NewSet(
@@ -47,9 +61,15 @@ func InitAPP() *app.App {
 		migratorSet,
 		grpc.NewInteractiveServiceServer,
 		events.NewInteractiveReadEventConsumer,
+		events.NewReadHistoryConsumer,
+		events.NewUniqueReaderConsumer,
+		events.NewArticleDeleteEventConsumer,
 		ioc.InitGRPCxServer,
 		ioc.NewConsumers,
-		wire.Struct(new(app.App), "GRPCServer", "Consumers"),
+		ioc.InitUniqueReaderJob,
+		ioc.InitTasks,
+		ioc.NewClosers,
+		wire.Struct(new(app.App), "GRPCServer", "Consumers", "Cron", "Closers"),
 	)
 	return new(app.App)
 }
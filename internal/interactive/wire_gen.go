@@ -16,6 +16,7 @@ import (
 	"github.com/tsukiyo/mercury/internal/interactive/repository/dao"
 	"github.com/tsukiyo/mercury/internal/interactive/service"
 	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
 )
 
 // Injectors from wire.go:
@@ -28,26 +29,48 @@ func InitAPP() *app.App {
 	db := ioc.InitDualWriteDB(dualWritePool)
 	interactiveDAO := dao.NewGORMInteractiveDAO(db)
 	cmdable := ioc.InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable, logger)
+	cntAggregator := ioc.InitCntAggregator(interactiveDAO, logger)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, cntAggregator, logger)
+	favoritesDAO := dao.NewGORMFavoritesDAO(db)
+	favoritesRepository := repository.NewCachedFavoritesRepository(favoritesDAO, interactiveCache, logger)
+	readHistoryDAO := dao.NewRedisReadHistoryDAO(cmdable)
+	historyRepository := repository.NewHistoryRepository(interactiveDAO, readHistoryDAO)
+	client := ioc.InitEtcdClient()
+	articleServiceClient := ioc.InitArticleRpcClient(client)
+	commentServiceClient := ioc.InitCommentRpcClient(client)
+	bizRegistry := ioc.InitBizRegistry(articleServiceClient, commentServiceClient)
+	codec := cursorx.InitCodec()
+	saramaClient := ioc.InitKafka()
+	syncProducer := ioc.NewSyncProducer(saramaClient)
+	producer := events.NewSaramaProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, favoritesRepository, historyRepository, bizRegistry, codec, producer, logger)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	server := ioc.InitGRPCxServer(interactiveServiceServer, logger)
-	client := ioc.InitKafka()
-	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(client, interactiveRepository, logger)
-	consumer := ioc.InitFixDataConsumer(srcDB, dstDB, client, logger)
-	v := ioc.NewConsumers(interactiveReadEventConsumer, consumer)
+	interactiveReadEventConsumer := events.NewInteractiveReadEventConsumer(saramaClient, interactiveRepository, logger)
+	readHistoryConsumer := events.NewReadHistoryConsumer(saramaClient, historyRepository, logger)
+	uniqueReaderDAO := dao.NewRedisUniqueReaderDAO(cmdable)
+	uniqueReaderRepository := repository.NewUniqueReaderRepository(interactiveDAO, uniqueReaderDAO, interactiveCache, logger)
+	uniqueReaderConsumer := events.NewUniqueReaderConsumer(saramaClient, uniqueReaderRepository, logger)
+	articleDeleteEventConsumer := events.NewArticleDeleteEventConsumer(saramaClient, interactiveRepository, logger)
+	consumer := ioc.InitFixDataConsumer(srcDB, dstDB, saramaClient, logger)
+	v := ioc.NewConsumers(interactiveReadEventConsumer, readHistoryConsumer, uniqueReaderConsumer, articleDeleteEventConsumer, consumer)
+	uniqueReaderJob := ioc.InitUniqueReaderJob(uniqueReaderRepository, logger)
+	cron := ioc.InitTasks(logger, uniqueReaderJob)
+	v2 := ioc.NewClosers(cntAggregator)
 	appApp := &app.App{
 		GRPCServer: server,
 		Consumers:  v,
+		Cron:       cron,
+		Closers:    v2,
 	}
 	return appApp
 }
 
 // wire.go:
 
-var thirdProvider = wire.NewSet(ioc.InitSrcDB, ioc.InitDstDB, ioc.InitDualWritePool, ioc.InitDualWriteDB, ioc.InitRedis, ioc.InitKafka, ioc.InitLogger, ioc.NewSyncProducer)
+var thirdProvider = wire.NewSet(ioc.InitSrcDB, ioc.InitDstDB, ioc.InitDualWritePool, ioc.InitDualWriteDB, ioc.InitRedis, ioc.InitKafka, ioc.InitLogger, ioc.NewSyncProducer, ioc.InitEtcdClient, ioc.InitArticleRpcClient, ioc.InitCommentRpcClient, cursorx.InitCodec)
 
-var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewCachedInteractiveRepository, dao.NewGORMInteractiveDAO, cache.NewRedisInteractiveCache)
+var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, events.NewSaramaProducer, repository.NewCachedInteractiveRepository, repository.NewCachedFavoritesRepository, repository.NewHistoryRepository, repository.NewUniqueReaderRepository, dao.NewGORMInteractiveDAO, dao.NewGORMFavoritesDAO, dao.NewRedisReadHistoryDAO, dao.NewRedisUniqueReaderDAO, cache.NewRedisInteractiveCache, ioc.InitBizRegistry, ioc.InitCntAggregator)
 
 var migratorSet = wire.NewSet(ioc.InitMigratorProducer, ioc.InitFixDataConsumer, ioc.InitMigratorWeb)
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type NotificationKind uint8
+
+const (
+	NotificationKindUnknown NotificationKind = iota
+	// NotificationKindReply a comment of the user is replied
+	NotificationKindReply
+	// NotificationKindMention the user is mentioned by @nickname in a comment
+	NotificationKindMention
+	// NotificationKindComment a resource of the user is commented
+	NotificationKindComment
+)
+
+func (k NotificationKind) Valid() bool {
+	return k >= NotificationKindReply && k <= NotificationKindComment
+}
+
+// Notification is an entry in the inbox of the user Uid, a user is notified of a comment once.
+type Notification struct {
+	Id        int64
+	Uid       int64
+	Kind      NotificationKind
+	Biz       string
+	BizId     int64
+	CommentId int64
+	// SenderUid is the author of the comment
+	SenderUid int64
+	Read      bool
+	Ctime     time.Time
+}
+
+// MuteSettings are the kinds of notifications the user does not receive.
+type MuteSettings struct {
+	Uid   int64
+	Kinds []NotificationKind
+}
+
+func (s MuteSettings) Muted(kind NotificationKind) bool {
+	return slices.Contains(s.Kinds, kind)
+}
+
+// Comment is a comment visible to everyone, the users related to it are notified.
+type Comment struct {
+	Id    int64
+	Biz   string
+	BizId int64
+	Uid   int64
+	// ParentUid is 0 for a top comment
+	ParentUid     int64
+	MentionedUids []int64
+	Ctime         time.Time
+}
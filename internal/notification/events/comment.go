@@ -0,0 +1,79 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/internal/notification/domain"
+	"github.com/tsukiyo/mercury/internal/notification/service"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+const topicCommentCreatedEvent = "comment_created_event"
+
+// CommentCreatedEvent is sent by the comment service once a comment is visible to everyone.
+type CommentCreatedEvent struct {
+	Id            int64
+	Biz           string
+	BizId         int64
+	Uid           int64
+	RootId        int64
+	RootUid       int64
+	ParentId      int64
+	ParentUid     int64
+	MentionedUids []int64
+	Ctime         int64
+}
+
+var _ Consumer = (*CommentEventConsumer)(nil)
+
+// CommentEventConsumer turns the comments into the notifications of the users related to them.
+type CommentEventConsumer struct {
+	client sarama.Client
+	svc    service.NotificationService
+	l      logger.Logger
+}
+
+func NewCommentEventConsumer(client sarama.Client, svc service.NotificationService, l logger.Logger) *CommentEventConsumer {
+	return &CommentEventConsumer{
+		client: client,
+		svc:    svc,
+		l:      l,
+	}
+}
+
+func (consumer *CommentEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("notification_comment", consumer.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicCommentCreatedEvent},
+			saramax.NewHandler[CommentCreatedEvent](consumer.l, consumer.Consume),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (consumer *CommentEventConsumer) Consume(msg *sarama.ConsumerMessage, evt CommentCreatedEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// consumed again after a failure, the users already notified are not notified twice
+	return consumer.svc.NotifyComment(ctx, domain.Comment{
+		Id:            evt.Id,
+		Biz:           evt.Biz,
+		BizId:         evt.BizId,
+		Uid:           evt.Uid,
+		ParentUid:     evt.ParentUid,
+		MentionedUids: evt.MentionedUids,
+		Ctime:         time.UnixMilli(evt.Ctime),
+	})
+}
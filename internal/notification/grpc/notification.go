@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	notificationv1 "github.com/tsukiyo/mercury/api/gen/notification/v1"
+	"github.com/tsukiyo/mercury/internal/notification/domain"
+	"github.com/tsukiyo/mercury/internal/notification/service"
+	"github.com/tsukiyo/mercury/pkg/grpcx"
+)
+
+var _ grpcx.Register = (*NotificationServiceServer)(nil)
+
+type NotificationServiceServer struct {
+	notificationv1.UnimplementedNotificationServiceServer
+	svc service.NotificationService
+}
+
+func NewNotificationServiceServer(svc service.NotificationService) *NotificationServiceServer {
+	return &NotificationServiceServer{
+		svc: svc,
+	}
+}
+
+func (n *NotificationServiceServer) Register(srv *grpc.Server) {
+	notificationv1.RegisterNotificationServiceServer(srv, n)
+}
+
+func (n *NotificationServiceServer) ListNotifications(ctx context.Context, request *notificationv1.ListNotificationsRequest) (*notificationv1.ListNotificationsResponse, error) {
+	ns, next, err := n.svc.ListNotifications(ctx, request.GetUid(), request.GetCursor(), request.GetLimit())
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*notificationv1.Notification, 0, len(ns))
+	for _, notification := range ns {
+		res = append(res, &notificationv1.Notification{
+			Id:        notification.Id,
+			Kind:      notificationv1.NotificationKind(notification.Kind),
+			Biz:       notification.Biz,
+			BizId:     notification.BizId,
+			CommentId: notification.CommentId,
+			SenderUid: notification.SenderUid,
+			Read:      notification.Read,
+			Ctime:     timestamppb.New(notification.Ctime),
+		})
+	}
+	return &notificationv1.ListNotificationsResponse{
+		Notifications: res,
+		NextCursor:    next,
+	}, nil
+}
+
+func (n *NotificationServiceServer) CountUnread(ctx context.Context, request *notificationv1.CountUnreadRequest) (*notificationv1.CountUnreadResponse, error) {
+	cnt, err := n.svc.CountUnread(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	return &notificationv1.CountUnreadResponse{Count: cnt}, nil
+}
+
+func (n *NotificationServiceServer) MarkRead(ctx context.Context, request *notificationv1.MarkReadRequest) (*notificationv1.MarkReadResponse, error) {
+	err := n.svc.MarkRead(ctx, request.GetUid(), request.GetIds())
+	return &notificationv1.MarkReadResponse{}, err
+}
+
+func (n *NotificationServiceServer) GetMuteSettings(ctx context.Context, request *notificationv1.GetMuteSettingsRequest) (*notificationv1.GetMuteSettingsResponse, error) {
+	settings, err := n.svc.GetMuteSettings(ctx, request.GetUid())
+	if err != nil {
+		return nil, err
+	}
+	kinds := make([]notificationv1.NotificationKind, 0, len(settings.Kinds))
+	for _, kind := range settings.Kinds {
+		kinds = append(kinds, notificationv1.NotificationKind(kind))
+	}
+	return &notificationv1.GetMuteSettingsResponse{MutedKinds: kinds}, nil
+}
+
+func (n *NotificationServiceServer) UpdateMuteSettings(ctx context.Context, request *notificationv1.UpdateMuteSettingsRequest) (*notificationv1.UpdateMuteSettingsResponse, error) {
+	kinds := make([]domain.NotificationKind, 0, len(request.GetMutedKinds()))
+	for _, kind := range request.GetMutedKinds() {
+		k := domain.NotificationKind(kind)
+		if !k.Valid() {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown notification kind %d", kind)
+		}
+		kinds = append(kinds, k)
+	}
+	err := n.svc.SetMuteSettings(ctx, domain.MuteSettings{
+		Uid:   request.GetUid(),
+		Kinds: kinds,
+	})
+	return &notificationv1.UpdateMuteSettingsResponse{}, err
+}
@@ -0,0 +1,26 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	igrpc "github.com/tsukiyo/mercury/internal/notification/grpc"
+	"github.com/tsukiyo/mercury/pkg/grpcx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func InitGRPCxServer(notification *igrpc.NotificationServiceServer, l logger.Logger) *grpcx.Server {
+	type Config struct {
+		Port int    `yaml:"port"`
+		Etcd string `yaml:"etcd"`
+		TTL  int64  `yaml:"ttl"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.server", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	srv := grpc.NewServer()
+	notification.Register(srv)
+	return grpcx.NewServer(srv, "notification", cfg.Port, []string{cfg.Etcd}, cfg.TTL, l)
+}
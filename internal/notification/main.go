@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func main() {
+	initViper()
+	initLogger()
+
+	app := InitAPP()
+	if err := app.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func initViper() {
+	cfile := pflag.String("config", "config/config.yaml", "set config file path")
+	pflag.Parse()
+
+	viper.SetConfigFile(*cfile)
+	viper.WatchConfig()
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		fmt.Println(in.Name, in.Op)
+	})
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func initLogger() {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		panic(err)
+	}
+	zap.ReplaceGlobals(logger)
+	zap.L().Info("logger initialized :)")
+}
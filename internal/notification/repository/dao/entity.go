@@ -0,0 +1,30 @@
+package dao
+
+type Notification struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// a user is notified of a comment once, the events consumed again are ignored
+	Uid       int64 `gorm:"uniqueIndex:uid_comment_id;index:uid_status"`
+	CommentId int64 `gorm:"uniqueIndex:uid_comment_id"`
+	Status    uint8 `gorm:"index:uid_status"`
+	Kind      uint8
+	Biz       string `gorm:"type:varchar(128)"`
+	BizId     int64
+	SenderUid int64
+	Ctime     int64
+	Utime     int64
+}
+
+const (
+	NotificationStatusUnknown = iota
+	NotificationStatusUnread
+	NotificationStatusRead
+)
+
+type MuteSetting struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"unique"`
+	// Kinds is a bitmask of the kinds muted, bit n for the kind n
+	Kinds uint32
+	Ctime int64
+	Utime int64
+}
@@ -0,0 +1,7 @@
+package dao
+
+import "gorm.io/gorm"
+
+func InitTable(db *gorm.DB) error {
+	return db.AutoMigrate(&Notification{}, &MuteSetting{})
+}
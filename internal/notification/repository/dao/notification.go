@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationDAO interface {
+	// Insert ignores the notifications of a user already notified of the comment.
+	Insert(ctx context.Context, ns []Notification) error
+	// FindByUid returns the notifications with id less than maxId latest first, 0 means from the latest.
+	FindByUid(ctx context.Context, uid, maxId int64, limit int) ([]Notification, error)
+	CountUnread(ctx context.Context, uid int64) (int64, error)
+	// MarkRead marks the notifications of the user read, no ids marks all of them.
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	// FindMuteSettings returns the settings of the users, the users never setting them are left out.
+	FindMuteSettings(ctx context.Context, uids []int64) ([]MuteSetting, error)
+	UpsertMuteSetting(ctx context.Context, s MuteSetting) error
+}
+
+var _ NotificationDAO = (*GORMNotificationDAO)(nil)
+
+type GORMNotificationDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMNotificationDAO(db *gorm.DB) NotificationDAO {
+	return &GORMNotificationDAO{
+		db: db,
+	}
+}
+
+func (dao *GORMNotificationDAO) Insert(ctx context.Context, ns []Notification) error {
+	if len(ns) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range ns {
+		ns[i].Status = NotificationStatusUnread
+		ns[i].Ctime, ns[i].Utime = now, now
+	}
+	return dao.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&ns).Error
+}
+
+func (dao *GORMNotificationDAO) FindByUid(ctx context.Context, uid, maxId int64, limit int) ([]Notification, error) {
+	var res []Notification
+	tx := dao.db.WithContext(ctx).Where("uid = ?", uid)
+	if maxId > 0 {
+		tx = tx.Where("id < ?", maxId)
+	}
+	err := tx.Order("id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMNotificationDAO) CountUnread(ctx context.Context, uid int64) (int64, error) {
+	var cnt int64
+	err := dao.db.WithContext(ctx).Model(&Notification{}).
+		Where("uid = ? AND status = ?", uid, NotificationStatusUnread).
+		Count(&cnt).Error
+	return cnt, err
+}
+
+func (dao *GORMNotificationDAO) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	tx := dao.db.WithContext(ctx).Model(&Notification{}).
+		Where("uid = ? AND status = ?", uid, NotificationStatusUnread)
+	if len(ids) > 0 {
+		tx = tx.Where("id IN ?", ids)
+	}
+	return tx.Updates(map[string]any{
+		"status": NotificationStatusRead,
+		"utime":  time.Now().UnixMilli(),
+	}).Error
+}
+
+func (dao *GORMNotificationDAO) FindMuteSettings(ctx context.Context, uids []int64) ([]MuteSetting, error) {
+	var res []MuteSetting
+	err := dao.db.WithContext(ctx).Where("uid IN ?", uids).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMNotificationDAO) UpsertMuteSetting(ctx context.Context, s MuteSetting) error {
+	now := time.Now().UnixMilli()
+	s.Ctime, s.Utime = now, now
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"kinds": s.Kinds,
+			"utime": now,
+		}),
+	}).Create(&s).Error
+}
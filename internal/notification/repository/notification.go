@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/notification/domain"
+	"github.com/tsukiyo/mercury/internal/notification/repository/dao"
+)
+
+type NotificationRepository interface {
+	// Create ignores the notifications of a user already notified of the comment.
+	Create(ctx context.Context, ns []domain.Notification) error
+	FindByUid(ctx context.Context, uid, maxId int64, limit int) ([]domain.Notification, error)
+	CountUnread(ctx context.Context, uid int64) (int64, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	// FindMuteSettings returns the settings by the uid, a user never setting them mutes nothing.
+	FindMuteSettings(ctx context.Context, uids []int64) (map[int64]domain.MuteSettings, error)
+	SetMuteSettings(ctx context.Context, s domain.MuteSettings) error
+}
+
+var _ NotificationRepository = (*notificationRepository)(nil)
+
+type notificationRepository struct {
+	dao dao.NotificationDAO
+}
+
+func NewNotificationRepository(notificationDAO dao.NotificationDAO) NotificationRepository {
+	return &notificationRepository{
+		dao: notificationDAO,
+	}
+}
+
+func (repo *notificationRepository) Create(ctx context.Context, ns []domain.Notification) error {
+	entities := make([]dao.Notification, 0, len(ns))
+	for _, n := range ns {
+		entities = append(entities, repo.toEntity(n))
+	}
+	return repo.dao.Insert(ctx, entities)
+}
+
+func (repo *notificationRepository) FindByUid(ctx context.Context, uid, maxId int64, limit int) ([]domain.Notification, error) {
+	ns, err := repo.dao.FindByUid(ctx, uid, maxId, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Notification, 0, len(ns))
+	for _, n := range ns {
+		res = append(res, repo.toDomain(n))
+	}
+	return res, nil
+}
+
+func (repo *notificationRepository) CountUnread(ctx context.Context, uid int64) (int64, error) {
+	return repo.dao.CountUnread(ctx, uid)
+}
+
+func (repo *notificationRepository) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	return repo.dao.MarkRead(ctx, uid, ids)
+}
+
+func (repo *notificationRepository) FindMuteSettings(ctx context.Context, uids []int64) (map[int64]domain.MuteSettings, error) {
+	settings, err := repo.dao.FindMuteSettings(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.MuteSettings, len(uids))
+	for _, uid := range uids {
+		res[uid] = domain.MuteSettings{Uid: uid}
+	}
+	for _, s := range settings {
+		res[s.Uid] = domain.MuteSettings{Uid: s.Uid, Kinds: repo.kindsFromMask(s.Kinds)}
+	}
+	return res, nil
+}
+
+func (repo *notificationRepository) SetMuteSettings(ctx context.Context, s domain.MuteSettings) error {
+	var mask uint32
+	for _, kind := range s.Kinds {
+		mask |= 1 << kind
+	}
+	return repo.dao.UpsertMuteSetting(ctx, dao.MuteSetting{
+		Uid:   s.Uid,
+		Kinds: mask,
+	})
+}
+
+func (repo *notificationRepository) kindsFromMask(mask uint32) []domain.NotificationKind {
+	var kinds []domain.NotificationKind
+	for kind := domain.NotificationKindReply; kind.Valid(); kind++ {
+		if mask&(1<<kind) != 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+func (repo *notificationRepository) toEntity(n domain.Notification) dao.Notification {
+	return dao.Notification{
+		Id:        n.Id,
+		Uid:       n.Uid,
+		CommentId: n.CommentId,
+		Kind:      uint8(n.Kind),
+		Biz:       n.Biz,
+		BizId:     n.BizId,
+		SenderUid: n.SenderUid,
+	}
+}
+
+func (repo *notificationRepository) toDomain(n dao.Notification) domain.Notification {
+	return domain.Notification{
+		Id:        n.Id,
+		Uid:       n.Uid,
+		Kind:      domain.NotificationKind(n.Kind),
+		Biz:       n.Biz,
+		BizId:     n.BizId,
+		CommentId: n.CommentId,
+		SenderUid: n.SenderUid,
+		Read:      n.Status == dao.NotificationStatusRead,
+		Ctime:     time.UnixMilli(n.Ctime),
+	}
+}
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./notification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./notification.go -package=svcmocks -destination=mocks/notification.mock.go NotificationService
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/tsukiyo/mercury/internal/notification/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationService is a mock of NotificationService interface.
+type MockNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceMockRecorder
+}
+
+// MockNotificationServiceMockRecorder is the mock recorder for MockNotificationService.
+type MockNotificationServiceMockRecorder struct {
+	mock *MockNotificationService
+}
+
+// NewMockNotificationService creates a new mock instance.
+func NewMockNotificationService(ctrl *gomock.Controller) *MockNotificationService {
+	mock := &MockNotificationService{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotificationService) CountUnread(ctx context.Context, uid int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, uid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationServiceMockRecorder) CountUnread(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotificationService)(nil).CountUnread), ctx, uid)
+}
+
+// GetMuteSettings mocks base method.
+func (m *MockNotificationService) GetMuteSettings(ctx context.Context, uid int64) (domain.MuteSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMuteSettings", ctx, uid)
+	ret0, _ := ret[0].(domain.MuteSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMuteSettings indicates an expected call of GetMuteSettings.
+func (mr *MockNotificationServiceMockRecorder) GetMuteSettings(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuteSettings", reflect.TypeOf((*MockNotificationService)(nil).GetMuteSettings), ctx, uid)
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotificationService) ListNotifications(ctx context.Context, uid int64, cursor string, limit int64) ([]domain.Notification, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Notification)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotificationServiceMockRecorder) ListNotifications(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotificationService)(nil).ListNotifications), ctx, uid, cursor, limit)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationService) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, uid, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationServiceMockRecorder) MarkRead(ctx, uid, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationService)(nil).MarkRead), ctx, uid, ids)
+}
+
+// NotifyComment mocks base method.
+func (m *MockNotificationService) NotifyComment(ctx context.Context, comment domain.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyComment indicates an expected call of NotifyComment.
+func (mr *MockNotificationServiceMockRecorder) NotifyComment(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyComment", reflect.TypeOf((*MockNotificationService)(nil).NotifyComment), ctx, comment)
+}
+
+// SetMuteSettings mocks base method.
+func (m *MockNotificationService) SetMuteSettings(ctx context.Context, s domain.MuteSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMuteSettings", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMuteSettings indicates an expected call of SetMuteSettings.
+func (mr *MockNotificationServiceMockRecorder) SetMuteSettings(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMuteSettings", reflect.TypeOf((*MockNotificationService)(nil).SetMuteSettings), ctx, s)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+	"github.com/tsukiyo/mercury/internal/notification/domain"
+	"github.com/tsukiyo/mercury/internal/notification/repository"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+)
+
+var ErrInvalidCursor = cursorx.ErrInvalidCursor
+
+const scopeList = "notification:list"
+
+//go:generate mockgen -source=./notification.go -package=svcmocks -destination=mocks/notification.mock.go NotificationService
+type NotificationService interface {
+	// NotifyComment notifies the parent author, the users mentioned and the author of the resource commented,
+	// each of them once, of the first kind not muted in that order. The author of the comment is never notified.
+	NotifyComment(ctx context.Context, comment domain.Comment) error
+	// ListNotifications pages the inbox latest first, an empty cursor means the first page.
+	// The returned cursor is empty when there are no more notifications.
+	ListNotifications(ctx context.Context, uid int64, cursor string, limit int64) ([]domain.Notification, string, error)
+	CountUnread(ctx context.Context, uid int64) (int64, error)
+	// MarkRead marks the notifications of the user read, no ids marks all of them.
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	GetMuteSettings(ctx context.Context, uid int64) (domain.MuteSettings, error)
+	SetMuteSettings(ctx context.Context, s domain.MuteSettings) error
+}
+
+var _ NotificationService = (*notificationService)(nil)
+
+type notificationService struct {
+	repo       repository.NotificationRepository
+	articleSvc articlev1.ArticleServiceClient
+	codec      *cursorx.Codec
+}
+
+func NewNotificationService(repo repository.NotificationRepository,
+	articleSvc articlev1.ArticleServiceClient,
+	codec *cursorx.Codec,
+) NotificationService {
+	return &notificationService{
+		repo:       repo,
+		articleSvc: articleSvc,
+		codec:      codec,
+	}
+}
+
+func (svc *notificationService) NotifyComment(ctx context.Context, comment domain.Comment) error {
+	// the kinds of each user in the order of preference
+	kinds := make(map[int64][]domain.NotificationKind)
+	var uids []int64
+	notify := func(uid int64, kind domain.NotificationKind) {
+		if uid <= 0 || uid == comment.Uid {
+			return
+		}
+		if _, ok := kinds[uid]; !ok {
+			uids = append(uids, uid)
+		}
+		kinds[uid] = append(kinds[uid], kind)
+	}
+	notify(comment.ParentUid, domain.NotificationKindReply)
+	for _, uid := range comment.MentionedUids {
+		notify(uid, domain.NotificationKindMention)
+	}
+	authorId, err := svc.findAuthor(ctx, comment.Biz, comment.BizId)
+	if err != nil {
+		return err
+	}
+	notify(authorId, domain.NotificationKindComment)
+	if len(uids) == 0 {
+		return nil
+	}
+
+	settings, err := svc.repo.FindMuteSettings(ctx, uids)
+	if err != nil {
+		return err
+	}
+	ns := make([]domain.Notification, 0, len(uids))
+	for _, uid := range uids {
+		for _, kind := range kinds[uid] {
+			if settings[uid].Muted(kind) {
+				continue
+			}
+			ns = append(ns, domain.Notification{
+				Uid:       uid,
+				Kind:      kind,
+				Biz:       comment.Biz,
+				BizId:     comment.BizId,
+				CommentId: comment.Id,
+				SenderUid: comment.Uid,
+			})
+			break
+		}
+	}
+	return svc.repo.Create(ctx, ns)
+}
+
+// findAuthor returns the owner of the resource commented, 0 if nobody owns it.
+func (svc *notificationService) findAuthor(ctx context.Context, biz string, bizId int64) (int64, error) {
+	if biz != "article" {
+		return 0, nil
+	}
+	// not GetPublishedById, which counts a read
+	resp, err := svc.articleSvc.ExistsPublished(ctx, &articlev1.ExistsPublishedRequest{Id: bizId})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetAuthorId(), nil
+}
+
+func (svc *notificationService) ListNotifications(ctx context.Context, uid int64, cursor string, limit int64) ([]domain.Notification, string, error) {
+	cur, err := svc.codec.Decode(scopeList, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	ns, err := svc.repo.FindByUid(ctx, uid, cur.Id, int(limit))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(ns) == 0 || int64(len(ns)) < limit {
+		return ns, "", nil
+	}
+	return ns, svc.codec.Encode(scopeList, cursorx.Cursor{Id: ns[len(ns)-1].Id}), nil
+}
+
+func (svc *notificationService) CountUnread(ctx context.Context, uid int64) (int64, error) {
+	return svc.repo.CountUnread(ctx, uid)
+}
+
+func (svc *notificationService) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	return svc.repo.MarkRead(ctx, uid, ids)
+}
+
+func (svc *notificationService) GetMuteSettings(ctx context.Context, uid int64) (domain.MuteSettings, error) {
+	settings, err := svc.repo.FindMuteSettings(ctx, []int64{uid})
+	if err != nil {
+		return domain.MuteSettings{}, err
+	}
+	return settings[uid], nil
+}
+
+func (svc *notificationService) SetMuteSettings(ctx context.Context, s domain.MuteSettings) error {
+	return svc.repo.SetMuteSettings(ctx, s)
+}
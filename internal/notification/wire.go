@@ -0,0 +1,48 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+
+	"github.com/tsukiyo/mercury/internal/notification/events"
+	"github.com/tsukiyo/mercury/internal/notification/grpc"
+	"github.com/tsukiyo/mercury/internal/notification/ioc"
+	"github.com/tsukiyo/mercury/internal/notification/repository"
+	"github.com/tsukiyo/mercury/internal/notification/repository/dao"
+	"github.com/tsukiyo/mercury/internal/notification/service"
+	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+)
+
+var thirdProviderSet = wire.NewSet(
+	ioc.InitLogger,
+	ioc.InitDB,
+	ioc.InitKafka,
+	ioc.InitEtcdClient,
+	cursorx.InitCodec,
+	ioc.InitArticleRpcClient,
+)
+
+var svcProviderSet = wire.NewSet(
+	grpc.NewNotificationServiceServer,
+	service.NewNotificationService,
+	repository.NewNotificationRepository,
+	dao.NewGORMNotificationDAO,
+)
+
+var eventsProviderSet = wire.NewSet(
+	events.NewCommentEventConsumer,
+	ioc.NewConsumers,
+)
+
+func InitAPP() *app.App {
+	wire.Build(
+		thirdProviderSet,
+		svcProviderSet,
+		eventsProviderSet,
+		ioc.InitGRPCxServer,
+		wire.Struct(new(app.App), "GRPCServer", "Consumers"),
+	)
+	return new(app.App)
+}
@@ -0,0 +1,50 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/tsukiyo/mercury/internal/notification/events"
+	"github.com/tsukiyo/mercury/internal/notification/grpc"
+	"github.com/tsukiyo/mercury/internal/notification/ioc"
+	"github.com/tsukiyo/mercury/internal/notification/repository"
+	"github.com/tsukiyo/mercury/internal/notification/repository/dao"
+	"github.com/tsukiyo/mercury/internal/notification/service"
+	"github.com/tsukiyo/mercury/pkg/app"
+	"github.com/tsukiyo/mercury/pkg/cursorx"
+)
+
+// Injectors from wire.go:
+
+func InitAPP() *app.App {
+	logger := ioc.InitLogger()
+	db := ioc.InitDB(logger)
+	notificationDAO := dao.NewGORMNotificationDAO(db)
+	notificationRepository := repository.NewNotificationRepository(notificationDAO)
+	client := ioc.InitEtcdClient()
+	articleServiceClient := ioc.InitArticleRpcClient(client)
+	codec := cursorx.InitCodec()
+	notificationService := service.NewNotificationService(notificationRepository, articleServiceClient, codec)
+	notificationServiceServer := grpc.NewNotificationServiceServer(notificationService)
+	server := ioc.InitGRPCxServer(notificationServiceServer, logger)
+	saramaClient := ioc.InitKafka()
+	commentEventConsumer := events.NewCommentEventConsumer(saramaClient, notificationService, logger)
+	v := ioc.NewConsumers(commentEventConsumer)
+	appApp := &app.App{
+		GRPCServer: server,
+		Consumers:  v,
+	}
+	return appApp
+}
+
+// wire.go:
+
+var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitDB, ioc.InitKafka, ioc.InitEtcdClient, cursorx.InitCodec, ioc.InitArticleRpcClient)
+
+var svcProviderSet = wire.NewSet(grpc.NewNotificationServiceServer, service.NewNotificationService, repository.NewNotificationRepository, dao.NewGORMNotificationDAO)
+
+var eventsProviderSet = wire.NewSet(events.NewCommentEventConsumer, ioc.NewConsumers)
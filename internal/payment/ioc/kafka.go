@@ -2,9 +2,12 @@ package ioc
 
 import (
 	"github.com/IBM/sarama"
+	rlock "github.com/gotomicro/redis-lock"
 	"github.com/spf13/viper"
+	"gorm.io/gorm"
 
-	"github.com/tsukiyo/mercury/internal/payment/events"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/outbox"
 )
 
 func InitKafka() sarama.Client {
@@ -27,10 +30,10 @@ func InitKafka() sarama.Client {
 	return client
 }
 
-func InitProducer(client sarama.Client) events.Producer {
-	res, err := events.NewSaramaProducer(client)
+func InitOutboxRelay(db *gorm.DB, client sarama.Client, rlockClient *rlock.Client, l logger.Logger) *outbox.Relay {
+	producer, err := sarama.NewSyncProducerFromClient(client)
 	if err != nil {
 		panic(err)
 	}
-	return res
+	return outbox.NewRelay(db, producer, rlockClient, "payment", l)
 }
@@ -13,7 +13,6 @@ import (
 	"github.com/wechatpay-apiv3/wechatpay-go/services/refunddomestic"
 	"github.com/wechatpay-apiv3/wechatpay-go/utils"
 
-	"github.com/tsukiyo/mercury/internal/payment/events"
 	"github.com/tsukiyo/mercury/internal/payment/repository"
 	"github.com/tsukiyo/mercury/internal/payment/service/wechat"
 	"github.com/tsukiyo/mercury/pkg/logger"
@@ -59,13 +58,12 @@ func InitWechatNativeService(
 	cli *core.Client,
 	repo repository.PaymentRepository,
 	l logger.Logger,
-	producer events.Producer,
 	cfg WechatConfig,
 ) *wechat.NativePaymentService {
 	return wechat.NewNativePaymentService(
 		&native.NativeApiService{Client: cli},
 		&refunddomestic.RefundsApiService{Client: cli},
-		cfg.AppID, cfg.MchID, repo, l, producer,
+		cfg.AppID, cfg.MchID, repo, l,
 	)
 }
 
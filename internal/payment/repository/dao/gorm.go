@@ -7,6 +7,8 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/tsukiyo/mercury/internal/payment/domain"
+	"github.com/tsukiyo/mercury/internal/payment/events"
+	"github.com/tsukiyo/mercury/pkg/outbox"
 )
 
 type GORMPaymentDAO struct {
@@ -45,7 +47,29 @@ func (p *GORMPaymentDAO) UpdateTxnIDAndStatus(ctx context.Context,
 	txnID string,
 	status domain.PaymentStatus,
 ) error {
-	return p.db.WithContext(ctx).Model(&Payment{}).
+	return updateTxnIDAndStatus(p.db.WithContext(ctx), bizTradeNo, txnID, status)
+}
+
+func (p *GORMPaymentDAO) UpdateTxnIDAndStatusWithEvent(ctx context.Context,
+	bizTradeNo string,
+	txnID string,
+	status domain.PaymentStatus,
+) error {
+	return p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := updateTxnIDAndStatus(tx, bizTradeNo, txnID, status)
+		if err != nil {
+			return err
+		}
+		evt := events.PaymentEvent{
+			BizTradeNo: bizTradeNo,
+			Status:     status.AsUint8(),
+		}
+		return outbox.Write(tx, evt.Topic(), bizTradeNo, evt)
+	})
+}
+
+func updateTxnIDAndStatus(db *gorm.DB, bizTradeNo string, txnID string, status domain.PaymentStatus) error {
+	return db.Model(&Payment{}).
 		Where("biz_trade_no = ?", bizTradeNo).
 		Updates(map[string]any{
 			"txn_id": txnID,
@@ -1,7 +1,11 @@
 package dao
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+
+	"github.com/tsukiyo/mercury/pkg/outbox"
+)
 
 func InitTables(db *gorm.DB) error {
-	return db.AutoMigrate(&Payment{})
+	return db.AutoMigrate(&Payment{}, &outbox.Message{})
 }
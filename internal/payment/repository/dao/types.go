@@ -11,6 +11,8 @@ import (
 type PaymentDAO interface {
 	Insert(ctx context.Context, payment Payment) error
 	UpdateTxnIDAndStatus(ctx context.Context, bizTradeNo string, txnID string, status domain.PaymentStatus) error
+	// UpdateTxnIDAndStatusWithEvent writes the PaymentEvent to the outbox in the same transaction
+	UpdateTxnIDAndStatusWithEvent(ctx context.Context, bizTradeNo string, txnID string, status domain.PaymentStatus) error
 	FindExpiredPayments(ctx context.Context, offset int, limit int, t time.Time) ([]Payment, error)
 	GetPayment(ctx context.Context, bizTradeNo string) (Payment, error)
 }
@@ -28,6 +28,10 @@ func (p *paymentRepository) UpdatePayment(ctx context.Context, payment domain.Pa
 	return p.dao.UpdateTxnIDAndStatus(ctx, payment.BizTradeNo, payment.TxnID, payment.Status)
 }
 
+func (p *paymentRepository) UpdatePaymentWithEvent(ctx context.Context, payment domain.Payment) error {
+	return p.dao.UpdateTxnIDAndStatusWithEvent(ctx, payment.BizTradeNo, payment.TxnID, payment.Status)
+}
+
 // FindExpiredPayments implements PaymentRepository.
 func (p *paymentRepository) FindExpiredPayments(ctx context.Context, offset int, limit int, t time.Time) ([]domain.Payment, error) {
 	payments, err := p.dao.FindExpiredPayments(ctx, offset, limit, t)
@@ -10,6 +10,8 @@ import (
 type PaymentRepository interface {
 	AddPayment(ctx context.Context, payment domain.Payment) error
 	UpdatePayment(ctx context.Context, payment domain.Payment) error
+	// UpdatePaymentWithEvent updates the payment and notifies downstream services with PaymentEvent
+	UpdatePaymentWithEvent(ctx context.Context, payment domain.Payment) error
 	FindExpiredPayments(ctx context.Context, offset int, limit int, t time.Time) ([]domain.Payment, error)
 	GetPayment(ctx context.Context, bizTradeNo string) (domain.Payment, error)
 }
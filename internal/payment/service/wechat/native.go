@@ -14,7 +14,6 @@ import (
 	"github.com/wechatpay-apiv3/wechatpay-go/services/refunddomestic"
 
 	"github.com/tsukiyo/mercury/internal/payment/domain"
-	"github.com/tsukiyo/mercury/internal/payment/events"
 	"github.com/tsukiyo/mercury/internal/payment/repository"
 	"github.com/tsukiyo/mercury/pkg/logger"
 )
@@ -29,7 +28,6 @@ type NativePaymentService struct {
 	notifyURL            string
 	repo                 repository.PaymentRepository
 	l                    logger.Logger
-	producer             events.Producer
 	callbackTypeToStatus map[string]domain.PaymentStatus
 }
 
@@ -40,7 +38,6 @@ func NewNativePaymentService(
 	mchID string,
 	repo repository.PaymentRepository,
 	l logger.Logger,
-	producer events.Producer,
 ) *NativePaymentService {
 	return &NativePaymentService{
 		nativeAPI: natieAPI,
@@ -50,7 +47,6 @@ func NewNativePaymentService(
 		notifyURL: "http://test.lazywoo.com/pay/callback",
 		repo:      repo,
 		l:         l,
-		producer:  producer,
 		callbackTypeToStatus: map[string]domain.PaymentStatus{
 			"SUCCESS":  domain.PaymentStatusSuccess,
 			"PAYERROR": domain.PaymentStatusFailed,
@@ -106,20 +102,8 @@ func (n *NativePaymentService) updateByTxn(ctx context.Context, txn *payments.Tr
 		TxnID:      *txn.TransactionId,
 		Status:     status,
 	}
-	err := n.repo.UpdatePayment(ctx, payment)
-	if err != nil {
-		return err
-	}
-	err = n.producer.ProducePaymentEvent(ctx, events.PaymentEvent{
-		BizTradeNo: payment.BizTradeNo,
-		Status:     payment.Status.AsUint8(),
-	})
-	if err != nil {
-		n.l.Error("send payment event failed", logger.Error(err),
-			logger.String("biz_trade_no", payment.BizTradeNo))
-		return err
-	}
-	return nil
+	// the event is written to the outbox along with the payment
+	return n.repo.UpdatePaymentWithEvent(ctx, payment)
 }
 
 func (n *NativePaymentService) FindExpiredPayments(ctx context.Context, offset int, limit int, t time.Time) ([]domain.Payment, error) {
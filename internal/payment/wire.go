@@ -17,7 +17,7 @@ var thirdPartySet = wire.NewSet(
 	ioc.InitDB,
 	ioc.InitLogger,
 	ioc.InitKafka,
-	ioc.InitProducer,
+	ioc.InitOutboxRelay,
 	ioc.InitWechatNotifyHandler,
 	ioc.InitWechatConfig,
 	ioc.InitWechatClient,
@@ -38,7 +38,7 @@ func InitAPP() *app.App {
 		ioc.InitSyncWechatPaymentJob,
 		ioc.InitWebServer,
 		ioc.InitGRPCxServer,
-		wire.Struct(new(app.App), "WebServer", "GRPCServer", "Cron"),
+		wire.Struct(new(app.App), "WebServer", "GRPCServer", "Cron", "OutboxRelay"),
 	)
 	return &app.App{}
 }
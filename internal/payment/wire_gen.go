@@ -8,7 +8,6 @@ package main
 
 import (
 	"github.com/google/wire"
-
 	"github.com/tsukiyo/mercury/internal/payment/grpc"
 	"github.com/tsukiyo/mercury/internal/payment/ioc"
 	"github.com/tsukiyo/mercury/internal/payment/repository"
@@ -27,9 +26,7 @@ func InitAPP() *app.App {
 	db := ioc.InitDB(logger)
 	paymentDAO := dao.NewGORMPaymentDAO(db)
 	paymentRepository := repository.NewPaymentRepository(paymentDAO)
-	saramaClient := ioc.InitKafka()
-	producer := ioc.InitProducer(saramaClient)
-	nativePaymentService := ioc.InitWechatNativeService(client, paymentRepository, logger, producer, wechatConfig)
+	nativePaymentService := ioc.InitWechatNativeService(client, paymentRepository, logger, wechatConfig)
 	wechatHandler := web.NewWechatHandler(handler, logger, nativePaymentService)
 	server := ioc.InitWebServer(wechatHandler)
 	wechatServiceServer := grpc.NewWechatPaymentServiceServer(nativePaymentService)
@@ -38,14 +35,17 @@ func InitAPP() *app.App {
 	rlockClient := ioc.InitRLockClient(cmdable)
 	syncWechatOrderJob := ioc.InitSyncWechatPaymentJob(nativePaymentService, rlockClient, logger)
 	cron := ioc.InitCronJobs(logger, syncWechatOrderJob)
+	saramaClient := ioc.InitKafka()
+	relay := ioc.InitOutboxRelay(db, saramaClient, rlockClient, logger)
 	appApp := &app.App{
-		WebServer:  server,
-		GRPCServer: grpcxServer,
-		Cron:       cron,
+		WebServer:   server,
+		GRPCServer:  grpcxServer,
+		Cron:        cron,
+		OutboxRelay: relay,
 	}
 	return appApp
 }
 
 // wire.go:
 
-var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.InitProducer, ioc.InitWechatNotifyHandler, ioc.InitWechatConfig, ioc.InitWechatClient, ioc.InitCronJobs, ioc.InitRedis, ioc.InitRLockClient)
+var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.InitOutboxRelay, ioc.InitWechatNotifyHandler, ioc.InitWechatConfig, ioc.InitWechatClient, ioc.InitCronJobs, ioc.InitRedis, ioc.InitRLockClient)
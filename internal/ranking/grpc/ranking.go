@@ -47,6 +47,20 @@ func (r *RankingServiceServer) TopN(ctx context.Context, _ *rankingv1.TopNReques
 	}, err
 }
 
+func (r *RankingServiceServer) TopNByTag(ctx context.Context, req *rankingv1.TopNByTagRequest) (*rankingv1.TopNByTagResponse, error) {
+	domainAtcls, err := r.svc.TopNByTag(ctx, req.GetTag())
+	if err != nil {
+		return &rankingv1.TopNByTagResponse{}, err
+	}
+	res := make([]*rankingv1.Article, 0, len(domainAtcls))
+	for _, atcl := range domainAtcls {
+		res = append(res, convertToV(atcl))
+	}
+	return &rankingv1.TopNByTagResponse{
+		Articles: res,
+	}, nil
+}
+
 func convertToV(domainArticle domain.Article) *rankingv1.Article {
 	return &rankingv1.Article{
 		Id:      domainArticle.Id,
@@ -59,5 +73,6 @@ func convertToV(domainArticle domain.Article) *rankingv1.Article {
 		},
 		Ctime: timestamppb.New(domainArticle.Ctime),
 		Utime: timestamppb.New(domainArticle.Utime),
+		Tags:  domainArticle.Tags,
 	}
 }
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/tsukiyo/mercury/internal/article/domain"
@@ -23,8 +24,8 @@ func NewRankingRedisCache(client redis.Cmdable) *RankingRedisCache {
 }
 
 func (cache *RankingRedisCache) Set(ctx context.Context, atcls []domain.Article) error {
-	for _, atcl := range atcls {
-		atcl.Content = atcl.Abstract()
+	for i := range atcls {
+		atcls[i].Content = atcls[i].Abstract
 	}
 	bs, err := json.Marshal(atcls)
 	if err != nil {
@@ -33,6 +34,40 @@ func (cache *RankingRedisCache) Set(ctx context.Context, atcls []domain.Article)
 	return cache.client.Set(ctx, cache.key, bs, cache.expiration).Err()
 }
 
+// SetByTags writes the topN of every tag in one pipeline
+func (cache *RankingRedisCache) SetByTags(ctx context.Context, topN map[string][]domain.Article) error {
+	_, err := cache.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for tag, atcls := range topN {
+			bs, err := json.Marshal(atcls)
+			if err != nil {
+				return err
+			}
+			pipe.Set(ctx, cache.tagKey(tag), bs, cache.expiration)
+		}
+		return nil
+	})
+	return err
+}
+
+func (cache *RankingRedisCache) GetByTag(ctx context.Context, tag string) ([]domain.Article, error) {
+	bs, err := cache.client.Get(ctx, cache.tagKey(tag)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var atcls []domain.Article
+	err = json.Unmarshal(bs, &atcls)
+	if err != nil {
+		return nil, err
+	}
+
+	return atcls, nil
+}
+
+func (cache *RankingRedisCache) tagKey(tag string) string {
+	return fmt.Sprintf("%s:tag:%s", cache.key, tag)
+}
+
 func (cache *RankingRedisCache) Get(ctx context.Context) ([]domain.Article, error) {
 	bs, err := cache.client.Get(ctx, cache.key).Bytes()
 	if err != nil {
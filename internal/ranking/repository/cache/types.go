@@ -9,4 +9,6 @@ import (
 type RankingCache interface {
 	Set(ctx context.Context, atcl []domain.Article) error
 	Get(ctx context.Context) ([]domain.Article, error)
+	SetByTags(ctx context.Context, topN map[string][]domain.Article) error
+	GetByTag(ctx context.Context, tag string) ([]domain.Article, error)
 }
@@ -11,6 +11,8 @@ import (
 type RankingRepository interface {
 	ReplaceTopN(ctx context.Context, atcls []domain.Article) error
 	GetTopN(ctx context.Context) ([]domain.Article, error)
+	ReplaceTagTopN(ctx context.Context, topN map[string][]domain.Article) error
+	GetTagTopN(ctx context.Context, tag string) ([]domain.Article, error)
 }
 
 var _ RankingRepository = (*RankingCachedRepository)(nil)
@@ -45,3 +47,12 @@ func (repo *RankingCachedRepository) GetTopN(ctx context.Context) ([]domain.Arti
 	}
 	return atcls, err
 }
+
+// ReplaceTagTopN only keeps the topN of tags in redis, there are too many tags to hold locally.
+func (repo *RankingCachedRepository) ReplaceTagTopN(ctx context.Context, topN map[string][]domain.Article) error {
+	return repo.redis.SetByTags(ctx, topN)
+}
+
+func (repo *RankingCachedRepository) GetTagTopN(ctx context.Context, tag string) ([]domain.Article, error) {
+	return repo.redis.GetByTag(ctx, tag)
+}
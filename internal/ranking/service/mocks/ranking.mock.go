@@ -68,3 +68,18 @@ func (mr *MockRankingServiceMockRecorder) TopN(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopN", reflect.TypeOf((*MockRankingService)(nil).TopN), ctx)
 }
+
+// TopNByTag mocks base method.
+func (m *MockRankingService) TopNByTag(ctx context.Context, tag string) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopNByTag", ctx, tag)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopNByTag indicates an expected call of TopNByTag.
+func (mr *MockRankingServiceMockRecorder) TopNByTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopNByTag", reflect.TypeOf((*MockRankingService)(nil).TopNByTag), ctx, tag)
+}
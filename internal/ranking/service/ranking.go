@@ -25,6 +25,8 @@ type RankingService interface {
 	RankTopN(ctx context.Context) error
 	// TopN GetTopN IDs
 	TopN(ctx context.Context) ([]domain.Article, error)
+	// TopNByTag GetTopN of articles with the tag, which is calculated along with RankTopN
+	TopNByTag(ctx context.Context, tag string) ([]domain.Article, error)
 }
 
 var _ RankingService = (*BatchRankingService)(nil)
@@ -33,9 +35,10 @@ type BatchRankingService struct {
 	atclCli articlev1.ArticleServiceClient
 	intrCli interactivev1.InteractiveServiceClient
 
-	repo      repository.RankingRepository
-	BatchSize int
-	TopNSize  int // limit topN Size
+	repo        repository.RankingRepository
+	BatchSize   int
+	TopNSize    int // limit topN Size
+	TagTopNSize int // limit topN Size of each tag
 
 	scoreFunc func(likeCnt int64, utime time.Time) float64
 }
@@ -46,22 +49,26 @@ func NewBatchRankingService(
 	repo repository.RankingRepository,
 ) RankingService {
 	svc := &BatchRankingService{
-		intrCli:   intrCli,
-		atclCli:   atclCli,
-		repo:      repo,
-		BatchSize: 100,
-		TopNSize:  200,
+		intrCli:     intrCli,
+		atclCli:     atclCli,
+		repo:        repo,
+		BatchSize:   100,
+		TopNSize:    200,
+		TagTopNSize: 50,
 	}
 	svc.scoreFunc = svc.score
 	return svc
 }
 
 func (svc *BatchRankingService) RankTopN(ctx context.Context) error {
-	atcls, err := svc.rankTopN(ctx)
+	atcls, tagAtcls, err := svc.rankTopN(ctx)
 	if err != nil {
 		return err
 	}
-	return svc.repo.ReplaceTopN(ctx, atcls)
+	if err = svc.repo.ReplaceTopN(ctx, atcls); err != nil {
+		return err
+	}
+	return svc.repo.ReplaceTagTopN(ctx, tagAtcls)
 }
 
 type score struct {
@@ -101,6 +108,25 @@ func (hp *scorePriorityQueue) pop() score {
 	return heap.Pop(hp).(score)
 }
 
+// offer keeps v if the heap is not full yet or v scores higher than the top
+func (hp *scorePriorityQueue) offer(v score, size int) {
+	if hp.Len() < size {
+		hp.push(v)
+	} else if v.score > hp.top().score {
+		hp.replace(v)
+	}
+}
+
+// sorted drains the heap and returns articles from the highest score to the lowest
+func (hp *scorePriorityQueue) sorted() []domain.Article {
+	n := hp.Len()
+	res := make([]domain.Article, n)
+	for i := n - 1; i >= 0; i-- {
+		res[i] = hp.pop().atcl
+	}
+	return res
+}
+
 // pop up and return to the top of the heap, while pushing v into the heap
 func (hp *scorePriorityQueue) replace(v score) score {
 	top := (*hp)[0]
@@ -113,23 +139,25 @@ func (hp *scorePriorityQueue) top() score {
 	return (*hp)[0]
 }
 
-func (svc *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, error) {
+// rankTopN returns the topN of all articles and the topN of each tag
+func (svc *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article, map[string][]domain.Article, error) {
 	// min-heap
 	topN := &scorePriorityQueue{}
+	tagTopN := make(map[string]*scorePriorityQueue)
 
 	now := time.Now()
 	ddl := now.Add(-time.Hour * 24 * 7)
-	offset := 0
+	cursor := ""
 
 	for {
 		// get a batch of publishedArticles
 		listPubResp, err := svc.atclCli.ListPub(ctx, &articlev1.ListPubRequest{
 			StartTime: timestamppb.New(now),
-			Offset:    int32(offset),
+			Cursor:    cursor,
 			Limit:     int32(svc.BatchSize),
 		})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		atcls := make([]domain.Article, 0, len(listPubResp.Articles))
@@ -148,7 +176,7 @@ func (svc *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article,
 			BizIds: atclIds,
 		})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		for _, atcl := range atcls {
@@ -158,36 +186,43 @@ func (svc *BatchRankingService) rankTopN(ctx context.Context) ([]domain.Article,
 			}
 			ele := score{atcl: atcl, score: svc.scoreFunc(intr.LikeCnt, atcl.Utime)}
 
-			if topN.Len() < svc.TopNSize {
-				topN.push(ele)
-			} else if ele.score > topN.top().score {
-				topN.replace(ele)
+			topN.offer(ele, svc.TopNSize)
+			for _, tag := range atcl.Tags {
+				hp, ok := tagTopN[tag]
+				if !ok {
+					hp = &scorePriorityQueue{}
+					tagTopN[tag] = hp
+				}
+				hp.offer(ele, svc.TagTopNSize)
 			}
 		}
 
 		// validate
-		if len(atcls) == 0 || len(atcls) < svc.BatchSize || atcls[len(atcls)-1].Utime.Before(ddl) {
+		if listPubResp.GetNextCursor() == "" || atcls[len(atcls)-1].Utime.Before(ddl) {
 			break
 		}
-
-		// maintain offset
-		offset = offset + len(atcls)
+		cursor = listPubResp.GetNextCursor()
 	}
 
-	n := topN.Len()
-	res := make([]domain.Article, n)
-	for i := n - 1; i >= 0; i-- {
-		val := topN.pop()
-		res[i] = val.atcl
+	tagRes := make(map[string][]domain.Article, len(tagTopN))
+	for tag, hp := range tagTopN {
+		tagRes[tag] = hp.sorted()
 	}
-
-	return res, nil
+	return topN.sorted(), tagRes, nil
 }
 
 func (svc *BatchRankingService) TopN(ctx context.Context) ([]domain.Article, error) {
 	return svc.repo.GetTopN(ctx)
 }
 
+func (svc *BatchRankingService) TopNByTag(ctx context.Context, tag string) ([]domain.Article, error) {
+	tags := domain.NormalizeTags([]string{tag})
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return svc.repo.GetTagTopN(ctx, tags[0])
+}
+
 // Algo Hacker News (p - 1) / (t + 2) ^ 1.5
 func (svc *BatchRankingService) score(likeCnt int64, utime time.Time) float64 {
 	const factor = 1.5
@@ -200,11 +235,13 @@ func articleToDomain(article *articlev1.Article) domain.Article {
 		domainArticle.Id = article.GetId()
 		domainArticle.Title = article.GetTitle()
 		domainArticle.Content = article.GetContent()
+		domainArticle.Abstract = article.GetAbstract()
 		domainArticle.Author = domain.Author{
 			Id:   article.GetAuthor().GetId(),
 			Name: article.GetAuthor().GetName(),
 		}
 		domainArticle.Status = domain.ArticleStatus(article.GetStatus())
+		domainArticle.Tags = article.GetTags()
 		domainArticle.Ctime = article.GetCtime().AsTime()
 		domainArticle.Utime = article.GetUtime().AsTime()
 	}
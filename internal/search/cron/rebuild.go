@@ -0,0 +1,43 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/tsukiyo/mercury/internal/search/service"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var _ cronx.Task = (*RebuildJob)(nil)
+
+// RebuildJob reloads the index of this instance, which repairs events lost on the way.
+// Every instance holds its own index, so there is no distributed lock.
+type RebuildJob struct {
+	svc     service.SearchService
+	timeout time.Duration
+	l       logger.Logger
+}
+
+func NewRebuildJob(svc service.SearchService, timeout time.Duration, l logger.Logger) *RebuildJob {
+	return &RebuildJob{
+		svc:     svc,
+		timeout: timeout,
+		l:       l,
+	}
+}
+
+func (job *RebuildJob) Name() string {
+	return "search_rebuild"
+}
+
+func (job *RebuildJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), job.timeout)
+	defer cancel()
+	cnt, err := job.svc.Rebuild(ctx)
+	if err != nil {
+		return err
+	}
+	job.l.Info("search index rebuilt", logger.Int("count", cnt))
+	return nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+type Article struct {
+	Id       int64
+	Title    string
+	Content  string
+	AuthorId int64
+	Utime    time.Time
+}
+
+// SearchHit is a matched article without content, Snippet holds the matched fragment instead.
+type SearchHit struct {
+	Article Article
+	Snippet string
+	Score   float64
+}
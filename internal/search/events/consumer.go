@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/internal/search/domain"
+	"github.com/tsukiyo/mercury/internal/search/service"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+const (
+	topicPublishEvent  = "article_publish_event"
+	topicWithdrawEvent = "article_withdraw_event"
+)
+
+type PublishEvent struct {
+	Aid      int64
+	AuthorId int64
+	Title    string
+	Content  string
+	Utime    int64
+}
+
+type WithdrawEvent struct {
+	Aid   int64
+	Utime int64
+}
+
+var _ Consumer = (*ArticleEventConsumer)(nil)
+
+// ArticleEventConsumer keeps the index of this instance up to date.
+// The index lives in memory, so every instance consumes all events with a consumer group of its own.
+type ArticleEventConsumer struct {
+	client sarama.Client
+	svc    service.SearchService
+	group  string
+	l      logger.Logger
+}
+
+func NewArticleEventConsumer(client sarama.Client, svc service.SearchService, group string, l logger.Logger) *ArticleEventConsumer {
+	return &ArticleEventConsumer{
+		client: client,
+		svc:    svc,
+		group:  group,
+		l:      l,
+	}
+}
+
+func (consumer *ArticleEventConsumer) Start() error {
+	pub, err := sarama.NewConsumerGroupFromClient(consumer.group+"_publish", consumer.client)
+	if err != nil {
+		return err
+	}
+	withdraw, err := sarama.NewConsumerGroupFromClient(consumer.group+"_withdraw", consumer.client)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := pub.Consume(context.Background(),
+			[]string{topicPublishEvent},
+			saramax.NewHandler[PublishEvent](consumer.l, consumer.ConsumePublish),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+	go func() {
+		err := withdraw.Consume(context.Background(),
+			[]string{topicWithdrawEvent},
+			saramax.NewHandler[WithdrawEvent](consumer.l, consumer.ConsumeWithdraw),
+		)
+		if err != nil {
+			consumer.l.Error("exited consumption cycle exception", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (consumer *ArticleEventConsumer) ConsumePublish(msg *sarama.ConsumerMessage, evt PublishEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return consumer.svc.Index(ctx, domain.Article{
+		Id:       evt.Aid,
+		Title:    evt.Title,
+		Content:  evt.Content,
+		AuthorId: evt.AuthorId,
+		Utime:    time.UnixMilli(evt.Utime),
+	})
+}
+
+func (consumer *ArticleEventConsumer) ConsumeWithdraw(msg *sarama.ConsumerMessage, evt WithdrawEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return consumer.svc.Remove(ctx, evt.Aid, time.UnixMilli(evt.Utime))
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	searchv1 "github.com/tsukiyo/mercury/api/gen/search/v1"
+	"github.com/tsukiyo/mercury/internal/search/service"
+)
+
+type SearchServiceServer struct {
+	searchv1.UnimplementedSearchServiceServer
+
+	svc service.SearchService
+}
+
+func NewSearchServiceServer(svc service.SearchService) *SearchServiceServer {
+	return &SearchServiceServer{
+		svc: svc,
+	}
+}
+
+func (s *SearchServiceServer) Register(server grpc.ServiceRegistrar) {
+	searchv1.RegisterSearchServiceServer(server, s)
+}
+
+func (s *SearchServiceServer) Search(ctx context.Context, req *searchv1.SearchRequest) (*searchv1.SearchResponse, error) {
+	hits, total, err := s.svc.Search(ctx, req.GetQuery(), int(req.GetOffset()), int(req.GetLimit()))
+	if errors.Is(err, service.ErrInvalidPage) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*searchv1.Hit, 0, len(hits))
+	for _, hit := range hits {
+		res = append(res, &searchv1.Hit{
+			Id:       hit.Article.Id,
+			Title:    hit.Article.Title,
+			AuthorId: hit.Article.AuthorId,
+			Snippet:  hit.Snippet,
+			Score:    hit.Score,
+			Utime:    timestamppb.New(hit.Article.Utime),
+		})
+	}
+	return &searchv1.SearchResponse{Hits: res, Total: int64(total)}, nil
+}
+
+func (s *SearchServiceServer) Rebuild(ctx context.Context, _ *searchv1.RebuildRequest) (*searchv1.RebuildResponse, error) {
+	cnt, err := s.svc.Rebuild(ctx)
+	return &searchv1.RebuildResponse{Count: int64(cnt)}, err
+}
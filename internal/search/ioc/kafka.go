@@ -0,0 +1,48 @@
+package ioc
+
+import (
+	"os"
+
+	"github.com/IBM/sarama"
+	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/internal/search/events"
+	"github.com/tsukiyo/mercury/internal/search/service"
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/saramax"
+)
+
+func InitKafka() sarama.Client {
+	type Config struct {
+		Addrs []string `yaml:"addrs"`
+	}
+	saramaCfg := sarama.NewConfig()
+
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	client, err := sarama.NewClient(cfg.Addrs, saramaCfg)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func InitArticleEventConsumer(client sarama.Client, svc service.SearchService, l logger.Logger) *events.ArticleEventConsumer {
+	// each instance needs a consumer group of its own, the hostname is unique enough by default
+	group := viper.GetString("kafka.group")
+	if group == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			panic(err)
+		}
+		group = "search_" + hostname
+	}
+	return events.NewArticleEventConsumer(client, svc, group, l)
+}
+
+func NewConsumers(consumer *events.ArticleEventConsumer) []saramax.Consumer {
+	return []saramax.Consumer{consumer}
+}
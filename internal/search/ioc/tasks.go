@@ -0,0 +1,36 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+
+	cron2 "github.com/tsukiyo/mercury/internal/search/cron"
+	"github.com/tsukiyo/mercury/internal/search/service"
+	"github.com/tsukiyo/mercury/pkg/cronx"
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+func InitRebuildJob(svc service.SearchService, l logger.Logger) *cron2.RebuildJob {
+	return cron2.NewRebuildJob(svc, time.Minute*5, l)
+}
+
+func InitTasks(l logger.Logger, rebuild *cron2.RebuildJob) *cron.Cron {
+	croj := cron.New(cron.WithSeconds())
+	bdr := cronx.NewCronJobBuilder(prometheus.SummaryOpts{
+		Namespace: "lazywoo",
+		Subsystem: "mercury",
+		Name:      "cron_job",
+		Help:      "metrics cron job",
+	}, l)
+	job := bdr.Build(rebuild)
+	// @every 1h
+	_, err := croj.AddJob("0 0 */1 * * ?", job)
+	if err != nil {
+		panic(err)
+	}
+	// the index lives in memory, build it at startup instead of waiting for the first tick
+	go job.Run()
+	return croj
+}
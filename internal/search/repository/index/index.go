@@ -0,0 +1,216 @@
+package index
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// BM25 parameters
+	k1 = 1.2
+	b  = 0.75
+	// a term in the title counts as much as titleBoost terms in the content
+	titleBoost = 2
+)
+
+type Document struct {
+	Id       int64
+	AuthorId int64
+	Title    string
+	Content  string
+	Utime    time.Time
+}
+
+type Hit struct {
+	Document Document
+	Score    float64
+	// Terms of the query matched by the document
+	Terms []string
+}
+
+type document struct {
+	Document
+	length int
+	tfs    map[string]float64
+}
+
+// Index is an in-memory inverted index ranking documents with BM25.
+// Changes carry the utime of the article, an older change never overrides a newer one.
+type Index struct {
+	mu sync.RWMutex
+	// term -> document id -> weighted term frequency
+	postings map[string]map[int64]float64
+	docs     map[int64]*document
+	// utime of deleted documents, keeps a late upsert from bringing them back
+	tombstones map[int64]time.Time
+	totalLen   int
+}
+
+func New() *Index {
+	return &Index{
+		postings:   make(map[string]map[int64]float64),
+		docs:       make(map[int64]*document),
+		tombstones: make(map[int64]time.Time),
+	}
+}
+
+func (idx *Index) Upsert(doc Document) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.upsert(doc)
+}
+
+func (idx *Index) Delete(id int64, utime time.Time) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.delete(id, utime)
+}
+
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Search returns hits of the page ordered by score and the total number of matched documents.
+func (idx *Index) Search(query string, offset, limit int) ([]Hit, int) {
+	terms := Terms(query)
+	if len(terms) == 0 {
+		return nil, 0
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	n := float64(len(idx.docs))
+	if n == 0 {
+		return nil, 0
+	}
+	avgLen := float64(idx.totalLen) / n
+
+	scores := make(map[int64]*Hit)
+	for _, term := range terms {
+		posting := idx.postings[term]
+		if len(posting) == 0 {
+			continue
+		}
+		df := float64(len(posting))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range posting {
+			doc := idx.docs[id]
+			norm := k1 * (1 - b + b*float64(doc.length)/avgLen)
+			hit, ok := scores[id]
+			if !ok {
+				hit = &Hit{Document: doc.Document}
+				scores[id] = hit
+			}
+			hit.Score += idf * tf * (k1 + 1) / (tf + norm)
+			hit.Terms = append(hit.Terms, term)
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for _, hit := range scores {
+		hits = append(hits, *hit)
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].Document.Utime.Equal(hits[j].Document.Utime) {
+			return hits[i].Document.Utime.After(hits[j].Document.Utime)
+		}
+		return hits[i].Document.Id > hits[j].Document.Id
+	})
+	total := len(hits)
+	if offset < 0 || limit <= 0 || offset >= total {
+		return nil, total
+	}
+	return hits[offset:min(offset+limit, total)], total
+}
+
+// Rebuild replaces the documents with docs loaded since the given time,
+// changes applied to the index after that are kept.
+func (idx *Index) Rebuild(docs []Document, since time.Time) {
+	fresh := New()
+	for _, doc := range docs {
+		fresh.upsert(doc)
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for id, ts := range idx.tombstones {
+		if !ts.Before(since) {
+			fresh.delete(id, ts)
+		}
+	}
+	for _, doc := range idx.docs {
+		if !doc.Utime.Before(since) {
+			fresh.upsert(doc.Document)
+		}
+	}
+	idx.postings = fresh.postings
+	idx.docs = fresh.docs
+	idx.tombstones = fresh.tombstones
+	idx.totalLen = fresh.totalLen
+}
+
+func (idx *Index) upsert(doc Document) {
+	if ts, ok := idx.tombstones[doc.Id]; ok {
+		if !doc.Utime.After(ts) {
+			return
+		}
+		delete(idx.tombstones, doc.Id)
+	}
+	if old, ok := idx.docs[doc.Id]; ok {
+		if doc.Utime.Before(old.Utime) {
+			return
+		}
+		idx.remove(old)
+	}
+
+	d := &document{Document: doc, tfs: make(map[string]float64)}
+	for _, token := range Tokenize(doc.Title, true) {
+		d.tfs[token.Term] += titleBoost
+		d.length++
+	}
+	for _, token := range Tokenize(doc.Content, true) {
+		d.tfs[token.Term]++
+		d.length++
+	}
+	for term, tf := range d.tfs {
+		posting, ok := idx.postings[term]
+		if !ok {
+			posting = make(map[int64]float64)
+			idx.postings[term] = posting
+		}
+		posting[doc.Id] = tf
+	}
+	idx.docs[doc.Id] = d
+	idx.totalLen += d.length
+}
+
+func (idx *Index) delete(id int64, utime time.Time) {
+	if doc, ok := idx.docs[id]; ok {
+		if utime.Before(doc.Utime) {
+			return
+		}
+		idx.remove(doc)
+	}
+	if ts, ok := idx.tombstones[id]; !ok || utime.After(ts) {
+		idx.tombstones[id] = utime
+	}
+}
+
+func (idx *Index) remove(doc *document) {
+	for term := range doc.tfs {
+		posting := idx.postings[term]
+		delete(posting, doc.Id)
+		if len(posting) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	delete(idx.docs, doc.Id)
+	idx.totalLen -= doc.length
+}
@@ -0,0 +1,209 @@
+package index
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIndexSearch(t *testing.T) {
+	now := time.Now()
+	idx := New()
+	idx.Upsert(Document{Id: 1, Title: "golang", Content: "golang generics", Utime: now})
+	idx.Upsert(Document{Id: 2, Title: "rust", Content: "a note comparing golang with many other languages", Utime: now})
+	idx.Upsert(Document{Id: 3, Title: "python", Content: "nothing related", Utime: now})
+	idx.Upsert(Document{Id: 4, Title: "python", Content: "nothing related", Utime: now.Add(time.Second)})
+
+	testCases := []struct {
+		name      string
+		query     string
+		offset    int
+		limit     int
+		wantIds   []int64
+		wantTotal int
+	}{
+		{
+			name:      "title and frequency rank higher",
+			query:     "Golang",
+			limit:     10,
+			wantIds:   []int64{1, 2},
+			wantTotal: 2,
+		},
+		{
+			name:      "more terms matched rank higher",
+			query:     "rust golang",
+			limit:     10,
+			wantIds:   []int64{2, 1},
+			wantTotal: 2,
+		},
+		{
+			name:      "ties broken by utime",
+			query:     "python",
+			limit:     10,
+			wantIds:   []int64{4, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "page",
+			query:     "golang",
+			offset:    1,
+			limit:     1,
+			wantIds:   []int64{2},
+			wantTotal: 2,
+		},
+		{
+			name:      "page beyond",
+			query:     "golang",
+			offset:    2,
+			limit:     1,
+			wantTotal: 2,
+		},
+		{
+			name:  "no match",
+			query: "java",
+			limit: 10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, total := idx.Search(tc.query, tc.offset, tc.limit)
+			var ids []int64
+			for _, hit := range hits {
+				ids = append(ids, hit.Document.Id)
+			}
+			if !reflect.DeepEqual(ids, tc.wantIds) || total != tc.wantTotal {
+				t.Errorf("Search() = %v, %d, want %v, %d", ids, total, tc.wantIds, tc.wantTotal)
+			}
+		})
+	}
+}
+
+func TestIndexTombstone(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name    string
+		changes func(idx *Index)
+		wantIds []int64
+	}{
+		{
+			name: "late upsert after withdrawing",
+			changes: func(idx *Index) {
+				idx.Delete(1, now)
+				idx.Upsert(Document{Id: 1, Title: "golang", Utime: now.Add(-time.Second)})
+			},
+		},
+		{
+			name: "upsert as old as withdrawing",
+			changes: func(idx *Index) {
+				idx.Delete(1, now)
+				idx.Upsert(Document{Id: 1, Title: "golang", Utime: now})
+			},
+		},
+		{
+			name: "published again",
+			changes: func(idx *Index) {
+				idx.Delete(1, now)
+				idx.Upsert(Document{Id: 1, Title: "golang", Utime: now.Add(time.Second)})
+			},
+			wantIds: []int64{1},
+		},
+		{
+			name: "late withdrawing after publishing again",
+			changes: func(idx *Index) {
+				idx.Upsert(Document{Id: 1, Title: "golang", Utime: now})
+				idx.Delete(1, now.Add(-time.Second))
+			},
+			wantIds: []int64{1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			idx := New()
+			tc.changes(idx)
+			if got := ids(idx); !reflect.DeepEqual(got, tc.wantIds) {
+				t.Errorf("documents = %v, want %v", got, tc.wantIds)
+			}
+			hits, _ := idx.Search("golang", 0, 10)
+			if len(hits) != len(tc.wantIds) {
+				t.Errorf("Search() hits %d, want %d", len(hits), len(tc.wantIds))
+			}
+		})
+	}
+}
+
+func TestIndexTombstoneRacing(t *testing.T) {
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		idx := New()
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			idx.Delete(1, now)
+		}()
+		go func() {
+			defer wg.Done()
+			idx.Upsert(Document{Id: 1, Title: "golang", Utime: now.Add(-time.Second)})
+		}()
+		wg.Wait()
+		if n := idx.Len(); n != 0 {
+			t.Fatalf("Len() = %d after withdrawing, want 0", n)
+		}
+	}
+}
+
+func TestIndexRebuild(t *testing.T) {
+	since := time.Now()
+	before, after := since.Add(-time.Minute), since.Add(time.Second)
+
+	idx := New()
+	// withdrawn while the index was not listening
+	idx.Upsert(Document{Id: 1, Title: "gone", Utime: before})
+	// edited after loading started
+	idx.Upsert(Document{Id: 2, Title: "edited", Utime: after})
+	// withdrawn after loading started
+	idx.Delete(3, after)
+	// withdrawn long ago and published again
+	idx.Delete(5, before.Add(-time.Minute))
+
+	idx.Rebuild([]Document{
+		{Id: 2, Title: "loaded", Utime: before},
+		{Id: 3, Title: "loaded", Utime: before},
+		{Id: 4, Title: "loaded", Utime: before},
+		{Id: 5, Title: "loaded", Utime: before},
+	}, since)
+
+	if got, want := ids(idx), []int64{2, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("documents = %v, want %v", got, want)
+	}
+	if got := idx.docs[2].Title; got != "edited" {
+		t.Errorf("document 2 title = %q, want %q", got, "edited")
+	}
+	if _, ok := idx.tombstones[3]; !ok {
+		t.Error("the tombstone after loading started is dropped")
+	}
+	if _, ok := idx.tombstones[5]; ok {
+		t.Error("the tombstone before loading started is kept")
+	}
+	if hits, _ := idx.Search("gone", 0, 10); len(hits) != 0 {
+		t.Errorf("Search() found %d documents removed", len(hits))
+	}
+	total := 0
+	for _, doc := range idx.docs {
+		total += doc.length
+	}
+	if idx.totalLen != total {
+		t.Errorf("totalLen = %d, want %d", idx.totalLen, total)
+	}
+}
+
+func ids(idx *Index) []int64 {
+	var res []int64
+	for id := range idx.docs {
+		res = append(res, id)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
@@ -0,0 +1,89 @@
+package index
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	snippetRunes = 120
+	// runes kept before the first match
+	snippetLead = 20
+
+	highlightOpen  = "<em>"
+	highlightClose = "</em>"
+	ellipsis       = "..."
+)
+
+// Snippet returns the fragment of text around the first matched term with every matched term highlighted,
+// or the beginning of text if none of terms matched. The text is HTML escaped apart from the highlight tags.
+func Snippet(text string, terms []string) string {
+	wanted := make(map[string]struct{}, len(terms))
+	for _, term := range terms {
+		wanted[term] = struct{}{}
+	}
+	var matches []Token
+	for _, token := range Tokenize(text, true) {
+		if _, ok := wanted[token.Term]; ok {
+			matches = append(matches, token)
+		}
+	}
+
+	start := 0
+	if len(matches) > 0 {
+		start = moveBack(text, matches[0].Start, snippetLead)
+	}
+	end := moveForward(text, start, snippetRunes)
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString(ellipsis)
+	}
+	pos := start
+	for _, rg := range merge(matches, start, end) {
+		sb.WriteString(html.EscapeString(text[pos:rg.Start]))
+		sb.WriteString(highlightOpen)
+		sb.WriteString(html.EscapeString(text[rg.Start:rg.End]))
+		sb.WriteString(highlightClose)
+		pos = rg.End
+	}
+	sb.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		sb.WriteString(ellipsis)
+	}
+	return sb.String()
+}
+
+// merge clips the matches into [start, end) and joins the overlapping ones, CJK bigrams overlap each other.
+func merge(matches []Token, start, end int) []Token {
+	var res []Token
+	for _, m := range matches {
+		if m.Start < start || m.Start >= end {
+			continue
+		}
+		m.End = min(m.End, end)
+		if n := len(res); n > 0 && m.Start <= res[n-1].End {
+			res[n-1].End = max(res[n-1].End, m.End)
+			continue
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+func moveBack(text string, pos, runes int) int {
+	for ; runes > 0 && pos > 0; runes-- {
+		_, size := utf8.DecodeLastRuneInString(text[:pos])
+		pos -= size
+	}
+	return pos
+}
+
+func moveForward(text string, pos, runes int) int {
+	for ; runes > 0 && pos < len(text); runes-- {
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return pos
+}
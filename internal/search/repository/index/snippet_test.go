@@ -0,0 +1,54 @@
+package index
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	testCases := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{
+			name:  "no match escaped",
+			text:  "a < b",
+			terms: []string{"c"},
+			want:  "a &lt; b",
+		},
+		{
+			name:  "every match highlighted",
+			text:  "Go is fun, go on",
+			terms: []string{"go"},
+			want:  "<em>Go</em> is fun, <em>go</em> on",
+		},
+		{
+			name:  "overlapping bigrams merged",
+			text:  "我爱中文搜索",
+			terms: []string{"中文", "文搜"},
+			want:  "我爱<em>中文搜</em>索",
+		},
+		{
+			name:  "leading ellipsis",
+			text:  strings.Repeat("x", 30) + " go",
+			terms: []string{"go"},
+			want:  "..." + strings.Repeat("x", 19) + " <em>go</em>",
+		},
+		{
+			name:  "trailing ellipsis",
+			text:  "go " + strings.Repeat("y", 200),
+			terms: []string{"go"},
+			want:  "<em>go</em> " + strings.Repeat("y", 117) + "...",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Snippet(tc.text, tc.terms)
+			if got != tc.want {
+				t.Errorf("Snippet() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
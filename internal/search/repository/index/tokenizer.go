@@ -0,0 +1,98 @@
+package index
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a term with its byte range in the original text.
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize splits text into lower-cased Latin words and CJK bigrams.
+// A CJK run of a single character yields the character itself,
+// unigrams adds every CJK character as well so that single character queries could match.
+func Tokenize(text string, unigrams bool) []Token {
+	var (
+		tokens []Token
+		// start of the current latin word, -1 if not in a word
+		wordStart = -1
+		// byte offsets of runes in the current CJK run
+		run []int
+	)
+	flushWord := func(end int) {
+		if wordStart >= 0 {
+			tokens = append(tokens, Token{
+				Term:  strings.ToLower(text[wordStart:end]),
+				Start: wordStart,
+				End:   end,
+			})
+			wordStart = -1
+		}
+	}
+	flushRun := func(end int) {
+		if len(run) == 0 {
+			return
+		}
+		run = append(run, end)
+		n := len(run) - 1
+		if n == 1 {
+			tokens = append(tokens, Token{Term: text[run[0]:run[1]], Start: run[0], End: run[1]})
+		}
+		for i := 0; i+1 < n; i++ {
+			if unigrams {
+				tokens = append(tokens, Token{Term: text[run[i]:run[i+1]], Start: run[i], End: run[i+1]})
+			}
+			tokens = append(tokens, Token{Term: text[run[i]:run[i+2]], Start: run[i], End: run[i+2]})
+		}
+		if unigrams && n > 1 {
+			tokens = append(tokens, Token{Term: text[run[n-1]:run[n]], Start: run[n-1], End: run[n]})
+		}
+		run = run[:0]
+	}
+
+	for i, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord(i)
+			run = append(run, i)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushRun(i)
+			if wordStart < 0 {
+				wordStart = i
+			}
+		default:
+			flushWord(i)
+			flushRun(i)
+		}
+	}
+	flushWord(len(text))
+	flushRun(len(text))
+	return tokens
+}
+
+// Terms returns the distinct terms of the query in order of appearance.
+func Terms(query string) []string {
+	tokens := Tokenize(query, false)
+	terms := make([]string, 0, len(tokens))
+	seen := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token.Term]; ok {
+			continue
+		}
+		seen[token.Term] = struct{}{}
+		terms = append(terms, token.Term)
+	}
+	return terms
+}
+
+func isCJK(r rune) bool {
+	if r < utf8.RuneSelf {
+		return false
+	}
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
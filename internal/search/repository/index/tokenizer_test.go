@@ -0,0 +1,94 @@
+package index
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		unigrams bool
+		want     []Token
+	}{
+		{
+			name: "latin words lower-cased",
+			text: "Hello, World 42",
+			want: []Token{
+				{Term: "hello", Start: 0, End: 5},
+				{Term: "world", Start: 7, End: 12},
+				{Term: "42", Start: 13, End: 15},
+			},
+		},
+		{
+			name: "cjk bigrams",
+			text: "Go语言",
+			want: []Token{
+				{Term: "go", Start: 0, End: 2},
+				{Term: "语言", Start: 2, End: 8},
+			},
+		},
+		{
+			name: "single cjk character",
+			text: "中 文",
+			want: []Token{
+				{Term: "中", Start: 0, End: 3},
+				{Term: "文", Start: 4, End: 7},
+			},
+		},
+		{
+			name:     "cjk bigrams with unigrams",
+			text:     "中文搜索",
+			unigrams: true,
+			want: []Token{
+				{Term: "中", Start: 0, End: 3},
+				{Term: "中文", Start: 0, End: 6},
+				{Term: "文", Start: 3, End: 6},
+				{Term: "文搜", Start: 3, End: 9},
+				{Term: "搜", Start: 6, End: 9},
+				{Term: "搜索", Start: 6, End: 12},
+				{Term: "索", Start: 9, End: 12},
+			},
+		},
+		{
+			name: "punctuation only",
+			text: "... ，。",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Tokenize(tc.text, tc.unigrams)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Tokenize() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "distinct in order",
+			query: "go Go 语言语言",
+			want:  []string{"go", "语言", "言语"},
+		},
+		{
+			name:  "empty",
+			query: " ",
+			want:  []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Terms(tc.query)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Terms() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
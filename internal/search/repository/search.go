@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ecodeclub/ekit/slice"
+
+	"github.com/tsukiyo/mercury/internal/search/domain"
+	"github.com/tsukiyo/mercury/internal/search/repository/index"
+)
+
+type SearchRepository interface {
+	Upsert(ctx context.Context, atcl domain.Article) error
+	Delete(ctx context.Context, id int64, utime time.Time) error
+	Search(ctx context.Context, query string, offset, limit int) ([]domain.SearchHit, int, error)
+	// Rebuild replaces the index with articles loaded since the given time
+	Rebuild(ctx context.Context, atcls []domain.Article, since time.Time) error
+}
+
+var _ SearchRepository = (*IndexSearchRepository)(nil)
+
+// IndexSearchRepository keeps articles in the in-process inverted index,
+// every instance holds the full index on its own.
+type IndexSearchRepository struct {
+	idx *index.Index
+}
+
+func NewIndexSearchRepository(idx *index.Index) SearchRepository {
+	return &IndexSearchRepository{
+		idx: idx,
+	}
+}
+
+func (repo *IndexSearchRepository) Upsert(_ context.Context, atcl domain.Article) error {
+	repo.idx.Upsert(repo.domainToDocument(atcl))
+	return nil
+}
+
+func (repo *IndexSearchRepository) Delete(_ context.Context, id int64, utime time.Time) error {
+	repo.idx.Delete(id, utime)
+	return nil
+}
+
+func (repo *IndexSearchRepository) Search(_ context.Context, query string, offset, limit int) ([]domain.SearchHit, int, error) {
+	hits, total := repo.idx.Search(query, offset, limit)
+	return slice.Map[index.Hit, domain.SearchHit](hits, func(idx int, src index.Hit) domain.SearchHit {
+		return domain.SearchHit{
+			Article: domain.Article{
+				Id:       src.Document.Id,
+				Title:    src.Document.Title,
+				AuthorId: src.Document.AuthorId,
+				Utime:    src.Document.Utime,
+			},
+			Snippet: index.Snippet(src.Document.Content, src.Terms),
+			Score:   src.Score,
+		}
+	}), total, nil
+}
+
+func (repo *IndexSearchRepository) Rebuild(_ context.Context, atcls []domain.Article, since time.Time) error {
+	repo.idx.Rebuild(slice.Map[domain.Article, index.Document](atcls, func(idx int, src domain.Article) index.Document {
+		return repo.domainToDocument(src)
+	}), since)
+	return nil
+}
+
+func (repo *IndexSearchRepository) domainToDocument(atcl domain.Article) index.Document {
+	return index.Document{
+		Id:       atcl.Id,
+		AuthorId: atcl.AuthorId,
+		Title:    atcl.Title,
+		Content:  atcl.Content,
+		Utime:    atcl.Utime,
+	}
+}
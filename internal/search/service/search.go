@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	articlev1 "github.com/tsukiyo/mercury/api/gen/article/v1"
+
+	atclDomain "github.com/tsukiyo/mercury/internal/article/domain"
+	"github.com/tsukiyo/mercury/internal/search/domain"
+	"github.com/tsukiyo/mercury/internal/search/repository"
+)
+
+// maxLimit bounds the hits of a page
+const maxLimit = 100
+
+var ErrInvalidPage = errors.New("offset must not be negative and limit must be in [1, 100]")
+
+//go:generate mockgen -source=./search.go -package=svcmocks -destination=mocks/search.mock.go SearchService
+type SearchService interface {
+	// Search returns hits of the page and the total number of matched articles
+	Search(ctx context.Context, query string, offset, limit int) ([]domain.SearchHit, int, error)
+	Index(ctx context.Context, atcl domain.Article) error
+	Remove(ctx context.Context, id int64, utime time.Time) error
+	// Rebuild reloads the index from published articles and returns how many were indexed
+	Rebuild(ctx context.Context) (int, error)
+}
+
+var _ SearchService = (*searchService)(nil)
+
+type searchService struct {
+	atclCli   articlev1.ArticleServiceClient
+	repo      repository.SearchRepository
+	batchSize int
+}
+
+func NewSearchService(atclCli articlev1.ArticleServiceClient, repo repository.SearchRepository) SearchService {
+	return &searchService{
+		atclCli:   atclCli,
+		repo:      repo,
+		batchSize: 100,
+	}
+}
+
+func (svc *searchService) Search(ctx context.Context, query string, offset, limit int) ([]domain.SearchHit, int, error) {
+	if offset < 0 || limit <= 0 || limit > maxLimit {
+		return nil, 0, ErrInvalidPage
+	}
+	return svc.repo.Search(ctx, query, offset, limit)
+}
+
+func (svc *searchService) Index(ctx context.Context, atcl domain.Article) error {
+	return svc.repo.Upsert(ctx, atcl)
+}
+
+func (svc *searchService) Remove(ctx context.Context, id int64, utime time.Time) error {
+	return svc.repo.Delete(ctx, id, utime)
+}
+
+func (svc *searchService) Rebuild(ctx context.Context) (int, error) {
+	since := time.Now()
+	var atcls []domain.Article
+	cursor := ""
+	for {
+		resp, err := svc.atclCli.ListPub(ctx, &articlev1.ListPubRequest{
+			StartTime: timestamppb.New(since),
+			Cursor:    cursor,
+			Limit:     int32(svc.batchSize),
+		})
+		if err != nil {
+			return 0, err
+		}
+		for _, atcl := range resp.GetArticles() {
+			// the online library keeps withdrawn articles as well
+			if atcl.GetStatus() != int32(atclDomain.ArticleStatusPublished) {
+				continue
+			}
+			atcls = append(atcls, domain.Article{
+				Id:       atcl.GetId(),
+				Title:    atcl.GetTitle(),
+				Content:  atcl.GetContent(),
+				AuthorId: atcl.GetAuthor().GetId(),
+				Utime:    atcl.GetUtime().AsTime(),
+			})
+		}
+		if resp.GetNextCursor() == "" {
+			break
+		}
+		cursor = resp.GetNextCursor()
+	}
+	return len(atcls), svc.repo.Rebuild(ctx, atcls, since)
+}
@@ -0,0 +1,50 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+
+	"github.com/tsukiyo/mercury/internal/search/grpc"
+	"github.com/tsukiyo/mercury/internal/search/ioc"
+	"github.com/tsukiyo/mercury/internal/search/repository"
+	"github.com/tsukiyo/mercury/internal/search/repository/index"
+	"github.com/tsukiyo/mercury/internal/search/service"
+	"github.com/tsukiyo/mercury/pkg/app"
+)
+
+var thirdProviderSet = wire.NewSet(
+	ioc.InitLogger,
+	ioc.InitKafka,
+	ioc.InitEtcdClient,
+	ioc.InitArticleRpcClient,
+)
+
+var svcProviderSet = wire.NewSet(
+	service.NewSearchService,
+	repository.NewIndexSearchRepository,
+	index.New,
+)
+
+var eventsProviderSet = wire.NewSet(
+	ioc.InitArticleEventConsumer,
+	ioc.NewConsumers,
+)
+
+var cronProviderSet = wire.NewSet(
+	ioc.InitRebuildJob,
+	ioc.InitTasks,
+)
+
+func InitAPP() *app.App {
+	wire.Build(
+		thirdProviderSet,
+		svcProviderSet,
+		eventsProviderSet,
+		cronProviderSet,
+		grpc.NewSearchServiceServer,
+		ioc.InitGRPCxServer,
+		wire.Struct(new(app.App), "GRPCServer", "Consumers", "Cron"),
+	)
+	return new(app.App)
+}
@@ -0,0 +1,51 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/tsukiyo/mercury/internal/search/grpc"
+	"github.com/tsukiyo/mercury/internal/search/ioc"
+	"github.com/tsukiyo/mercury/internal/search/repository"
+	"github.com/tsukiyo/mercury/internal/search/repository/index"
+	"github.com/tsukiyo/mercury/internal/search/service"
+	"github.com/tsukiyo/mercury/pkg/app"
+)
+
+// Injectors from wire.go:
+
+func InitAPP() *app.App {
+	client := ioc.InitEtcdClient()
+	articleServiceClient := ioc.InitArticleRpcClient(client)
+	indexIndex := index.New()
+	searchRepository := repository.NewIndexSearchRepository(indexIndex)
+	searchService := service.NewSearchService(articleServiceClient, searchRepository)
+	searchServiceServer := grpc.NewSearchServiceServer(searchService)
+	logger := ioc.InitLogger()
+	server := ioc.InitGRPCxServer(searchServiceServer, logger)
+	saramaClient := ioc.InitKafka()
+	articleEventConsumer := ioc.InitArticleEventConsumer(saramaClient, searchService, logger)
+	v := ioc.NewConsumers(articleEventConsumer)
+	rebuildJob := ioc.InitRebuildJob(searchService, logger)
+	cron := ioc.InitTasks(logger, rebuildJob)
+	appApp := &app.App{
+		GRPCServer: server,
+		Consumers:  v,
+		Cron:       cron,
+	}
+	return appApp
+}
+
+// wire.go:
+
+var thirdProviderSet = wire.NewSet(ioc.InitLogger, ioc.InitKafka, ioc.InitEtcdClient, ioc.InitArticleRpcClient)
+
+var svcProviderSet = wire.NewSet(service.NewSearchService, repository.NewIndexSearchRepository, index.New)
+
+var eventsProviderSet = wire.NewSet(ioc.InitArticleEventConsumer, ioc.NewConsumers)
+
+var cronProviderSet = wire.NewSet(ioc.InitRebuildJob, ioc.InitTasks)
@@ -68,6 +68,22 @@ func (u *UserServiceServer) FindOrCreateByWechat(ctx context.Context, request *u
 	}, err
 }
 
+func (u *UserServiceServer) FindByNickNames(ctx context.Context, request *userv1.FindByNickNamesRequest) (*userv1.FindByNickNamesResponse, error) {
+	users, err := u.service.FindByNickNames(ctx, request.GetNickNames())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*userv1.User, 0, len(users))
+	for _, user := range users {
+		// only the public fields, the users are looked up by others
+		res = append(res, &userv1.User{
+			Id:       user.Id,
+			NickName: user.NickName,
+		})
+	}
+	return &userv1.FindByNickNamesResponse{Users: res}, nil
+}
+
 func convertToDomain(u *userv1.User) domain.User {
 	domainUser := domain.User{}
 	if u != nil {
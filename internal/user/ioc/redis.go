@@ -3,6 +3,8 @@ package ioc
 import (
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/pkg/redisx/hook/metrics"
 )
 
 func InitRedis() redis.Cmdable {
@@ -23,5 +25,7 @@ func InitRedis() redis.Cmdable {
 		Password: cfg.Password,
 		DB:       cfg.DB,
 	})
+	// hit and miss of the read-through caches are labelled by the hook
+	cmd.AddHook(metrics.NewPrometheusHook("lazywoo", "mercury", "instance-0", "redis_cmd"))
 	return cmd
 }
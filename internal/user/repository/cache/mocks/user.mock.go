@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	domain "github.com/tsukiyo/mercury/internal/user/domain"
+	cachex "github.com/tsukiyo/mercury/pkg/cachex"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,18 +56,18 @@ func (mr *MockUserCacheMockRecorder) Delete(ctx, id any) *gomock.Call {
 }
 
 // Get mocks base method.
-func (m *MockUserCache) Get(ctx context.Context, id int64) (domain.User, error) {
+func (m *MockUserCache) Get(ctx context.Context, id int64, load cachex.Loader[domain.User]) (domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret := m.ctrl.Call(m, "Get", ctx, id, load)
 	ret0, _ := ret[0].(domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockUserCacheMockRecorder) Get(ctx, id any) *gomock.Call {
+func (mr *MockUserCacheMockRecorder) Get(ctx, id, load any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserCache)(nil).Get), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserCache)(nil).Get), ctx, id, load)
 }
 
 // Set mocks base method.
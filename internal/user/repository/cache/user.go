@@ -2,11 +2,13 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/tsukiyo/mercury/internal/user/domain"
+	"github.com/tsukiyo/mercury/internal/user/repository/dao"
+	"github.com/tsukiyo/mercury/pkg/cachex"
+	"github.com/tsukiyo/mercury/pkg/logger"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -17,32 +19,23 @@ var _ UserCache = (*UserRedisCache)(nil)
 
 //go:generate mockgen -source=./user.go -package=cachemocks -destination=mocks/user.mock.go UserCache
 type UserCache interface {
-	Get(ctx context.Context, id int64) (domain.User, error)
+	// Get reads through the cache, load is called once for concurrent misses of the user,
+	// a missing user is cached for a short time and returned with dao.ErrUserNotFound.
+	Get(ctx context.Context, id int64, load cachex.Loader[domain.User]) (domain.User, error)
 	Set(ctx context.Context, u domain.User) error
 	Delete(ctx context.Context, id int64) error
 }
 
 type UserRedisCache struct {
-	client     redis.Cmdable
-	expiration time.Duration
+	users *cachex.ReadThrough[domain.User]
 }
 
-func (cache *UserRedisCache) Get(ctx context.Context, id int64) (domain.User, error) {
-	val, err := cache.client.Get(ctx, cache.key(id)).Bytes()
-	if err != nil {
-		return domain.User{}, err
-	}
-	var u domain.User
-	err = json.Unmarshal(val, &u)
-	return u, err
+func (cache *UserRedisCache) Get(ctx context.Context, id int64, load cachex.Loader[domain.User]) (domain.User, error) {
+	return cache.users.Get(ctx, cache.key(id), load)
 }
 
 func (cache *UserRedisCache) Set(ctx context.Context, u domain.User) error {
-	val, err := json.Marshal(u)
-	if err != nil {
-		return err
-	}
-	return cache.client.Set(ctx, cache.key(u.Id), val, cache.expiration).Err()
+	return cache.users.Set(ctx, cache.key(u.Id), u)
 }
 
 func (cache *UserRedisCache) key(id int64) string {
@@ -50,12 +43,17 @@ func (cache *UserRedisCache) key(id int64) string {
 }
 
 func (cache *UserRedisCache) Delete(ctx context.Context, id int64) error {
-	return cache.client.Del(ctx, cache.key(id)).Err()
+	return cache.users.Del(ctx, cache.key(id))
 }
 
-func NewUserRedisCache(client redis.Cmdable) UserCache {
+func NewUserRedisCache(client redis.Cmdable, l logger.Logger) UserCache {
 	return &UserRedisCache{
-		client:     client,
-		expiration: time.Second * 3,
+		users: cachex.NewReadThrough[domain.User](cachex.NewRedisStore[domain.User](client), "user_info", cachex.Options{
+			TTL:         time.Minute * 15,
+			Jitter:      time.Minute * 3,
+			NotFound:    dao.ErrUserNotFound,
+			NotFoundTTL: time.Second * 30,
+			Beta:        1,
+		}, l),
 	}
 }
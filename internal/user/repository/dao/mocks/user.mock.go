@@ -70,6 +70,21 @@ func (mr *MockUserDAOMockRecorder) FindById(ctx, uid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserDAO)(nil).FindById), ctx, uid)
 }
 
+// FindByNickNames mocks base method.
+func (m *MockUserDAO) FindByNickNames(ctx context.Context, nickNames []string) ([]dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNickNames", ctx, nickNames)
+	ret0, _ := ret[0].([]dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNickNames indicates an expected call of FindByNickNames.
+func (mr *MockUserDAOMockRecorder) FindByNickNames(ctx, nickNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNickNames", reflect.TypeOf((*MockUserDAO)(nil).FindByNickNames), ctx, nickNames)
+}
+
 // FindByPhone mocks base method.
 func (m *MockUserDAO) FindByPhone(ctx context.Context, phone string) (dao.User, error) {
 	m.ctrl.T.Helper()
@@ -25,6 +25,7 @@ type UserDAO interface {
 	FindById(ctx context.Context, uid int64) (User, error)
 	UpdateNonZeroFields(ctx context.Context, user User) error
 	FindByWechat(ctx context.Context, openID string) (User, error)
+	FindByNickNames(ctx context.Context, nickNames []string) ([]User, error)
 }
 
 type GORMUserDAO struct {
@@ -73,12 +74,18 @@ func (dao *GORMUserDAO) FindByWechat(ctx context.Context, openID string) (User,
 	return u, err
 }
 
+func (dao *GORMUserDAO) FindByNickNames(ctx context.Context, nickNames []string) ([]User, error) {
+	var users []User
+	err := dao.db.WithContext(ctx).Where("nick_name IN ?", nickNames).Find(&users).Error
+	return users, err
+}
+
 type User struct {
 	Id            int64 `gorm:"primaryKey,autoIncrement"`
 	Birthday      sql.NullInt64
 	Email         sql.NullString `gorm:"unique"`
 	Phone         sql.NullString `gorm:"unique"`
-	NickName      sql.NullString
+	NickName      sql.NullString `gorm:"index;type:varchar(64)"`
 	Password      string         `gorm:"not null"`
 	AboutMe       sql.NullString `gorm:"default:这个用户很懒什么都没有留下;type=varchar(1024)"`
 	WechatUnionID sql.NullString `gorm:"unique"`
@@ -84,6 +84,21 @@ func (mr *MockUserRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserRepository)(nil).FindById), ctx, id)
 }
 
+// FindByNickNames mocks base method.
+func (m *MockUserRepository) FindByNickNames(ctx context.Context, nickNames []string) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNickNames", ctx, nickNames)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNickNames indicates an expected call of FindByNickNames.
+func (mr *MockUserRepositoryMockRecorder) FindByNickNames(ctx, nickNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNickNames", reflect.TypeOf((*MockUserRepository)(nil).FindByNickNames), ctx, nickNames)
+}
+
 // FindByPhone mocks base method.
 func (m *MockUserRepository) FindByPhone(ctx context.Context, phone string) (domain.User, error) {
 	m.ctrl.T.Helper()
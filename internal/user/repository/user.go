@@ -3,7 +3,6 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"log"
 	"time"
 
 	"github.com/tsukiyo/mercury/internal/user/domain"
@@ -24,6 +23,7 @@ type UserRepository interface {
 	Update(ctx context.Context, user domain.User) error
 	FindById(ctx context.Context, id int64) (domain.User, error)
 	FindByWechat(ctx context.Context, openID string) (domain.User, error)
+	FindByNickNames(ctx context.Context, nickNames []string) ([]domain.User, error)
 }
 
 type CachedUserRepository struct {
@@ -67,30 +67,14 @@ func (r *CachedUserRepository) Update(ctx context.Context, user domain.User) err
 }
 
 func (r *CachedUserRepository) FindById(ctx context.Context, id int64) (domain.User, error) {
-	u, err := r.cache.Get(ctx, id)
-	if err == nil {
-		// 有数据
-		return u, nil
-	}
-	//if err == redis.ErrKeyNotExist {
-	//	// 无数据
-	//}
-	// 缓存出错
-	// TODO 数据库限流
-
-	ue, err := r.dao.FindById(ctx, id)
-	if err != nil {
-		return domain.User{}, err
-	}
-	u = r.entityToDomain(ue)
-
-	go func() {
-		err = r.cache.Set(ctx, u)
+	return r.cache.Get(ctx, id, func(ctx context.Context) (domain.User, error) {
+		// TODO 数据库限流
+		ue, err := r.dao.FindById(ctx, id)
 		if err != nil {
-			log.Printf("redis set failed! reason:%v \n", err)
+			return domain.User{}, err
 		}
-	}()
-	return u, err
+		return r.entityToDomain(ue), nil
+	})
 }
 
 func (r *CachedUserRepository) FindByWechat(ctx context.Context, openID string) (domain.User, error) {
@@ -101,6 +85,18 @@ func (r *CachedUserRepository) FindByWechat(ctx context.Context, openID string)
 	return r.entityToDomain(u), nil
 }
 
+func (r *CachedUserRepository) FindByNickNames(ctx context.Context, nickNames []string) ([]domain.User, error) {
+	users, err := r.dao.FindByNickNames(ctx, nickNames)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		res = append(res, r.entityToDomain(u))
+	}
+	return res, nil
+}
+
 func (r *CachedUserRepository) domainToEntity(user domain.User) dao.User {
 	return dao.User{
 		Id: user.Id,
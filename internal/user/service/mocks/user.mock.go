@@ -40,6 +40,21 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// FindByNickNames mocks base method.
+func (m *MockUserService) FindByNickNames(ctx context.Context, nickNames []string) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNickNames", ctx, nickNames)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNickNames indicates an expected call of FindByNickNames.
+func (mr *MockUserServiceMockRecorder) FindByNickNames(ctx, nickNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNickNames", reflect.TypeOf((*MockUserService)(nil).FindByNickNames), ctx, nickNames)
+}
+
 // FindOrCreate mocks base method.
 func (m *MockUserService) FindOrCreate(ctx context.Context, phone string) (domain.User, error) {
 	m.ctrl.T.Helper()
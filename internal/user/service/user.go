@@ -17,6 +17,9 @@ var (
 	ErrInvalidUserOrPassword = errors.New("incorrect account or password")
 )
 
+// maxNickNames bounds the nick names looked up at once
+const maxNickNames = 50
+
 var _ UserService = (*userService)(nil)
 
 //go:generate mockgen -source=./user.go -package=svcmocks -destination=mocks/user.mock.go UserService
@@ -27,6 +30,8 @@ type UserService interface {
 	Profile(ctx context.Context, uid int64) (domain.User, error)
 	FindOrCreate(ctx context.Context, phone string) (domain.User, error)
 	FindOrCreateByWechat(ctx context.Context, info domain.WechatInfo) (domain.User, error)
+	// FindByNickNames returns the users having the nick names, at most maxNickNames of them are looked up.
+	FindByNickNames(ctx context.Context, nickNames []string) ([]domain.User, error)
 }
 
 type userService struct {
@@ -108,3 +113,13 @@ func (svc *userService) FindOrCreateByWechat(ctx context.Context, info domain.We
 	}
 	return svc.repo.FindByWechat(ctx, info.OpenID)
 }
+
+func (svc *userService) FindByNickNames(ctx context.Context, nickNames []string) ([]domain.User, error) {
+	if len(nickNames) == 0 {
+		return []domain.User{}, nil
+	}
+	if len(nickNames) > maxNickNames {
+		nickNames = nickNames[:maxNickNames]
+	}
+	return svc.repo.FindByNickNames(ctx, nickNames)
+}
@@ -8,7 +8,6 @@ package main
 
 import (
 	"github.com/google/wire"
-
 	"github.com/tsukiyo/mercury/internal/user/grpc"
 	"github.com/tsukiyo/mercury/internal/user/ioc"
 	"github.com/tsukiyo/mercury/internal/user/repository"
@@ -25,7 +24,7 @@ func InitAPP() *app.App {
 	db := ioc.InitDB(logger)
 	userDAO := dao.NewGORMUserDAO(db)
 	cmdable := ioc.InitRedis()
-	userCache := cache.NewUserRedisCache(cmdable)
+	userCache := cache.NewUserRedisCache(cmdable, logger)
 	userRepository := repository.NewCachedUserRepository(userDAO, userCache)
 	userService := service.NewUserService(userRepository, logger)
 	userServiceServer := grpc.NewUserServiceServer(userService)
@@ -1,21 +1,42 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/robfig/cron/v3"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/tsukiyo/mercury/pkg/cronx"
 	"github.com/tsukiyo/mercury/pkg/ginx"
 	"github.com/tsukiyo/mercury/pkg/grpcx"
+	"github.com/tsukiyo/mercury/pkg/outbox"
 	"github.com/tsukiyo/mercury/pkg/saramax"
 )
 
 type App struct {
-	GRPCServer *grpcx.Server
-	WebServer  *ginx.Server
-	Consumers  []saramax.Consumer
-	Cron       *cron.Cron
+	GRPCServer  *grpcx.Server
+	WebServer   *ginx.Server
+	Consumers   []saramax.Consumer
+	Cron        *cron.Cron
+	Scheduler   *cronx.Scheduler
+	OutboxRelay *outbox.Relay
+	// Closers are closed in order on SIGINT or SIGTERM, once the gRPC server has finished the requests in flight.
+	Closers []Closer
+}
+
+// Closer releases what a component holds when the app stops, it should return by the time ctx is done.
+type Closer interface {
+	Close(ctx context.Context) error
 }
 
+// closeTimeout bounds the time all the Closers are given on shutdown.
+const closeTimeout = 10 * time.Second
+
 func (a *App) Run() error {
 	var eg errgroup.Group
 	if a.GRPCServer != nil {
@@ -45,5 +66,41 @@ func (a *App) Run() error {
 		})
 	}
 
-	return eg.Wait()
+	if a.Scheduler != nil {
+		eg.Go(func() error {
+			return a.Scheduler.Start(context.Background())
+		})
+	}
+
+	if a.OutboxRelay != nil {
+		eg.Go(func() error {
+			return a.OutboxRelay.Start(context.Background())
+		})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	done := make(chan error, 1)
+	go func() {
+		done <- eg.Wait()
+	}()
+	select {
+	case err := <-done:
+		return errors.Join(err, a.close())
+	case <-ctx.Done():
+		return a.close()
+	}
+}
+
+func (a *App) close() error {
+	var errs []error
+	if a.GRPCServer != nil {
+		errs = append(errs, a.GRPCServer.Close())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	for _, c := range a.Closers {
+		errs = append(errs, c.Close(ctx))
+	}
+	return errors.Join(errs...)
 }
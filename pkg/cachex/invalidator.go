@@ -0,0 +1,122 @@
+package cachex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// Invalidator broadcasts the keys changed on one instance through redis pub/sub,
+// so that every instance subscribed to the channel drops them from its local caches.
+// Messages are lost while an instance is disconnected, local caches have to expire soon enough.
+type Invalidator struct {
+	client  pubSub
+	channel string
+	// origin tells the messages of the instance itself apart, which it has handled already
+	origin string
+	l      logger.Logger
+
+	mu     sync.RWMutex
+	locals []interface{ Del(key string) }
+}
+
+// pubSub is what the invalidator uses of the redis client.
+type pubSub interface {
+	Publish(ctx context.Context, channel string, message any) *redis.IntCmd
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+}
+
+// ErrPubSubUnsupported the redis client is not able to subscribe.
+var ErrPubSubUnsupported = errors.New("the redis client does not support pub/sub")
+
+type invalidation struct {
+	Origin string `json:"origin"`
+	Key    string `json:"key"`
+}
+
+func NewInvalidator(client redis.UniversalClient, channel string, l logger.Logger) *Invalidator {
+	return newInvalidator(client, channel, l)
+}
+
+func newInvalidator(client pubSub, channel string, l logger.Logger) *Invalidator {
+	return &Invalidator{
+		client:  client,
+		channel: channel,
+		origin:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		l:       l,
+	}
+}
+
+// StartInvalidator creates the invalidator of the channel and subscribes to it in the background,
+// ErrPubSubUnsupported is returned if cmd is not a client able to subscribe.
+func StartInvalidator(cmd redis.Cmdable, channel string, l logger.Logger) (*Invalidator, error) {
+	client, ok := cmd.(redis.UniversalClient)
+	if !ok {
+		return nil, ErrPubSubUnsupported
+	}
+	inv := NewInvalidator(client, channel, l)
+	go inv.Subscribe(context.Background())
+	return inv, nil
+}
+
+// Register adds the local cache dropping the keys broadcast by other instances.
+func (inv *Invalidator) Register(local interface{ Del(key string) }) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.locals = append(inv.locals, local)
+}
+
+func (inv *Invalidator) Publish(ctx context.Context, key string) error {
+	bs, err := json.Marshal(invalidation{Origin: inv.origin, Key: key})
+	if err != nil {
+		return err
+	}
+	return inv.client.Publish(ctx, inv.channel, bs).Err()
+}
+
+// Subscribe drops the keys published by other instances from the registered caches until ctx is done,
+// the subscription reconnects by itself.
+func (inv *Invalidator) Subscribe(ctx context.Context) {
+	ps := inv.client.Subscribe(ctx, inv.channel)
+	defer ps.Close()
+	ch := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			inv.handle(msg.Payload)
+		}
+	}
+}
+
+// handle drops the key of the message unless the instance itself published it.
+func (inv *Invalidator) handle(payload string) {
+	var evt invalidation
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		inv.l.Error("invalid cache invalidation", logger.String("payload", payload), logger.Error(err))
+		return
+	}
+	if evt.Origin == inv.origin {
+		return
+	}
+	inv.drop(evt.Key)
+}
+
+func (inv *Invalidator) drop(key string) {
+	inv.mu.RLock()
+	defer inv.mu.RUnlock()
+	for _, local := range inv.locals {
+		local.Del(key)
+	}
+}
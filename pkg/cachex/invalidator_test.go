@@ -0,0 +1,90 @@
+package cachex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// fakePubSub keeps the messages published instead of sending them.
+type fakePubSub struct {
+	msgs []string
+}
+
+func (f *fakePubSub) Publish(ctx context.Context, channel string, message any) *redis.IntCmd {
+	f.msgs = append(f.msgs, string(message.([]byte)))
+	cmd := redis.NewIntCmd(ctx)
+	cmd.SetVal(1)
+	return cmd
+}
+
+func (f *fakePubSub) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return nil
+}
+
+func TestInvalidatorHandle(t *testing.T) {
+	ps := &fakePubSub{}
+	publisher := newInvalidator(ps, "ch", logger.NewNopLogger())
+	// another instance, which has the key cached
+	subscriber := newInvalidator(ps, "ch", logger.NewNopLogger())
+	subscriber.origin = publisher.origin + "-other"
+	local := NewLocalCache[string](1<<20, time.Minute)
+	subscriber.Register(local)
+
+	testCases := []struct {
+		name    string
+		payload func() string
+		wantHit bool
+	}{
+		{
+			name: "dropped by other instances",
+			payload: func() string {
+				if err := publisher.Publish(context.Background(), "k"); err != nil {
+					t.Fatalf("Publish() error = %v", err)
+				}
+				return ps.msgs[len(ps.msgs)-1]
+			},
+		},
+		{
+			name: "kept by the instance publishing",
+			payload: func() string {
+				if err := subscriber.Publish(context.Background(), "k"); err != nil {
+					t.Fatalf("Publish() error = %v", err)
+				}
+				return ps.msgs[len(ps.msgs)-1]
+			},
+			wantHit: true,
+		},
+		{
+			name: "invalid payload ignored",
+			payload: func() string {
+				return "not json"
+			},
+			wantHit: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := local.Set("k", "v"); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			subscriber.handle(tc.payload())
+			if _, ok := local.Get("k"); ok != tc.wantHit {
+				t.Errorf("Get() hit = %v, want %v", ok, tc.wantHit)
+			}
+		})
+	}
+}
+
+func TestStartInvalidatorUnsupported(t *testing.T) {
+	// a pipeline publishes but is not able to subscribe
+	pipe := redis.NewClient(&redis.Options{}).Pipeline()
+	_, err := StartInvalidator(pipe, "ch", logger.NewNopLogger())
+	if err != ErrPubSubUnsupported {
+		t.Errorf("StartInvalidator() error = %v, want %v", err, ErrPubSubUnsupported)
+	}
+}
@@ -0,0 +1,48 @@
+package cachex
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/coocood/freecache"
+)
+
+// LocalCache keeps the values in the process as json, freecache evicts the least recently used ones
+// once the size is reached, and keeps them off the heap scanned by the garbage collector.
+type LocalCache[T any] struct {
+	cache *freecache.Cache
+	ttl   time.Duration
+}
+
+// NewLocalCache creates the cache of size bytes, at least 512KB, whose values live for ttl, at least a second.
+func NewLocalCache[T any](size int, ttl time.Duration) *LocalCache[T] {
+	return &LocalCache[T]{
+		cache: freecache.NewCache(size),
+		ttl:   max(ttl, time.Second),
+	}
+}
+
+func (c *LocalCache[T]) Get(key string) (T, bool) {
+	var val T
+	bs, err := c.cache.Get([]byte(key))
+	if err != nil {
+		return val, false
+	}
+	if err = json.Unmarshal(bs, &val); err != nil {
+		return val, false
+	}
+	return val, true
+}
+
+// Set returns freecache.ErrLargeEntry if the value takes more than 1/1024 of the size.
+func (c *LocalCache[T]) Set(key string, val T) error {
+	bs, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return c.cache.Set([]byte(key), bs, int(c.ttl/time.Second))
+}
+
+func (c *LocalCache[T]) Del(key string) {
+	c.cache.Del([]byte(key))
+}
@@ -0,0 +1,50 @@
+package cachex
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coocood/freecache"
+)
+
+type article struct {
+	Id    int64
+	Title string
+	Tags  []string
+}
+
+func TestLocalCache(t *testing.T) {
+	c := NewLocalCache[article](1<<20, time.Minute)
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get() of a missing key hit")
+	}
+
+	want := article{Id: 1, Title: "title", Tags: []string{"go"}}
+	if err := c.Set("a", want); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, ok := c.Get("a")
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %v, %v, want %v, true", got, ok, want)
+	}
+
+	c.Del("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get() of a deleted key hit")
+	}
+}
+
+func TestLocalCacheLargeEntry(t *testing.T) {
+	// an entry takes 1/1024 of the size at most
+	c := NewLocalCache[string](1<<20, time.Minute)
+	err := c.Set("large", strings.Repeat("x", 2<<10))
+	if !errors.Is(err, freecache.ErrLargeEntry) {
+		t.Errorf("Set() error = %v, want %v", err, freecache.ErrLargeEntry)
+	}
+	if _, ok := c.Get("large"); ok {
+		t.Error("Get() of an entry failed to be set hit")
+	}
+}
@@ -0,0 +1,157 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+	"github.com/tsukiyo/mercury/pkg/redisx/hook/metrics"
+)
+
+var _ Tier[any] = (*ReadThrough[any])(nil)
+
+// ReadThrough loads values from the storage on cache misses and keeps them in the store.
+// Concurrent misses of a key in one process load it once, and a value is refreshed in the background
+// with a probability rising as it gets close to expiry, so hot keys rarely expire under load.
+type ReadThrough[T any] struct {
+	store Store[T]
+	// name labels the reads in the metrics of the redis hook
+	name  string
+	opts  Options
+	group singleflight.Group
+	l     logger.Logger
+}
+
+func NewReadThrough[T any](store Store[T], name string, opts Options, l logger.Logger) *ReadThrough[T] {
+	if opts.LoadTimeout <= 0 {
+		opts.LoadTimeout = 3 * time.Second
+	}
+	return &ReadThrough[T]{
+		store: store,
+		name:  name,
+		opts:  opts,
+		l:     l,
+	}
+}
+
+// Get returns the cached value of the key, or the value loaded by load on a miss.
+// A missing value cached before is returned with Options.NotFound.
+func (rt *ReadThrough[T]) Get(ctx context.Context, key string, load Loader[T]) (T, error) {
+	e, err := rt.store.Get(metrics.WithCache(ctx, rt.name), key)
+	if err == nil {
+		if rt.expiring(e) {
+			rt.refresh(key, load)
+		}
+		if e.NotFound {
+			return e.Val, rt.opts.NotFound
+		}
+		return e.Val, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		rt.l.Error("read cache failed, loading from the storage",
+			logger.String("cache", rt.name),
+			logger.String("key", key),
+			logger.Error(err),
+		)
+	}
+	ch := rt.group.DoChan(key, func() (any, error) {
+		// shared by the callers missing together, one of them leaving does not fail the others
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rt.opts.LoadTimeout)
+		defer cancel()
+		return rt.load(ctx, key, load)
+	})
+	select {
+	case res := <-ch:
+		val, _ := res.Val.(T)
+		return val, res.Err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Set caches the value written to the storage, it expires without being refreshed early.
+func (rt *ReadThrough[T]) Set(ctx context.Context, key string, val T) error {
+	ttl := rt.opts.TTL + rt.jitter()
+	return rt.store.Set(ctx, key, Entry[T]{
+		Val:    val,
+		Expiry: time.Now().Add(ttl).UnixMilli(),
+	}, ttl)
+}
+
+func (rt *ReadThrough[T]) Del(ctx context.Context, key string) error {
+	return rt.store.Del(ctx, key)
+}
+
+func (rt *ReadThrough[T]) load(ctx context.Context, key string, load Loader[T]) (T, error) {
+	start := time.Now()
+	val, err := load(ctx)
+	e := Entry[T]{
+		Val:   val,
+		Delta: time.Since(start).Milliseconds(),
+	}
+	ttl := rt.opts.TTL
+	if err != nil {
+		if !rt.cachesNotFound() || !errors.Is(err, rt.opts.NotFound) {
+			return val, err
+		}
+		var zero T
+		e.Val, e.NotFound, ttl = zero, true, rt.opts.NotFoundTTL
+	}
+	ttl += rt.jitter()
+	e.Expiry = time.Now().Add(ttl).UnixMilli()
+	if er := rt.store.Set(ctx, key, e, ttl); er != nil {
+		rt.l.Error("write cache failed",
+			logger.String("cache", rt.name),
+			logger.String("key", key),
+			logger.Error(er),
+		)
+	}
+	return val, err
+}
+
+// refresh loads the value in the background, joining the flight loading the key if any.
+func (rt *ReadThrough[T]) refresh(key string, load Loader[T]) {
+	// the result is buffered by the channel, nobody waits for it
+	rt.group.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), rt.opts.LoadTimeout)
+		defer cancel()
+		val, err := rt.load(ctx, key, load)
+		if err != nil && !errors.Is(err, rt.opts.NotFound) {
+			rt.l.Error("refresh cache failed",
+				logger.String("cache", rt.name),
+				logger.String("key", key),
+				logger.Error(err),
+			)
+		}
+		return val, err
+	})
+}
+
+// expiring decides whether to refresh the entry early, the probability rises exponentially
+// as the expiry gets closer and the slower the value is to load, see "Optimal Probabilistic Cache Stampede Prevention".
+func (rt *ReadThrough[T]) expiring(e Entry[T]) bool {
+	if rt.opts.Beta <= 0 || e.Delta <= 0 {
+		return false
+	}
+	// 1 - rand.Float64() is in (0, 1], keeping the logarithm finite
+	gap := -float64(e.Delta) * rt.opts.Beta * math.Log(1-rand.Float64())
+	return time.Now().UnixMilli()+int64(gap) >= e.Expiry
+}
+
+func (rt *ReadThrough[T]) jitter() time.Duration {
+	if rt.opts.Jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(rt.opts.Jitter)))
+}
+
+func (rt *ReadThrough[T]) cachesNotFound() bool {
+	return rt.opts.NotFound != nil && rt.opts.NotFoundTTL > 0
+}
@@ -0,0 +1,301 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// memStore keeps the entries in memory along with the ttl they were set with.
+type memStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry[string]
+	ttls    map[string]time.Duration
+	gets    atomic.Int64
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		entries: make(map[string]Entry[string]),
+		ttls:    make(map[string]time.Duration),
+	}
+}
+
+func (s *memStore) Get(ctx context.Context, key string) (Entry[string], error) {
+	s.gets.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return e, redis.Nil
+	}
+	return e, nil
+}
+
+func (s *memStore) Set(ctx context.Context, key string, e Entry[string], ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = e
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *memStore) Del(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *memStore) entry(key string) (Entry[string], time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	return e, s.ttls[key], ok
+}
+
+var errNotFound = errors.New("not found")
+
+func TestReadThroughMergesMisses(t *testing.T) {
+	store := newMemStore()
+	rt := NewReadThrough[string](store, "test", Options{TTL: time.Minute}, logger.NewNopLogger())
+	var loads atomic.Int64
+	release := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		loads.Add(1)
+		<-release
+		return "v", nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	vals := make([]string, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vals[i], errs[i] = rt.Get(context.Background(), "k", load)
+		}()
+	}
+	// every caller has missed before the load finishes
+	for store.gets.Load() < callers {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Errorf("loaded %d times, want once", n)
+	}
+	for i := range vals {
+		if vals[i] != "v" || errs[i] != nil {
+			t.Errorf("Get() = %q, %v, want %q, nil", vals[i], errs[i], "v")
+		}
+	}
+}
+
+func TestReadThroughCallerLeavingShared(t *testing.T) {
+	store := newMemStore()
+	rt := NewReadThrough[string](store, "test", Options{TTL: time.Minute}, logger.NewNopLogger())
+	started, release := make(chan struct{}), make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		close(started)
+		select {
+		case <-release:
+			return "v", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := rt.Get(ctx, "k", load)
+		first <- err
+	}()
+	<-started
+	second := make(chan string, 1)
+	go func() {
+		val, _ := rt.Get(context.Background(), "k", load)
+		second <- val
+	}()
+	for store.gets.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Get() of the caller leaving error = %v, want %v", err, context.Canceled)
+	}
+	close(release)
+	if val := <-second; val != "v" {
+		t.Errorf("Get() of the caller waiting = %q, want %q", val, "v")
+	}
+	if _, _, ok := store.entry("k"); !ok {
+		t.Error("the value loaded is not cached")
+	}
+}
+
+func TestReadThroughNotFound(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts Options
+		// wantLoads after getting the missing key twice
+		wantLoads int
+	}{
+		{
+			name:      "cached",
+			opts:      Options{TTL: time.Minute, NotFound: errNotFound, NotFoundTTL: time.Second * 5},
+			wantLoads: 1,
+		},
+		{
+			name:      "not cached without a ttl",
+			opts:      Options{TTL: time.Minute, NotFound: errNotFound},
+			wantLoads: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newMemStore()
+			rt := NewReadThrough[string](store, "test", tc.opts, logger.NewNopLogger())
+			loads := 0
+			load := func(ctx context.Context) (string, error) {
+				loads++
+				return "", errNotFound
+			}
+			for i := 0; i < 2; i++ {
+				if _, err := rt.Get(context.Background(), "k", load); !errors.Is(err, errNotFound) {
+					t.Fatalf("Get() error = %v, want %v", err, errNotFound)
+				}
+			}
+			if loads != tc.wantLoads {
+				t.Errorf("loaded %d times, want %d", loads, tc.wantLoads)
+			}
+			if e, ttl, ok := store.entry("k"); ok && (!e.NotFound || ttl != tc.opts.NotFoundTTL) {
+				t.Errorf("cached %+v for %v, want a missing value for %v", e, ttl, tc.opts.NotFoundTTL)
+			}
+		})
+	}
+}
+
+func TestReadThroughOtherErrorsNotCached(t *testing.T) {
+	store := newMemStore()
+	rt := NewReadThrough[string](store, "test",
+		Options{TTL: time.Minute, NotFound: errNotFound, NotFoundTTL: time.Second}, logger.NewNopLogger())
+	errDB := errors.New("db down")
+	_, err := rt.Get(context.Background(), "k", func(ctx context.Context) (string, error) {
+		return "", errDB
+	})
+	if !errors.Is(err, errDB) {
+		t.Fatalf("Get() error = %v, want %v", err, errDB)
+	}
+	if _, _, ok := store.entry("k"); ok {
+		t.Error("a failed load is cached")
+	}
+}
+
+func TestReadThroughEarlyRefresh(t *testing.T) {
+	testCases := []struct {
+		name        string
+		beta        float64
+		entry       Entry[string]
+		wantRefresh bool
+	}{
+		{
+			name: "expiring",
+			beta: 1,
+			entry: Entry[string]{
+				Val:    "old",
+				Delta:  100,
+				Expiry: time.Now().UnixMilli(),
+			},
+			wantRefresh: true,
+		},
+		{
+			name: "far from expiry",
+			beta: 1,
+			entry: Entry[string]{
+				Val:    "old",
+				Delta:  1,
+				Expiry: time.Now().Add(time.Hour).UnixMilli(),
+			},
+		},
+		{
+			name: "never refreshed early without beta",
+			entry: Entry[string]{
+				Val:    "old",
+				Delta:  100,
+				Expiry: time.Now().UnixMilli(),
+			},
+		},
+		{
+			name: "set without loading",
+			beta: 1,
+			entry: Entry[string]{
+				Val:    "old",
+				Expiry: time.Now().UnixMilli(),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newMemStore()
+			_ = store.Set(context.Background(), "k", tc.entry, time.Minute)
+			rt := NewReadThrough[string](store, "test", Options{TTL: time.Minute, Beta: tc.beta}, logger.NewNopLogger())
+			refreshed := make(chan struct{}, 1)
+			got, err := rt.Get(context.Background(), "k", func(ctx context.Context) (string, error) {
+				refreshed <- struct{}{}
+				return "new", nil
+			})
+			// the cached value is returned while refreshing
+			if err != nil || got != "old" {
+				t.Fatalf("Get() = %q, %v, want %q, nil", got, err, "old")
+			}
+			select {
+			case <-refreshed:
+				if !tc.wantRefresh {
+					t.Error("refreshed early")
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tc.wantRefresh {
+					t.Error("not refreshed early")
+				}
+			}
+		})
+	}
+}
+
+func TestReadThroughJitter(t *testing.T) {
+	store := newMemStore()
+	opts := Options{TTL: time.Minute, Jitter: 10 * time.Second}
+	rt := NewReadThrough[string](store, "test", opts, logger.NewNopLogger())
+	seen := make(map[time.Duration]struct{})
+	for i := 0; i < 20; i++ {
+		key := string(rune('a' + i))
+		if err := rt.Set(context.Background(), key, "v"); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		e, ttl, _ := store.entry(key)
+		if ttl < opts.TTL || ttl >= opts.TTL+opts.Jitter {
+			t.Errorf("ttl = %v, want in [%v, %v)", ttl, opts.TTL, opts.TTL+opts.Jitter)
+		}
+		if exp := time.UnixMilli(e.Expiry); time.Until(exp) > ttl {
+			t.Errorf("expiry %v is later than the ttl %v", exp, ttl)
+		}
+		seen[ttl] = struct{}{}
+	}
+	if len(seen) < 2 {
+		t.Error("the keys set together expire together")
+	}
+}
@@ -0,0 +1,44 @@
+package cachex
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var _ Store[any] = (*RedisStore[any])(nil)
+
+// RedisStore keeps the entries as json strings.
+type RedisStore[T any] struct {
+	client redis.Cmdable
+}
+
+func NewRedisStore[T any](client redis.Cmdable) *RedisStore[T] {
+	return &RedisStore[T]{
+		client: client,
+	}
+}
+
+func (s *RedisStore[T]) Get(ctx context.Context, key string) (Entry[T], error) {
+	var e Entry[T]
+	bs, err := s.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return e, err
+	}
+	err = json.Unmarshal(bs, &e)
+	return e, err
+}
+
+func (s *RedisStore[T]) Set(ctx context.Context, key string, e Entry[T], ttl time.Duration) error {
+	bs, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, key, bs, ttl).Err()
+}
+
+func (s *RedisStore[T]) Del(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
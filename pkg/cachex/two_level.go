@@ -0,0 +1,74 @@
+package cachex
+
+import (
+	"context"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+var _ Tier[any] = (*TwoLevel[any])(nil)
+
+// TwoLevel reads the local cache before the remote tier, values are kept locally once read.
+// Changes are broadcast by the invalidator, every other instance drops the key from its local cache.
+type TwoLevel[T any] struct {
+	local *LocalCache[T]
+	// remote is nil if the values are loaded from the storage directly on local misses
+	remote Tier[T]
+	inv    *Invalidator
+	l      logger.Logger
+}
+
+func NewTwoLevel[T any](local *LocalCache[T], remote Tier[T], inv *Invalidator, l logger.Logger) *TwoLevel[T] {
+	inv.Register(local)
+	return &TwoLevel[T]{
+		local:  local,
+		remote: remote,
+		inv:    inv,
+		l:      l,
+	}
+}
+
+func (c *TwoLevel[T]) Get(ctx context.Context, key string, load Loader[T]) (T, error) {
+	if val, ok := c.local.Get(key); ok {
+		return val, nil
+	}
+	var (
+		val T
+		err error
+	)
+	if c.remote == nil {
+		val, err = load(ctx)
+	} else {
+		val, err = c.remote.Get(ctx, key, load)
+	}
+	if err != nil {
+		return val, err
+	}
+	if er := c.local.Set(key, val); er != nil {
+		c.l.Warn("write local cache failed", logger.String("key", key), logger.Error(er))
+	}
+	return val, nil
+}
+
+// Set keeps the value in both tiers, other instances drop their local copies and read the remote tier again.
+func (c *TwoLevel[T]) Set(ctx context.Context, key string, val T) error {
+	if c.remote != nil {
+		if err := c.remote.Set(ctx, key, val); err != nil {
+			return err
+		}
+	}
+	if err := c.local.Set(key, val); err != nil {
+		c.l.Warn("write local cache failed", logger.String("key", key), logger.Error(err))
+	}
+	return c.inv.Publish(ctx, key)
+}
+
+func (c *TwoLevel[T]) Del(ctx context.Context, key string) error {
+	c.local.Del(key)
+	if c.remote != nil {
+		if err := c.remote.Del(ctx, key); err != nil {
+			return err
+		}
+	}
+	return c.inv.Publish(ctx, key)
+}
@@ -0,0 +1,130 @@
+package cachex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// mapTier is a remote tier in memory.
+type mapTier struct {
+	vals  map[string]string
+	loads int
+}
+
+func (m *mapTier) Get(ctx context.Context, key string, load Loader[string]) (string, error) {
+	if val, ok := m.vals[key]; ok {
+		return val, nil
+	}
+	m.loads++
+	val, err := load(ctx)
+	if err != nil {
+		return "", err
+	}
+	m.vals[key] = val
+	return val, nil
+}
+
+func (m *mapTier) Set(ctx context.Context, key string, val string) error {
+	m.vals[key] = val
+	return nil
+}
+
+func (m *mapTier) Del(ctx context.Context, key string) error {
+	delete(m.vals, key)
+	return nil
+}
+
+func newTestTwoLevel(remote Tier[string]) (*TwoLevel[string], *fakePubSub) {
+	ps := &fakePubSub{}
+	inv := newInvalidator(ps, "ch", logger.NewNopLogger())
+	return NewTwoLevel[string](NewLocalCache[string](1<<20, time.Minute), remote, inv, logger.NewNopLogger()), ps
+}
+
+func TestTwoLevelGet(t *testing.T) {
+	remote := &mapTier{vals: map[string]string{"cached": "remote"}}
+	c, _ := newTestTwoLevel(remote)
+	loads := 0
+	load := func(ctx context.Context) (string, error) {
+		loads++
+		return "loaded", nil
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := c.Get(context.Background(), "k", load)
+		if err != nil || got != "loaded" {
+			t.Fatalf("Get() = %q, %v, want %q, nil", got, err, "loaded")
+		}
+	}
+	if loads != 1 || remote.loads != 1 {
+		t.Errorf("loaded %d times through %d remote misses, want once", loads, remote.loads)
+	}
+
+	// read from the remote tier on a local miss, and locally afterwards
+	got, err := c.Get(context.Background(), "cached", load)
+	if err != nil || got != "remote" {
+		t.Fatalf("Get() = %q, %v, want %q, nil", got, err, "remote")
+	}
+	remote.vals["cached"] = "changed"
+	if got, _ = c.Get(context.Background(), "cached", load); got != "remote" {
+		t.Errorf("Get() = %q, want the local copy %q", got, "remote")
+	}
+}
+
+func TestTwoLevelGetWithoutRemote(t *testing.T) {
+	c, _ := newTestTwoLevel(nil)
+	errLoad := errors.New("load failed")
+	_, err := c.Get(context.Background(), "k", func(ctx context.Context) (string, error) {
+		return "", errLoad
+	})
+	if !errors.Is(err, errLoad) {
+		t.Fatalf("Get() error = %v, want %v", err, errLoad)
+	}
+	// the error is not cached
+	got, err := c.Get(context.Background(), "k", func(ctx context.Context) (string, error) {
+		return "v", nil
+	})
+	if err != nil || got != "v" {
+		t.Errorf("Get() = %q, %v, want %q, nil", got, err, "v")
+	}
+}
+
+func TestTwoLevelChangesPublished(t *testing.T) {
+	remote := &mapTier{vals: map[string]string{}}
+	c, ps := newTestTwoLevel(remote)
+	noLoad := func(ctx context.Context) (string, error) {
+		t.Fatal("loaded a value set")
+		return "", nil
+	}
+
+	if err := c.Set(context.Background(), "k", "v"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, _ := c.Get(context.Background(), "k", noLoad); got != "v" || remote.vals["k"] != "v" {
+		t.Errorf("Get() = %q and remote %q after Set, want %q", got, remote.vals["k"], "v")
+	}
+
+	if err := c.Del(context.Background(), "k"); err != nil {
+		t.Fatalf("Del() error = %v", err)
+	}
+	if _, ok := remote.vals["k"]; ok {
+		t.Error("the remote tier keeps the key deleted")
+	}
+	if _, ok := c.local.Get("k"); ok {
+		t.Error("the local cache keeps the key deleted")
+	}
+
+	if len(ps.msgs) != 2 {
+		t.Fatalf("published %d invalidations, want 2", len(ps.msgs))
+	}
+	for _, msg := range ps.msgs {
+		var evt invalidation
+		if err := json.Unmarshal([]byte(msg), &evt); err != nil || evt.Key != "k" {
+			t.Errorf("published %s, want the invalidation of %q", msg, "k")
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Package cachex reads through the cache in front of slower storage.
+package cachex
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is a value kept in the cache along with what is needed to refresh it early.
+type Entry[T any] struct {
+	Val T `json:"val"`
+	// NotFound the value is missing from the storage, Val is the zero value
+	NotFound bool `json:"not_found,omitempty"`
+	// Delta how long loading the value took in milliseconds, zero if it is set without loading
+	Delta int64 `json:"delta,omitempty"`
+	// Expiry unix timestamp in milliseconds of when the entry expires
+	Expiry int64 `json:"expiry"`
+}
+
+// Store keeps the entries, Get returns redis.Nil if the key is missing.
+type Store[T any] interface {
+	Get(ctx context.Context, key string) (Entry[T], error)
+	Set(ctx context.Context, key string, e Entry[T], ttl time.Duration) error
+	Del(ctx context.Context, key string) error
+}
+
+// Loader loads the value from the storage when the cache misses.
+type Loader[T any] func(ctx context.Context) (T, error)
+
+// Tier is a cache reading through to the storage, ReadThrough is one.
+type Tier[T any] interface {
+	Get(ctx context.Context, key string, load Loader[T]) (T, error)
+	Set(ctx context.Context, key string, val T) error
+	Del(ctx context.Context, key string) error
+}
+
+type Options struct {
+	// TTL of the values, each key lives up to Jitter longer at random so that keys set together do not expire together.
+	TTL    time.Duration
+	Jitter time.Duration
+	// NotFound is the error of loading a missing value, which is cached for NotFoundTTL.
+	// Missing values are not cached if either is zero.
+	NotFound    error
+	NotFoundTTL time.Duration
+	// Beta scales how early values are refreshed before they expire, 1 is usually enough,
+	// the larger the earlier, zero never refreshes early.
+	Beta float64
+	// LoadTimeout bounds the loads shared by the callers missing together and the refreshes in the background,
+	// three seconds by default.
+	LoadTimeout time.Duration
+}
@@ -61,6 +61,7 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		cancel()
 		if err != nil {
 			s.l.Error("preempt task failed", logger.Error(err))
+			s.limiter.Release(1)
 			time.Sleep(s.interval)
 			continue
 		}
@@ -69,6 +70,10 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		executor, ok := s.executors[tsk.Executor]
 		if !ok {
 			s.l.Error(fmt.Sprintf("unknown executor or unregisterd: %v", tsk.Executor))
+			s.limiter.Release(1)
+			if tsk.CancelFunc != nil {
+				tsk.CancelFunc()
+			}
 			continue
 		}
 
@@ -0,0 +1,14 @@
+package cursorx
+
+import (
+	"github.com/spf13/viper"
+)
+
+// InitCodec builds the codec signing with "cursor.key" of the config, every service paging by cursors shares it.
+func InitCodec() *Codec {
+	key := viper.GetString("cursor.key")
+	if key == "" {
+		panic("cursor.key is required to sign cursors")
+	}
+	return NewCodec([]byte(key))
+}
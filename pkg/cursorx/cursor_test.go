@@ -0,0 +1,149 @@
+package cursorx
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		cur  Cursor
+	}{
+		{
+			name: "utime and id",
+			cur:  Cursor{Utime: time.UnixMilli(1700000000123), Id: 42},
+		},
+		{
+			name: "id alone",
+			cur:  Cursor{Id: 1 << 62},
+		},
+		{
+			name: "negative id",
+			cur:  Cursor{Id: -7},
+		},
+		{
+			name: "zero",
+		},
+	}
+	c := NewCodec([]byte("key"))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.Decode("scope", c.Encode("scope", tc.cur))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !got.Utime.Equal(tc.cur.Utime) || got.Id != tc.cur.Id {
+				t.Errorf("Decode() = %v, want %v", got, tc.cur)
+			}
+		})
+	}
+}
+
+func TestCodecDecodeEmpty(t *testing.T) {
+	got, err := NewCodec([]byte("key")).Decode("scope", "")
+	if err != nil || !reflect.DeepEqual(got, Cursor{}) {
+		t.Errorf("Decode() = %v, %v, want the zero cursor", got, err)
+	}
+}
+
+func TestCodecDecodeInvalid(t *testing.T) {
+	c := NewCodec([]byte("key"))
+	token := c.Encode("scope", Cursor{Utime: time.UnixMilli(1700000000123), Id: 42})
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	tamper := func(i int) string {
+		b := append([]byte(nil), raw...)
+		b[i] ^= 1
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	testCases := []struct {
+		name  string
+		codec *Codec
+		scope string
+		token string
+	}{
+		{
+			name:  "tampered payload",
+			codec: c,
+			scope: "scope",
+			token: tamper(0),
+		},
+		{
+			name:  "tampered mac",
+			codec: c,
+			scope: "scope",
+			token: tamper(len(raw) - 1),
+		},
+		{
+			name:  "another scope",
+			codec: c,
+			scope: "other",
+			token: token,
+		},
+		{
+			name:  "another key",
+			codec: NewCodec([]byte("other")),
+			scope: "scope",
+			token: token,
+		},
+		{
+			name:  "truncated",
+			codec: c,
+			scope: "scope",
+			token: token[:len(token)-2],
+		},
+		{
+			name:  "mac alone",
+			codec: c,
+			scope: "scope",
+			token: base64.RawURLEncoding.EncodeToString(raw[len(raw)-macSize:]),
+		},
+		{
+			name:  "not base64",
+			codec: c,
+			scope: "scope",
+			token: "!!not a cursor!!",
+		},
+		{
+			name:  "garbage",
+			codec: c,
+			scope: "scope",
+			token: base64.RawURLEncoding.EncodeToString([]byte("some garbage bytes here")),
+		},
+		{
+			name:  "signed garbage",
+			codec: c,
+			scope: "scope",
+			token: signed(c, "scope", []byte{0xff, 0xff, 0xff}),
+		},
+		{
+			name:  "signed trailing bytes",
+			codec: c,
+			scope: "scope",
+			token: signed(c, "scope", []byte{0x02, 0x04, 0x00}),
+		},
+		{
+			name:  "signed negative utime",
+			codec: c,
+			scope: "scope",
+			token: signed(c, "scope", []byte{0x01, 0x04}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.codec.Decode(tc.scope, tc.token)
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode() error = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+// signed builds a token of the payload with a valid mac.
+func signed(c *Codec, scope string, payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(append(payload, c.sign(scope, payload)...))
+}
@@ -0,0 +1,161 @@
+package diffx
+
+import (
+	"strings"
+)
+
+type Op uint8
+
+const (
+	OpEqual Op = iota
+	OpInsert
+	OpDelete
+)
+
+type Edit struct {
+	Op   Op
+	Line string
+}
+
+const (
+	// maxLines caps the lines left to diff once the common prefix and suffix are trimmed,
+	// the search takes O((N+M)D) time.
+	maxLines = 10000
+	// maxEdits caps D, the trace kept to recover the path grows with D^2.
+	maxEdits = 1000
+)
+
+// Lines splits both texts on '\n' and returns the shortest edit script
+// turning a into b, or deleting a and inserting b when they are too large or too different.
+func Lines(a, b string) []Edit {
+	return Slices(splitLines(a), splitLines(b))
+}
+
+// Slices computes the shortest edit script between a and b with Myers' O(ND) algorithm,
+// past maxLines or maxEdits it deletes all of a and inserts all of b instead.
+func Slices(a, b []string) []Edit {
+	// the common prefix and suffix never take part in the edit script,
+	// trimming them keeps the trace small for the usual "edit a few lines" case.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]Edit, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		edits = append(edits, Edit{Op: OpEqual, Line: line})
+	}
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, Edit{Op: OpEqual, Line: line})
+	}
+	return edits
+}
+
+func myers(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	if n+m > maxLines {
+		return replace(a, b)
+	}
+	maxD := min(n+m, maxEdits)
+	offset := maxD
+	v := make([]int, 2*maxD+2)
+	// trace[d] keeps the diagonals [-d, d] reached after round d, the only ones
+	// round d+1 reads, so the trace grows with D^2 instead of D*(N+M).
+	trace := make([][]int, 0, maxD+1)
+
+	var d int
+	found := false
+search:
+	for d = 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break search
+			}
+		}
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+	}
+	if !found {
+		return replace(a, b)
+	}
+
+	// walk the trace backwards to recover the path
+	edits := make([]Edit, 0, n+m)
+	x, y := n, m
+	for ; d > 0; d-- {
+		// the previous round reached the diagonals [-(d-1), d-1]
+		prev, base := trace[d-1], d-1
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[base+k-1] < prev[base+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[base+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, Edit{Op: OpEqual, Line: a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, Edit{Op: OpInsert, Line: b[y]})
+		} else {
+			x--
+			edits = append(edits, Edit{Op: OpDelete, Line: a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, Edit{Op: OpEqual, Line: a[x]})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+func replace(a, b []string) []Edit {
+	edits := make([]Edit, 0, len(a)+len(b))
+	for _, line := range a {
+		edits = append(edits, Edit{Op: OpDelete, Line: line})
+	}
+	for _, line := range b {
+		edits = append(edits, Edit{Op: OpInsert, Line: line})
+	}
+	return edits
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,215 @@
+package diffx
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    string
+		b    string
+		want []Edit
+	}{
+		{
+			name: "both empty",
+			want: []Edit{},
+		},
+		{
+			name: "from empty",
+			b:    "a\nb",
+			want: []Edit{{Op: OpInsert, Line: "a"}, {Op: OpInsert, Line: "b"}},
+		},
+		{
+			name: "to empty",
+			a:    "a\nb",
+			want: []Edit{{Op: OpDelete, Line: "a"}, {Op: OpDelete, Line: "b"}},
+		},
+		{
+			name: "equal",
+			a:    "a\nb",
+			b:    "a\r\nb",
+			want: []Edit{{Op: OpEqual, Line: "a"}, {Op: OpEqual, Line: "b"}},
+		},
+		{
+			name: "changed line in the middle",
+			a:    "a\nb\nc",
+			b:    "a\nx\nc",
+			want: []Edit{
+				{Op: OpEqual, Line: "a"},
+				{Op: OpDelete, Line: "b"},
+				{Op: OpInsert, Line: "x"},
+				{Op: OpEqual, Line: "c"},
+			},
+		},
+		{
+			name: "insert and delete around kept lines",
+			a:    "a\nb\nc\na\nb\nb\na",
+			b:    "c\nb\na\nb\na\nc",
+			want: []Edit{
+				{Op: OpDelete, Line: "a"},
+				{Op: OpDelete, Line: "b"},
+				{Op: OpEqual, Line: "c"},
+				{Op: OpInsert, Line: "b"},
+				{Op: OpEqual, Line: "a"},
+				{Op: OpEqual, Line: "b"},
+				{Op: OpDelete, Line: "b"},
+				{Op: OpEqual, Line: "a"},
+				{Op: OpInsert, Line: "c"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Lines(tc.a, tc.b)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Lines() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSlicesShortest(t *testing.T) {
+	testCases := []struct {
+		name  string
+		a     []string
+		b     []string
+		wantD int
+	}{
+		{
+			name:  "disjoint",
+			a:     []string{"a", "b", "c"},
+			b:     []string{"x", "y"},
+			wantD: 5,
+		},
+		{
+			name:  "classic example",
+			a:     strings.Split("abcabba", ""),
+			b:     strings.Split("cbabac", ""),
+			wantD: 5,
+		},
+		{
+			name:  "interleaved",
+			a:     strings.Split("axbxcxdx", ""),
+			b:     strings.Split("abcd", ""),
+			wantD: 4,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			edits := Slices(tc.a, tc.b)
+			assertApplies(t, tc.a, tc.b, edits)
+			if d := distance(edits); d != tc.wantD {
+				t.Errorf("edit distance = %d, want %d", d, tc.wantD)
+			}
+		})
+	}
+}
+
+func TestSlicesLarge(t *testing.T) {
+	a := make([]string, 0, 5000)
+	b := make([]string, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		line := strings.Repeat("x", i%7)
+		a = append(a, line)
+		if i%3 == 0 {
+			b = append(b, "changed")
+			continue
+		}
+		b = append(b, line)
+	}
+	edits := Slices(a, b)
+	assertApplies(t, a, b, edits)
+}
+
+func TestSlicesCapped(t *testing.T) {
+	lines := func(n int, prefix string) []string {
+		res := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			res = append(res, prefix+strconv.Itoa(i))
+		}
+		return res
+	}
+	testCases := []struct {
+		name      string
+		a         []string
+		b         []string
+		wantD     int
+		wantSplit bool
+	}{
+		{
+			name:  "too many lines",
+			a:     append(lines(maxLines/2, "a"), "same"),
+			b:     append(lines(maxLines/2+1, "b"), "same"),
+			wantD: maxLines + 1,
+		},
+		{
+			name:  "too many edits",
+			a:     lines(maxEdits/2+1, "a"),
+			b:     lines(maxEdits/2+1, "b"),
+			wantD: maxEdits + 2,
+		},
+		{
+			name:      "edits within the cap",
+			a:         lines(maxEdits/2, "a"),
+			b:         append(lines(maxEdits/2, "b"), "a0"),
+			wantD:     maxEdits - 1,
+			wantSplit: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			edits := Slices(tc.a, tc.b)
+			assertApplies(t, tc.a, tc.b, edits)
+			if d := distance(edits); d != tc.wantD {
+				t.Errorf("edit distance = %d, want %d", d, tc.wantD)
+			}
+			// replacing deletes every line of a before inserting any of b
+			split := false
+			for i := 1; i < len(edits); i++ {
+				if edits[i-1].Op != OpDelete && edits[i].Op == OpDelete {
+					split = true
+				}
+			}
+			if split != tc.wantSplit {
+				t.Errorf("deletes after other edits = %v, want %v", split, tc.wantSplit)
+			}
+		})
+	}
+}
+
+// assertApplies checks the kept and deleted lines are a and the kept and inserted ones are b.
+func assertApplies(t *testing.T, a, b []string, edits []Edit) {
+	t.Helper()
+	from, to := make([]string, 0, len(a)), make([]string, 0, len(b))
+	for _, e := range edits {
+		switch e.Op {
+		case OpEqual:
+			from = append(from, e.Line)
+			to = append(to, e.Line)
+		case OpDelete:
+			from = append(from, e.Line)
+		case OpInsert:
+			to = append(to, e.Line)
+		}
+	}
+	if !reflect.DeepEqual(from, a) {
+		t.Errorf("kept and deleted lines = %v, want %v", from, a)
+	}
+	if !reflect.DeepEqual(to, b) {
+		t.Errorf("kept and inserted lines = %v, want %v", to, b)
+	}
+}
+
+func distance(edits []Edit) int {
+	d := 0
+	for _, e := range edits {
+		if e.Op != OpEqual {
+			d++
+		}
+	}
+	return d
+}
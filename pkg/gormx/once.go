@@ -0,0 +1,40 @@
+// Package gormx holds helpers shared by the GORM based DAOs.
+package gormx
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DataMigration records a one-time data change of a service by its name.
+type DataMigration struct {
+	Name  string `gorm:"primaryKey;type:varchar(128)"`
+	Ctime int64
+}
+
+func (DataMigration) TableName() string {
+	return "data_migrations"
+}
+
+// RunOnce runs fn in a transaction along with recording name, fn is skipped once name is recorded.
+// The record is inserted first, so an instance starting at the same time waits on it and then skips fn,
+// and a failed fn rolls the record back to be run again on the next start.
+func RunOnce(db *gorm.DB, name string, fn func(tx *gorm.DB) error) error {
+	err := db.AutoMigrate(&DataMigration{})
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&DataMigration{Name: name, Ctime: time.Now().UnixMilli()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		return fn(tx)
+	})
+}
@@ -0,0 +1,67 @@
+package markdown
+
+type Kind uint8
+
+const (
+	KindDocument Kind = iota
+	KindParagraph
+	KindHeading
+	KindBlockquote
+	KindList
+	KindListItem
+	KindCodeBlock
+	KindHTMLBlock
+	KindThematicBreak
+
+	KindText
+	KindEmphasis
+	KindStrong
+	KindStrikethrough
+	KindCode
+	KindLink
+	KindImage
+	KindLineBreak
+	KindSoftBreak
+	KindRawHTML
+)
+
+// IsBlock tells block nodes from inline nodes.
+func (k Kind) IsBlock() bool {
+	return k <= KindThematicBreak
+}
+
+// Node is a node of the syntax tree, only the fields relevant to its Kind are set.
+type Node struct {
+	Kind     Kind
+	Children []*Node
+
+	// Literal is the content of text, code, code block and raw html nodes
+	Literal string
+	// Level of a heading, 1 to 6
+	Level int
+	// ID is the anchor of a heading, unique within the document
+	ID string
+	// Ordered, Start and Tight describe a list, paragraphs of a tight list are rendered without <p>
+	Ordered bool
+	Start   int
+	Tight   bool
+	// Lang is the info string of a fenced code block
+	Lang string
+	// Dest and Title of a link or an image
+	Dest  string
+	Title string
+}
+
+func (n *Node) append(child *Node) {
+	n.Children = append(n.Children, child)
+}
+
+// Walk visits n and its descendants depth-first, the children of a node are skipped when fn returns false.
+func Walk(n *Node, fn func(n *Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, child := range n.Children {
+		Walk(child, fn)
+	}
+}
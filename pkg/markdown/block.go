@@ -0,0 +1,512 @@
+package markdown
+
+import (
+	"strings"
+)
+
+// Parse parses src into a syntax tree.
+// It covers the common subset of CommonMark: ATX and setext headings, paragraphs, block quotes,
+// nested lists, fenced and indented code, thematic breaks and raw html, plus GFM strikethrough.
+// Reference links and tables are not supported and are left as text.
+func Parse(src string) *Node {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+	doc := &Node{Kind: KindDocument, Children: parseBlocks(lines)}
+	Walk(doc, func(n *Node) bool {
+		switch n.Kind {
+		case KindParagraph, KindHeading:
+			n.Children = parseInlines(n.Literal, 0)
+			n.Literal = ""
+			return false
+		default:
+			return n.Kind.IsBlock()
+		}
+	})
+	assignHeadingIDs(doc)
+	return doc
+}
+
+func parseBlocks(lines []string) []*Node {
+	var (
+		blocks []*Node
+		para   []string
+	)
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, &Node{Kind: KindParagraph, Literal: strings.TrimSpace(strings.Join(para, "\n"))})
+			para = nil
+		}
+	}
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			flush()
+			i++
+			continue
+		}
+		indent := leadingSpaces(line)
+		if indent >= 4 {
+			if len(para) > 0 {
+				// paragraph continuation
+				para = append(para, strings.TrimLeft(line, " "))
+				i++
+				continue
+			}
+			var node *Node
+			node, i = parseIndentedCode(lines, i)
+			blocks = append(blocks, node)
+			continue
+		}
+		trimmed := line[indent:]
+
+		if fence, info, ok := openFence(trimmed); ok {
+			flush()
+			var node *Node
+			node, i = parseFencedCode(lines, i+1, indent, fence, info)
+			blocks = append(blocks, node)
+			continue
+		}
+		if level, text, ok := atxHeading(trimmed); ok {
+			flush()
+			blocks = append(blocks, &Node{Kind: KindHeading, Level: level, Literal: text})
+			i++
+			continue
+		}
+		if len(para) > 0 {
+			if level, ok := setextUnderline(trimmed); ok {
+				blocks = append(blocks, &Node{Kind: KindHeading, Level: level, Literal: strings.TrimSpace(strings.Join(para, "\n"))})
+				para = nil
+				i++
+				continue
+			}
+		}
+		if isThematicBreak(trimmed) {
+			flush()
+			blocks = append(blocks, &Node{Kind: KindThematicBreak})
+			i++
+			continue
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			flush()
+			var node *Node
+			node, i = parseBlockquote(lines, i)
+			blocks = append(blocks, node)
+			continue
+		}
+		if m, content, ok := parseListMarker(line); ok && (len(para) == 0 || m.canInterrupt(content)) {
+			flush()
+			var node *Node
+			node, i = parseList(lines, i, m)
+			blocks = append(blocks, node)
+			continue
+		}
+		if len(para) == 0 && isHTMLBlockStart(trimmed) {
+			var node *Node
+			node, i = parseHTMLBlock(lines, i)
+			blocks = append(blocks, node)
+			continue
+		}
+		para = append(para, trimmed)
+		i++
+	}
+	flush()
+	return blocks
+}
+
+func parseIndentedCode(lines []string, i int) (*Node, int) {
+	var code []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if !isBlank(line) && leadingSpaces(line) < 4 {
+			break
+		}
+		code = append(code, removeIndent(line, 4))
+	}
+	// trailing blank lines belong to whatever comes next
+	for len(code) > 0 && isBlank(code[len(code)-1]) {
+		code = code[:len(code)-1]
+	}
+	return &Node{Kind: KindCodeBlock, Literal: strings.Join(code, "\n") + "\n"}, i
+}
+
+func parseFencedCode(lines []string, i, indent int, fence, info string) (*Node, int) {
+	var code []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isClosingFence(line, fence) {
+			i++
+			break
+		}
+		code = append(code, removeIndent(line, indent))
+	}
+	node := &Node{Kind: KindCodeBlock}
+	if fields := strings.Fields(info); len(fields) > 0 {
+		node.Lang = unescapeBackslash(fields[0])
+	}
+	if len(code) > 0 {
+		node.Literal = strings.Join(code, "\n") + "\n"
+	}
+	return node, i
+}
+
+func parseBlockquote(lines []string, i int) (*Node, int) {
+	var inner []string
+	for i < len(lines) {
+		line := lines[i]
+		indent := leadingSpaces(line)
+		if indent < 4 && strings.HasPrefix(line[indent:], ">") {
+			content := line[indent+1:]
+			content = strings.TrimPrefix(content, " ")
+			inner = append(inner, content)
+			i++
+			continue
+		}
+		if isBlank(line) {
+			break
+		}
+		// lazy continuation of a paragraph in the quote
+		if len(inner) > 0 && !isBlank(inner[len(inner)-1]) && !startsBlock(line) {
+			inner = append(inner, line)
+			i++
+			continue
+		}
+		break
+	}
+	return &Node{Kind: KindBlockquote, Children: parseBlocks(inner)}, i
+}
+
+type listMarker struct {
+	ordered bool
+	start   int
+	// the bullet character, or the delimiter after the number of an ordered item
+	delim byte
+	// width is the indentation of the content of the item
+	width int
+}
+
+func (m listMarker) sameList(o listMarker) bool {
+	return m.ordered == o.ordered && m.delim == o.delim
+}
+
+// canInterrupt reports whether the item may start in the middle of a paragraph.
+func (m listMarker) canInterrupt(content string) bool {
+	if isBlank(content) {
+		return false
+	}
+	return !m.ordered || m.start == 1
+}
+
+// parseListMarker parses the list marker at the start of line, content is the rest of the line.
+func parseListMarker(line string) (listMarker, string, bool) {
+	indent := leadingSpaces(line)
+	if indent >= 4 {
+		return listMarker{}, "", false
+	}
+	rest := line[indent:]
+	var (
+		m      listMarker
+		marker int
+	)
+	switch {
+	case rest == "":
+		return listMarker{}, "", false
+	case rest[0] == '-' || rest[0] == '*' || rest[0] == '+':
+		m.delim = rest[0]
+		marker = 1
+	default:
+		n := 0
+		for n < len(rest) && n < 9 && rest[n] >= '0' && rest[n] <= '9' {
+			m.start = m.start*10 + int(rest[n]-'0')
+			n++
+		}
+		if n == 0 || n == len(rest) || (rest[n] != '.' && rest[n] != ')') {
+			return listMarker{}, "", false
+		}
+		m.ordered = true
+		m.delim = rest[n]
+		marker = n + 1
+	}
+	if marker < len(rest) && rest[marker] != ' ' {
+		return listMarker{}, "", false
+	}
+	content := rest[marker:]
+	spaces := leadingSpaces(content)
+	switch {
+	case isBlank(content):
+		m.width = indent + marker + 1
+		content = ""
+	case spaces > 4:
+		// the content starts with indented code
+		m.width = indent + marker + 1
+		content = content[1:]
+	default:
+		m.width = indent + marker + spaces
+		content = content[spaces:]
+	}
+	return m, content, true
+}
+
+func parseList(lines []string, i int, first listMarker) (*Node, int) {
+	list := &Node{Kind: KindList, Ordered: first.ordered, Start: first.start, Tight: true}
+	for i < len(lines) {
+		m, content, ok := parseListMarker(lines[i])
+		if !ok || !m.sameList(first) || isThematicBreak(strings.TrimLeft(lines[i], " ")) {
+			break
+		}
+		itemLines := []string{content}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if isBlank(line) {
+				j := i
+				for j < len(lines) && isBlank(lines[j]) {
+					j++
+				}
+				if j < len(lines) && leadingSpaces(lines[j]) >= m.width {
+					for ; i < j; i++ {
+						itemLines = append(itemLines, "")
+					}
+					continue
+				}
+				break
+			}
+			if leadingSpaces(line) >= m.width {
+				itemLines = append(itemLines, line[m.width:])
+				i++
+				continue
+			}
+			if _, _, ok := parseListMarker(line); ok {
+				break
+			}
+			// lazy continuation of the paragraph in the item
+			if !isBlank(itemLines[len(itemLines)-1]) && !startsBlock(line) {
+				itemLines = append(itemLines, strings.TrimLeft(line, " "))
+				i++
+				continue
+			}
+			break
+		}
+		item := &Node{Kind: KindListItem, Children: parseBlocks(itemLines)}
+		if len(item.Children) > 1 && hasInnerBlank(itemLines) {
+			list.Tight = false
+		}
+		list.append(item)
+
+		// a blank line between two items makes the list loose
+		j := i
+		for j < len(lines) && isBlank(lines[j]) {
+			j++
+		}
+		if j == i || j == len(lines) {
+			continue
+		}
+		if next, _, ok := parseListMarker(lines[j]); ok && next.sameList(first) &&
+			!isThematicBreak(strings.TrimLeft(lines[j], " ")) {
+			list.Tight = false
+			i = j
+			continue
+		}
+		break
+	}
+	return list, i
+}
+
+// blockTags are the html tags starting an html block, other tags are inline html within a paragraph.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "details": true,
+	"dialog": true, "div": true, "dl": true, "dt": true, "dd": true, "fieldset": true,
+	"figcaption": true, "figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true, "iframe": true,
+	"li": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true, "script": true,
+	"section": true, "style": true, "summary": true, "table": true, "tbody": true, "td": true,
+	"tfoot": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+func isHTMLBlockStart(s string) bool {
+	if strings.HasPrefix(s, "<!--") {
+		return true
+	}
+	if !strings.HasPrefix(s, "<") {
+		return false
+	}
+	s = strings.TrimPrefix(s[1:], "/")
+	n := 0
+	for n < len(s) && isAlnum(s[n]) {
+		n++
+	}
+	if n == 0 {
+		return false
+	}
+	if n < len(s) && s[n] != ' ' && s[n] != '>' && s[n] != '/' {
+		return false
+	}
+	return blockTags[strings.ToLower(s[:n])]
+}
+
+func parseHTMLBlock(lines []string, i int) (*Node, int) {
+	var raw []string
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		raw = append(raw, lines[i])
+	}
+	return &Node{Kind: KindHTMLBlock, Literal: strings.Join(raw, "\n") + "\n"}, i
+}
+
+// startsBlock reports whether line starts a block interrupting a paragraph.
+func startsBlock(line string) bool {
+	indent := leadingSpaces(line)
+	if indent >= 4 {
+		return false
+	}
+	s := line[indent:]
+	if _, _, ok := openFence(s); ok {
+		return true
+	}
+	if _, _, ok := atxHeading(s); ok {
+		return true
+	}
+	if isThematicBreak(s) || strings.HasPrefix(s, ">") || isHTMLBlockStart(s) {
+		return true
+	}
+	m, content, ok := parseListMarker(line)
+	return ok && m.canInterrupt(content)
+}
+
+func openFence(s string) (fence, info string, ok bool) {
+	if len(s) < 3 || (s[0] != '`' && s[0] != '~') {
+		return "", "", false
+	}
+	n := 0
+	for n < len(s) && s[n] == s[0] {
+		n++
+	}
+	if n < 3 {
+		return "", "", false
+	}
+	info = strings.TrimSpace(s[n:])
+	if s[0] == '`' && strings.Contains(info, "`") {
+		return "", "", false
+	}
+	return s[:n], info, true
+}
+
+func isClosingFence(line, fence string) bool {
+	indent := leadingSpaces(line)
+	if indent >= 4 {
+		return false
+	}
+	s := strings.TrimRight(line[indent:], " ")
+	if len(s) < len(fence) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != fence[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func atxHeading(s string) (int, string, bool) {
+	level := 0
+	for level < len(s) && s[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(s) && s[level] != ' ') {
+		return 0, "", false
+	}
+	text := strings.TrimSpace(s[level:])
+	// the optional closing sequence
+	if trimmed := strings.TrimRight(text, "#"); trimmed != text {
+		if trimmed == "" {
+			text = ""
+		} else if strings.HasSuffix(trimmed, " ") {
+			text = strings.TrimSpace(trimmed)
+		}
+	}
+	return level, text, true
+}
+
+func setextUnderline(s string) (int, bool) {
+	s = strings.TrimRight(s, " ")
+	if s == "" || strings.Trim(s, string(s[0])) != "" {
+		return 0, false
+	}
+	switch s[0] {
+	case '=':
+		return 1, true
+	case '-':
+		return 2, true
+	default:
+		return 0, false
+	}
+}
+
+func isThematicBreak(s string) bool {
+	if s == "" || (s[0] != '-' && s[0] != '*' && s[0] != '_') {
+		return false
+	}
+	cnt := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case s[0]:
+			cnt++
+		case ' ':
+		default:
+			return false
+		}
+	}
+	return cnt >= 3
+}
+
+func hasInnerBlank(lines []string) bool {
+	end := len(lines)
+	for end > 0 && isBlank(lines[end-1]) {
+		end--
+	}
+	for _, line := range lines[:end] {
+		if isBlank(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+func leadingSpaces(s string) int {
+	n := 0
+	for n < len(s) && s[n] == ' ' {
+		n++
+	}
+	return n
+}
+
+// removeIndent removes at most n leading spaces.
+func removeIndent(s string, n int) string {
+	return s[min(leadingSpaces(s), n):]
+}
+
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			spaces := 4 - col%4
+			b.WriteString(strings.Repeat(" ", spaces))
+			col += spaces
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
@@ -0,0 +1,430 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	autolinkRegexp = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*)>`)
+	emailRegexp    = regexp.MustCompile(`^<([A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)*)>`)
+	inlineHTMLRe   = regexp.MustCompile(`^(?:<[A-Za-z][A-Za-z0-9-]*(?:\s+[A-Za-z_:][A-Za-z0-9_.:-]*(?:\s*=\s*(?:[^\s"'=<>` + "`" + `]+|'[^']*'|"[^"]*"))?)*\s*/?>|</[A-Za-z][A-Za-z0-9-]*\s*>|<!--[\s\S]*?-->)`)
+	entityRegexp   = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9A-Fa-f]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+)
+
+// maxInlineDepth bounds the nesting of emphasis, links and images,
+// the text nested deeper is kept literal and link brackets nested deeper never open a link.
+const maxInlineDepth = 16
+
+// parseInlines parses the text of a paragraph or a heading, depth is how deep s is nested in other inlines.
+func parseInlines(s string, depth int) []*Node {
+	if depth > maxInlineDepth {
+		return []*Node{{Kind: KindText, Literal: s}}
+	}
+	var (
+		nodes []*Node
+		buf   strings.Builder
+		// the delimiter runs known to have no closer after them, by their char and length
+		unclosed = make(map[string]bool)
+	)
+	flush := func() {
+		if buf.Len() > 0 {
+			nodes = append(nodes, &Node{Kind: KindText, Literal: buf.String()})
+			buf.Reset()
+		}
+	}
+	add := func(n *Node) {
+		flush()
+		nodes = append(nodes, n)
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				add(&Node{Kind: KindLineBreak})
+				i = skipSpaces(s, i+2)
+				continue
+			}
+			if i+1 < len(s) && isPunct(s[i+1]) {
+				buf.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+		case '`':
+			n := runLength(s, i, '`')
+			if end := findCodeSpanEnd(s, i+n, n); end >= 0 {
+				add(&Node{Kind: KindCode, Literal: normalizeCodeSpan(s[i+n : end])})
+				i = end + n
+				continue
+			}
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+		case '*', '_', '~':
+			if node, end, ok := parseEmphasis(s, i, depth, unclosed); ok {
+				add(node)
+				i = end
+				continue
+			}
+			// the whole run is literal, trying its tail again would pair it wrongly
+			n := runLength(s, i, c)
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				if link, end, ok := parseLink(s, i+1, KindImage, depth); ok {
+					for _, n := range link {
+						add(n)
+					}
+					i = end
+					continue
+				}
+			}
+		case '[':
+			if link, end, ok := parseLink(s, i, KindLink, depth); ok {
+				for _, n := range link {
+					add(n)
+				}
+				i = end
+				continue
+			}
+		case '<':
+			if m := autolinkRegexp.FindStringSubmatch(s[i:]); m != nil {
+				add(&Node{Kind: KindLink, Dest: m[1], Children: []*Node{{Kind: KindText, Literal: m[1]}}})
+				i += len(m[0])
+				continue
+			}
+			if m := emailRegexp.FindStringSubmatch(s[i:]); m != nil {
+				add(&Node{Kind: KindLink, Dest: "mailto:" + m[1], Children: []*Node{{Kind: KindText, Literal: m[1]}}})
+				i += len(m[0])
+				continue
+			}
+			if m := inlineHTMLRe.FindString(s[i:]); m != "" {
+				add(&Node{Kind: KindRawHTML, Literal: m})
+				i += len(m)
+				continue
+			}
+		case '&':
+			if m := entityRegexp.FindString(s[i:]); m != "" {
+				buf.WriteString(html.UnescapeString(m))
+				i += len(m)
+				continue
+			}
+		case '\n':
+			text := buf.String()
+			trimmed := strings.TrimRight(text, " ")
+			buf.Reset()
+			buf.WriteString(trimmed)
+			if len(text)-len(trimmed) >= 2 {
+				add(&Node{Kind: KindLineBreak})
+			} else {
+				add(&Node{Kind: KindSoftBreak})
+			}
+			i = skipSpaces(s, i+1)
+			continue
+		}
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+	return nodes
+}
+
+// parseEmphasis parses the emphasis, strong emphasis or strikethrough opened by the delimiter run at i.
+// A run with no closer is recorded in unclosed, the runs of the same kind after it are not searched again.
+func parseEmphasis(s string, i, depth int, unclosed map[string]bool) (*Node, int, bool) {
+	c := s[i]
+	n := runLength(s, i, c)
+	if !leftFlanking(s, i, n) {
+		return nil, 0, false
+	}
+	var kinds []Kind
+	switch {
+	case c == '~' && n == 2:
+		kinds = []Kind{KindStrikethrough}
+	case c == '~':
+		return nil, 0, false
+	case n == 1:
+		kinds = []Kind{KindEmphasis}
+	case n == 2:
+		kinds = []Kind{KindStrong}
+	case n == 3:
+		kinds = []Kind{KindEmphasis, KindStrong}
+	default:
+		return nil, 0, false
+	}
+	run := s[i : i+n]
+	if unclosed[run] {
+		return nil, 0, false
+	}
+	end := findCloser(s, i+n, c, n)
+	if end < 0 {
+		unclosed[run] = true
+		return nil, 0, false
+	}
+	inner := s[i+n : end]
+	node := &Node{Kind: kinds[0]}
+	cur := node
+	for _, kind := range kinds[1:] {
+		child := &Node{Kind: kind}
+		cur.append(child)
+		cur = child
+	}
+	cur.Children = parseInlines(inner, depth+1)
+	return node, end + n, true
+}
+
+// findCloser looks for a right-flanking run of exactly n c from i, it returns the start of the run.
+func findCloser(s string, i int, c byte, n int) int {
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			i += 2
+			continue
+		case '`':
+			m := runLength(s, i, '`')
+			if end := findCodeSpanEnd(s, i+m, m); end >= 0 {
+				i = end + m
+				continue
+			}
+			i += m
+			continue
+		case c:
+			m := runLength(s, i, c)
+			if m == n && rightFlanking(s, i, m) {
+				return i
+			}
+			i += m
+			continue
+		}
+		i++
+	}
+	return -1
+}
+
+func leftFlanking(s string, i, n int) bool {
+	if i+n >= len(s) || isSpace(s[i+n]) {
+		return false
+	}
+	// no intraword emphasis with underscores
+	return s[i] != '_' || i == 0 || !isAlnum(s[i-1])
+}
+
+func rightFlanking(s string, i, n int) bool {
+	if i == 0 || isSpace(s[i-1]) {
+		return false
+	}
+	return s[i] != '_' || i+n >= len(s) || !isAlnum(s[i+n])
+}
+
+// parseLink parses an inline link or image whose text starts with the '[' at i.
+// A link containing another link is not a link, its brackets are returned as text around the parsed text,
+// parsing the text again from the next position would take exponential time on nested brackets.
+func parseLink(s string, i int, kind Kind, depth int) ([]*Node, int, bool) {
+	closing := findLinkTextEnd(s, i+1)
+	if closing < 0 || closing+1 >= len(s) || s[closing+1] != '(' {
+		return nil, 0, false
+	}
+	dest, title, end, ok := parseLinkTail(s, closing+2)
+	if !ok {
+		return nil, 0, false
+	}
+	children := parseInlines(s[i+1:closing], depth+1)
+	if kind == KindLink && containsLink(children) {
+		nodes := make([]*Node, 0, len(children)+2)
+		nodes = append(nodes, &Node{Kind: KindText, Literal: "["})
+		nodes = append(nodes, children...)
+		nodes = append(nodes, &Node{Kind: KindText, Literal: "]"})
+		return nodes, closing + 1, true
+	}
+	return []*Node{{Kind: kind, Dest: dest, Title: title, Children: children}}, end, true
+}
+
+// findLinkTextEnd returns the ']' closing the link text from i, brackets nested deeper than maxInlineDepth are not closed.
+func findLinkTextEnd(s string, i int) int {
+	depth := 0
+	for i < len(s) {
+		switch s[i] {
+		case '\\':
+			i += 2
+			continue
+		case '`':
+			m := runLength(s, i, '`')
+			if end := findCodeSpanEnd(s, i+m, m); end >= 0 {
+				i = end + m
+				continue
+			}
+			i += m
+			continue
+		case '[':
+			depth++
+			if depth > maxInlineDepth {
+				return -1
+			}
+		case ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+		i++
+	}
+	return -1
+}
+
+// parseLinkTail parses `dest "title")` from i, the position right after '('.
+func parseLinkTail(s string, i int) (dest, title string, end int, ok bool) {
+	i = skipWhitespace(s, i)
+	switch {
+	case i < len(s) && s[i] == '<':
+		j := i + 1
+		for j < len(s) && s[j] != '>' && s[j] != '\n' && s[j] != '<' {
+			if s[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(s) || s[j] != '>' {
+			return "", "", 0, false
+		}
+		dest = s[i+1 : j]
+		i = j + 1
+	default:
+		j, depth := i, 0
+		for j < len(s) && !isSpace(s[j]) {
+			if s[j] == '\\' && j+1 < len(s) {
+				j += 2
+				continue
+			}
+			if s[j] == '(' {
+				depth++
+				// unbalanced parentheses would be scanned to the end of the text for every link
+				if depth > maxInlineDepth {
+					return "", "", 0, false
+				}
+			}
+			if s[j] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+			j++
+		}
+		dest = s[i:j]
+		i = j
+	}
+	start := i
+	i = skipWhitespace(s, i)
+	if i < len(s) && i > start && (s[i] == '"' || s[i] == '\'' || s[i] == '(') {
+		closer := s[i]
+		if closer == '(' {
+			closer = ')'
+		}
+		j := i + 1
+		for j < len(s) && s[j] != closer {
+			// a title in parentheses can not contain an unescaped '(', it stops the scan early
+			if s[j] == '(' && closer == ')' {
+				return "", "", 0, false
+			}
+			if s[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(s) {
+			return "", "", 0, false
+		}
+		title = s[i+1 : j]
+		i = skipWhitespace(s, j+1)
+	}
+	if i >= len(s) || s[i] != ')' {
+		return "", "", 0, false
+	}
+	return html.UnescapeString(unescapeBackslash(dest)), html.UnescapeString(unescapeBackslash(title)), i + 1, true
+}
+
+func containsLink(nodes []*Node) bool {
+	for _, n := range nodes {
+		if n.Kind == KindLink || containsLink(n.Children) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCodeSpanEnd looks for a backtick run of exactly n from i, it returns the start of the run.
+func findCodeSpanEnd(s string, i, n int) int {
+	for i < len(s) {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		m := runLength(s, i, '`')
+		if m == n {
+			return i
+		}
+		i += m
+	}
+	return -1
+}
+
+func normalizeCodeSpan(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) >= 2 && s[0] == ' ' && s[len(s)-1] == ' ' && strings.Trim(s, " ") != "" {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
+
+func unescapeBackslash(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return i
+}
+
+func skipWhitespace(s string, i int) int {
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
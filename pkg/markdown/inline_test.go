@@ -0,0 +1,136 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseInlines(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "link",
+			src:  `[a](b "t")`,
+			want: "<p><a href=\"b\" title=\"t\">a</a></p>\n",
+		},
+		{
+			name: "title in parentheses",
+			src:  "[a](b (t))",
+			want: "<p><a href=\"b\" title=\"t\">a</a></p>\n",
+		},
+		{
+			name: "unclosed title in parentheses",
+			src:  "[a](b (t)",
+			want: "<p>[a](b (t)</p>\n",
+		},
+		{
+			name: "nested links keep the inner one",
+			src:  "[[a](b)](c)",
+			want: "<p>[<a href=\"b\">a</a>](c)</p>\n",
+		},
+		{
+			name: "link text around a nested link",
+			src:  "a [b [c](d) e](f) g",
+			want: "<p>a [b <a href=\"d\">c</a> e](f) g</p>\n",
+		},
+		{
+			name: "image in a link",
+			src:  "[![img](i.png)](l)",
+			want: "<p><a href=\"l\"><img src=\"i.png\" alt=\"img\"></a></p>\n",
+		},
+		{
+			name: "emphasis",
+			src:  "*a **b** c*",
+			want: "<p><em>a <strong>b</strong> c</em></p>\n",
+		},
+		{
+			name: "unclosed emphasis",
+			src:  "*a *b c",
+			want: "<p>*a *b c</p>\n",
+		},
+		{
+			name: "code span is literal",
+			src:  "`[a](b)`",
+			want: "<p><code>[a](b)</code></p>\n",
+		},
+		{
+			name: "brackets nested too deep only keep the innermost link",
+			src:  strings.Repeat("[", maxInlineDepth+1) + "a" + strings.Repeat("](b)", maxInlineDepth+1),
+			want: "<p>" + strings.Repeat("[", maxInlineDepth) + "<a href=\"b\">a</a>" + strings.Repeat("](b)", maxInlineDepth) + "</p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Sanitize(Render(Parse(tc.src)))
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHeadingIDs(t *testing.T) {
+	doc := Parse("# A\n# A\n# A-1\n# A")
+	var ids []string
+	for _, h := range TOC(doc) {
+		ids = append(ids, h.ID)
+	}
+	want := "a a-1 a-1-1 a-2"
+	if got := strings.Join(ids, " "); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestPathological checks inputs which made the parser exponential or quadratic finish in time.
+func TestPathological(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+	}{
+		{
+			name: "nested link brackets",
+			src:  strings.Repeat("[", 40) + "a" + strings.Repeat("](b)", 40),
+		},
+		{
+			name: "many nested link brackets",
+			src:  strings.Repeat("[", 50000) + "a" + strings.Repeat("](b)", 50000),
+		},
+		{
+			name: "unclosed brackets",
+			src:  strings.Repeat("[a", 100000),
+		},
+		{
+			name: "unclosed link destinations",
+			src:  strings.Repeat("[x](", 50000),
+		},
+		{
+			name: "unclosed link titles",
+			src:  strings.Repeat("[x](a (", 30000),
+		},
+		{
+			name: "unclosed emphasis",
+			src:  strings.Repeat("*a ", 70000),
+		},
+		{
+			name: "nested emphasis",
+			src:  strings.Repeat("*_", 50000) + "a" + strings.Repeat("_*", 50000),
+		},
+		{
+			name: "headings with the same text",
+			src:  strings.Repeat("# a\n", 50000),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			Process(tc.src)
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("took %v", elapsed)
+			}
+		})
+	}
+}
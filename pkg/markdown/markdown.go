@@ -0,0 +1,28 @@
+// Package markdown turns the markdown source of articles into what readers are served:
+// sanitized html, a plain-text abstract, a reading-time estimate and a table of contents.
+package markdown
+
+import (
+	"time"
+)
+
+// AbstractLength is the maximum number of runes of an abstract.
+const AbstractLength = 100
+
+type Result struct {
+	HTML        string
+	Abstract    string
+	ReadingTime time.Duration
+	TOC         []Heading
+}
+
+// Process parses src once and derives everything from the tree.
+func Process(src string) Result {
+	doc := Parse(src)
+	return Result{
+		HTML:        Sanitize(Render(doc)),
+		Abstract:    Abstract(Text(doc), AbstractLength),
+		ReadingTime: ReadingTime(doc),
+		TOC:         TOC(doc),
+	}
+}
@@ -0,0 +1,129 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Render renders the tree as html.
+// Raw html in the source is copied as is, the result has to go through Sanitize before being served.
+func Render(doc *Node) string {
+	var b strings.Builder
+	renderBlocks(&b, doc.Children, false)
+	return b.String()
+}
+
+func renderBlocks(b *strings.Builder, nodes []*Node, tight bool) {
+	for _, n := range nodes {
+		renderBlock(b, n, tight)
+	}
+}
+
+func renderBlock(b *strings.Builder, n *Node, tight bool) {
+	switch n.Kind {
+	case KindParagraph:
+		if tight {
+			renderInlines(b, n.Children)
+			return
+		}
+		b.WriteString("<p>")
+		renderInlines(b, n.Children)
+		b.WriteString("</p>\n")
+	case KindHeading:
+		level := strconv.Itoa(n.Level)
+		b.WriteString("<h" + level)
+		if n.ID != "" {
+			b.WriteString(` id="` + html.EscapeString(n.ID) + `"`)
+		}
+		b.WriteString(">")
+		renderInlines(b, n.Children)
+		b.WriteString("</h" + level + ">\n")
+	case KindBlockquote:
+		b.WriteString("<blockquote>\n")
+		renderBlocks(b, n.Children, false)
+		b.WriteString("</blockquote>\n")
+	case KindList:
+		tag := "ul"
+		if n.Ordered {
+			tag = "ol"
+		}
+		b.WriteString("<" + tag)
+		if n.Ordered && n.Start != 1 {
+			b.WriteString(` start="` + strconv.Itoa(n.Start) + `"`)
+		}
+		b.WriteString(">\n")
+		for _, item := range n.Children {
+			b.WriteString("<li>")
+			renderBlocks(b, item.Children, n.Tight)
+			b.WriteString("</li>\n")
+		}
+		b.WriteString("</" + tag + ">\n")
+	case KindCodeBlock:
+		b.WriteString("<pre><code")
+		if lang := codeLang(n.Lang); lang != "" {
+			b.WriteString(` class="language-` + lang + `"`)
+		}
+		b.WriteString(">")
+		b.WriteString(html.EscapeString(n.Literal))
+		b.WriteString("</code></pre>\n")
+	case KindHTMLBlock:
+		b.WriteString(n.Literal)
+	case KindThematicBreak:
+		b.WriteString("<hr>\n")
+	}
+}
+
+func renderInlines(b *strings.Builder, nodes []*Node) {
+	for _, n := range nodes {
+		switch n.Kind {
+		case KindText:
+			b.WriteString(html.EscapeString(n.Literal))
+		case KindSoftBreak:
+			b.WriteString("\n")
+		case KindLineBreak:
+			b.WriteString("<br>\n")
+		case KindCode:
+			b.WriteString("<code>" + html.EscapeString(n.Literal) + "</code>")
+		case KindEmphasis:
+			renderWrapped(b, "em", n.Children)
+		case KindStrong:
+			renderWrapped(b, "strong", n.Children)
+		case KindStrikethrough:
+			renderWrapped(b, "del", n.Children)
+		case KindLink:
+			b.WriteString(`<a href="` + html.EscapeString(n.Dest) + `"`)
+			if n.Title != "" {
+				b.WriteString(` title="` + html.EscapeString(n.Title) + `"`)
+			}
+			b.WriteString(">")
+			renderInlines(b, n.Children)
+			b.WriteString("</a>")
+		case KindImage:
+			b.WriteString(`<img src="` + html.EscapeString(n.Dest) + `" alt="` + html.EscapeString(inlineText(n.Children)) + `"`)
+			if n.Title != "" {
+				b.WriteString(` title="` + html.EscapeString(n.Title) + `"`)
+			}
+			b.WriteString(">")
+		case KindRawHTML:
+			b.WriteString(n.Literal)
+		}
+	}
+}
+
+func renderWrapped(b *strings.Builder, tag string, children []*Node) {
+	b.WriteString("<" + tag + ">")
+	renderInlines(b, children)
+	b.WriteString("</" + tag + ">")
+}
+
+// codeLang keeps the characters valid in a class name.
+func codeLang(lang string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 128 && (isAlnum(byte(r)) || r == '-' || r == '_' || r == '+') {
+			return r
+		}
+		return -1
+	}, lang)
+}
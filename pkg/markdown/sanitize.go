@@ -0,0 +1,186 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedTags maps the allowed tags to their allowed attributes, everything else is dropped.
+var allowedTags = map[string][]string{
+	"p": nil, "br": nil, "hr": nil, "div": nil, "span": nil,
+	"h1": {"id"}, "h2": {"id"}, "h3": {"id"}, "h4": {"id"}, "h5": {"id"}, "h6": {"id"},
+	"blockquote": nil, "pre": nil, "code": {"class"},
+	"em": nil, "strong": nil, "del": nil, "s": nil, "b": nil, "i": nil, "u": nil,
+	"sup": nil, "sub": nil, "kbd": nil, "mark": nil,
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"a": {"href", "title"}, "img": {"src", "alt", "title", "width", "height"},
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"align"}, "td": {"align"},
+	"details": nil, "summary": nil, "figure": nil, "figcaption": nil,
+}
+
+// droppedContent are the tags whose content is dropped along with them.
+var droppedContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "noembed": true, "noframes": true, "template": true, "textarea": true,
+	"select": true, "title": true, "svg": true, "math": true, "xmp": true, "plaintext": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+var (
+	idRegexp    = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+	classRegexp = regexp.MustCompile(`^language-[A-Za-z0-9_+-]+$`)
+	alignRegexp = regexp.MustCompile(`^(left|center|right)$`)
+	numRegexp   = regexp.MustCompile(`^[0-9]{1,9}$`)
+)
+
+// Sanitize keeps the allowlisted tags and attributes of src and balances the tags kept.
+// Links may only point to http, https, mailto or relative urls, images to http, https or relative urls.
+func Sanitize(src string) string {
+	z := html.NewTokenizer(strings.NewReader(src))
+	var (
+		b     strings.Builder
+		stack []string
+		// the dropped element being skipped and its nesting depth
+		skipTag string
+		skip    int
+	)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			for i := len(stack) - 1; i >= 0; i-- {
+				b.WriteString("</" + stack[i] + ">")
+			}
+			return b.String()
+		case html.TextToken:
+			if skip == 0 {
+				b.WriteString(html.EscapeString(string(z.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if skip > 0 {
+				if tok.Data == skipTag && tt == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if droppedContent[tok.Data] {
+				if tt == html.StartTagToken {
+					skipTag, skip = tok.Data, 1
+				}
+				continue
+			}
+			attrs, ok := allowedTags[tok.Data]
+			if !ok {
+				continue
+			}
+			b.WriteString("<" + tok.Data)
+			writeAttrs(&b, tok, attrs)
+			b.WriteString(">")
+			if !voidTags[tok.Data] {
+				stack = append(stack, tok.Data)
+			}
+		case html.EndTagToken:
+			tok := z.Token()
+			if skip > 0 {
+				if tok.Data == skipTag {
+					skip--
+				}
+				continue
+			}
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i] != tok.Data {
+					continue
+				}
+				// close the tags left open inside it
+				for j := len(stack) - 1; j >= i; j-- {
+					b.WriteString("</" + stack[j] + ">")
+				}
+				stack = stack[:i]
+				break
+			}
+		}
+	}
+}
+
+func writeAttrs(b *strings.Builder, tok html.Token, allowed []string) {
+	external := false
+	for _, attr := range tok.Attr {
+		if attr.Namespace != "" || !contains(allowed, attr.Key) {
+			continue
+		}
+		val := attr.Val
+		switch attr.Key {
+		case "href":
+			var ok bool
+			if val, ok = safeURL(val, "http", "https", "mailto"); !ok {
+				continue
+			}
+			external = isAbsoluteURL(val)
+		case "src":
+			var ok bool
+			if val, ok = safeURL(val, "http", "https"); !ok {
+				continue
+			}
+		case "id":
+			if !idRegexp.MatchString(val) {
+				continue
+			}
+		case "class":
+			if !classRegexp.MatchString(val) {
+				continue
+			}
+		case "align":
+			if !alignRegexp.MatchString(val) {
+				continue
+			}
+		case "start", "width", "height":
+			if !numRegexp.MatchString(val) {
+				continue
+			}
+		}
+		b.WriteString(" " + attr.Key + `="` + html.EscapeString(val) + `"`)
+	}
+	if external {
+		b.WriteString(` rel="nofollow noopener noreferrer"`)
+	}
+}
+
+// safeURL strips the characters browsers ignore in urls, then checks the scheme against schemes.
+func safeURL(raw string, schemes ...string) (string, bool) {
+	u := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+	if u == "" {
+		return "", false
+	}
+	colon := strings.IndexByte(u, ':')
+	if colon < 0 || strings.ContainsAny(u[:colon], "/?#") {
+		// relative
+		return u, true
+	}
+	return u, contains(schemes, strings.ToLower(u[:colon]))
+}
+
+func isAbsoluteURL(u string) bool {
+	if strings.HasPrefix(u, "//") {
+		return true
+	}
+	colon := strings.IndexByte(u, ':')
+	return colon > 0 && !strings.ContainsAny(u[:colon], "/?#")
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
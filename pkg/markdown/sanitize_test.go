@@ -0,0 +1,64 @@
+package markdown
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "script dropped with its content",
+			src:  "<script>alert(1)</script>ok",
+			want: "ok",
+		},
+		{
+			name: "script inside svg",
+			src:  "<svg><script>1</script></svg>b",
+			want: "b",
+		},
+		{
+			name: "javascript link",
+			src:  `<a href="javascript:alert(1)">x</a>`,
+			want: "<a>x</a>",
+		},
+		{
+			name: "event handler",
+			src:  `<img src=x onerror=alert(1)>`,
+			want: `<img src="x">`,
+		},
+		{
+			name: "external link",
+			src:  `<a href="https://x" target="_blank">x</a>`,
+			want: `<a href="https://x" rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name: "style attribute",
+			src:  `<div style="x">a</div>`,
+			want: "<div>a</div>",
+		},
+		{
+			name: "code language",
+			src:  `<code class="language-go">x</code>`,
+			want: `<code class="language-go">x</code>`,
+		},
+		{
+			name: "unbalanced tags",
+			src:  "<p><b>a</p>",
+			want: "<p><b>a</b></p>",
+		},
+		{
+			name: "raw html in markdown",
+			src:  Render(Parse(`a <img src=x onerror=alert(1)> [b](javascript:alert(1))`)),
+			want: "<p>a <img src=\"x\"> <a>b</a></p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Sanitize(tc.src); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
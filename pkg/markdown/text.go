@@ -0,0 +1,175 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// reading speeds of latin words and CJK characters per minute
+	wordsPerMinute = 230
+	cjkPerMinute   = 400
+	// time spent looking at an image
+	imageSeconds = 12
+)
+
+// Heading is an entry of the table of contents.
+type Heading struct {
+	Level int
+	Text  string
+	ID    string
+}
+
+// TOC lists the headings of the document in order.
+func TOC(doc *Node) []Heading {
+	var res []Heading
+	Walk(doc, func(n *Node) bool {
+		if n.Kind == KindHeading {
+			res = append(res, Heading{Level: n.Level, Text: inlineText(n.Children), ID: n.ID})
+			return false
+		}
+		return n.Kind.IsBlock()
+	})
+	return res
+}
+
+// Text extracts the prose of the document, blocks are separated by '\n'.
+// Code blocks and raw html are left out.
+func Text(doc *Node) string {
+	var lines []string
+	Walk(doc, func(n *Node) bool {
+		switch n.Kind {
+		case KindParagraph, KindHeading:
+			if text := strings.TrimSpace(inlineText(n.Children)); text != "" {
+				lines = append(lines, text)
+			}
+			return false
+		case KindCodeBlock, KindHTMLBlock:
+			return false
+		default:
+			return n.Kind.IsBlock()
+		}
+	})
+	return strings.Join(lines, "\n")
+}
+
+// Abstract collapses the white spaces of text and cuts it to at most n runes.
+func Abstract(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	rs := []rune(text)
+	if len(rs) <= n {
+		return text
+	}
+	return strings.TrimRightFunc(string(rs[:n]), unicode.IsSpace) + "..."
+}
+
+// ReadingTime estimates the time to read the document, rounded up to seconds.
+func ReadingTime(doc *Node) time.Duration {
+	var words, cjk, images int
+	count := func(s string) {
+		inWord := false
+		for _, r := range s {
+			switch {
+			case isCJK(r):
+				cjk++
+				inWord = false
+			case unicode.IsSpace(r) || unicode.IsPunct(r):
+				inWord = false
+			default:
+				if !inWord {
+					words++
+				}
+				inWord = true
+			}
+		}
+	}
+	Walk(doc, func(n *Node) bool {
+		switch n.Kind {
+		case KindText, KindCode, KindCodeBlock:
+			count(n.Literal)
+		case KindImage:
+			images++
+		}
+		return true
+	})
+	minutes := float64(words)/wordsPerMinute + float64(cjk)/cjkPerMinute
+	seconds := int64(minutes*60+0.999) + int64(images*imageSeconds)
+	return time.Duration(seconds) * time.Second
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// inlineText is the plain text of inline nodes, images contribute their alt text.
+func inlineText(nodes []*Node) string {
+	var b strings.Builder
+	var write func(nodes []*Node)
+	write = func(nodes []*Node) {
+		for _, n := range nodes {
+			switch n.Kind {
+			case KindText, KindCode:
+				b.WriteString(n.Literal)
+			case KindSoftBreak, KindLineBreak:
+				b.WriteString(" ")
+			case KindRawHTML:
+			default:
+				write(n.Children)
+			}
+		}
+	}
+	write(nodes)
+	return b.String()
+}
+
+// assignHeadingIDs gives every heading an anchor from its text, duplicates get a numeric suffix.
+func assignHeadingIDs(doc *Node) {
+	used := make(map[string]struct{})
+	// next is the suffix to try first for a base, headings with the same text do not retry the suffixes taken
+	next := make(map[string]int)
+	Walk(doc, func(n *Node) bool {
+		if n.Kind != KindHeading {
+			return n.Kind.IsBlock()
+		}
+		base := slug(inlineText(n.Children))
+		i, id := next[base], base
+		if i > 0 {
+			id = base + "-" + strconv.Itoa(i)
+		}
+		for {
+			if _, ok := used[id]; !ok {
+				break
+			}
+			i++
+			id = base + "-" + strconv.Itoa(i)
+		}
+		used[id] = struct{}{}
+		next[base] = i + 1
+		n.ID = id
+		return false
+	})
+}
+
+func slug(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "section"
+	}
+	return b.String()
+}
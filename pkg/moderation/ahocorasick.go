@@ -0,0 +1,89 @@
+package moderation
+
+import "unicode"
+
+// automaton is an Aho-Corasick automaton matching all the patterns in one pass of the text,
+// the patterns and the text are compared case-insensitively.
+type automaton struct {
+	nodes []acNode
+}
+
+type acNode struct {
+	next map[rune]int
+	// fail is the node of the longest proper suffix of this node which is also a prefix of a pattern
+	fail int
+	// out are the indexes of the patterns ending at this node, including the ones ending at its fail nodes
+	out []int
+}
+
+func newAutomaton(patterns []string) *automaton {
+	a := &automaton{nodes: []acNode{{}}}
+	for i, p := range patterns {
+		cur := 0
+		for _, r := range p {
+			r = unicode.ToLower(r)
+			nxt, ok := a.nodes[cur].next[r]
+			if !ok {
+				if a.nodes[cur].next == nil {
+					a.nodes[cur].next = make(map[rune]int)
+				}
+				a.nodes = append(a.nodes, acNode{})
+				nxt = len(a.nodes) - 1
+				a.nodes[cur].next[r] = nxt
+			}
+			cur = nxt
+		}
+		// empty patterns would match everywhere
+		if cur != 0 {
+			a.nodes[cur].out = append(a.nodes[cur].out, i)
+		}
+	}
+
+	// the fail links of a node depend on the nodes shallower than it, so they are built breadth first
+	queue := make([]int, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range a.nodes[cur].next {
+			fail := a.nodes[cur].fail
+			for fail != 0 && !a.has(fail, r) {
+				fail = a.nodes[fail].fail
+			}
+			if nxt, ok := a.nodes[fail].next[r]; ok && nxt != child {
+				fail = nxt
+			}
+			a.nodes[child].fail = fail
+			a.nodes[child].out = append(a.nodes[child].out, a.nodes[fail].out...)
+			queue = append(queue, child)
+		}
+	}
+	return a
+}
+
+func (a *automaton) has(node int, r rune) bool {
+	_, ok := a.nodes[node].next[r]
+	return ok
+}
+
+// match calls fn with the index of each pattern found in text, until fn returns false.
+// A pattern is reported as many times as it occurs.
+func (a *automaton) match(text string, fn func(idx int) bool) {
+	cur := 0
+	for _, r := range text {
+		r = unicode.ToLower(r)
+		for cur != 0 && !a.has(cur, r) {
+			cur = a.nodes[cur].fail
+		}
+		if nxt, ok := a.nodes[cur].next[r]; ok {
+			cur = nxt
+		}
+		for _, idx := range a.nodes[cur].out {
+			if !fn(idx) {
+				return
+			}
+		}
+	}
+}
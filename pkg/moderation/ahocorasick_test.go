@@ -0,0 +1,99 @@
+package moderation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAutomatonMatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patterns []string
+		text     string
+		// want are the indexes of the patterns in the order they are found
+		want []int
+	}{
+		{
+			name: "no patterns",
+			text: "anything",
+			want: []int{},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"abc"},
+			text:     "abdabx",
+			want:     []int{},
+		},
+		{
+			name:     "overlapping patterns",
+			patterns: []string{"he", "she", "his", "hers"},
+			text:     "ushers",
+			want:     []int{1, 0, 3},
+		},
+		{
+			name:     "pattern inside another one",
+			patterns: []string{"abcd", "bc"},
+			text:     "abcd",
+			want:     []int{1, 0},
+		},
+		{
+			name:     "outputs merged along the fail links",
+			patterns: []string{"a", "aa", "aaa"},
+			text:     "aaa",
+			want:     []int{0, 1, 0, 2, 1, 0},
+		},
+		{
+			name:     "fail link after a mismatch",
+			patterns: []string{"abab", "bac"},
+			text:     "ababac",
+			want:     []int{0, 1},
+		},
+		{
+			name:     "repeated occurrences",
+			patterns: []string{"ab"},
+			text:     "abxab",
+			want:     []int{0, 0},
+		},
+		{
+			name:     "case folded",
+			patterns: []string{"SpAm", "ΣΟΦΊΑ"},
+			text:     "no sPaM, σοφία",
+			want:     []int{0, 1},
+		},
+		{
+			name:     "empty patterns never match",
+			patterns: []string{"", "b", ""},
+			text:     "abc",
+			want:     []int{1},
+		},
+		{
+			name:     "duplicated patterns both reported",
+			patterns: []string{"x", "x"},
+			text:     "x",
+			want:     []int{0, 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []int{}
+			newAutomaton(tc.patterns).match(tc.text, func(idx int) bool {
+				got = append(got, idx)
+				return true
+			})
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("match() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAutomatonMatchStop(t *testing.T) {
+	got := []int{}
+	newAutomaton([]string{"a", "b"}).match("abab", func(idx int) bool {
+		got = append(got, idx)
+		return len(got) < 2
+	})
+	if want := []int{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("match() = %v, want %v", got, want)
+	}
+}
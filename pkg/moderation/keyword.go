@@ -0,0 +1,117 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync/atomic"
+)
+
+// RuleSet are the rules of the KeywordFilter by the verdict of the content matching them.
+type RuleSet struct {
+	Reject Rules `yaml:"reject"`
+	Review Rules `yaml:"review"`
+}
+
+type Rules struct {
+	Keywords []string `yaml:"keywords"`
+	Regexes  []string `yaml:"regexes"`
+}
+
+var _ ContentFilter = (*KeywordFilter)(nil)
+
+// KeywordFilter matches the content against keywords with an Aho-Corasick automaton and against regexes,
+// the rules could be reloaded while filtering.
+type KeywordFilter struct {
+	m atomic.Pointer[matcher]
+}
+
+func NewKeywordFilter(rules RuleSet) (*KeywordFilter, error) {
+	f := &KeywordFilter{}
+	err := f.Reload(rules)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload replaces the rules, the old ones are kept if the new ones are invalid.
+func (f *KeywordFilter) Reload(rules RuleSet) error {
+	m, err := newMatcher(rules)
+	if err != nil {
+		return err
+	}
+	f.m.Store(m)
+	return nil
+}
+
+func (f *KeywordFilter) Filter(ctx context.Context, content string) (Result, error) {
+	return f.m.Load().filter(content), nil
+}
+
+type rule struct {
+	pattern string
+	verdict Verdict
+}
+
+type regexRule struct {
+	re      *regexp.Regexp
+	verdict Verdict
+}
+
+type matcher struct {
+	ac *automaton
+	// keywords are indexed as the patterns of ac
+	keywords []rule
+	regexes  []regexRule
+}
+
+func newMatcher(rules RuleSet) (*matcher, error) {
+	m := &matcher{}
+	for _, g := range []struct {
+		rules   Rules
+		verdict Verdict
+	}{
+		{rules: rules.Reject, verdict: VerdictReject},
+		{rules: rules.Review, verdict: VerdictReview},
+	} {
+		for _, kw := range g.rules.Keywords {
+			m.keywords = append(m.keywords, rule{pattern: kw, verdict: g.verdict})
+		}
+		for _, expr := range g.rules.Regexes {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid moderation regex %q: %w", expr, err)
+			}
+			m.regexes = append(m.regexes, regexRule{re: re, verdict: g.verdict})
+		}
+	}
+	patterns := make([]string, 0, len(m.keywords))
+	for _, kw := range m.keywords {
+		patterns = append(patterns, kw.pattern)
+	}
+	m.ac = newAutomaton(patterns)
+	return m, nil
+}
+
+func (m *matcher) filter(content string) Result {
+	var res Result
+	seen := make(map[int]struct{})
+	m.ac.match(content, func(idx int) bool {
+		if _, ok := seen[idx]; !ok {
+			seen[idx] = struct{}{}
+			kw := m.keywords[idx]
+			res.Verdict = max(res.Verdict, kw.verdict)
+			res.Hits = append(res.Hits, kw.pattern)
+		}
+		// the hits of the other keywords are still collected for the review
+		return true
+	})
+	for _, r := range m.regexes {
+		if r.re.MatchString(content) {
+			res.Verdict = max(res.Verdict, r.verdict)
+			res.Hits = append(res.Hits, r.re.String())
+		}
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./types.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./types.go -package=moderationmocks -destination=mocks/moderation.mock.go ContentFilter
+//
+
+// Package moderationmocks is a generated GoMock package.
+package moderationmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	moderation "github.com/tsukiyo/mercury/pkg/moderation"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockContentFilter is a mock of ContentFilter interface.
+type MockContentFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockContentFilterMockRecorder
+}
+
+// MockContentFilterMockRecorder is the mock recorder for MockContentFilter.
+type MockContentFilterMockRecorder struct {
+	mock *MockContentFilter
+}
+
+// NewMockContentFilter creates a new mock instance.
+func NewMockContentFilter(ctrl *gomock.Controller) *MockContentFilter {
+	mock := &MockContentFilter{ctrl: ctrl}
+	mock.recorder = &MockContentFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContentFilter) EXPECT() *MockContentFilterMockRecorder {
+	return m.recorder
+}
+
+// Filter mocks base method.
+func (m *MockContentFilter) Filter(ctx context.Context, content string) (moderation.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Filter", ctx, content)
+	ret0, _ := ret[0].(moderation.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Filter indicates an expected call of Filter.
+func (mr *MockContentFilterMockRecorder) Filter(ctx, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockContentFilter)(nil).Filter), ctx, content)
+}
@@ -0,0 +1,35 @@
+package moderation
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// NewReloadingKeywordFilter loads the RuleSet under key of the config and reloads it once the config changes,
+// the rules loaded before are kept if the new ones could not be loaded.
+func NewReloadingKeywordFilter(key string, l logger.Logger) (*KeywordFilter, error) {
+	var rules RuleSet
+	err := viper.UnmarshalKey(key, &rules)
+	if err != nil {
+		return nil, err
+	}
+	kw, err := NewKeywordFilter(rules)
+	if err != nil {
+		return nil, err
+	}
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		var rules RuleSet
+		err := viper.UnmarshalKey(key, &rules)
+		if err == nil {
+			err = kw.Reload(rules)
+		}
+		if err != nil {
+			l.Error("reload moderation rules failed", logger.String("key", key), logger.Error(err))
+			return
+		}
+		l.Info("moderation rules reloaded", logger.String("key", key))
+	})
+	return kw, nil
+}
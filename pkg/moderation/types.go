@@ -0,0 +1,59 @@
+package moderation
+
+import "context"
+
+// Verdict is what a filter decides on the content, a greater one is more severe.
+type Verdict uint8
+
+const (
+	VerdictPass Verdict = iota
+	// VerdictReview the content is held until an admin reviews it
+	VerdictReview
+	VerdictReject
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictPass:
+		return "pass"
+	case VerdictReview:
+		return "review"
+	case VerdictReject:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+type Result struct {
+	Verdict Verdict
+	// Hits are what the content matched, for the admins reviewing it
+	Hits []string
+}
+
+//go:generate mockgen -source=./types.go -package=moderationmocks -destination=mocks/moderation.mock.go ContentFilter
+type ContentFilter interface {
+	Filter(ctx context.Context, content string) (Result, error)
+}
+
+var _ ContentFilter = Chain(nil)
+
+// Chain runs the filters in order and returns the most severe verdict with the hits of all,
+// it stops at the first rejection.
+type Chain []ContentFilter
+
+func (c Chain) Filter(ctx context.Context, content string) (Result, error) {
+	var res Result
+	for _, f := range c {
+		r, err := f.Filter(ctx, content)
+		if err != nil {
+			return res, err
+		}
+		res.Verdict = max(res.Verdict, r.Verdict)
+		res.Hits = append(res.Hits, r.Hits...)
+		if res.Verdict == VerdictReject {
+			break
+		}
+	}
+	return res, nil
+}
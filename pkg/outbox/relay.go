@@ -0,0 +1,314 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/sarama"
+	rlock "github.com/gotomicro/redis-lock"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// Relay sends the pending messages of the outbox to kafka.
+// Only the instance holding the distributed lock relays, and a message is not sent
+// until the earlier messages with the same key have been sent, so the order per key is kept.
+// A message that reaches the retry limit keeps blocking its key until it is handled manually.
+// Messages are deleted once sent, one that is sent but fails to be deleted is sent again,
+// consumers have to be idempotent.
+type Relay struct {
+	db       *gorm.DB
+	producer sarama.SyncProducer
+	client   *rlock.Client
+	lockKey  string
+	l        logger.Logger
+
+	batchSize      int
+	interval       time.Duration
+	lockExpiration time.Duration
+	// retry with exponential backoff from minBackoff up to maxBackoff
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	maxRetries int
+
+	lock *rlock.Lock
+	lost atomic.Bool
+
+	counter *prometheus.CounterVec
+	lag     prometheus.Gauge
+}
+
+// the metrics are shared by the relays of a process and told apart by the relay label
+var (
+	registerOnce   sync.Once
+	messageCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "lazywoo",
+		Subsystem: "mercury",
+		Name:      "outbox_messages",
+		Help:      "messages handled by the outbox relay",
+	}, []string{"relay", "topic", "result"})
+	lagGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "lazywoo",
+		Subsystem: "mercury",
+		Name:      "outbox_lag_seconds",
+		Help:      "age of the oldest pending message in the outbox",
+	}, []string{"relay"})
+)
+
+// NewRelay creates a relay of the outbox in db, name tells relays of different services apart.
+func NewRelay(db *gorm.DB, producer sarama.SyncProducer, client *rlock.Client, name string, l logger.Logger) *Relay {
+	registerOnce.Do(func() {
+		prometheus.MustRegister(messageCounter, lagGauge)
+	})
+
+	return &Relay{
+		db:             db,
+		producer:       producer,
+		client:         client,
+		lockKey:        "rlock:outbox:" + name,
+		l:              l,
+		batchSize:      200,
+		interval:       time.Second,
+		lockExpiration: time.Second * 30,
+		minBackoff:     time.Second,
+		maxBackoff:     time.Minute * 5,
+		maxRetries:     20,
+		counter:        messageCounter.MustCurryWith(prometheus.Labels{"relay": name}),
+		lag:            lagGauge.WithLabelValues(name),
+	}
+}
+
+// Start relays until ctx is done.
+func (r *Relay) Start(ctx context.Context) error {
+	defer r.unlock()
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !r.holdLock() {
+			r.sleep(ctx)
+			continue
+		}
+		cnt, err := r.relay(ctx)
+		if err != nil {
+			r.l.Error("relay outbox failed", logger.String("lock", r.lockKey), logger.Error(err))
+		}
+		// a full batch sent means there might be more
+		if err != nil || cnt < r.batchSize {
+			r.sleep(ctx)
+		}
+	}
+}
+
+// relay handles a batch of the messages due and returns how many were sent.
+func (r *Relay) relay(ctx context.Context) (int, error) {
+	err := r.updateLag(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UnixMilli()
+	// a message waiting for its retry or given up blocks the later messages of its key
+	blocking := r.db.Table(Message{}.TableName()+" AS e").
+		Select("1").
+		Where("e.`key` = outbox_messages.`key` AND e.id < outbox_messages.id").
+		Where("e.status = ? OR e.next_time > ?", StatusFailed, now)
+	var msgs []Message
+	err = r.db.WithContext(ctx).
+		Where("status = ? AND next_time <= ?", StatusPending, now).
+		Where("NOT EXISTS (?)", blocking).
+		Order("id ASC").
+		Limit(r.batchSize).
+		Find(&msgs).Error
+	if err != nil || len(msgs) == 0 {
+		return 0, err
+	}
+
+	// keys with a message failed to be sent in this batch
+	blocked := make(map[string]struct{})
+	sent := make([]int64, 0, len(msgs))
+	for _, round := range rounds(msgs) {
+		if ctx.Err() != nil {
+			break
+		}
+		// the messages of the round by what is sent for them
+		pending := make(map[*sarama.ProducerMessage]Message, len(round))
+		pms := make([]*sarama.ProducerMessage, 0, len(round))
+		for _, msg := range round {
+			if _, ok := blocked[msg.Key]; ok {
+				continue
+			}
+			pm := &sarama.ProducerMessage{
+				Topic: msg.Topic,
+				Key:   sarama.StringEncoder(msg.Key),
+				Value: sarama.ByteEncoder(msg.Payload),
+			}
+			pending[pm] = msg
+			pms = append(pms, pm)
+		}
+		if len(pms) == 0 {
+			continue
+		}
+		failed := r.send(pms)
+		for _, pm := range pms {
+			msg := pending[pm]
+			er, ok := failed[pm]
+			if !ok {
+				sent = append(sent, msg.Id)
+				r.counter.WithLabelValues(msg.Topic, "sent").Inc()
+				continue
+			}
+			blocked[msg.Key] = struct{}{}
+			if er = r.retryLater(ctx, msg, er); er != nil {
+				err = errors.Join(err, er)
+			}
+		}
+		// the messages sent are still deleted below
+		if err != nil {
+			break
+		}
+	}
+	if len(sent) > 0 {
+		if er := r.db.WithContext(ctx).Where("id IN ?", sent).Delete(&Message{}).Error; er != nil {
+			err = errors.Join(err, er)
+		}
+	}
+	return len(sent), err
+}
+
+// rounds splits the messages in id order into rounds holding one message of each key at most,
+// a round is sent at once and the next message of a key waits for the round before it.
+func rounds(msgs []Message) [][]Message {
+	var res [][]Message
+	cnts := make(map[string]int)
+	for _, msg := range msgs {
+		i := cnts[msg.Key]
+		if i == len(res) {
+			res = append(res, nil)
+		}
+		res[i] = append(res[i], msg)
+		cnts[msg.Key] = i + 1
+	}
+	return res
+}
+
+// send sends the messages in one request and returns the errors of the ones failed.
+func (r *Relay) send(pms []*sarama.ProducerMessage) map[*sarama.ProducerMessage]error {
+	err := r.producer.SendMessages(pms)
+	if err == nil {
+		return nil
+	}
+	failed := make(map[*sarama.ProducerMessage]error, len(pms))
+	var perrs sarama.ProducerErrors
+	if !errors.As(err, &perrs) {
+		for _, pm := range pms {
+			failed[pm] = err
+		}
+		return failed
+	}
+	for _, perr := range perrs {
+		failed[perr.Msg] = perr.Err
+	}
+	return failed
+}
+
+// updateLag sets the lag to the age of the oldest pending message, due or not.
+func (r *Relay) updateLag(ctx context.Context) error {
+	var oldest []int64
+	err := r.db.WithContext(ctx).Model(&Message{}).
+		Where("status = ?", StatusPending).
+		Order("id ASC").
+		Limit(1).
+		Pluck("ctime", &oldest).Error
+	if err != nil {
+		return err
+	}
+	if len(oldest) == 0 {
+		r.lag.Set(0)
+		return nil
+	}
+	r.lag.Set(time.Since(time.UnixMilli(oldest[0])).Seconds())
+	return nil
+}
+
+// retryLater schedules the message with backoff, or gives it up once it reaches the retry limit.
+func (r *Relay) retryLater(ctx context.Context, msg Message, cause error) error {
+	retries := msg.Retries + 1
+	backoff := r.maxBackoff
+	if shift := retries - 1; shift < 32 {
+		backoff = min(r.minBackoff<<shift, r.maxBackoff)
+	}
+	status, result := StatusPending, "retry"
+	if retries >= r.maxRetries {
+		status, result = StatusFailed, "failed"
+		r.l.Error("outbox message reaches the retry limit",
+			logger.Int64("id", msg.Id),
+			logger.String("topic", msg.Topic),
+			logger.String("key", msg.Key),
+			logger.Error(cause))
+	}
+	r.counter.WithLabelValues(msg.Topic, result).Inc()
+
+	lastErr := cause.Error()
+	if len(lastErr) > 1024 {
+		lastErr = lastErr[:1024]
+	}
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&Message{}).
+		Where("id = ?", msg.Id).
+		Updates(map[string]any{
+			"status":     status,
+			"retries":    retries,
+			"next_time":  now.Add(backoff).UnixMilli(),
+			"last_error": lastErr,
+			"utime":      now.UnixMilli(),
+		}).Error
+}
+
+func (r *Relay) holdLock() bool {
+	if r.lock != nil && !r.lost.Load() {
+		return true
+	}
+	r.lock = nil
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	lock, err := r.client.TryLock(ctx, r.lockKey, r.lockExpiration)
+	if err != nil {
+		// held by another instance
+		return false
+	}
+	r.lock = lock
+	r.lost.Store(false)
+	go func() {
+		// automatic renewal
+		if err := lock.AutoRefresh(r.lockExpiration/2, time.Second); err != nil {
+			r.l.Error("renewal distributed lock failed", logger.String("lock", r.lockKey), logger.Error(err))
+			r.lost.Store(true)
+		}
+	}()
+	return true
+}
+
+func (r *Relay) unlock() {
+	if r.lock == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.lock.Unlock(ctx); err != nil {
+		r.l.Error("release distributed lock failed", logger.String("lock", r.lockKey), logger.Error(err))
+	}
+	r.lock = nil
+}
+
+func (r *Relay) sleep(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(r.interval):
+	}
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRounds(t *testing.T) {
+	testCases := []struct {
+		name string
+		msgs []Message
+		// want are the ids of each round
+		want [][]int64
+	}{
+		{
+			name: "no messages",
+		},
+		{
+			name: "distinct keys in one round",
+			msgs: []Message{{Id: 1, Key: "a"}, {Id: 2, Key: "b"}, {Id: 3, Key: "c"}},
+			want: [][]int64{{1, 2, 3}},
+		},
+		{
+			name: "same key in later rounds",
+			msgs: []Message{
+				{Id: 1, Key: "a"},
+				{Id: 2, Key: "a"},
+				{Id: 3, Key: "b"},
+				{Id: 4, Key: "a"},
+				{Id: 5, Key: "b"},
+				{Id: 6, Key: "c"},
+			},
+			want: [][]int64{{1, 3, 6}, {2, 5}, {4}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got [][]int64
+			for _, round := range rounds(tc.msgs) {
+				ids := make([]int64, 0, len(round))
+				for _, msg := range round {
+					ids = append(ids, msg.Id)
+				}
+				got = append(got, ids)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rounds() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// the transactional outbox stores messages in the same transaction as the business change,
+// a relay sends them to kafka afterwards, so a crash between the commit and the send loses nothing.
+
+const (
+	StatusPending uint8 = iota
+	// StatusFailed the message reached the retry limit and is left for manual handling
+	StatusFailed
+)
+
+type Message struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Topic string `gorm:"type:varchar(255)"`
+	// Key messages with the same key are sent in the order they were written
+	Key     string `gorm:"type:varchar(255);index"`
+	Payload []byte `gorm:"type:BLOB"`
+	Status  uint8  `gorm:"index"`
+	Retries int
+	// NextTime earliest time to send the message again, unix timestamp in milliseconds
+	NextTime  int64
+	LastError string `gorm:"type:varchar(1024)"`
+	Ctime     int64
+	Utime     int64
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+func InitTable(db *gorm.DB) error {
+	return db.AutoMigrate(&Message{})
+}
+
+// Write marshals evt into json and adds it to the outbox with tx,
+// which should be the transaction of the business change.
+func Write(tx *gorm.DB, topic, key string, evt any) error {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	return tx.Create(&Message{
+		Topic:    topic,
+		Key:      key,
+		Payload:  payload,
+		Status:   StatusPending,
+		NextTime: now,
+		Ctime:    now,
+		Utime:    now,
+	}).Error
+}
@@ -10,6 +10,14 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+type cacheKey struct{}
+
+// WithCache marks the commands run with the context as reads of the cache,
+// the name labels them apart from other commands in the metrics.
+func WithCache(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, cacheKey{}, name)
+}
+
 type PrometheusHook struct {
 	summaryVec *prometheus.SummaryVec
 }
@@ -27,7 +35,7 @@ func NewPrometheusHook(
 		ConstLabels: prometheus.Labels{
 			"instance_id": instanceId,
 		},
-	}, []string{"cmd", "hit_cache"})
+	}, []string{"cmd", "cache", "hit_cache"})
 	prometheus.MustRegister(summaryVec)
 	return &PrometheusHook{
 		summaryVec: summaryVec,
@@ -45,9 +53,9 @@ func (p *PrometheusHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
 		start := time.Now()
 		var err error
 		defer func() {
-			keyExist := err == redis.Nil
-			p.summaryVec.WithLabelValues(cmd.Name(),
-				strconv.FormatBool(keyExist)).
+			cache, _ := ctx.Value(cacheKey{}).(string)
+			p.summaryVec.WithLabelValues(cmd.Name(), cache,
+				strconv.FormatBool(hit(cmd, err))).
 				Observe(float64(time.Since(start)))
 		}()
 		err = next(ctx, cmd)
@@ -60,3 +68,18 @@ func (p *PrometheusHook) ProcessPipelineHook(next redis.ProcessPipelineHook) red
 		return next(ctx, cmds)
 	}
 }
+
+// hit tells whether the command found the key, a hash missing in HMGET is returned as nils rather than redis.Nil.
+func hit(cmd redis.Cmder, err error) bool {
+	if err != nil {
+		return false
+	}
+	if c, ok := cmd.(*redis.SliceCmd); ok {
+		for _, v := range c.Val() {
+			if v == nil {
+				return false
+			}
+		}
+	}
+	return true
+}
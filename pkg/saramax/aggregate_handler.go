@@ -0,0 +1,133 @@
+package saramax
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/tsukiyo/mercury/pkg/logger"
+)
+
+// Aggregator merges the events of a partition claim in memory until they are flushed.
+type Aggregator[Evt any] interface {
+	Add(msg *sarama.ConsumerMessage, evt Evt)
+	// Len is the number of events added since the last flush.
+	Len() int
+	// Flush writes what is merged, a failed flush keeps it to be flushed again.
+	Flush(ctx context.Context) error
+}
+
+// AggregateHandler flushes the aggregated events on a timer or when enough events are added,
+// and marks the offsets only after a flush succeeds, so the events are consumed again after a crash
+// instead of being lost with the memory.
+type AggregateHandler[Evt any] struct {
+	l             logger.Logger
+	newAggregator func() Aggregator[Evt]
+	flushSize     int
+	flushInterval time.Duration
+	flushTimeout  time.Duration
+}
+
+type AggregateOption[Evt any] func(hdl *AggregateHandler[Evt])
+
+func NewAggregateHandler[Evt any](l logger.Logger, newAggregator func() Aggregator[Evt], opts ...AggregateOption[Evt]) *AggregateHandler[Evt] {
+	hdl := &AggregateHandler[Evt]{
+		l:             l,
+		newAggregator: newAggregator,
+		flushSize:     1000,
+		flushInterval: time.Second,
+		flushTimeout:  3 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(hdl)
+	}
+	return hdl
+}
+
+func WithFlushSize[Evt any](size int) AggregateOption[Evt] {
+	return func(hdl *AggregateHandler[Evt]) {
+		hdl.flushSize = size
+	}
+}
+
+func WithFlushInterval[Evt any](interval time.Duration) AggregateOption[Evt] {
+	return func(hdl *AggregateHandler[Evt]) {
+		hdl.flushInterval = interval
+	}
+}
+
+func (hdl *AggregateHandler[Evt]) Setup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (hdl *AggregateHandler[Evt]) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (hdl *AggregateHandler[Evt]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	// each claim aggregates on its own, the offsets of a partition are marked in order
+	agg := hdl.newAggregator()
+	ticker := time.NewTicker(hdl.flushInterval)
+	defer ticker.Stop()
+	// last is the latest message consumed but not marked yet
+	var last *sarama.ConsumerMessage
+	flush := func() bool {
+		if last == nil {
+			return true
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), hdl.flushTimeout)
+		defer cancel()
+		err := agg.Flush(ctx)
+		if err != nil {
+			hdl.l.Error("flush aggregated events failed",
+				logger.String("topic", claim.Topic()),
+				logger.Int32("partition", claim.Partition()),
+				logger.Int("pending", agg.Len()),
+				logger.Error(err),
+			)
+			return false
+		}
+		session.MarkMessage(last, "")
+		last = nil
+		return true
+	}
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				flush()
+				return nil
+			}
+			last = msg
+			var evt Evt
+			err := json.Unmarshal(msg.Value, &evt)
+			if err != nil {
+				// skipped, its offset is marked along with the next flush
+				hdl.l.Error("unmarshal message failed",
+					logger.String("topic", msg.Topic),
+					logger.Int32("partition", msg.Partition),
+					logger.Int64("offset", msg.Offset),
+					logger.Error(err),
+				)
+				continue
+			}
+			agg.Add(msg, evt)
+			// stop consuming until the flush succeeds, the memory is bounded while the storage is down
+			for agg.Len() >= hdl.flushSize && !flush() {
+				select {
+				case <-ticker.C:
+				case <-session.Context().Done():
+					return nil
+				}
+			}
+		case <-ticker.C:
+			flush()
+		case <-session.Context().Done():
+			flush()
+			return nil
+		}
+	}
+}